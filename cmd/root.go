@@ -220,6 +220,7 @@ import (
 	_ "github.com/googleapis/genai-toolbox/internal/sources/alloydbadmin"
 	_ "github.com/googleapis/genai-toolbox/internal/sources/alloydbpg"
 	_ "github.com/googleapis/genai-toolbox/internal/sources/athena"
+	_ "github.com/googleapis/genai-toolbox/internal/sources/azureblob"
 	_ "github.com/googleapis/genai-toolbox/internal/sources/bigquery"
 	_ "github.com/googleapis/genai-toolbox/internal/sources/bigtable"
 	_ "github.com/googleapis/genai-toolbox/internal/sources/cassandra"
@@ -232,6 +233,7 @@ import (
 	_ "github.com/googleapis/genai-toolbox/internal/sources/cloudsqlpg"
 	_ "github.com/googleapis/genai-toolbox/internal/sources/cloudwatch"
 	_ "github.com/googleapis/genai-toolbox/internal/sources/couchbase"
+	_ "github.com/googleapis/genai-toolbox/internal/sources/datadog"
 	_ "github.com/googleapis/genai-toolbox/internal/sources/dataplex"
 	_ "github.com/googleapis/genai-toolbox/internal/sources/dgraph"
 	_ "github.com/googleapis/genai-toolbox/internal/sources/documentdb"
@@ -239,24 +241,33 @@ import (
 	_ "github.com/googleapis/genai-toolbox/internal/sources/elasticsearch"
 	_ "github.com/googleapis/genai-toolbox/internal/sources/firebird"
 	_ "github.com/googleapis/genai-toolbox/internal/sources/firestore"
+	_ "github.com/googleapis/genai-toolbox/internal/sources/gcplogging"
+	_ "github.com/googleapis/genai-toolbox/internal/sources/gcs"
 	_ "github.com/googleapis/genai-toolbox/internal/sources/honeycomb"
 	_ "github.com/googleapis/genai-toolbox/internal/sources/http"
+	_ "github.com/googleapis/genai-toolbox/internal/sources/influxdb"
+	_ "github.com/googleapis/genai-toolbox/internal/sources/kafka"
+	_ "github.com/googleapis/genai-toolbox/internal/sources/loki"
 	_ "github.com/googleapis/genai-toolbox/internal/sources/looker"
 	_ "github.com/googleapis/genai-toolbox/internal/sources/mindsdb"
+	_ "github.com/googleapis/genai-toolbox/internal/sources/mock"
 	_ "github.com/googleapis/genai-toolbox/internal/sources/mongodb"
 	_ "github.com/googleapis/genai-toolbox/internal/sources/mssql"
 	_ "github.com/googleapis/genai-toolbox/internal/sources/mysql"
 	_ "github.com/googleapis/genai-toolbox/internal/sources/neo4j"
 	_ "github.com/googleapis/genai-toolbox/internal/sources/neptune"
 	_ "github.com/googleapis/genai-toolbox/internal/sources/oceanbase"
+	_ "github.com/googleapis/genai-toolbox/internal/sources/opensearch"
 	_ "github.com/googleapis/genai-toolbox/internal/sources/oracle"
 	_ "github.com/googleapis/genai-toolbox/internal/sources/postgres"
+	_ "github.com/googleapis/genai-toolbox/internal/sources/prometheus"
 	_ "github.com/googleapis/genai-toolbox/internal/sources/qldb"
 	_ "github.com/googleapis/genai-toolbox/internal/sources/redis"
 	_ "github.com/googleapis/genai-toolbox/internal/sources/redshift"
 	_ "github.com/googleapis/genai-toolbox/internal/sources/s3"
 	_ "github.com/googleapis/genai-toolbox/internal/sources/serverlessspark"
 	_ "github.com/googleapis/genai-toolbox/internal/sources/singlestore"
+	_ "github.com/googleapis/genai-toolbox/internal/sources/snowflake"
 	_ "github.com/googleapis/genai-toolbox/internal/sources/spanner"
 	_ "github.com/googleapis/genai-toolbox/internal/sources/splunk"
 	_ "github.com/googleapis/genai-toolbox/internal/sources/sqlite"
@@ -996,6 +1007,9 @@ func run(cmd *Command) error {
 		if err == context.DeadlineExceeded {
 			return fmt.Errorf("graceful shutdown timed out... forcing exit")
 		}
+		if err := s.ResourceMgr.CloseSources(shutdownContext); err != nil {
+			cmd.logger.WarnContext(shutdownContext, err.Error())
+		}
 	}
 
 	return nil
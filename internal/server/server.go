@@ -16,6 +16,7 @@ package server
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net"
@@ -153,6 +154,78 @@ func (r *ResourceManager) GetPromptsMap() map[string]prompts.Prompt {
 	return r.prompts
 }
 
+// CheckSourcesHealth runs HealthCheck concurrently on every source that
+// implements sources.Pingable, and returns the result for each by source name.
+// A nil error means the source is healthy; sources that don't implement
+// sources.Pingable are omitted.
+func (r *ResourceManager) CheckSourcesHealth(ctx context.Context) map[string]error {
+	r.mu.RLock()
+	pingable := make(map[string]sources.Pingable)
+	for name, source := range r.sources {
+		if p, ok := source.(sources.Pingable); ok {
+			pingable[name] = p
+		}
+	}
+	r.mu.RUnlock()
+
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		results = make(map[string]error, len(pingable))
+	)
+	for name, p := range pingable {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := p.HealthCheck(ctx)
+			if err != nil {
+				err = fmt.Errorf("source %q is unhealthy: %w", name, err)
+			}
+			mu.Lock()
+			results[name] = err
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+	return results
+}
+
+// CloseSources concurrently closes every source that implements
+// sources.ContextCloser or io.Closer, and returns the joined close errors.
+// ContextCloser sources are closed with ctx, so ctx bounds the shutdown.
+func (r *ResourceManager) CloseSources(ctx context.Context) error {
+	r.mu.RLock()
+	closers := make(map[string]func() error)
+	for name, source := range r.sources {
+		switch c := source.(type) {
+		case sources.ContextCloser:
+			closers[name] = func() error { return c.CloseWithContext(ctx) }
+		case io.Closer:
+			closers[name] = c.Close
+		}
+	}
+	r.mu.RUnlock()
+
+	var (
+		mu   sync.Mutex
+		wg   sync.WaitGroup
+		errs []error
+	)
+	for name, closeSource := range closers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := closeSource(); err != nil {
+				mu.Lock()
+				errs = append(errs, fmt.Errorf("unable to close source %q: %w", name, err))
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
 func InitializeConfigs(ctx context.Context, cfg ServerConfig) (
 	map[string]sources.Source,
 	map[string]auth.AuthService,
@@ -16,12 +16,14 @@ package server_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/googleapis/genai-toolbox/internal/auth"
@@ -197,3 +199,87 @@ func TestUpdateServer(t *testing.T) {
 		t.Errorf("error updating server, promptset (-want +got):\n%s", diff)
 	}
 }
+
+// plainSource is a source that doesn't implement sources.Pingable.
+type plainSource struct{}
+
+func (s *plainSource) SourceKind() string             { return "plain" }
+func (s *plainSource) ToConfig() sources.SourceConfig { return nil }
+
+// pingableSource is a source whose HealthCheck returns err.
+type pingableSource struct {
+	err error
+}
+
+func (s *pingableSource) SourceKind() string                { return "pingable" }
+func (s *pingableSource) ToConfig() sources.SourceConfig    { return nil }
+func (s *pingableSource) HealthCheck(context.Context) error { return s.err }
+
+func TestCheckSourcesHealth(t *testing.T) {
+	resourceMgr := server.NewResourceManager(map[string]sources.Source{
+		"healthy":    &pingableSource{},
+		"unhealthy":  &pingableSource{err: fmt.Errorf("connection refused")},
+		"unpingable": &plainSource{},
+	}, nil, nil, nil, nil, nil)
+
+	results := resourceMgr.CheckSourcesHealth(context.Background())
+	if len(results) != 2 {
+		t.Fatalf("unexpected results: %v", results)
+	}
+	if err := results["healthy"]; err != nil {
+		t.Errorf("unexpected error for healthy source: %s", err)
+	}
+	if err := results["unhealthy"]; err == nil || !strings.Contains(err.Error(), "connection refused") {
+		t.Errorf("unexpected error for unhealthy source: %v", err)
+	}
+}
+
+// closableSource is a source whose Close records the call and returns err.
+type closableSource struct {
+	closed bool
+	err    error
+}
+
+func (s *closableSource) SourceKind() string             { return "closable" }
+func (s *closableSource) ToConfig() sources.SourceConfig { return nil }
+func (s *closableSource) Close() error {
+	s.closed = true
+	return s.err
+}
+
+// blockingSource is a source whose CloseWithContext blocks until ctx is done.
+type blockingSource struct{}
+
+func (s *blockingSource) SourceKind() string             { return "blocking" }
+func (s *blockingSource) ToConfig() sources.SourceConfig { return nil }
+func (s *blockingSource) CloseWithContext(ctx context.Context) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func TestCloseSources(t *testing.T) {
+	closable := &closableSource{}
+	failing := &closableSource{err: fmt.Errorf("connection reset")}
+	resourceMgr := server.NewResourceManager(map[string]sources.Source{
+		"closable": closable,
+		"failing":  failing,
+		"blocking": &blockingSource{},
+		"plain":    &plainSource{},
+	}, nil, nil, nil, nil, nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	err := resourceMgr.CloseSources(ctx)
+	if !closable.closed || !failing.closed {
+		t.Errorf("expected all io.Closer sources to be closed")
+	}
+	if err == nil {
+		t.Fatal("expected close errors")
+	}
+	if !strings.Contains(err.Error(), "connection reset") {
+		t.Errorf("missing error for failing source: %s", err)
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded for blocking source: %s", err)
+	}
+}
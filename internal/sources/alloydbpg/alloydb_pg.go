@@ -34,9 +34,7 @@ const SourceKind string = "alloydb-postgres"
 var _ sources.SourceConfig = Config{}
 
 func init() {
-	if !sources.Register(SourceKind, newConfig) {
-		panic(fmt.Sprintf("source kind %q already registered", SourceKind))
-	}
+	sources.MustRegister(SourceKind, newConfig)
 }
 
 func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources.SourceConfig, error) {
@@ -97,6 +95,11 @@ func (s *Source) ToConfig() sources.SourceConfig {
 	return s.Config
 }
 
+// HealthCheck pings the database.
+func (s *Source) HealthCheck(ctx context.Context) error {
+	return s.Pool.Ping(ctx)
+}
+
 func (s *Source) PostgresPool() *pgxpool.Pool {
 	return s.Pool
 }
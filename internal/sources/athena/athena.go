@@ -22,8 +22,6 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/aws/aws-sdk-go-v2/config"
-	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/service/athena"
 	"github.com/goccy/go-yaml"
 	"github.com/googleapis/genai-toolbox/internal/sources"
@@ -37,9 +35,7 @@ const SourceKind string = "athena"
 var _ sources.SourceConfig = Config{}
 
 func init() {
-	if !sources.Register(SourceKind, newConfig) {
-		panic(fmt.Sprintf("source kind %q already registered", SourceKind))
-	}
+	sources.MustRegister(SourceKind, newConfig)
 }
 
 func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources.SourceConfig, error) {
@@ -47,17 +43,45 @@ func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources
 	if err := decoder.DecodeContext(ctx, &actual); err != nil {
 		return nil, err
 	}
+
+	for _, secret := range []struct {
+		field string
+		value *string
+		file  string
+	}{
+		{"secretAccessKey", &actual.SecretAccessKey, actual.SecretAccessKeyFile},
+		{"sessionToken", &actual.SessionToken, actual.SessionTokenFile},
+	} {
+		resolved, err := sourceutil.ResolveSecret(secret.field, *secret.value, secret.file)
+		if err != nil {
+			return nil, fmt.Errorf("source %q (%s): unable to resolve secret: %w", name, SourceKind, err)
+		}
+		*secret.value = resolved
+	}
+
+	switch actual.EncryptionOption {
+	case "", "SSE_S3":
+	case "SSE_KMS", "CSE_KMS":
+		if actual.KmsKey == "" {
+			return nil, fmt.Errorf("source %q (%s): kmsKey is required with encryptionOption %s", name, SourceKind, actual.EncryptionOption)
+		}
+	default:
+		return nil, fmt.Errorf("source %q (%s): invalid encryptionOption %q, must be SSE_S3, SSE_KMS, or CSE_KMS", name, SourceKind, actual.EncryptionOption)
+	}
+	if actual.MaxRetries < 0 {
+		return nil, fmt.Errorf("source %q (%s): maxRetries must not be negative", name, SourceKind)
+	}
 	return actual, nil
 }
 
 // Config holds the Athena source configuration.
-// Note: Fields like Database, OutputLocation, WorkGroup, etc. are stored for use by
-// consuming code when executing queries. They are not used during client initialization,
-// which only requires Region for authentication and connection setup.
+// Note: Fields like Database, OutputLocation, WorkGroup, and the encryption settings
+// are applied when executing queries with RunQuery. They are not used during client
+// initialization, which only uses Region for authentication and connection setup.
 type Config struct {
 	Name                 string `yaml:"name" validate:"required"`
 	Kind                 string `yaml:"kind" validate:"required"`
-	Region               string `yaml:"region" validate:"required"`
+	Region               string `yaml:"region"`               // Optional: resolved from AWS_REGION, the shared config, or EC2 instance metadata if unset
 	Database             string `yaml:"database"`             // Optional: default database for queries
 	OutputLocation       string `yaml:"outputLocation"`       // Optional: S3 location for query results (s3://bucket/path/)
 	WorkGroup            string `yaml:"workGroup"`            // Optional: Athena workgroup for query execution
@@ -66,7 +90,15 @@ type Config struct {
 	QueryResultsLocation string `yaml:"queryResultsLocation"` // Optional: S3 location for query results (alias for OutputLocation)
 	AccessKeyID          string `yaml:"accessKeyId"`          // Optional: explicit credentials
 	SecretAccessKey      string `yaml:"secretAccessKey"`      // Optional: explicit credentials
+	SecretAccessKeyFile  string `yaml:"secretAccessKeyFile"`  // Optional: file to read secretAccessKey from
 	SessionToken         string `yaml:"sessionToken"`         // Optional: session token
+	SessionTokenFile     string `yaml:"sessionTokenFile"`     // Optional: file to read sessionToken from
+	RoleARN              string `yaml:"roleArn"`              // Optional: role to assume with the resolved credentials
+	RoleSessionName      string `yaml:"roleSessionName"`      // Optional: session name for the assumed role
+	ExternalID           string `yaml:"externalId"`           // Optional: external ID required by the role trust policy
+	UseFIPS              bool   `yaml:"useFIPS"`              // Optional: use FIPS endpoints
+	UseDualStack         bool   `yaml:"useDualStack"`         // Optional: use dual-stack (IPv4 and IPv6) endpoints
+	MaxRetries           int    `yaml:"maxRetries"`           // Optional: retries after the first attempt, e.g. on throttling (default: 2)
 }
 
 func (r Config) SourceConfigKind() string {
@@ -74,14 +106,14 @@ func (r Config) SourceConfigKind() string {
 }
 
 func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.Source, error) {
-	client, err := initAthenaClient(ctx, tracer, r.Name, r.Region, r.AccessKeyID, r.SecretAccessKey, r.SessionToken)
+	client, err := initAthenaClient(ctx, tracer, r)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create Athena client: %w", err)
 	}
 
 	// Verify the connection by listing databases
 	_, err = client.ListDatabases(ctx, &athena.ListDatabasesInput{
-		CatalogName: sourceutil.StringPtr("AwsDataCatalog"),
+		CatalogName: sourceutil.StringPtr(DefaultCatalog),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("source %q (%s): unable to connect successfully: %w", r.Name, SourceKind, err)
@@ -90,6 +122,7 @@ func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.So
 	s := &Source{
 		Config: r,
 		Client: client,
+		api:    client,
 	}
 	return s, nil
 }
@@ -99,6 +132,7 @@ var _ sources.Source = &Source{}
 type Source struct {
 	Config
 	Client *athena.Client
+	api    athenaAPI // Client, replaced in tests
 }
 
 func (s *Source) SourceKind() string {
@@ -109,6 +143,15 @@ func (s *Source) ToConfig() sources.SourceConfig {
 	return s.Config
 }
 
+// HealthCheck lists databases in the default catalog, as Initialize does.
+func (s *Source) HealthCheck(ctx context.Context) error {
+	_, err := s.Client.ListDatabases(ctx, &athena.ListDatabasesInput{
+		CatalogName: sourceutil.StringPtr(DefaultCatalog),
+		MaxResults:  sourceutil.Int32Ptr(1),
+	})
+	return err
+}
+
 // AthenaClient returns the underlying AWS Athena client for direct API access.
 func (s *Source) AthenaClient() *athena.Client {
 	return s.Client
@@ -117,27 +160,28 @@ func (s *Source) AthenaClient() *athena.Client {
 // Close is not needed for this source because AWS SDK v2 clients manage
 // their own connection pooling and cleanup automatically.
 
-func initAthenaClient(ctx context.Context, tracer trace.Tracer, name, region, accessKeyID, secretAccessKey, sessionToken string) (*athena.Client, error) {
+func initAthenaClient(ctx context.Context, tracer trace.Tracer, r Config) (*athena.Client, error) {
 	//nolint:all // Reassigned ctx
-	ctx, span := sources.InitConnectionSpan(ctx, tracer, SourceKind, name)
+	ctx, span := sources.InitConnectionSpan(ctx, tracer, SourceKind, r.Name)
 	defer span.End()
 
-	// Build AWS config load options
-	configOpts := []func(*config.LoadOptions) error{
-		config.WithRegion(region),
-	}
-
-	// Use explicit credentials if provided
-	if accessKeyID != "" && secretAccessKey != "" {
-		configOpts = append(configOpts, config.WithCredentialsProvider(
-			credentials.NewStaticCredentialsProvider(accessKeyID, secretAccessKey, sessionToken),
-		))
-	}
-
-	// Load AWS configuration
-	cfg, err := config.LoadDefaultConfig(ctx, configOpts...)
+	cfg, err := sourceutil.LoadAWSConfig(ctx, sourceutil.AWSOptions{
+		Region:          r.Region,
+		AccessKeyID:     r.AccessKeyID,
+		SecretAccessKey: r.SecretAccessKey,
+		SessionToken:    r.SessionToken,
+		RoleARN:         r.RoleARN,
+		RoleSessionName: r.RoleSessionName,
+		ExternalID:      r.ExternalID,
+		UseFIPS:         r.UseFIPS,
+		UseDualStack:    r.UseDualStack,
+		MaxRetries:      r.MaxRetries,
+	})
 	if err != nil {
-		return nil, fmt.Errorf("unable to load AWS config: %w", err)
+		return nil, err
+	}
+	if cfg.Region == "" {
+		return nil, fmt.Errorf("unable to determine AWS region, set region in the source config or AWS_REGION")
 	}
 
 	// Create Athena client
@@ -17,10 +17,16 @@ package athena
 import (
 	"bytes"
 	"context"
+	"fmt"
 	"testing"
+	"time"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/athena"
+	"github.com/aws/aws-sdk-go-v2/service/athena/types"
 	"github.com/goccy/go-yaml"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestParseFromYamlAthena(t *testing.T) {
@@ -124,6 +130,40 @@ queryResultsLocation: s3://prod-bucket/query-results/`,
 				QueryResultsLocation: "s3://prod-bucket/query-results/",
 			},
 		},
+		{
+			name: "valid configuration with assumed role",
+			yamlContent: `name: test-athena
+kind: athena
+region: us-east-1
+roleArn: arn:aws:iam::123456789012:role/toolbox
+roleSessionName: toolbox-session
+externalId: my-external-id`,
+			wantErr: false,
+			expected: Config{
+				Name:            "test-athena",
+				Kind:            "athena",
+				Region:          "us-east-1",
+				RoleARN:         "arn:aws:iam::123456789012:role/toolbox",
+				RoleSessionName: "toolbox-session",
+				ExternalID:      "my-external-id",
+			},
+		},
+		{
+			name: "valid configuration with FIPS and dual-stack endpoints",
+			yamlContent: `name: test-athena
+kind: athena
+region: us-east-1
+useFIPS: true
+useDualStack: true`,
+			wantErr: false,
+			expected: Config{
+				Name:         "test-athena",
+				Kind:         "athena",
+				Region:       "us-east-1",
+				UseFIPS:      true,
+				UseDualStack: true,
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -156,6 +196,11 @@ queryResultsLocation: s3://prod-bucket/query-results/`,
 				if tt.expected.QueryResultsLocation != "" {
 					assert.Equal(t, tt.expected.QueryResultsLocation, config.(Config).QueryResultsLocation)
 				}
+				assert.Equal(t, tt.expected.RoleARN, config.(Config).RoleARN)
+				assert.Equal(t, tt.expected.RoleSessionName, config.(Config).RoleSessionName)
+				assert.Equal(t, tt.expected.ExternalID, config.(Config).ExternalID)
+				assert.Equal(t, tt.expected.UseFIPS, config.(Config).UseFIPS)
+				assert.Equal(t, tt.expected.UseDualStack, config.(Config).UseDualStack)
 			}
 		})
 	}
@@ -178,6 +223,20 @@ region: [invalid yaml syntax`,
   kind: athena
     region: us-east-1`,
 		},
+		{
+			name: "invalid encryption option",
+			yamlContent: `name: test-athena
+kind: athena
+region: us-east-1
+encryptionOption: AES256`,
+		},
+		{
+			name: "KMS encryption without key",
+			yamlContent: `name: test-athena
+kind: athena
+region: us-east-1
+encryptionOption: SSE_KMS`,
+		},
 	}
 
 	for _, tt := range tests {
@@ -200,3 +259,420 @@ func TestSourceKindAthena(t *testing.T) {
 	source := Source{Config: config}
 	assert.Equal(t, SourceKind, source.SourceKind())
 }
+
+// fakeAthenaClient serves canned responses for the query helpers.
+type fakeAthenaClient struct {
+	started  *athena.StartQueryExecutionInput
+	states   []types.QueryExecutionState
+	polls    int
+	reason   string
+	pages    []*athena.GetQueryResultsOutput
+	pageReqs []*athena.GetQueryResultsInput
+	stopped  []string
+	dbPages  []*athena.ListDatabasesOutput
+	dbReqs   []*athena.ListDatabasesInput
+	tblPages []*athena.ListTableMetadataOutput
+	tblReqs  []*athena.ListTableMetadataInput
+	nqPages  []*athena.ListNamedQueriesOutput
+	nqReqs   []*athena.ListNamedQueriesInput
+	nqBatch  [][]string
+	nqByID   map[string]types.NamedQuery
+	created  *athena.CreateNamedQueryInput
+}
+
+func (f *fakeAthenaClient) StartQueryExecution(ctx context.Context, params *athena.StartQueryExecutionInput, optFns ...func(*athena.Options)) (*athena.StartQueryExecutionOutput, error) {
+	f.started = params
+	return &athena.StartQueryExecutionOutput{QueryExecutionId: aws.String("query-1")}, nil
+}
+
+func (f *fakeAthenaClient) GetQueryExecution(ctx context.Context, params *athena.GetQueryExecutionInput, optFns ...func(*athena.Options)) (*athena.GetQueryExecutionOutput, error) {
+	state := f.states[min(f.polls, len(f.states)-1)]
+	f.polls++
+	return &athena.GetQueryExecutionOutput{
+		QueryExecution: &types.QueryExecution{
+			QueryExecutionId: params.QueryExecutionId,
+			Status:           &types.QueryExecutionStatus{State: state, StateChangeReason: aws.String(f.reason)},
+			Statistics: &types.QueryExecutionStatistics{
+				DataScannedInBytes:          aws.Int64(2048),
+				EngineExecutionTimeInMillis: aws.Int64(1200),
+				TotalExecutionTimeInMillis:  aws.Int64(1500),
+				QueryQueueTimeInMillis:      aws.Int64(250),
+			},
+		},
+	}, nil
+}
+
+func (f *fakeAthenaClient) GetQueryResults(ctx context.Context, params *athena.GetQueryResultsInput, optFns ...func(*athena.Options)) (*athena.GetQueryResultsOutput, error) {
+	f.pageReqs = append(f.pageReqs, params)
+	return f.pages[len(f.pageReqs)-1], nil
+}
+
+func (f *fakeAthenaClient) StopQueryExecution(ctx context.Context, params *athena.StopQueryExecutionInput, optFns ...func(*athena.Options)) (*athena.StopQueryExecutionOutput, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	f.stopped = append(f.stopped, aws.ToString(params.QueryExecutionId))
+	return &athena.StopQueryExecutionOutput{}, nil
+}
+
+func (f *fakeAthenaClient) ListDatabases(ctx context.Context, params *athena.ListDatabasesInput, optFns ...func(*athena.Options)) (*athena.ListDatabasesOutput, error) {
+	f.dbReqs = append(f.dbReqs, params)
+	return f.dbPages[len(f.dbReqs)-1], nil
+}
+
+func (f *fakeAthenaClient) ListTableMetadata(ctx context.Context, params *athena.ListTableMetadataInput, optFns ...func(*athena.Options)) (*athena.ListTableMetadataOutput, error) {
+	f.tblReqs = append(f.tblReqs, params)
+	return f.tblPages[len(f.tblReqs)-1], nil
+}
+
+func (f *fakeAthenaClient) ListNamedQueries(ctx context.Context, params *athena.ListNamedQueriesInput, optFns ...func(*athena.Options)) (*athena.ListNamedQueriesOutput, error) {
+	f.nqReqs = append(f.nqReqs, params)
+	return f.nqPages[len(f.nqReqs)-1], nil
+}
+
+func (f *fakeAthenaClient) BatchGetNamedQuery(ctx context.Context, params *athena.BatchGetNamedQueryInput, optFns ...func(*athena.Options)) (*athena.BatchGetNamedQueryOutput, error) {
+	f.nqBatch = append(f.nqBatch, params.NamedQueryIds)
+	out := &athena.BatchGetNamedQueryOutput{}
+	for _, id := range params.NamedQueryIds {
+		if q, ok := f.nqByID[id]; ok {
+			out.NamedQueries = append(out.NamedQueries, q)
+		} else {
+			out.UnprocessedNamedQueryIds = append(out.UnprocessedNamedQueryIds, types.UnprocessedNamedQueryId{
+				NamedQueryId: aws.String(id),
+				ErrorCode:    aws.String("INVALID_INPUT"),
+				ErrorMessage: aws.String("named query not found"),
+			})
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeAthenaClient) GetNamedQuery(ctx context.Context, params *athena.GetNamedQueryInput, optFns ...func(*athena.Options)) (*athena.GetNamedQueryOutput, error) {
+	q, ok := f.nqByID[aws.ToString(params.NamedQueryId)]
+	if !ok {
+		return nil, fmt.Errorf("InvalidRequestException: named query not found")
+	}
+	return &athena.GetNamedQueryOutput{NamedQuery: &q}, nil
+}
+
+func (f *fakeAthenaClient) CreateNamedQuery(ctx context.Context, params *athena.CreateNamedQueryInput, optFns ...func(*athena.Options)) (*athena.CreateNamedQueryOutput, error) {
+	f.created = params
+	return &athena.CreateNamedQueryOutput{NamedQueryId: aws.String("nq-new")}, nil
+}
+
+func resultRow(values ...*string) types.Row {
+	row := types.Row{}
+	for _, v := range values {
+		row.Data = append(row.Data, types.Datum{VarCharValue: v})
+	}
+	return row
+}
+
+func TestRunQuery(t *testing.T) {
+	metadata := &types.ResultSetMetadata{ColumnInfo: []types.ColumnInfo{
+		{Name: aws.String("id"), Type: aws.String("bigint")},
+		{Name: aws.String("name"), Type: aws.String("varchar")},
+		{Name: aws.String("created"), Type: aws.String("timestamp")},
+	}}
+	client := &fakeAthenaClient{
+		states: []types.QueryExecutionState{types.QueryExecutionStateQueued, types.QueryExecutionStateRunning, types.QueryExecutionStateSucceeded},
+		pages: []*athena.GetQueryResultsOutput{
+			{
+				ResultSet: &types.ResultSet{
+					ResultSetMetadata: metadata,
+					Rows: []types.Row{
+						resultRow(aws.String("id"), aws.String("name"), aws.String("created")),
+						resultRow(aws.String("1"), aws.String("alice"), aws.String("2024-01-02 03:04:05.000")),
+					},
+				},
+				NextToken: aws.String("page-2"),
+			},
+			{
+				ResultSet: &types.ResultSet{
+					ResultSetMetadata: metadata,
+					Rows:              []types.Row{resultRow(aws.String("2"), nil, nil)},
+				},
+			},
+		},
+	}
+	source := &Source{
+		Config: Config{Name: "test", Database: "analytics", OutputLocation: "s3://results/", WorkGroup: "agents"},
+		api:    client,
+	}
+
+	results, err := source.RunQuery(context.Background(), "SELECT id, name, created FROM users")
+	require.NoError(t, err)
+
+	assert.Equal(t, "analytics", aws.ToString(client.started.QueryExecutionContext.Database))
+	assert.Equal(t, "s3://results/", aws.ToString(client.started.ResultConfiguration.OutputLocation))
+	assert.Equal(t, "agents", aws.ToString(client.started.WorkGroup))
+
+	assert.Equal(t, "query-1", results.QueryExecutionID)
+	assert.Equal(t, &QueryStatistics{
+		DataScannedBytes:    2048,
+		EngineExecutionTime: 1200 * time.Millisecond,
+		TotalExecutionTime:  1500 * time.Millisecond,
+		QueryQueueTime:      250 * time.Millisecond,
+	}, results.Statistics)
+	assert.Equal(t, []Column{{Name: "id", Type: "bigint"}, {Name: "name", Type: "varchar"}, {Name: "created", Type: "timestamp"}}, results.Columns)
+	assert.Equal(t, [][]any{
+		{int64(1), "alice", time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)},
+		{int64(2), nil, nil},
+	}, results.Rows)
+	assert.Equal(t, "page-2", aws.ToString(client.pageReqs[1].NextToken))
+}
+
+func TestRunQueryFailed(t *testing.T) {
+	source := &Source{
+		Config: Config{Name: "test"},
+		api: &fakeAthenaClient{
+			states: []types.QueryExecutionState{types.QueryExecutionStateFailed},
+			reason: "SYNTAX_ERROR: line 1:8: Column 'nme' cannot be resolved",
+		},
+	}
+
+	_, err := source.RunQuery(context.Background(), "SELECT nme FROM users")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Column 'nme' cannot be resolved")
+}
+
+func TestRunQueryStopsOnCancel(t *testing.T) {
+	client := &fakeAthenaClient{states: []types.QueryExecutionState{types.QueryExecutionStateRunning}}
+	source := &Source{Config: Config{Name: "test"}, api: client}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := source.RunQuery(ctx, "SELECT * FROM huge_table")
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, []string{"query-1"}, client.stopped)
+}
+
+func TestStartQueryInput(t *testing.T) {
+	source := &Source{Config: Config{
+		Database:             "analytics",
+		WorkGroup:            "agents",
+		QueryResultsLocation: "s3://results/",
+		EncryptionOption:     "SSE_KMS",
+		KmsKey:               "arn:aws:kms:us-east-1:123456789012:key/abcd",
+	}}
+
+	input := source.startQueryInput("SELECT 1")
+	assert.Equal(t, "agents", aws.ToString(input.WorkGroup))
+	require.NotNil(t, input.ResultConfiguration)
+	assert.Equal(t, "s3://results/", aws.ToString(input.ResultConfiguration.OutputLocation))
+	require.NotNil(t, input.ResultConfiguration.EncryptionConfiguration)
+	assert.Equal(t, types.EncryptionOptionSseKms, input.ResultConfiguration.EncryptionConfiguration.EncryptionOption)
+	assert.Equal(t, "arn:aws:kms:us-east-1:123456789012:key/abcd", aws.ToString(input.ResultConfiguration.EncryptionConfiguration.KmsKey))
+
+	// OutputLocation takes precedence over its alias
+	source.OutputLocation = "s3://primary/"
+	input = source.startQueryInput("SELECT 1")
+	assert.Equal(t, "s3://primary/", aws.ToString(input.ResultConfiguration.OutputLocation))
+
+	// Without result settings the workgroup defaults apply
+	input = (&Source{}).startQueryInput("SELECT 1")
+	assert.Nil(t, input.ResultConfiguration)
+	assert.Nil(t, input.WorkGroup)
+}
+
+func TestFetchAllResults(t *testing.T) {
+	metadata := &types.ResultSetMetadata{ColumnInfo: []types.ColumnInfo{
+		{Name: aws.String("id"), Type: aws.String("bigint")},
+		{Name: aws.String("name"), Type: aws.String("varchar")},
+	}}
+	header := resultRow(aws.String("id"), aws.String("name"))
+	newClient := func() *fakeAthenaClient {
+		return &fakeAthenaClient{pages: []*athena.GetQueryResultsOutput{
+			{
+				ResultSet: &types.ResultSet{ResultSetMetadata: metadata, Rows: []types.Row{header, resultRow(aws.String("1"), aws.String("alice"))}},
+				NextToken: aws.String("page-2"),
+			},
+			{
+				ResultSet: &types.ResultSet{ResultSetMetadata: metadata, Rows: []types.Row{resultRow(aws.String("2"), nil)}},
+				NextToken: aws.String("page-3"),
+			},
+			{
+				ResultSet: &types.ResultSet{ResultSetMetadata: metadata, Rows: []types.Row{resultRow(aws.String("3"), aws.String("carol"))}},
+			},
+		}}
+	}
+
+	client := newClient()
+	source := &Source{Config: Config{Name: "test"}, api: client}
+	rows, err := source.FetchAllResults(context.Background(), "query-1")
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{"1", "alice"}, {"2", ""}, {"3", "carol"}}, rows)
+	require.Len(t, client.pageReqs, 3)
+	assert.Nil(t, client.pageReqs[0].NextToken)
+	assert.Equal(t, "page-3", aws.ToString(client.pageReqs[2].NextToken))
+
+	// Stopping the iterator early doesn't fetch the remaining pages
+	client = newClient()
+	source.api = client
+	for row, err := range source.IterateResults(context.Background(), "query-1") {
+		require.NoError(t, err)
+		assert.Equal(t, []string{"1", "alice"}, row)
+		break
+	}
+	assert.Len(t, client.pageReqs, 1)
+}
+
+func TestFetchAllResultsKeepsHeaderLikeRowsAfterFirstPage(t *testing.T) {
+	metadata := &types.ResultSetMetadata{ColumnInfo: []types.ColumnInfo{
+		{Name: aws.String("key"), Type: aws.String("varchar")},
+		{Name: aws.String("value"), Type: aws.String("varchar")},
+	}}
+	header := resultRow(aws.String("key"), aws.String("value"))
+	client := &fakeAthenaClient{pages: []*athena.GetQueryResultsOutput{
+		{
+			ResultSet: &types.ResultSet{ResultSetMetadata: metadata, Rows: []types.Row{header, resultRow(aws.String("a"), aws.String("1"))}},
+			NextToken: aws.String("page-2"),
+		},
+		{
+			// A data row that happens to equal the column names
+			ResultSet: &types.ResultSet{ResultSetMetadata: metadata, Rows: []types.Row{header, resultRow(aws.String("b"), aws.String("2"))}},
+		},
+	}}
+	source := &Source{Config: Config{Name: "test"}, api: client}
+
+	rows, err := source.FetchAllResults(context.Background(), "query-1")
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{"a", "1"}, {"key", "value"}, {"b", "2"}}, rows)
+}
+
+func TestListDatabases(t *testing.T) {
+	client := &fakeAthenaClient{dbPages: []*athena.ListDatabasesOutput{
+		{DatabaseList: []types.Database{{Name: aws.String("default")}}, NextToken: aws.String("page-2")},
+		{DatabaseList: []types.Database{{Name: aws.String("analytics")}}},
+	}}
+	source := &Source{Config: Config{Name: "test"}, api: client}
+
+	databases, err := source.ListDatabases(context.Background(), "")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"default", "analytics"}, databases)
+	require.Len(t, client.dbReqs, 2)
+	assert.Equal(t, DefaultCatalog, aws.ToString(client.dbReqs[0].CatalogName))
+	assert.Equal(t, "page-2", aws.ToString(client.dbReqs[1].NextToken))
+}
+
+func TestListTables(t *testing.T) {
+	client := &fakeAthenaClient{tblPages: []*athena.ListTableMetadataOutput{
+		{
+			TableMetadataList: []types.TableMetadata{{
+				Name:          aws.String("events"),
+				TableType:     aws.String("EXTERNAL_TABLE"),
+				Columns:       []types.Column{{Name: aws.String("id"), Type: aws.String("bigint")}},
+				PartitionKeys: []types.Column{{Name: aws.String("dt"), Type: aws.String("string")}},
+			}},
+			NextToken: aws.String("page-2"),
+		},
+		{TableMetadataList: []types.TableMetadata{{Name: aws.String("users_view"), TableType: aws.String("VIRTUAL_VIEW")}}},
+	}}
+	source := &Source{Config: Config{Name: "test"}, api: client}
+
+	tables, err := source.ListTables(context.Background(), "my_catalog", "analytics")
+	require.NoError(t, err)
+	assert.Equal(t, []TableMetadata{
+		{
+			Name:          "events",
+			TableType:     "EXTERNAL_TABLE",
+			Columns:       []Column{{Name: "id", Type: "bigint"}},
+			PartitionKeys: []Column{{Name: "dt", Type: "string"}},
+		},
+		{Name: "users_view", TableType: "VIRTUAL_VIEW", Columns: []Column{}, PartitionKeys: []Column{}},
+	}, tables)
+	require.Len(t, client.tblReqs, 2)
+	assert.Equal(t, "my_catalog", aws.ToString(client.tblReqs[0].CatalogName))
+	assert.Equal(t, "analytics", aws.ToString(client.tblReqs[0].DatabaseName))
+	assert.Equal(t, "page-2", aws.ToString(client.tblReqs[1].NextToken))
+
+	_, err = source.ListTables(context.Background(), "", "")
+	require.Error(t, err)
+}
+
+func TestListNamedQueries(t *testing.T) {
+	ids := make([]string, MaxNamedQueryBatch+1)
+	byID := map[string]types.NamedQuery{}
+	for i := range ids {
+		ids[i] = fmt.Sprintf("nq-%d", i)
+		byID[ids[i]] = types.NamedQuery{
+			NamedQueryId: aws.String(ids[i]),
+			Name:         aws.String(fmt.Sprintf("query %d", i)),
+			Database:     aws.String("analytics"),
+			QueryString:  aws.String("SELECT 1"),
+			WorkGroup:    aws.String("reporting"),
+		}
+	}
+	client := &fakeAthenaClient{
+		nqPages: []*athena.ListNamedQueriesOutput{
+			{NamedQueryIds: ids[:10], NextToken: aws.String("page-2")},
+			{NamedQueryIds: ids[10:]},
+		},
+		nqByID: byID,
+	}
+	source := &Source{Config: Config{Name: "test", WorkGroup: "reporting"}, api: client}
+
+	queries, err := source.ListNamedQueries(context.Background())
+	require.NoError(t, err)
+	require.Len(t, queries, len(ids))
+	assert.Equal(t, NamedQuery{ID: "nq-0", Name: "query 0", Database: "analytics", QueryString: "SELECT 1", WorkGroup: "reporting"}, queries[0])
+	require.Len(t, client.nqReqs, 2)
+	assert.Equal(t, "reporting", aws.ToString(client.nqReqs[0].WorkGroup))
+	assert.Equal(t, "page-2", aws.ToString(client.nqReqs[1].NextToken))
+	require.Len(t, client.nqBatch, 2)
+	assert.Len(t, client.nqBatch[0], MaxNamedQueryBatch)
+	assert.Len(t, client.nqBatch[1], 1)
+
+	client = &fakeAthenaClient{
+		nqPages: []*athena.ListNamedQueriesOutput{{NamedQueryIds: []string{"deleted"}}},
+		nqByID:  map[string]types.NamedQuery{},
+	}
+	source = &Source{Config: Config{Name: "test"}, api: client}
+	_, err = source.ListNamedQueries(context.Background())
+	assert.ErrorContains(t, err, "unable to get named query deleted: INVALID_INPUT")
+}
+
+func TestGetNamedQuery(t *testing.T) {
+	client := &fakeAthenaClient{nqByID: map[string]types.NamedQuery{
+		"nq-primary": {NamedQueryId: aws.String("nq-primary"), Name: aws.String("daily"), WorkGroup: aws.String("primary")},
+		"nq-other":   {NamedQueryId: aws.String("nq-other"), Name: aws.String("adhoc"), WorkGroup: aws.String("reporting")},
+	}}
+	source := &Source{Config: Config{Name: "test"}, api: client}
+
+	q, err := source.GetNamedQuery(context.Background(), "nq-primary")
+	require.NoError(t, err)
+	assert.Equal(t, "daily", q.Name)
+
+	_, err = source.GetNamedQuery(context.Background(), "nq-other")
+	assert.ErrorContains(t, err, "named query nq-other not found in workgroup primary")
+
+	_, err = source.GetNamedQuery(context.Background(), "missing")
+	assert.ErrorContains(t, err, "unable to get named query missing")
+}
+
+func TestCreateNamedQuery(t *testing.T) {
+	client := &fakeAthenaClient{}
+	source := &Source{Config: Config{Name: "test", Database: "analytics", WorkGroup: "reporting"}, api: client}
+
+	id, err := source.CreateNamedQuery(context.Background(), "daily", "Daily active users", "", "SELECT count(*) FROM events")
+	require.NoError(t, err)
+	assert.Equal(t, "nq-new", id)
+	assert.Equal(t, "daily", aws.ToString(client.created.Name))
+	assert.Equal(t, "Daily active users", aws.ToString(client.created.Description))
+	assert.Equal(t, "analytics", aws.ToString(client.created.Database))
+	assert.Equal(t, "reporting", aws.ToString(client.created.WorkGroup))
+
+	source = &Source{Config: Config{Name: "test"}, api: client}
+	_, err = source.CreateNamedQuery(context.Background(), "daily", "", "", "SELECT 1")
+	assert.ErrorContains(t, err, "name, database, and sql are required")
+}
+
+func TestConvertValue(t *testing.T) {
+	assert.Equal(t, true, convertValue(aws.String("true"), "boolean"))
+	assert.Equal(t, int64(42), convertValue(aws.String("42"), "integer"))
+	assert.Equal(t, 1.5, convertValue(aws.String("1.5"), "double"))
+	assert.Equal(t, "12345678901234567890.12", convertValue(aws.String("12345678901234567890.12"), "decimal"))
+	assert.Equal(t, time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), convertValue(aws.String("2024-01-02"), "date"))
+	assert.Equal(t, "not a number", convertValue(aws.String("not a number"), "bigint"))
+	assert.Nil(t, convertValue(nil, "varchar"))
+}
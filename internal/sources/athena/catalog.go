@@ -0,0 +1,108 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package athena
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/athena"
+	"github.com/aws/aws-sdk-go-v2/service/athena/types"
+)
+
+// DefaultCatalog is the AWS Glue Data Catalog, used when no catalog is given.
+const DefaultCatalog = "AwsDataCatalog"
+
+// TableMetadata describes a table in an Athena data catalog.
+type TableMetadata struct {
+	Name          string
+	TableType     string // e.g. EXTERNAL_TABLE, VIRTUAL_VIEW
+	Columns       []Column
+	PartitionKeys []Column
+}
+
+// ListDatabases returns the names of the databases in catalog, following
+// NextToken across pages. An empty catalog means DefaultCatalog.
+func (s *Source) ListDatabases(ctx context.Context, catalog string) ([]string, error) {
+	catalog = catalogOrDefault(catalog)
+	databases := []string{}
+	var nextToken *string
+	for {
+		out, err := s.api.ListDatabases(ctx, &athena.ListDatabasesInput{
+			CatalogName: aws.String(catalog),
+			NextToken:   nextToken,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("unable to list databases in catalog %s: %w", catalog, err)
+		}
+		for _, db := range out.DatabaseList {
+			databases = append(databases, aws.ToString(db.Name))
+		}
+		if aws.ToString(out.NextToken) == "" {
+			return databases, nil
+		}
+		nextToken = out.NextToken
+	}
+}
+
+// ListTables returns the tables in database, including their column and
+// partition key schemas, following NextToken across pages. An empty catalog
+// means DefaultCatalog.
+func (s *Source) ListTables(ctx context.Context, catalog, database string) ([]TableMetadata, error) {
+	if database == "" {
+		return nil, fmt.Errorf("database is required")
+	}
+	catalog = catalogOrDefault(catalog)
+	tables := []TableMetadata{}
+	var nextToken *string
+	for {
+		out, err := s.api.ListTableMetadata(ctx, &athena.ListTableMetadataInput{
+			CatalogName:  aws.String(catalog),
+			DatabaseName: aws.String(database),
+			NextToken:    nextToken,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("unable to list tables in %s.%s: %w", catalog, database, err)
+		}
+		for _, table := range out.TableMetadataList {
+			tables = append(tables, TableMetadata{
+				Name:          aws.ToString(table.Name),
+				TableType:     aws.ToString(table.TableType),
+				Columns:       toColumns(table.Columns),
+				PartitionKeys: toColumns(table.PartitionKeys),
+			})
+		}
+		if aws.ToString(out.NextToken) == "" {
+			return tables, nil
+		}
+		nextToken = out.NextToken
+	}
+}
+
+func catalogOrDefault(catalog string) string {
+	if catalog == "" {
+		return DefaultCatalog
+	}
+	return catalog
+}
+
+func toColumns(columns []types.Column) []Column {
+	result := make([]Column, len(columns))
+	for i, c := range columns {
+		result[i] = Column{Name: aws.ToString(c.Name), Type: aws.ToString(c.Type)}
+	}
+	return result
+}
@@ -0,0 +1,153 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package athena
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/athena"
+	"github.com/aws/aws-sdk-go-v2/service/athena/types"
+)
+
+// MaxNamedQueryBatch is the most named queries BatchGetNamedQuery returns per call.
+const MaxNamedQueryBatch = 50
+
+// DefaultWorkGroup is the workgroup queries run in when none is configured.
+const DefaultWorkGroup = "primary"
+
+// NamedQuery is a saved query.
+type NamedQuery struct {
+	ID          string
+	Name        string
+	Description string
+	Database    string
+	QueryString string
+	WorkGroup   string
+}
+
+// ListNamedQueries returns the named queries in the configured workgroup,
+// following NextToken across pages.
+func (s *Source) ListNamedQueries(ctx context.Context) ([]NamedQuery, error) {
+	var ids []string
+	var nextToken *string
+	for {
+		out, err := s.api.ListNamedQueries(ctx, &athena.ListNamedQueriesInput{
+			WorkGroup: s.workGroupPtr(),
+			NextToken: nextToken,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("unable to list named queries in workgroup %s: %w", s.workGroup(), err)
+		}
+		ids = append(ids, out.NamedQueryIds...)
+		if aws.ToString(out.NextToken) == "" {
+			break
+		}
+		nextToken = out.NextToken
+	}
+
+	queries := make([]NamedQuery, 0, len(ids))
+	for start := 0; start < len(ids); start += MaxNamedQueryBatch {
+		batch := ids[start:min(start+MaxNamedQueryBatch, len(ids))]
+		out, err := s.api.BatchGetNamedQuery(ctx, &athena.BatchGetNamedQueryInput{NamedQueryIds: batch})
+		if err != nil {
+			return nil, fmt.Errorf("unable to get named queries: %w", err)
+		}
+		if len(out.UnprocessedNamedQueryIds) > 0 {
+			u := out.UnprocessedNamedQueryIds[0]
+			return nil, fmt.Errorf("unable to get named query %s: %s: %s", aws.ToString(u.NamedQueryId), aws.ToString(u.ErrorCode), aws.ToString(u.ErrorMessage))
+		}
+		for _, q := range out.NamedQueries {
+			queries = append(queries, toNamedQuery(q))
+		}
+	}
+	return queries, nil
+}
+
+// GetNamedQuery returns the named query with the given ID. Named queries in
+// other workgroups are reported as not found.
+func (s *Source) GetNamedQuery(ctx context.Context, id string) (*NamedQuery, error) {
+	out, err := s.api.GetNamedQuery(ctx, &athena.GetNamedQueryInput{NamedQueryId: aws.String(id)})
+	if err != nil {
+		return nil, fmt.Errorf("unable to get named query %s: %w", id, err)
+	}
+	if out.NamedQuery == nil {
+		return nil, fmt.Errorf("named query %s not found", id)
+	}
+	q := toNamedQuery(*out.NamedQuery)
+	if q.WorkGroup != s.workGroup() {
+		return nil, fmt.Errorf("named query %s not found in workgroup %s", id, s.workGroup())
+	}
+	return &q, nil
+}
+
+// CreateNamedQuery saves sql as a named query in the configured workgroup and
+// returns its ID. An empty database means the configured database.
+func (s *Source) CreateNamedQuery(ctx context.Context, name, description, database, sql string) (string, error) {
+	if database == "" {
+		database = s.Database
+	}
+	if name == "" || database == "" || sql == "" {
+		return "", fmt.Errorf("name, database, and sql are required")
+	}
+
+	input := &athena.CreateNamedQueryInput{
+		Name:        aws.String(name),
+		Database:    aws.String(database),
+		QueryString: aws.String(sql),
+		WorkGroup:   s.workGroupPtr(),
+	}
+	if description != "" {
+		input.Description = aws.String(description)
+	}
+	out, err := s.api.CreateNamedQuery(ctx, input)
+	if err != nil {
+		return "", fmt.Errorf("unable to create named query %q: %w", name, err)
+	}
+	return aws.ToString(out.NamedQueryId), nil
+}
+
+// workGroup returns the configured workgroup, or DefaultWorkGroup.
+func (s *Source) workGroup() string {
+	if s.WorkGroup == "" {
+		return DefaultWorkGroup
+	}
+	return s.WorkGroup
+}
+
+// workGroupPtr returns the configured workgroup for a request, or nil so
+// Athena uses its default.
+func (s *Source) workGroupPtr() *string {
+	if s.WorkGroup == "" {
+		return nil
+	}
+	return aws.String(s.WorkGroup)
+}
+
+func toNamedQuery(q types.NamedQuery) NamedQuery {
+	workGroup := aws.ToString(q.WorkGroup)
+	if workGroup == "" {
+		workGroup = DefaultWorkGroup
+	}
+	return NamedQuery{
+		ID:          aws.ToString(q.NamedQueryId),
+		Name:        aws.ToString(q.Name),
+		Description: aws.ToString(q.Description),
+		Database:    aws.ToString(q.Database),
+		QueryString: aws.ToString(q.QueryString),
+		WorkGroup:   workGroup,
+	}
+}
@@ -0,0 +1,348 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package athena
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/athena"
+	"github.com/aws/aws-sdk-go-v2/service/athena/types"
+)
+
+// Query polling constants
+const (
+	QueryPollInterval    = 200 * time.Millisecond // Initial delay between GetQueryExecution calls
+	MaxQueryPollInterval = 2 * time.Second        // Upper bound for the polling delay
+	StopQueryTimeout     = 10 * time.Second       // Time allowed to stop a query after its context is done
+)
+
+// athenaAPI is the subset of the Athena API used by the query, catalog, and
+// named query helpers.
+type athenaAPI interface {
+	StartQueryExecution(ctx context.Context, params *athena.StartQueryExecutionInput, optFns ...func(*athena.Options)) (*athena.StartQueryExecutionOutput, error)
+	GetQueryExecution(ctx context.Context, params *athena.GetQueryExecutionInput, optFns ...func(*athena.Options)) (*athena.GetQueryExecutionOutput, error)
+	GetQueryResults(ctx context.Context, params *athena.GetQueryResultsInput, optFns ...func(*athena.Options)) (*athena.GetQueryResultsOutput, error)
+	StopQueryExecution(ctx context.Context, params *athena.StopQueryExecutionInput, optFns ...func(*athena.Options)) (*athena.StopQueryExecutionOutput, error)
+	ListDatabases(ctx context.Context, params *athena.ListDatabasesInput, optFns ...func(*athena.Options)) (*athena.ListDatabasesOutput, error)
+	ListTableMetadata(ctx context.Context, params *athena.ListTableMetadataInput, optFns ...func(*athena.Options)) (*athena.ListTableMetadataOutput, error)
+	ListNamedQueries(ctx context.Context, params *athena.ListNamedQueriesInput, optFns ...func(*athena.Options)) (*athena.ListNamedQueriesOutput, error)
+	BatchGetNamedQuery(ctx context.Context, params *athena.BatchGetNamedQueryInput, optFns ...func(*athena.Options)) (*athena.BatchGetNamedQueryOutput, error)
+	GetNamedQuery(ctx context.Context, params *athena.GetNamedQueryInput, optFns ...func(*athena.Options)) (*athena.GetNamedQueryOutput, error)
+	CreateNamedQuery(ctx context.Context, params *athena.CreateNamedQueryInput, optFns ...func(*athena.Options)) (*athena.CreateNamedQueryOutput, error)
+}
+
+// Column describes a column of a query result.
+type Column struct {
+	Name string
+	Type string // Athena type, e.g. varchar, bigint, double, timestamp
+}
+
+// QueryResults holds the rows returned by RunQuery. Values are converted to Go
+// types based on the column type: integers to int64, floating point numbers to
+// float64, booleans to bool, date and timestamp to time.Time, and everything
+// else (including decimal, to preserve precision) to string. NULLs are nil.
+type QueryResults struct {
+	QueryExecutionID string
+	Columns          []Column
+	Rows             [][]any
+	Statistics       *QueryStatistics // Query execution statistics, nil if Athena reported none
+}
+
+// QueryStatistics contains statistics about query execution. Athena bills by
+// DataScannedBytes.
+type QueryStatistics struct {
+	DataScannedBytes    int64         // Number of bytes scanned
+	EngineExecutionTime time.Duration // Time the query engine spent running the query
+	TotalExecutionTime  time.Duration // Time from submission to completion, including queueing
+	QueryQueueTime      time.Duration // Time the query waited for resources before running
+}
+
+// RunQuery runs sql using the configured database, workgroup, output location,
+// and result encryption, waits for it to finish, and returns all of its rows.
+// If ctx is done before the query finishes, the query is stopped so it doesn't
+// keep scanning (and billing for) data.
+func (s *Source) RunQuery(ctx context.Context, sql string) (*QueryResults, error) {
+	out, err := s.api.StartQueryExecution(ctx, s.startQueryInput(sql))
+	if err != nil {
+		return nil, fmt.Errorf("unable to start query: %w", err)
+	}
+	id := aws.ToString(out.QueryExecutionId)
+
+	execution, err := s.waitForQuery(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := s.fetchResults(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	results.Statistics = toQueryStatistics(execution.Statistics)
+	return results, nil
+}
+
+func toQueryStatistics(stats *types.QueryExecutionStatistics) *QueryStatistics {
+	if stats == nil {
+		return nil
+	}
+	return &QueryStatistics{
+		DataScannedBytes:    aws.ToInt64(stats.DataScannedInBytes),
+		EngineExecutionTime: time.Duration(aws.ToInt64(stats.EngineExecutionTimeInMillis)) * time.Millisecond,
+		TotalExecutionTime:  time.Duration(aws.ToInt64(stats.TotalExecutionTimeInMillis)) * time.Millisecond,
+		QueryQueueTime:      time.Duration(aws.ToInt64(stats.QueryQueueTimeInMillis)) * time.Millisecond,
+	}
+}
+
+// startQueryInput builds the StartQueryExecution request for sql from the
+// configured database, workgroup, result location, and result encryption.
+func (s *Source) startQueryInput(sql string) *athena.StartQueryExecutionInput {
+	input := &athena.StartQueryExecutionInput{
+		QueryString: aws.String(sql),
+	}
+	if s.Database != "" {
+		input.QueryExecutionContext = &types.QueryExecutionContext{Database: aws.String(s.Database)}
+	}
+	if s.WorkGroup != "" {
+		input.WorkGroup = aws.String(s.WorkGroup)
+	}
+
+	outputLocation := s.OutputLocation
+	if outputLocation == "" {
+		outputLocation = s.QueryResultsLocation
+	}
+	if outputLocation != "" || s.EncryptionOption != "" {
+		resultConfig := &types.ResultConfiguration{}
+		if outputLocation != "" {
+			resultConfig.OutputLocation = aws.String(outputLocation)
+		}
+		if s.EncryptionOption != "" {
+			resultConfig.EncryptionConfiguration = &types.EncryptionConfiguration{
+				EncryptionOption: types.EncryptionOption(s.EncryptionOption),
+			}
+			if s.KmsKey != "" {
+				resultConfig.EncryptionConfiguration.KmsKey = aws.String(s.KmsKey)
+			}
+		}
+		input.ResultConfiguration = resultConfig
+	}
+	return input
+}
+
+// StopQuery stops a running query. Stopping a query that has already finished
+// has no effect.
+func (s *Source) StopQuery(ctx context.Context, queryExecutionID string) error {
+	_, err := s.api.StopQueryExecution(ctx, &athena.StopQueryExecutionInput{
+		QueryExecutionId: aws.String(queryExecutionID),
+	})
+	if err != nil {
+		return fmt.Errorf("unable to stop query %s: %w", queryExecutionID, err)
+	}
+	return nil
+}
+
+// waitForQuery polls GetQueryExecution with exponential backoff until the query
+// reaches a terminal state. The query is stopped if ctx is done first.
+func (s *Source) waitForQuery(ctx context.Context, id string) (*types.QueryExecution, error) {
+	delay := QueryPollInterval
+	for {
+		out, err := s.api.GetQueryExecution(ctx, &athena.GetQueryExecutionInput{QueryExecutionId: aws.String(id)})
+		if err != nil {
+			return nil, fmt.Errorf("unable to get query execution %s: %w", id, err)
+		}
+		execution := out.QueryExecution
+		if execution != nil && execution.Status != nil {
+			switch execution.Status.State {
+			case types.QueryExecutionStateSucceeded:
+				return execution, nil
+			case types.QueryExecutionStateFailed:
+				return nil, fmt.Errorf("query %s failed: %s", id, aws.ToString(execution.Status.StateChangeReason))
+			case types.QueryExecutionStateCancelled:
+				return nil, fmt.Errorf("query %s was cancelled", id)
+			}
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			// ctx is already done, so stop the query with a fresh deadline
+			stopCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), StopQueryTimeout)
+			defer cancel()
+			if err := s.StopQuery(stopCtx, id); err != nil {
+				return nil, fmt.Errorf("%w (%w)", ctx.Err(), err)
+			}
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+		delay = min(delay*2, MaxQueryPollInterval)
+	}
+}
+
+// FetchAllResults returns every row of a finished query as strings, following
+// NextToken across pages and removing header rows. NULLs are returned as "".
+func (s *Source) FetchAllResults(ctx context.Context, queryExecutionID string) ([][]string, error) {
+	rows := [][]string{}
+	for row, err := range s.IterateResults(ctx, queryExecutionID) {
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// IterateResults returns an iterator over the rows of a finished query, fetching
+// pages on demand. Iteration stops after the first error.
+//
+//	for row, err := range source.IterateResults(ctx, id) {
+//		if err != nil {
+//			return err
+//		}
+//		...
+//	}
+func (s *Source) IterateResults(ctx context.Context, queryExecutionID string) iter.Seq2[[]string, error] {
+	return func(yield func([]string, error) bool) {
+		_, err := s.walkResults(ctx, queryExecutionID, func(_ []Column, row types.Row) bool {
+			values := make([]string, len(row.Data))
+			for i, datum := range row.Data {
+				values[i] = aws.ToString(datum.VarCharValue)
+			}
+			return yield(values, nil)
+		})
+		if err != nil {
+			yield(nil, err)
+		}
+	}
+}
+
+// fetchResults reads every page of results for a finished query, converting
+// values to Go types.
+func (s *Source) fetchResults(ctx context.Context, id string) (*QueryResults, error) {
+	results := &QueryResults{QueryExecutionID: id, Rows: [][]any{}}
+	columns, err := s.walkResults(ctx, id, func(columns []Column, row types.Row) bool {
+		values := make([]any, len(row.Data))
+		for i, datum := range row.Data {
+			columnType := ""
+			if i < len(columns) {
+				columnType = columns[i].Type
+			}
+			values[i] = convertValue(datum.VarCharValue, columnType)
+		}
+		results.Rows = append(results.Rows, values)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	results.Columns = columns
+	return results, nil
+}
+
+// walkResults calls fn for each result row of a finished query until fn returns
+// false, following NextToken across pages. Athena repeats the column names as
+// the first row of a SELECT's results; such header rows are skipped. It returns
+// the result columns.
+func (s *Source) walkResults(ctx context.Context, id string, fn func(columns []Column, row types.Row) bool) ([]Column, error) {
+	var (
+		columns   []Column
+		nextToken *string
+	)
+	for firstPage := true; ; firstPage = false {
+		out, err := s.api.GetQueryResults(ctx, &athena.GetQueryResultsInput{
+			QueryExecutionId: aws.String(id),
+			NextToken:        nextToken,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("unable to get query results for %s: %w", id, err)
+		}
+		if out.ResultSet == nil {
+			return columns, nil
+		}
+
+		if columns == nil && out.ResultSet.ResultSetMetadata != nil {
+			for _, info := range out.ResultSet.ResultSetMetadata.ColumnInfo {
+				columns = append(columns, Column{
+					Name: aws.ToString(info.Name),
+					Type: aws.ToString(info.Type),
+				})
+			}
+		}
+
+		for i, row := range out.ResultSet.Rows {
+			// Only the first page starts with the column names
+			if firstPage && i == 0 && isHeaderRow(row, columns) {
+				continue
+			}
+			if !fn(columns, row) {
+				return columns, nil
+			}
+		}
+
+		if aws.ToString(out.NextToken) == "" {
+			return columns, nil
+		}
+		nextToken = out.NextToken
+	}
+}
+
+// isHeaderRow reports whether row repeats the column names.
+func isHeaderRow(row types.Row, columns []Column) bool {
+	if len(columns) == 0 || len(row.Data) != len(columns) {
+		return false
+	}
+	for i, datum := range row.Data {
+		if aws.ToString(datum.VarCharValue) != columns[i].Name {
+			return false
+		}
+	}
+	return true
+}
+
+// convertValue converts an Athena result value to a Go value based on the
+// column type. Values that fail to parse are returned as strings.
+func convertValue(value *string, columnType string) any {
+	if value == nil {
+		return nil
+	}
+	v := *value
+	switch columnType {
+	case "boolean":
+		if b, err := strconv.ParseBool(v); err == nil {
+			return b
+		}
+	case "tinyint", "smallint", "integer", "int", "bigint":
+		if i, err := strconv.ParseInt(v, 10, 64); err == nil {
+			return i
+		}
+	case "float", "real", "double":
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			return f
+		}
+	case "date":
+		if t, err := time.Parse(time.DateOnly, v); err == nil {
+			return t
+		}
+	case "timestamp":
+		if t, err := time.Parse("2006-01-02 15:04:05.999999999", v); err == nil {
+			return t
+		}
+	}
+	return v
+}
@@ -0,0 +1,179 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package azureblob provides a source implementation for Azure Blob Storage.
+//
+// The source authenticates with a shared account key, a SAS token, or a
+// managed identity. Its blob methods mirror the object methods of the s3
+// source, so callers can move between the two with minimal changes.
+package azureblob
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+	"github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	sourceutil "github.com/googleapis/genai-toolbox/internal/sources/util"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const SourceKind string = "azureblob"
+
+// validate interface
+var _ sources.SourceConfig = Config{}
+
+func init() {
+	sources.MustRegister(SourceKind, newConfig)
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources.SourceConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+
+	for _, secret := range []struct {
+		field string
+		value *string
+		file  string
+	}{
+		{"accountKey", &actual.AccountKey, actual.AccountKeyFile},
+		{"sasToken", &actual.SASToken, actual.SASTokenFile},
+	} {
+		resolved, err := sourceutil.ResolveSecret(secret.field, *secret.value, secret.file)
+		if err != nil {
+			return nil, fmt.Errorf("source %q (%s): unable to resolve secret: %w", name, SourceKind, err)
+		}
+		*secret.value = resolved
+	}
+
+	authMethods := 0
+	for _, set := range []bool{actual.AccountKey != "", actual.SASToken != "", actual.UseManagedIdentity} {
+		if set {
+			authMethods++
+		}
+	}
+	if authMethods != 1 {
+		return nil, fmt.Errorf("source %q (%s): exactly one of accountKey, sasToken, or useManagedIdentity is required", name, SourceKind)
+	}
+	if actual.ManagedIdentityClientID != "" && !actual.UseManagedIdentity {
+		return nil, fmt.Errorf("source %q (%s): managedIdentityClientId requires useManagedIdentity", name, SourceKind)
+	}
+	return actual, nil
+}
+
+type Config struct {
+	Name                    string `yaml:"name" validate:"required"`
+	Kind                    string `yaml:"kind" validate:"required"`
+	AccountName             string `yaml:"accountName" validate:"required"`
+	Container               string `yaml:"container" validate:"required"` // Default container, also used to verify access
+	AccountKey              string `yaml:"accountKey"`                    // Shared key authentication
+	AccountKeyFile          string `yaml:"accountKeyFile"`                // Optional: file to read accountKey from
+	SASToken                string `yaml:"sasToken"`                      // Shared access signature authentication
+	SASTokenFile            string `yaml:"sasTokenFile"`                  // Optional: file to read sasToken from
+	UseManagedIdentity      bool   `yaml:"useManagedIdentity"`            // Managed identity authentication, e.g. on AKS or Azure VMs
+	ManagedIdentityClientID string `yaml:"managedIdentityClientId"`       // Optional: client ID of a user-assigned identity
+	Endpoint                string `yaml:"endpoint"`                      // Optional: e.g., http://127.0.0.1:10000/devstoreaccount1 for Azurite
+}
+
+func (r Config) SourceConfigKind() string {
+	return SourceKind
+}
+
+func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.Source, error) {
+	client, err := initAzureBlobClient(ctx, tracer, r)
+	if err != nil {
+		return nil, fmt.Errorf("source %q (%s): unable to create Azure Blob client: %w", r.Name, SourceKind, err)
+	}
+
+	s := &Source{
+		Config: r,
+		Client: client,
+	}
+	if err := s.HealthCheck(ctx); err != nil {
+		return nil, fmt.Errorf("source %q (%s): unable to connect successfully: %w", r.Name, SourceKind, err)
+	}
+	return s, nil
+}
+
+var _ sources.Source = &Source{}
+
+type Source struct {
+	Config
+	Client *azblob.Client
+}
+
+func (s *Source) SourceKind() string {
+	return SourceKind
+}
+
+func (s *Source) ToConfig() sources.SourceConfig {
+	return s.Config
+}
+
+// HealthCheck lists at most one blob in the configured container.
+func (s *Source) HealthCheck(ctx context.Context) error {
+	pager := s.Client.NewListBlobsFlatPager(s.Container, &azblob.ListBlobsFlatOptions{MaxResults: sourceutil.Int32Ptr(1)})
+	_, err := pager.NextPage(ctx)
+	return err
+}
+
+// AzureBlobClient returns the underlying Azure Blob Storage client for direct API access.
+func (s *Source) AzureBlobClient() *azblob.Client {
+	return s.Client
+}
+
+// Close is not needed for this source because Azure SDK clients hold no
+// resources beyond the shared HTTP transport.
+
+// serviceURL returns the blob service URL for the account, or the configured
+// endpoint override.
+func serviceURL(r Config) string {
+	if r.Endpoint != "" {
+		return strings.TrimSuffix(r.Endpoint, "/") + "/"
+	}
+	return fmt.Sprintf("https://%s.blob.core.windows.net/", r.AccountName)
+}
+
+func initAzureBlobClient(ctx context.Context, tracer trace.Tracer, r Config) (*azblob.Client, error) {
+	//nolint:all // Reassigned ctx
+	ctx, span := sources.InitConnectionSpan(ctx, tracer, SourceKind, r.Name)
+	defer span.End()
+
+	svcURL := serviceURL(r)
+	switch {
+	case r.AccountKey != "":
+		cred, err := azblob.NewSharedKeyCredential(r.AccountName, r.AccountKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid account key: %w", err)
+		}
+		return azblob.NewClientWithSharedKeyCredential(svcURL, cred, nil)
+	case r.SASToken != "":
+		return azblob.NewClientWithNoCredential(svcURL+"?"+strings.TrimPrefix(r.SASToken, "?"), nil)
+	default:
+		opts := &azidentity.ManagedIdentityCredentialOptions{}
+		if r.ManagedIdentityClientID != "" {
+			opts.ID = azidentity.ClientID(r.ManagedIdentityClientID)
+		}
+		cred, err := azidentity.NewManagedIdentityCredential(opts)
+		if err != nil {
+			return nil, fmt.Errorf("unable to create managed identity credential: %w", err)
+		}
+		return azblob.NewClient(svcURL, cred, nil)
+	}
+}
@@ -0,0 +1,279 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azureblob
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+	"github.com/goccy/go-yaml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFromYamlAzureBlob(t *testing.T) {
+	tests := []struct {
+		name        string
+		yamlContent string
+		wantErr     string
+		expected    Config
+	}{
+		{
+			name: "account key",
+			yamlContent: `name: test-azureblob
+kind: azureblob
+accountName: mystorage
+container: documents
+accountKey: c2VjcmV0`,
+			expected: Config{
+				Name:        "test-azureblob",
+				Kind:        "azureblob",
+				AccountName: "mystorage",
+				Container:   "documents",
+				AccountKey:  "c2VjcmV0",
+			},
+		},
+		{
+			name: "user-assigned managed identity",
+			yamlContent: `name: test-azureblob
+kind: azureblob
+accountName: mystorage
+container: documents
+useManagedIdentity: true
+managedIdentityClientId: 00000000-0000-0000-0000-000000000000`,
+			expected: Config{
+				Name:                    "test-azureblob",
+				Kind:                    "azureblob",
+				AccountName:             "mystorage",
+				Container:               "documents",
+				UseManagedIdentity:      true,
+				ManagedIdentityClientID: "00000000-0000-0000-0000-000000000000",
+			},
+		},
+		{
+			name: "sas token with endpoint",
+			yamlContent: `name: test-azureblob
+kind: azureblob
+accountName: devstoreaccount1
+container: documents
+sasToken: sv=2022-11-02&sig=abc
+endpoint: http://127.0.0.1:10000/devstoreaccount1`,
+			expected: Config{
+				Name:        "test-azureblob",
+				Kind:        "azureblob",
+				AccountName: "devstoreaccount1",
+				Container:   "documents",
+				SASToken:    "sv=2022-11-02&sig=abc",
+				Endpoint:    "http://127.0.0.1:10000/devstoreaccount1",
+			},
+		},
+		{
+			name: "no credentials",
+			yamlContent: `name: test-azureblob
+kind: azureblob
+accountName: mystorage
+container: documents`,
+			wantErr: "exactly one of accountKey, sasToken, or useManagedIdentity is required",
+		},
+		{
+			name: "account key and managed identity",
+			yamlContent: `name: test-azureblob
+kind: azureblob
+accountName: mystorage
+container: documents
+accountKey: c2VjcmV0
+useManagedIdentity: true`,
+			wantErr: "exactly one of accountKey, sasToken, or useManagedIdentity is required",
+		},
+		{
+			name: "client id without managed identity",
+			yamlContent: `name: test-azureblob
+kind: azureblob
+accountName: mystorage
+container: documents
+accountKey: c2VjcmV0
+managedIdentityClientId: 00000000-0000-0000-0000-000000000000`,
+			wantErr: "managedIdentityClientId requires useManagedIdentity",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decoder := yaml.NewDecoder(bytes.NewReader([]byte(tt.yamlContent)))
+			config, err := newConfig(context.Background(), "test-azureblob", decoder)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, config)
+		})
+	}
+}
+
+func TestServiceURL(t *testing.T) {
+	assert.Equal(t, "https://mystorage.blob.core.windows.net/", serviceURL(Config{AccountName: "mystorage"}))
+	assert.Equal(t, "http://127.0.0.1:10000/devstoreaccount1/", serviceURL(Config{AccountName: "devstoreaccount1", Endpoint: "http://127.0.0.1:10000/devstoreaccount1/"}))
+}
+
+func TestContainerOrDefault(t *testing.T) {
+	s := &Source{Config: Config{Container: "documents"}}
+	assert.Equal(t, "documents", s.containerOrDefault(""))
+	assert.Equal(t, "archive", s.containerOrDefault("archive"))
+}
+
+// newTestSource returns a Source whose client sends every request to handler.
+func newTestSource(t *testing.T, handler http.HandlerFunc) *Source {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	opts := &azblob.ClientOptions{}
+	opts.Transport = server.Client()
+	opts.Retry.MaxRetries = -1
+	client, err := azblob.NewClientWithNoCredential(server.URL+"/", opts)
+	require.NoError(t, err)
+	return &Source{Config: Config{Name: "test", Kind: SourceKind, Container: "documents"}, Client: client}
+}
+
+func TestListBlobs(t *testing.T) {
+	var markers []string
+	source := newTestSource(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/documents", r.URL.Path)
+		assert.Equal(t, "list", r.URL.Query().Get("comp"))
+		assert.Equal(t, "reports/", r.URL.Query().Get("prefix"))
+		marker := r.URL.Query().Get("marker")
+		markers = append(markers, marker)
+
+		w.Header().Set("Content-Type", "application/xml")
+		if marker == "" {
+			_, _ = w.Write([]byte(`<?xml version="1.0" encoding="utf-8"?>
+<EnumerationResults ServiceEndpoint="http://127.0.0.1/" ContainerName="documents">
+  <Blobs>
+    <Blob>
+      <Name>reports/a.csv</Name>
+      <Properties>
+        <Last-Modified>Mon, 02 Jan 2006 15:04:05 GMT</Last-Modified>
+        <Etag>0x8D1</Etag>
+        <Content-Length>12</Content-Length>
+      </Properties>
+    </Blob>
+  </Blobs>
+  <NextMarker>page2</NextMarker>
+</EnumerationResults>`))
+			return
+		}
+		_, _ = w.Write([]byte(`<?xml version="1.0" encoding="utf-8"?>
+<EnumerationResults ServiceEndpoint="http://127.0.0.1/" ContainerName="documents">
+  <Blobs>
+    <Blob>
+      <Name>reports/b.csv</Name>
+      <Properties>
+        <Content-Length>34</Content-Length>
+      </Properties>
+    </Blob>
+  </Blobs>
+  <NextMarker />
+</EnumerationResults>`))
+	})
+
+	blobs, err := source.ListBlobs(context.Background(), "", "reports/")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"", "page2"}, markers)
+	require.Len(t, blobs, 2)
+	assert.Equal(t, "reports/a.csv", blobs[0].Key)
+	assert.Equal(t, int64(12), blobs[0].Size)
+	assert.True(t, blobs[0].LastModified.Equal(time.Date(2006, 1, 2, 15, 4, 5, 0, time.UTC)))
+	assert.Equal(t, "0x8D1", blobs[0].ETag)
+	assert.Equal(t, BlobInfo{Key: "reports/b.csv", Size: 34}, blobs[1])
+}
+
+func TestListBlobsError(t *testing.T) {
+	source := newTestSource(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("x-ms-error-code", "ContainerNotFound")
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	_, err := source.ListBlobs(context.Background(), "missing", "")
+	require.ErrorContains(t, err, "unable to list blobs in missing")
+}
+
+func TestGetBlob(t *testing.T) {
+	source := newTestSource(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		if r.URL.Path != "/archive/notes.txt" {
+			w.Header().Set("x-ms-error-code", "BlobNotFound")
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte("hello"))
+	})
+
+	body, err := source.GetBlob(context.Background(), "archive", "notes.txt")
+	require.NoError(t, err)
+	defer body.Close()
+	got, err := io.ReadAll(body)
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(got))
+
+	_, err = source.GetBlob(context.Background(), "", "missing.txt")
+	require.ErrorContains(t, err, "unable to get documents/missing.txt")
+}
+
+func TestPutBlob(t *testing.T) {
+	var (
+		staged      strings.Builder
+		committed   bool
+		contentType string
+	)
+	source := newTestSource(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, "/documents/notes.txt", r.URL.Path)
+		switch r.URL.Query().Get("comp") {
+		case "block":
+			b, _ := io.ReadAll(r.Body)
+			staged.Write(b)
+		case "blocklist":
+			committed = true
+			contentType = r.Header.Get("x-ms-blob-content-type")
+		default:
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	err := source.PutBlob(context.Background(), "", "notes.txt", strings.NewReader("hello"), PutOptions{ContentType: "text/plain"})
+	require.NoError(t, err)
+	assert.Equal(t, "hello", staged.String())
+	assert.True(t, committed)
+	assert.Equal(t, "text/plain", contentType)
+}
+
+func TestPutBlobError(t *testing.T) {
+	source := newTestSource(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("x-ms-error-code", "AuthorizationFailure")
+		w.WriteHeader(http.StatusForbidden)
+	})
+
+	err := source.PutBlob(context.Background(), "archive", "notes.txt", strings.NewReader("hello"), PutOptions{})
+	require.ErrorContains(t, err, "unable to put archive/notes.txt")
+}
@@ -0,0 +1,112 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azureblob
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/blob"
+	sourceutil "github.com/googleapis/genai-toolbox/internal/sources/util"
+)
+
+// BlobInfo describes a blob in a container.
+type BlobInfo struct {
+	Key          string
+	Size         int64
+	LastModified time.Time
+	ETag         string
+}
+
+// ListBlobs returns every blob in container whose name starts with prefix,
+// following continuation markers across pages. An empty container means the
+// configured default container.
+func (s *Source) ListBlobs(ctx context.Context, container, prefix string) ([]BlobInfo, error) {
+	container = s.containerOrDefault(container)
+
+	opts := &azblob.ListBlobsFlatOptions{}
+	if prefix != "" {
+		opts.Prefix = sourceutil.StringPtr(prefix)
+	}
+	blobs := []BlobInfo{}
+	pager := s.Client.NewListBlobsFlatPager(container, opts)
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("unable to list blobs in %s: %w", container, err)
+		}
+		if page.Segment == nil {
+			continue
+		}
+		for _, item := range page.Segment.BlobItems {
+			info := BlobInfo{Key: sourceutil.StringValue(item.Name)}
+			if p := item.Properties; p != nil {
+				if p.ContentLength != nil {
+					info.Size = *p.ContentLength
+				}
+				if p.LastModified != nil {
+					info.LastModified = *p.LastModified
+				}
+				if p.ETag != nil {
+					info.ETag = string(*p.ETag)
+				}
+			}
+			blobs = append(blobs, info)
+		}
+	}
+	return blobs, nil
+}
+
+// PutOptions controls how PutBlob stores a blob.
+type PutOptions struct {
+	ContentType string // Optional: MIME type of the blob
+}
+
+// GetBlob returns the contents of name. The caller must close the returned
+// reader. An empty container means the configured default container.
+func (s *Source) GetBlob(ctx context.Context, container, name string) (io.ReadCloser, error) {
+	container = s.containerOrDefault(container)
+	resp, err := s.Client.DownloadStream(ctx, container, name, nil)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get %s/%s: %w", container, name, err)
+	}
+	return resp.Body, nil
+}
+
+// PutBlob uploads body to name as a block blob, replacing any existing blob.
+// An empty container means the configured default container.
+func (s *Source) PutBlob(ctx context.Context, container, name string, body io.Reader, opts PutOptions) error {
+	container = s.containerOrDefault(container)
+	uploadOpts := &azblob.UploadStreamOptions{}
+	if opts.ContentType != "" {
+		uploadOpts.HTTPHeaders = &blob.HTTPHeaders{BlobContentType: sourceutil.StringPtr(opts.ContentType)}
+	}
+	if _, err := s.Client.UploadStream(ctx, container, name, body, uploadOpts); err != nil {
+		return fmt.Errorf("unable to put %s/%s: %w", container, name, err)
+	}
+	return nil
+}
+
+// containerOrDefault returns container, or the configured default container
+// if container is empty.
+func (s *Source) containerOrDefault(container string) string {
+	if container != "" {
+		return container
+	}
+	return s.Container
+}
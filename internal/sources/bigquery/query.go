@@ -0,0 +1,69 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bigquery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	bigqueryapi "cloud.google.com/go/bigquery"
+	"google.golang.org/api/iterator"
+)
+
+// Query runs sql as a query job in the configured location, waits for it to
+// complete, and returns every result row keyed by column name. Unqualified
+// table names resolve against the configured default dataset, if any.
+func (s *Source) Query(ctx context.Context, sql string) ([]map[string]bigqueryapi.Value, error) {
+	if s.Client == nil {
+		return nil, fmt.Errorf("source %q (%s): Query requires server-side credentials and cannot be used with useClientOAuth", s.Name, SourceKind)
+	}
+
+	q := s.Client.Query(sql)
+	q.Location = s.Location
+	if s.Dataset != "" {
+		q.DefaultProjectID = s.Project
+		q.DefaultDatasetID = s.Dataset
+	}
+
+	job, err := q.Run(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to start query: %w", err)
+	}
+	status, err := job.Wait(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to wait for query job %s: %w", job.ID(), err)
+	}
+	if err := status.Err(); err != nil {
+		return nil, fmt.Errorf("query job %s failed: %w", job.ID(), err)
+	}
+
+	it, err := job.Read(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read results of query job %s: %w", job.ID(), err)
+	}
+	rows := []map[string]bigqueryapi.Value{}
+	for {
+		row := map[string]bigqueryapi.Value{}
+		err := it.Next(&row)
+		if errors.Is(err, iterator.Done) {
+			return rows, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("unable to read results of query job %s: %w", job.ID(), err)
+		}
+		rows = append(rows, row)
+	}
+}
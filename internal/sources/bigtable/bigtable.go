@@ -16,6 +16,7 @@ package bigtable
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"cloud.google.com/go/bigtable"
@@ -32,9 +33,7 @@ const SourceKind string = "bigtable"
 var _ sources.SourceConfig = Config{}
 
 func init() {
-	if !sources.Register(SourceKind, newConfig) {
-		panic(fmt.Sprintf("source kind %q already registered", SourceKind))
-	}
+	sources.MustRegister(SourceKind, newConfig)
 }
 
 func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources.SourceConfig, error) {
@@ -57,14 +56,15 @@ func (r Config) SourceConfigKind() string {
 }
 
 func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.Source, error) {
-	client, err := initBigtableClient(ctx, tracer, r.Name, r.Project, r.Instance)
+	client, adminClient, err := initBigtableClient(ctx, tracer, r.Name, r.Project, r.Instance)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create client: %w", err)
 	}
 
 	s := &Source{
-		Config: r,
-		Client: client,
+		Config:      r,
+		Client:      client,
+		adminClient: adminClient,
 	}
 	return s, nil
 }
@@ -73,7 +73,8 @@ var _ sources.Source = &Source{}
 
 type Source struct {
 	Config
-	Client *bigtable.Client
+	Client      *bigtable.Client
+	adminClient *bigtable.AdminClient
 }
 
 func (s *Source) SourceKind() string {
@@ -84,11 +85,23 @@ func (s *Source) ToConfig() sources.SourceConfig {
 	return s.Config
 }
 
+// HealthCheck lists the tables in the instance, which requires the
+// bigtable.tables.list permission.
+func (s *Source) HealthCheck(ctx context.Context) error {
+	_, err := s.adminClient.Tables(ctx)
+	return err
+}
+
 func (s *Source) BigtableClient() *bigtable.Client {
 	return s.Client
 }
 
-func initBigtableClient(ctx context.Context, tracer trace.Tracer, name, project, instance string) (*bigtable.Client, error) {
+// Close closes the data and table admin clients.
+func (s *Source) Close() error {
+	return errors.Join(s.Client.Close(), s.adminClient.Close())
+}
+
+func initBigtableClient(ctx context.Context, tracer trace.Tracer, name, project, instance string) (*bigtable.Client, *bigtable.AdminClient, error) {
 	//nolint:all // Reassigned ctx
 	ctx, span := sources.InitConnectionSpan(ctx, tracer, SourceKind, name)
 	defer span.End()
@@ -97,14 +110,21 @@ func initBigtableClient(ctx context.Context, tracer trace.Tracer, name, project,
 	poolSize := 10
 	userAgent, err := util.UserAgentFromContext(ctx)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	client, err := bigtable.NewClient(ctx, project, instance, option.WithUserAgent(userAgent), option.WithGRPCConnectionPool(poolSize))
 
 	if err != nil {
-		return nil, fmt.Errorf("unable to create bigtable.NewClient: %w", err)
+		return nil, nil, fmt.Errorf("unable to create bigtable.NewClient: %w", err)
+	}
+
+	// Set up the table admin client used by HealthCheck.
+	adminClient, err := bigtable.NewAdminClient(ctx, project, instance, option.WithUserAgent(userAgent))
+	if err != nil {
+		client.Close()
+		return nil, nil, fmt.Errorf("unable to create bigtable.NewAdminClient: %w", err)
 	}
 
-	return client, nil
+	return client, adminClient, nil
 }
@@ -15,14 +15,19 @@
 package bigtable_test
 
 import (
+	"context"
 	"testing"
+	"time"
 
+	"cloud.google.com/go/bigtable/bttest"
 	yaml "github.com/goccy/go-yaml"
 	"github.com/google/go-cmp/cmp"
 	"github.com/googleapis/genai-toolbox/internal/server"
 	"github.com/googleapis/genai-toolbox/internal/sources"
 	"github.com/googleapis/genai-toolbox/internal/sources/bigtable"
 	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/util"
+	"go.opentelemetry.io/otel/trace/noop"
 )
 
 func TestParseFromYamlBigtableDb(t *testing.T) {
@@ -114,3 +119,39 @@ func TestFailParseFromYaml(t *testing.T) {
 		})
 	}
 }
+
+func TestHealthCheck(t *testing.T) {
+	srv, err := bttest.NewServer("localhost:0")
+	if err != nil {
+		t.Fatalf("unable to start bigtable emulator: %s", err)
+	}
+	t.Setenv("BIGTABLE_EMULATOR_HOST", srv.Addr)
+
+	cfg := bigtable.Config{
+		Name:     "my-bigtable-instance",
+		Kind:     bigtable.SourceKind,
+		Project:  "my-project",
+		Instance: "my-instance",
+	}
+	ctx := util.WithUserAgent(context.Background(), "test")
+	src, err := cfg.Initialize(ctx, noop.NewTracerProvider().Tracer("test"))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	s := src.(*bigtable.Source)
+	t.Cleanup(func() {
+		if err := s.Close(); err != nil {
+			t.Errorf("unexpected close error: %s", err)
+		}
+	})
+	if err := s.HealthCheck(ctx); err != nil {
+		t.Fatalf("unexpected health check error: %s", err)
+	}
+
+	srv.Close()
+	ctx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	if err := s.HealthCheck(ctx); err == nil {
+		t.Fatalf("expected health check to fail after the emulator stopped")
+	}
+}
@@ -17,19 +17,33 @@ package cassandra
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
 	gocql "github.com/apache/cassandra-gocql-driver/v2"
 	"github.com/goccy/go-yaml"
 	"github.com/googleapis/genai-toolbox/internal/sources"
+	sourceutil "github.com/googleapis/genai-toolbox/internal/sources/util"
 	"go.opentelemetry.io/otel/trace"
 )
 
 const SourceKind string = "cassandra"
 
+// consistencyLevels maps the supported consistency config values to gocql levels.
+var consistencyLevels = map[string]gocql.Consistency{
+	"ANY":          gocql.Any,
+	"ONE":          gocql.One,
+	"TWO":          gocql.Two,
+	"THREE":        gocql.Three,
+	"QUORUM":       gocql.Quorum,
+	"ALL":          gocql.All,
+	"LOCAL_QUORUM": gocql.LocalQuorum,
+	"EACH_QUORUM":  gocql.EachQuorum,
+	"LOCAL_ONE":    gocql.LocalOne,
+}
+
 func init() {
-	if !sources.Register(SourceKind, newConfig) {
-		panic(fmt.Sprintf("source kind %q already registered", SourceKind))
-	}
+	sources.MustRegister(SourceKind, newConfig)
 }
 
 func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources.SourceConfig, error) {
@@ -37,6 +51,24 @@ func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources
 	if err := decoder.DecodeContext(ctx, &actual); err != nil {
 		return nil, err
 	}
+
+	password, err := sourceutil.ResolveSecret("password", actual.Password, actual.PasswordFile)
+	if err != nil {
+		return nil, fmt.Errorf("source %q (%s): unable to resolve secret: %w", name, SourceKind, err)
+	}
+	actual.Password = password
+
+	if actual.Consistency != "" {
+		if _, ok := consistencyLevels[strings.ToUpper(actual.Consistency)]; !ok {
+			return nil, fmt.Errorf("source %q (%s): invalid consistency %q, must be one of ANY, ONE, TWO, THREE, QUORUM, ALL, LOCAL_QUORUM, EACH_QUORUM, or LOCAL_ONE", name, SourceKind, actual.Consistency)
+		}
+	}
+	if actual.ConnectTimeout != "" {
+		d, err := time.ParseDuration(actual.ConnectTimeout)
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("source %q (%s): invalid connectTimeout %q: must be a positive duration such as \"10s\"", name, SourceKind, actual.ConnectTimeout)
+		}
+	}
 	return actual, nil
 }
 
@@ -48,10 +80,13 @@ type Config struct {
 	ProtoVersion           int      `yaml:"protoVersion"`
 	Username               string   `yaml:"username"`
 	Password               string   `yaml:"password"`
+	PasswordFile           string   `yaml:"passwordFile"`
 	CAPath                 string   `yaml:"caPath"`
 	CertPath               string   `yaml:"certPath"`
 	KeyPath                string   `yaml:"keyPath"`
 	EnableHostVerification bool     `yaml:"enableHostVerification"`
+	Consistency            string   `yaml:"consistency"`    // Optional: default consistency level, e.g. LOCAL_QUORUM (default: QUORUM)
+	ConnectTimeout         string   `yaml:"connectTimeout"` // Optional: initial connection timeout, e.g. "10s" (default: driver default)
 }
 
 // Initialize implements sources.SourceConfig.
@@ -64,6 +99,10 @@ func (c Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.So
 		Config:  c,
 		Session: session,
 	}
+	if err := s.HealthCheck(ctx); err != nil {
+		session.Close()
+		return nil, fmt.Errorf("source %q (%s): unable to connect successfully: %w", c.Name, SourceKind, err)
+	}
 	return s, nil
 }
 
@@ -88,6 +127,37 @@ func (s *Source) ToConfig() sources.SourceConfig {
 	return s.Config
 }
 
+// HealthCheck runs a lightweight query against the system keyspace.
+func (s *Source) HealthCheck(ctx context.Context) error {
+	return s.Session.Query("SELECT release_version FROM system.local").WithContext(ctx).Exec()
+}
+
+// Query runs a CQL statement with positional args bound to its ? markers and
+// returns every result row keyed by column name. Statements that return no
+// rows, such as INSERT, return an empty slice.
+func (s *Source) Query(ctx context.Context, cql string, args ...any) ([]map[string]any, error) {
+	iter := s.Session.Query(cql, args...).WithContext(ctx).Iter()
+	rows, err := iter.SliceMap()
+	if closeErr := iter.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to run query: %w", err)
+	}
+	if rows == nil {
+		rows = []map[string]any{}
+	}
+	return rows, nil
+}
+
+// Close closes the session and all of its connections.
+func (s *Source) Close() error {
+	if s.Session != nil {
+		s.Session.Close()
+	}
+	return nil
+}
+
 // SourceKind implements sources.Source.
 func (s Source) SourceKind() string {
 	return SourceKind
@@ -108,6 +178,15 @@ func initCassandraSession(ctx context.Context, tracer trace.Tracer, c Config) (*
 	cluster := gocql.NewCluster(c.Hosts...)
 	cluster.ProtoVersion = c.ProtoVersion
 	cluster.Keyspace = c.Keyspace
+	if c.Consistency != "" {
+		// Validated by newConfig
+		cluster.Consistency = consistencyLevels[strings.ToUpper(c.Consistency)]
+	}
+	if c.ConnectTimeout != "" {
+		// Validated by newConfig
+		connectTimeout, _ := time.ParseDuration(c.ConnectTimeout)
+		cluster.ConnectTimeout = connectTimeout
+	}
 
 	// Configure authentication if username is provided
 	if c.Username != "" {
@@ -90,6 +90,29 @@ func TestParseFromYamlCassandra(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc: "with consistency and connect timeout",
+			in: `
+			sources:
+				my-scylla-instance:
+					kind: cassandra
+					hosts:
+						- "scylla-1:9042"
+					keyspace: "example_keyspace"
+					consistency: local_quorum
+					connectTimeout: 10s
+			`,
+			want: server.SourceConfigs{
+				"my-scylla-instance": cassandra.Config{
+					Name:           "my-scylla-instance",
+					Kind:           cassandra.SourceKind,
+					Hosts:          []string{"scylla-1:9042"},
+					Keyspace:       "example_keyspace",
+					Consistency:    "local_quorum",
+					ConnectTimeout: "10s",
+				},
+			},
+		},
 	}
 	for _, tc := range tcs {
 		t.Run(tc.desc, func(t *testing.T) {
@@ -136,6 +159,30 @@ func TestFailParseFromYaml(t *testing.T) {
 			`,
 			err: "unable to parse source \"my-cassandra-instance\" as \"cassandra\": Key: 'Config.Hosts' Error:Field validation for 'Hosts' failed on the 'required' tag",
 		},
+		{
+			desc: "invalid consistency",
+			in: `
+			sources:
+				my-cassandra-instance:
+					kind: cassandra
+					hosts:
+						- "my-host"
+					consistency: SERIAL
+			`,
+			err: "unable to parse source \"my-cassandra-instance\" as \"cassandra\": source \"my-cassandra-instance\" (cassandra): invalid consistency \"SERIAL\", must be one of ANY, ONE, TWO, THREE, QUORUM, ALL, LOCAL_QUORUM, EACH_QUORUM, or LOCAL_ONE",
+		},
+		{
+			desc: "invalid connect timeout",
+			in: `
+			sources:
+				my-cassandra-instance:
+					kind: cassandra
+					hosts:
+						- "my-host"
+					connectTimeout: "10"
+			`,
+			err: "unable to parse source \"my-cassandra-instance\" as \"cassandra\": source \"my-cassandra-instance\" (cassandra): invalid connectTimeout \"10\": must be a positive duration such as \"10s\"",
+		},
 	}
 
 	for _, tc := range tcs {
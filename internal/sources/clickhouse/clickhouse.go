@@ -24,18 +24,24 @@ import (
 	_ "github.com/ClickHouse/clickhouse-go/v2"
 	"github.com/goccy/go-yaml"
 	"github.com/googleapis/genai-toolbox/internal/sources"
+	sourceutil "github.com/googleapis/genai-toolbox/internal/sources/util"
 	"go.opentelemetry.io/otel/trace"
 )
 
 const SourceKind string = "clickhouse"
 
+// Default connection pool settings
+const (
+	DefaultMaxOpenConns    = 25              // Default maximum open connections
+	DefaultMaxIdleConns    = 5               // Default maximum idle connections
+	DefaultConnMaxLifetime = 5 * time.Minute // Default connection maximum lifetime
+)
+
 // validate interface
 var _ sources.SourceConfig = Config{}
 
 func init() {
-	if !sources.Register(SourceKind, newConfig) {
-		panic(fmt.Sprintf("source kind %q already registered", SourceKind))
-	}
+	sources.MustRegister(SourceKind, newConfig)
 }
 
 func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources.SourceConfig, error) {
@@ -43,19 +49,43 @@ func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources
 	if err := decoder.DecodeContext(ctx, &actual); err != nil {
 		return nil, err
 	}
+
+	password, err := sourceutil.ResolveSecret("password", actual.Password, actual.PasswordFile)
+	if err != nil {
+		return nil, fmt.Errorf("source %q (%s): unable to resolve secret: %w", name, SourceKind, err)
+	}
+	actual.Password = password
+
+	if err := validateConfig(actual.Protocol); err != nil {
+		return nil, fmt.Errorf("source %q (%s): %w", name, SourceKind, err)
+	}
+	if actual.MaxOpenConns < 0 || actual.MaxIdleConns < 0 {
+		return nil, fmt.Errorf("source %q (%s): maxOpenConns and maxIdleConns must not be negative", name, SourceKind)
+	}
+	if _, err := sourceutil.ParseDurationOption("connMaxLifetime", actual.ConnMaxLifetime, DefaultConnMaxLifetime); err != nil {
+		return nil, fmt.Errorf("source %q (%s): %w", name, SourceKind, err)
+	}
+	if _, err := sourceutil.ParseDurationOption("connMaxIdleTime", actual.ConnMaxIdleTime, 0); err != nil {
+		return nil, fmt.Errorf("source %q (%s): %w", name, SourceKind, err)
+	}
 	return actual, nil
 }
 
 type Config struct {
-	Name     string `yaml:"name" validate:"required"`
-	Kind     string `yaml:"kind" validate:"required"`
-	Host     string `yaml:"host" validate:"required"`
-	Port     string `yaml:"port" validate:"required"`
-	Database string `yaml:"database" validate:"required"`
-	User     string `yaml:"user" validate:"required"`
-	Password string `yaml:"password"`
-	Protocol string `yaml:"protocol"`
-	Secure   bool   `yaml:"secure"`
+	Name            string `yaml:"name" validate:"required"`
+	Kind            string `yaml:"kind" validate:"required"`
+	Host            string `yaml:"host" validate:"required"`
+	Port            string `yaml:"port" validate:"required"`
+	Database        string `yaml:"database" validate:"required"`
+	User            string `yaml:"user" validate:"required"`
+	Password        string `yaml:"password"`
+	PasswordFile    string `yaml:"passwordFile"`
+	Protocol        string `yaml:"protocol"`
+	Secure          bool   `yaml:"secure"`
+	MaxOpenConns    int    `yaml:"maxOpenConns"`    // Optional: max open connections (default 25)
+	MaxIdleConns    int    `yaml:"maxIdleConns"`    // Optional: max idle connections (default 5)
+	ConnMaxLifetime string `yaml:"connMaxLifetime"` // Optional: max time a connection is reused, e.g. "30m" (default 5m)
+	ConnMaxIdleTime string `yaml:"connMaxIdleTime"` // Optional: max time a connection stays idle, e.g. "5m" (default: no limit)
 }
 
 func (r Config) SourceConfigKind() string {
@@ -63,14 +93,15 @@ func (r Config) SourceConfigKind() string {
 }
 
 func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.Source, error) {
-	pool, err := initClickHouseConnectionPool(ctx, tracer, r.Name, r.Host, r.Port, r.User, r.Password, r.Database, r.Protocol, r.Secure)
+	pool, err := initClickHouseConnectionPool(ctx, tracer, r)
 	if err != nil {
-		return nil, fmt.Errorf("unable to create pool: %w", err)
+		return nil, fmt.Errorf("source %q (%s): unable to create pool: %w", r.Name, SourceKind, err)
 	}
 
 	err = pool.PingContext(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("unable to connect successfully: %w", err)
+		pool.Close()
+		return nil, fmt.Errorf("source %q (%s): unable to connect successfully: %w", r.Name, SourceKind, err)
 	}
 
 	s := &Source{
@@ -95,10 +126,30 @@ func (s *Source) ToConfig() sources.SourceConfig {
 	return s.Config
 }
 
+// HealthCheck pings the database.
+func (s *Source) HealthCheck(ctx context.Context) error {
+	return s.Pool.PingContext(ctx)
+}
+
+// ClickHouseDB returns the underlying database connection for direct SQL operations.
+func (s *Source) ClickHouseDB() *sql.DB {
+	return s.Pool
+}
+
+// ClickHousePool returns the underlying database connection. It is equivalent
+// to ClickHouseDB.
 func (s *Source) ClickHousePool() *sql.DB {
 	return s.Pool
 }
 
+// Close closes the database connection and releases resources.
+func (s *Source) Close() error {
+	if s == nil || s.Pool == nil {
+		return nil
+	}
+	return s.Pool.Close()
+}
+
 func validateConfig(protocol string) error {
 	validProtocols := map[string]bool{"http": true, "https": true}
 
@@ -108,11 +159,12 @@ func validateConfig(protocol string) error {
 	return nil
 }
 
-func initClickHouseConnectionPool(ctx context.Context, tracer trace.Tracer, name, host, port, user, pass, dbname, protocol string, secure bool) (*sql.DB, error) {
+func initClickHouseConnectionPool(ctx context.Context, tracer trace.Tracer, r Config) (*sql.DB, error) {
 	//nolint:all // Reassigned ctx
-	ctx, span := sources.InitConnectionSpan(ctx, tracer, SourceKind, name)
+	ctx, span := sources.InitConnectionSpan(ctx, tracer, SourceKind, r.Name)
 	defer span.End()
 
+	protocol := r.Protocol
 	if protocol == "" {
 		protocol = "https"
 	}
@@ -121,15 +173,15 @@ func initClickHouseConnectionPool(ctx context.Context, tracer trace.Tracer, name
 		return nil, err
 	}
 
-	encodedUser := url.QueryEscape(user)
-	encodedPass := url.QueryEscape(pass)
+	encodedUser := url.QueryEscape(r.User)
+	encodedPass := url.QueryEscape(r.Password)
 
 	var dsn string
 	scheme := protocol
-	if protocol == "http" && secure {
+	if protocol == "http" && r.Secure {
 		scheme = "https"
 	}
-	dsn = fmt.Sprintf("%s://%s:%s@%s:%s/%s", scheme, encodedUser, encodedPass, host, port, dbname)
+	dsn = fmt.Sprintf("%s://%s:%s@%s:%s/%s", scheme, encodedUser, encodedPass, r.Host, r.Port, r.Database)
 	if scheme == "https" {
 		dsn += "?secure=true&skip_verify=false"
 	}
@@ -139,9 +191,21 @@ func initClickHouseConnectionPool(ctx context.Context, tracer trace.Tracer, name
 		return nil, fmt.Errorf("sql.Open: %w", err)
 	}
 
-	pool.SetMaxOpenConns(25)
-	pool.SetMaxIdleConns(5)
-	pool.SetConnMaxLifetime(5 * time.Minute)
+	// Configure connection pool with defaults
+	maxOpenConns, maxIdleConns := r.MaxOpenConns, r.MaxIdleConns
+	if maxOpenConns == 0 {
+		maxOpenConns = DefaultMaxOpenConns
+	}
+	if maxIdleConns == 0 {
+		maxIdleConns = DefaultMaxIdleConns
+	}
+	pool.SetMaxOpenConns(maxOpenConns)
+	pool.SetMaxIdleConns(maxIdleConns)
+
+	connMaxLifetime, _ := sourceutil.ParseDurationOption("connMaxLifetime", r.ConnMaxLifetime, DefaultConnMaxLifetime)
+	connMaxIdleTime, _ := sourceutil.ParseDurationOption("connMaxIdleTime", r.ConnMaxIdleTime, 0)
+	pool.SetConnMaxLifetime(connMaxLifetime)
+	pool.SetConnMaxIdleTime(connMaxIdleTime)
 
 	return pool, nil
 }
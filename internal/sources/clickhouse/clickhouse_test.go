@@ -85,6 +85,33 @@ func TestNewConfig(t *testing.T) {
 				Secure:   false,
 			},
 		},
+		{
+			name: "connection pool settings",
+			yaml: `
+				name: pooled-clickhouse
+				kind: clickhouse
+				host: localhost
+				port: "8443"
+				user: default
+				database: default
+				maxOpenConns: 50
+				maxIdleConns: 10
+				connMaxLifetime: 30m
+				connMaxIdleTime: 2m
+			`,
+			expected: Config{
+				Name:            "pooled-clickhouse",
+				Kind:            "clickhouse",
+				Host:            "localhost",
+				Port:            "8443",
+				User:            "default",
+				Database:        "default",
+				MaxOpenConns:    50,
+				MaxIdleConns:    10,
+				ConnMaxLifetime: "30m",
+				ConnMaxIdleTime: "2m",
+			},
+		},
 		{
 			name: "http protocol",
 			yaml: `
@@ -172,6 +199,42 @@ func TestNewConfigInvalidYAML(t *testing.T) {
 			`,
 			expectError: true,
 		},
+		{
+			name: "invalid protocol",
+			yaml: `
+				name: test-clickhouse
+				kind: clickhouse
+				protocol: native
+			`,
+			expectError: true,
+		},
+		{
+			name: "negative maxOpenConns",
+			yaml: `
+				name: test-clickhouse
+				kind: clickhouse
+				maxOpenConns: -1
+			`,
+			expectError: true,
+		},
+		{
+			name: "invalid connMaxLifetime",
+			yaml: `
+				name: test-clickhouse
+				kind: clickhouse
+				connMaxLifetime: forever
+			`,
+			expectError: true,
+		},
+		{
+			name: "negative connMaxIdleTime",
+			yaml: `
+				name: test-clickhouse
+				kind: clickhouse
+				connMaxIdleTime: -5m
+			`,
+			expectError: true,
+		},
 		{
 			name: "missing required fields",
 			yaml: `
@@ -334,7 +397,17 @@ func TestInitClickHouseConnectionPoolDSNGeneration(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			pool, err := initClickHouseConnectionPool(ctx, tracer, "test", tt.host, tt.port, tt.user, tt.pass, tt.dbname, tt.protocol, tt.secure)
+			cfg := Config{
+				Name:     "test",
+				Host:     tt.host,
+				Port:     tt.port,
+				User:     tt.user,
+				Password: tt.pass,
+				Database: tt.dbname,
+				Protocol: tt.protocol,
+				Secure:   tt.secure,
+			}
+			pool, err := initClickHouseConnectionPool(ctx, tracer, cfg)
 
 			if !tt.shouldErr && err != nil {
 				t.Errorf("Expected no error, got: %v", err)
@@ -346,3 +419,49 @@ func TestInitClickHouseConnectionPoolDSNGeneration(t *testing.T) {
 		})
 	}
 }
+
+func TestInitClickHouseConnectionPoolSettings(t *testing.T) {
+	tracer := otel.Tracer("test")
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		cfg      Config
+		wantOpen int
+	}{
+		{
+			name:     "defaults",
+			cfg:      Config{Name: "test", Host: "localhost", Port: "8443", User: "default", Database: "default"},
+			wantOpen: DefaultMaxOpenConns,
+		},
+		{
+			name:     "custom pool size",
+			cfg:      Config{Name: "test", Host: "localhost", Port: "8443", User: "default", Database: "default", MaxOpenConns: 7, MaxIdleConns: 2, ConnMaxLifetime: "1m"},
+			wantOpen: 7,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pool, err := initClickHouseConnectionPool(ctx, tracer, tt.cfg)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			defer pool.Close()
+
+			if got := pool.Stats().MaxOpenConnections; got != tt.wantOpen {
+				t.Errorf("MaxOpenConnections = %d, want %d", got, tt.wantOpen)
+			}
+		})
+	}
+}
+
+func TestSourceClickHouseDB(t *testing.T) {
+	s := &Source{}
+	if s.ClickHouseDB() != nil {
+		t.Errorf("expected nil DB on empty source")
+	}
+	if err := s.Close(); err != nil {
+		t.Errorf("Close on empty source: %v", err)
+	}
+}
@@ -38,9 +38,7 @@ var _ sources.SourceConfig = Config{}
 type HealthcareServiceCreator func(tokenString string) (*healthcare.Service, error)
 
 func init() {
-	if !sources.Register(SourceKind, newConfig) {
-		panic(fmt.Sprintf("source kind %q already registered", SourceKind))
-	}
+	sources.MustRegister(SourceKind, newConfig)
 }
 
 func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources.SourceConfig, error) {
@@ -198,6 +196,17 @@ func (s *Source) ToConfig() sources.SourceConfig {
 	return s.Config
 }
 
+// HealthCheck fetches the configured dataset. With client OAuth there are no
+// server-side credentials to check, so it always succeeds.
+func (s *Source) HealthCheck(ctx context.Context) error {
+	if s.UseClientOAuth {
+		return nil
+	}
+	dsName := fmt.Sprintf("projects/%s/locations/%s/datasets/%s", s.Project(), s.Region(), s.Dataset)
+	_, err := s.service.Projects.Locations.Datasets.Get(dsName).Context(ctx).Do()
+	return err
+}
+
 func (s *Source) Project() string {
 	return s.Config.Project
 }
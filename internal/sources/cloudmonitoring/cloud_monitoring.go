@@ -53,9 +53,7 @@ func (rt *userAgentRoundTripper) RoundTrip(req *http.Request) (*http.Response, e
 var _ sources.SourceConfig = Config{}
 
 func init() {
-	if !sources.Register(SourceKind, newConfig) {
-		panic(fmt.Sprintf("source kind %q already registered", SourceKind))
-	}
+	sources.MustRegister(SourceKind, newConfig)
 }
 
 func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources.SourceConfig, error) {
@@ -84,6 +82,7 @@ func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.So
 	}
 
 	var client *http.Client
+	var tokenSource oauth2.TokenSource
 	if r.UseClientOAuth {
 		client = &http.Client{
 			Transport: &userAgentRoundTripper{
@@ -103,13 +102,15 @@ func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.So
 			next:      baseClient.Transport,
 		}
 		client = baseClient
+		tokenSource = creds.TokenSource
 	}
 
 	s := &Source{
-		Config:    r,
-		BaseURL:   "https://monitoring.googleapis.com",
-		Client:    client,
-		UserAgent: ua,
+		Config:      r,
+		BaseURL:     "https://monitoring.googleapis.com",
+		Client:      client,
+		UserAgent:   ua,
+		TokenSource: tokenSource,
 	}
 	return s, nil
 }
@@ -118,9 +119,10 @@ var _ sources.Source = &Source{}
 
 type Source struct {
 	Config
-	BaseURL   string `yaml:"baseUrl"`
-	Client    *http.Client
-	UserAgent string
+	BaseURL     string `yaml:"baseUrl"`
+	Client      *http.Client
+	UserAgent   string
+	TokenSource oauth2.TokenSource
 }
 
 func (s *Source) SourceKind() string {
@@ -131,6 +133,18 @@ func (s *Source) ToConfig() sources.SourceConfig {
 	return s.Config
 }
 
+// HealthCheck fetches an access token from the server-side credentials. Cloud
+// Monitoring requests are scoped to a project chosen per tool call, so there is
+// no source-level API call to probe. With client OAuth there are no
+// server-side credentials to check, so it always succeeds.
+func (s *Source) HealthCheck(ctx context.Context) error {
+	if s.UseClientOAuth {
+		return nil
+	}
+	_, err := s.TokenSource.Token()
+	return err
+}
+
 func (s *Source) GetClient(ctx context.Context, accessToken string) (*http.Client, error) {
 	if s.UseClientOAuth {
 		if accessToken == "" {
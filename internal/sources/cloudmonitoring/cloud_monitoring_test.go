@@ -15,6 +15,8 @@
 package cloudmonitoring_test
 
 import (
+	"context"
+	"errors"
 	"testing"
 
 	yaml "github.com/goccy/go-yaml"
@@ -23,6 +25,7 @@ import (
 	"github.com/googleapis/genai-toolbox/internal/sources"
 	"github.com/googleapis/genai-toolbox/internal/sources/cloudmonitoring"
 	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"golang.org/x/oauth2"
 )
 
 func TestParseFromYamlCloudMonitoring(t *testing.T) {
@@ -133,3 +136,40 @@ func TestFailParseFromYaml(t *testing.T) {
 		})
 	}
 }
+
+type failingTokenSource struct{}
+
+func (failingTokenSource) Token() (*oauth2.Token, error) {
+	return nil, errors.New("invalid_grant")
+}
+
+func TestHealthCheck(t *testing.T) {
+	t.Parallel()
+	tcs := []struct {
+		desc    string
+		source  *cloudmonitoring.Source
+		wantErr bool
+	}{
+		{
+			desc:   "valid credentials",
+			source: &cloudmonitoring.Source{TokenSource: oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "token"})},
+		},
+		{
+			desc:    "invalid credentials",
+			source:  &cloudmonitoring.Source{TokenSource: failingTokenSource{}},
+			wantErr: true,
+		},
+		{
+			desc:   "client OAuth",
+			source: &cloudmonitoring.Source{Config: cloudmonitoring.Config{UseClientOAuth: true}},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := tc.source.HealthCheck(context.Background())
+			if tc.wantErr != (err != nil) {
+				t.Fatalf("HealthCheck() error = %v, wantErr %t", err, tc.wantErr)
+			}
+		})
+	}
+}
@@ -54,9 +54,7 @@ func (rt *userAgentRoundTripper) RoundTrip(req *http.Request) (*http.Response, e
 var _ sources.SourceConfig = Config{}
 
 func init() {
-	if !sources.Register(SourceKind, newConfig) {
-		panic(fmt.Sprintf("source kind %q already registered", SourceKind))
-	}
+	sources.MustRegister(SourceKind, newConfig)
 }
 
 func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources.SourceConfig, error) {
@@ -136,6 +134,17 @@ func (s *Source) ToConfig() sources.SourceConfig {
 	return s.Config
 }
 
+// HealthCheck lists an instance in the default project. It always succeeds
+// with client OAuth or without a default project, since there is nothing to
+// check.
+func (s *Source) HealthCheck(ctx context.Context) error {
+	if s.UseClientOAuth || s.DefaultProject == "" {
+		return nil
+	}
+	_, err := s.Service.Instances.List(s.DefaultProject).MaxResults(1).Context(ctx).Do()
+	return err
+}
+
 func (s *Source) GetService(ctx context.Context, accessToken string) (*sqladmin.Service, error) {
 	if s.UseClientOAuth {
 		token := &oauth2.Token{AccessToken: accessToken}
@@ -34,9 +34,7 @@ const SourceKind string = "cloud-sql-mysql"
 var _ sources.SourceConfig = Config{}
 
 func init() {
-	if !sources.Register(SourceKind, newConfig) {
-		panic(fmt.Sprintf("source kind %q already registered", SourceKind))
-	}
+	sources.MustRegister(SourceKind, newConfig)
 }
 
 func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources.SourceConfig, error) {
@@ -96,6 +94,11 @@ func (s *Source) ToConfig() sources.SourceConfig {
 	return s.Config
 }
 
+// HealthCheck pings the database.
+func (s *Source) HealthCheck(ctx context.Context) error {
+	return s.Pool.PingContext(ctx)
+}
+
 func (s *Source) MySQLPool() *sql.DB {
 	return s.Pool
 }
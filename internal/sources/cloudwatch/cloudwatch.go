@@ -20,11 +20,10 @@ package cloudwatch
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
-	"github.com/aws/aws-sdk-go-v2/config"
-	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
 	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
 	"github.com/goccy/go-yaml"
@@ -39,9 +38,7 @@ const SourceKind string = "cloudwatch"
 var _ sources.SourceConfig = Config{}
 
 func init() {
-	if !sources.Register(SourceKind, newConfig) {
-		panic(fmt.Sprintf("source kind %q already registered", SourceKind))
-	}
+	sources.MustRegister(SourceKind, newConfig)
 }
 
 func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources.SourceConfig, error) {
@@ -49,20 +46,50 @@ func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources
 	if err := decoder.DecodeContext(ctx, &actual); err != nil {
 		return nil, err
 	}
+
+	for _, secret := range []struct {
+		field string
+		value *string
+		file  string
+	}{
+		{"secretAccessKey", &actual.SecretAccessKey, actual.SecretAccessKeyFile},
+		{"sessionToken", &actual.SessionToken, actual.SessionTokenFile},
+	} {
+		resolved, err := sourceutil.ResolveSecret(secret.field, *secret.value, secret.file)
+		if err != nil {
+			return nil, fmt.Errorf("source %q (%s): unable to resolve secret: %w", name, SourceKind, err)
+		}
+		*secret.value = resolved
+	}
+
+	if err := sourceutil.ValidateAWSEndpoint(actual.Endpoint, actual.UseFIPS, actual.UseDualStack); err != nil {
+		return nil, fmt.Errorf("source %q (%s): %w", name, SourceKind, err)
+	}
+	if actual.MaxRetries < 0 {
+		return nil, fmt.Errorf("source %q (%s): maxRetries must not be negative", name, SourceKind)
+	}
 	return actual, nil
 }
 
 // Config represents the configuration for a CloudWatch Logs source.
 // It provides access to AWS CloudWatch Logs for querying and streaming log data.
 type Config struct {
-	Name            string `yaml:"name" validate:"required"`
-	Kind            string `yaml:"kind" validate:"required"`
-	Region          string `yaml:"region" validate:"required"`
-	LogGroupName    string `yaml:"logGroupName"` // Optional: default log group to query
-	Endpoint        string `yaml:"endpoint"`     // Optional: for custom endpoints (e.g., LocalStack)
-	AccessKeyID     string `yaml:"accessKeyId"`
-	SecretAccessKey string `yaml:"secretAccessKey"`
-	SessionToken    string `yaml:"sessionToken"`
+	Name                string `yaml:"name" validate:"required"`
+	Kind                string `yaml:"kind" validate:"required"`
+	Region              string `yaml:"region"`       // Optional: resolved from AWS_REGION, the shared config, or EC2 instance metadata if unset
+	LogGroupName        string `yaml:"logGroupName"` // Optional: default log group to query
+	Endpoint            string `yaml:"endpoint"`     // Optional: for custom endpoints (e.g., LocalStack)
+	AccessKeyID         string `yaml:"accessKeyId"`
+	SecretAccessKey     string `yaml:"secretAccessKey"`
+	SecretAccessKeyFile string `yaml:"secretAccessKeyFile"`
+	SessionToken        string `yaml:"sessionToken"`
+	SessionTokenFile    string `yaml:"sessionTokenFile"`
+	RoleARN             string `yaml:"roleArn"`         // Optional: role to assume with the resolved credentials
+	RoleSessionName     string `yaml:"roleSessionName"` // Optional: session name for the assumed role
+	ExternalID          string `yaml:"externalId"`      // Optional: external ID required by the role trust policy
+	UseFIPS             bool   `yaml:"useFIPS"`         // Optional: use FIPS endpoints
+	UseDualStack        bool   `yaml:"useDualStack"`    // Optional: use dual-stack (IPv4 and IPv6) endpoints
+	MaxRetries          int    `yaml:"maxRetries"`      // Optional: retries after the first attempt, e.g. on throttling (default: 2)
 }
 
 func (r Config) SourceConfigKind() string {
@@ -73,7 +100,7 @@ func (r Config) SourceConfigKind() string {
 // It establishes a connection to AWS CloudWatch Logs and verifies connectivity
 // by attempting to describe log groups.
 func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.Source, error) {
-	client, err := initCloudWatchLogsClient(ctx, tracer, r.Name, r.Region, r.Endpoint, r.AccessKeyID, r.SecretAccessKey, r.SessionToken)
+	client, err := initCloudWatchLogsClient(ctx, tracer, r)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create CloudWatch Logs client: %w", err)
 	}
@@ -110,6 +137,14 @@ func (s *Source) ToConfig() sources.SourceConfig {
 	return s.Config
 }
 
+// HealthCheck describes a single log group, as Initialize does.
+func (s *Source) HealthCheck(ctx context.Context) error {
+	_, err := s.Client.DescribeLogGroups(ctx, &cloudwatchlogs.DescribeLogGroupsInput{
+		Limit: sourceutil.Int32Ptr(1),
+	})
+	return err
+}
+
 // CloudWatchLogsClient returns the underlying CloudWatch Logs client.
 // This allows direct access to the AWS SDK client for advanced operations.
 func (s *Source) CloudWatchLogsClient() *cloudwatchlogs.Client {
@@ -379,9 +414,24 @@ func (s *Source) GetInsightsQueryResults(ctx context.Context, queryID string) (*
 
 // ListLogGroups returns a list of log groups in the account.
 // This is useful for discovering available log groups to query.
-func (s *Source) ListLogGroups(ctx context.Context, limit int32, nextToken string) ([]string, string, error) {
+// namePrefix limits the results to groups whose names start with it, and
+// namePattern to groups whose names contain it, ignoring case. At most one of
+// the two may be set; leave both empty to list every group.
+func (s *Source) ListLogGroups(ctx context.Context, namePrefix, namePattern string, limit int32, nextToken string) ([]string, string, error) {
+	if namePrefix != "" && namePattern != "" {
+		return nil, "", fmt.Errorf("namePrefix and namePattern cannot both be specified")
+	}
+
 	input := &cloudwatchlogs.DescribeLogGroupsInput{}
 
+	if namePrefix != "" {
+		input.LogGroupNamePrefix = &namePrefix
+	}
+
+	if namePattern != "" {
+		input.LogGroupNamePattern = &namePattern
+	}
+
 	if limit > 0 {
 		input.Limit = &limit
 	}
@@ -435,43 +485,74 @@ func (s *Source) ListLogStreams(ctx context.Context, logGroupName string, limit
 	return output.LogStreams, sourceutil.StringValue(output.NextToken), nil
 }
 
+// DeleteLogGroup deletes a log group and all of its log streams and events.
+// Deleting a log group that does not exist is not an error. Unlike the list
+// methods, the configured default log group is never used, so logGroupName
+// must be specified.
+func (s *Source) DeleteLogGroup(ctx context.Context, logGroupName string) error {
+	if logGroupName == "" {
+		return fmt.Errorf("logGroupName must be specified")
+	}
+	_, err := s.Client.DeleteLogGroup(ctx, &cloudwatchlogs.DeleteLogGroupInput{LogGroupName: &logGroupName})
+	if err != nil && !isNotFound(err) {
+		return fmt.Errorf("failed to delete log group %q: %w", logGroupName, err)
+	}
+	return nil
+}
+
+// DeleteLogStream deletes a log stream and all of its events. Deleting a log
+// stream that does not exist, or whose log group does not exist, is not an
+// error.
+func (s *Source) DeleteLogStream(ctx context.Context, logGroupName, logStreamName string) error {
+	if logGroupName == "" || logStreamName == "" {
+		return fmt.Errorf("logGroupName and logStreamName must be specified")
+	}
+	_, err := s.Client.DeleteLogStream(ctx, &cloudwatchlogs.DeleteLogStreamInput{
+		LogGroupName:  &logGroupName,
+		LogStreamName: &logStreamName,
+	})
+	if err != nil && !isNotFound(err) {
+		return fmt.Errorf("failed to delete log stream %q of log group %q: %w", logStreamName, logGroupName, err)
+	}
+	return nil
+}
+
+// isNotFound reports whether err is a ResourceNotFoundException.
+func isNotFound(err error) bool {
+	var notFound *types.ResourceNotFoundException
+	return errors.As(err, &notFound)
+}
+
 // initCloudWatchLogsClient initializes an AWS CloudWatch Logs client with the provided configuration.
 // It supports both default AWS credential chain and explicit credentials.
-func initCloudWatchLogsClient(ctx context.Context, tracer trace.Tracer, name, region, endpoint, accessKeyID, secretAccessKey, sessionToken string) (*cloudwatchlogs.Client, error) {
+func initCloudWatchLogsClient(ctx context.Context, tracer trace.Tracer, r Config) (*cloudwatchlogs.Client, error) {
 	//nolint:all // Reassigned ctx
-	ctx, span := sources.InitConnectionSpan(ctx, tracer, SourceKind, name)
+	ctx, span := sources.InitConnectionSpan(ctx, tracer, SourceKind, r.Name)
 	defer span.End()
 
-	// Build AWS config load options
-	configOpts := []func(*config.LoadOptions) error{
-		config.WithRegion(region),
-	}
-
-	// Use explicit credentials if provided
-	if accessKeyID != "" && secretAccessKey != "" {
-		configOpts = append(configOpts, config.WithCredentialsProvider(
-			credentials.NewStaticCredentialsProvider(accessKeyID, secretAccessKey, sessionToken),
-		))
-	}
-
-	// Load AWS configuration
-	cfg, err := config.LoadDefaultConfig(ctx, configOpts...)
+	// The endpoint is for LocalStack or custom endpoints
+	cfg, err := sourceutil.LoadAWSConfig(ctx, sourceutil.AWSOptions{
+		Region:          r.Region,
+		AccessKeyID:     r.AccessKeyID,
+		SecretAccessKey: r.SecretAccessKey,
+		SessionToken:    r.SessionToken,
+		Endpoint:        r.Endpoint,
+		RoleARN:         r.RoleARN,
+		RoleSessionName: r.RoleSessionName,
+		ExternalID:      r.ExternalID,
+		UseFIPS:         r.UseFIPS,
+		UseDualStack:    r.UseDualStack,
+		MaxRetries:      r.MaxRetries,
+	})
 	if err != nil {
-		return nil, fmt.Errorf("unable to load AWS config: %w", err)
+		return nil, err
 	}
-
-	// Create CloudWatch Logs client options
-	opts := []func(*cloudwatchlogs.Options){}
-
-	// Add custom endpoint if specified (for LocalStack or custom endpoints)
-	if endpoint != "" {
-		opts = append(opts, func(o *cloudwatchlogs.Options) {
-			o.BaseEndpoint = &endpoint
-		})
+	if cfg.Region == "" {
+		return nil, fmt.Errorf("unable to determine AWS region, set region in the source config or AWS_REGION")
 	}
 
 	// Create the CloudWatch Logs client
-	client := cloudwatchlogs.NewFromConfig(cfg, opts...)
+	client := cloudwatchlogs.NewFromConfig(cfg)
 
 	return client, nil
 }
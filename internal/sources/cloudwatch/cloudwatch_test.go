@@ -17,9 +17,12 @@ package cloudwatch
 import (
 	"bytes"
 	"context"
+	"fmt"
 	"testing"
 	"time"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
 	"github.com/goccy/go-yaml"
 	sourceutil "github.com/googleapis/genai-toolbox/internal/sources/util"
 	"github.com/stretchr/testify/assert"
@@ -110,6 +113,52 @@ sessionToken: FwoGZXIvYXdzEBQaDH1234567890EXAMPLE`,
 				SessionToken:    "FwoGZXIvYXdzEBQaDH1234567890EXAMPLE",
 			},
 		},
+		{
+			name: "valid configuration with assumed role",
+			yamlContent: `name: test-cloudwatch
+kind: cloudwatch
+region: us-east-1
+roleArn: arn:aws:iam::123456789012:role/toolbox
+roleSessionName: toolbox-session
+externalId: my-external-id`,
+			wantErr: false,
+			expected: Config{
+				Name:            "test-cloudwatch",
+				Kind:            "cloudwatch",
+				Region:          "us-east-1",
+				RoleARN:         "arn:aws:iam::123456789012:role/toolbox",
+				RoleSessionName: "toolbox-session",
+				ExternalID:      "my-external-id",
+			},
+		},
+		{
+			name: "valid configuration with FIPS and dual-stack endpoints",
+			yamlContent: `name: test-cloudwatch
+kind: cloudwatch
+region: us-east-1
+useFIPS: true
+useDualStack: true`,
+			wantErr: false,
+			expected: Config{
+				Name:         "test-cloudwatch",
+				Kind:         "cloudwatch",
+				Region:       "us-east-1",
+				UseFIPS:      true,
+				UseDualStack: true,
+			},
+		},
+		{
+			name: "custom endpoint with FIPS",
+			yamlContent: `name: test-cloudwatch
+kind: cloudwatch
+region: us-east-1
+endpoint: http://localhost:4566
+useFIPS: true`,
+			wantErr: true,
+			expected: Config{
+				Name: "test-cloudwatch",
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -131,6 +180,11 @@ sessionToken: FwoGZXIvYXdzEBQaDH1234567890EXAMPLE`,
 				assert.Equal(t, tt.expected.AccessKeyID, cfg.AccessKeyID)
 				assert.Equal(t, tt.expected.SecretAccessKey, cfg.SecretAccessKey)
 				assert.Equal(t, tt.expected.SessionToken, cfg.SessionToken)
+				assert.Equal(t, tt.expected.RoleARN, cfg.RoleARN)
+				assert.Equal(t, tt.expected.RoleSessionName, cfg.RoleSessionName)
+				assert.Equal(t, tt.expected.ExternalID, cfg.ExternalID)
+				assert.Equal(t, tt.expected.UseFIPS, cfg.UseFIPS)
+				assert.Equal(t, tt.expected.UseDualStack, cfg.UseDualStack)
 			}
 		})
 	}
@@ -375,6 +429,86 @@ func TestGetInsightsQueryResults_Validation(t *testing.T) {
 	}
 }
 
+func TestListLogGroups_Validation(t *testing.T) {
+	source := &Source{}
+	_, _, err := source.ListLogGroups(context.Background(), "/aws/lambda/", "api", 50, "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "namePrefix and namePattern cannot both be specified")
+}
+
+func TestMetricFilters_Validation(t *testing.T) {
+	source := &Source{}
+	_, err := source.DescribeMetricFilters(context.Background(), "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "logGroupName must be specified")
+
+	valid := MetricFilterDefinition{
+		Name:    "errors",
+		Pattern: "ERROR",
+		Transformation: MetricTransformation{
+			MetricName: "ErrorCount",
+			Namespace:  "App",
+			Value:      "1",
+		},
+	}
+	defaultValue := 0.0
+	tests := []struct {
+		name    string
+		group   string
+		modify  func(*MetricFilterDefinition)
+		wantErr string
+	}{
+		{name: "missing log group", modify: func(*MetricFilterDefinition) {}, wantErr: "logGroupName must be specified"},
+		{name: "missing name", group: "/app", modify: func(d *MetricFilterDefinition) { d.Name = "" }, wantErr: "filter name must be specified"},
+		{name: "missing metric value", group: "/app", modify: func(d *MetricFilterDefinition) { d.Transformation.Value = "" }, wantErr: "metric name, namespace and value must be specified"},
+		{
+			name:  "default value with dimensions",
+			group: "/app",
+			modify: func(d *MetricFilterDefinition) {
+				d.Transformation.DefaultValue = &defaultValue
+				d.Transformation.Dimensions = map[string]string{"Service": "$.service"}
+			},
+			wantErr: "cannot have a default value",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			def := valid
+			tt.modify(&def)
+			err := (&Source{}).PutMetricFilter(context.Background(), tt.group, def)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+
+	input, err := buildPutMetricFilterInput("/app", valid)
+	require.NoError(t, err)
+	assert.Equal(t, "/app", *input.LogGroupName)
+	assert.Equal(t, "errors", *input.FilterName)
+	assert.Equal(t, "ERROR", *input.FilterPattern)
+	require.Len(t, input.MetricTransformations, 1)
+	assert.Equal(t, "ErrorCount", *input.MetricTransformations[0].MetricName)
+	assert.Equal(t, "App", *input.MetricTransformations[0].MetricNamespace)
+	assert.Equal(t, "1", *input.MetricTransformations[0].MetricValue)
+}
+
+func TestDeleteLogs_Validation(t *testing.T) {
+	source := &Source{}
+	err := source.DeleteLogGroup(context.Background(), "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "logGroupName must be specified")
+
+	err = source.DeleteLogStream(context.Background(), "/aws/lambda/my-function", "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "logGroupName and logStreamName must be specified")
+}
+
+func TestIsNotFound(t *testing.T) {
+	notFound := &types.ResourceNotFoundException{Message: aws.String("The specified log group does not exist.")}
+	assert.True(t, isNotFound(fmt.Errorf("operation error: %w", notFound)))
+	assert.False(t, isNotFound(&types.InvalidParameterException{}))
+}
+
 func TestHelperFunctions(t *testing.T) {
 	t.Run("int32Ptr", func(t *testing.T) {
 		value := int32(42)
@@ -155,7 +155,7 @@ func ExampleSource_ListLogGroups() {
 
 	fmt.Println("Listing CloudWatch log groups...")
 	fmt.Println("Usage pattern:")
-	fmt.Println("  logGroups, nextToken, err := source.ListLogGroups(ctx, 50, \"\")")
+	fmt.Println("  logGroups, nextToken, err := source.ListLogGroups(ctx, \"/aws/lambda/\", \"\", 50, \"\")")
 	fmt.Println("  for _, lg := range logGroups {")
 	fmt.Println("      fmt.Println(lg)")
 	fmt.Println("  }")
@@ -163,7 +163,7 @@ func ExampleSource_ListLogGroups() {
 	// Output:
 	// Listing CloudWatch log groups...
 	// Usage pattern:
-	//   logGroups, nextToken, err := source.ListLogGroups(ctx, 50, "")
+	//   logGroups, nextToken, err := source.ListLogGroups(ctx, "/aws/lambda/", "", 50, "")
 	//   for _, lg := range logGroups {
 	//       fmt.Println(lg)
 	//   }
@@ -0,0 +1,148 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudwatch
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
+	sourceutil "github.com/googleapis/genai-toolbox/internal/sources/util"
+)
+
+// MetricFilter is a metric filter of a log group, which publishes a CloudWatch
+// metric from the log events that match its pattern.
+type MetricFilter struct {
+	Name            string
+	Pattern         string
+	LogGroupName    string
+	CreatedAt       time.Time
+	Transformations []MetricTransformation
+}
+
+// MetricTransformation describes the metric a metric filter publishes.
+type MetricTransformation struct {
+	MetricName   string
+	Namespace    string
+	Value        string            // Value published for each matching event, e.g. "1" or "$.latency"
+	DefaultValue *float64          // Optional: value published when no events match
+	Dimensions   map[string]string // Optional: dimension name to field selector, e.g. {"Service": "$.service"}
+	Unit         string            // Optional: e.g. Count, Milliseconds (default: None)
+}
+
+// MetricFilterDefinition describes a metric filter to create or replace with
+// PutMetricFilter.
+type MetricFilterDefinition struct {
+	Name           string
+	Pattern        string // Filter pattern; empty matches every event
+	Transformation MetricTransformation
+}
+
+// DescribeMetricFilters returns every metric filter of a log group, following
+// NextToken across pages. If logGroupName is empty, the configured default log
+// group is used.
+func (s *Source) DescribeMetricFilters(ctx context.Context, logGroupName string) ([]MetricFilter, error) {
+	if logGroupName == "" {
+		logGroupName = s.LogGroupName
+	}
+	if logGroupName == "" {
+		return nil, fmt.Errorf("logGroupName must be specified")
+	}
+
+	filters := []MetricFilter{}
+	input := &cloudwatchlogs.DescribeMetricFiltersInput{LogGroupName: &logGroupName}
+	for {
+		output, err := s.Client.DescribeMetricFilters(ctx, input)
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe metric filters: %w", err)
+		}
+		for _, mf := range output.MetricFilters {
+			filter := MetricFilter{
+				Name:         sourceutil.StringValue(mf.FilterName),
+				Pattern:      sourceutil.StringValue(mf.FilterPattern),
+				LogGroupName: sourceutil.StringValue(mf.LogGroupName),
+			}
+			if mf.CreationTime != nil {
+				filter.CreatedAt = time.UnixMilli(*mf.CreationTime)
+			}
+			for _, mt := range mf.MetricTransformations {
+				filter.Transformations = append(filter.Transformations, MetricTransformation{
+					MetricName:   sourceutil.StringValue(mt.MetricName),
+					Namespace:    sourceutil.StringValue(mt.MetricNamespace),
+					Value:        sourceutil.StringValue(mt.MetricValue),
+					DefaultValue: mt.DefaultValue,
+					Dimensions:   mt.Dimensions,
+					Unit:         string(mt.Unit),
+				})
+			}
+			filters = append(filters, filter)
+		}
+
+		if sourceutil.StringValue(output.NextToken) == "" {
+			return filters, nil
+		}
+		input.NextToken = output.NextToken
+	}
+}
+
+// PutMetricFilter creates a metric filter on a log group, or replaces the
+// filter with the same name. If logGroupName is empty, the configured default
+// log group is used.
+func (s *Source) PutMetricFilter(ctx context.Context, logGroupName string, filter MetricFilterDefinition) error {
+	if logGroupName == "" {
+		logGroupName = s.LogGroupName
+	}
+	input, err := buildPutMetricFilterInput(logGroupName, filter)
+	if err != nil {
+		return err
+	}
+	if _, err := s.Client.PutMetricFilter(ctx, input); err != nil {
+		return fmt.Errorf("failed to put metric filter: %w", err)
+	}
+	return nil
+}
+
+func buildPutMetricFilterInput(logGroupName string, filter MetricFilterDefinition) (*cloudwatchlogs.PutMetricFilterInput, error) {
+	if logGroupName == "" {
+		return nil, fmt.Errorf("logGroupName must be specified")
+	}
+	if filter.Name == "" {
+		return nil, fmt.Errorf("filter name must be specified")
+	}
+	mt := filter.Transformation
+	if mt.MetricName == "" || mt.Namespace == "" || mt.Value == "" {
+		return nil, fmt.Errorf("metric name, namespace and value must be specified")
+	}
+	if mt.DefaultValue != nil && len(mt.Dimensions) > 0 {
+		return nil, fmt.Errorf("a metric with dimensions cannot have a default value")
+	}
+
+	transformation := types.MetricTransformation{
+		MetricName:      &mt.MetricName,
+		MetricNamespace: &mt.Namespace,
+		MetricValue:     &mt.Value,
+		DefaultValue:    mt.DefaultValue,
+		Dimensions:      mt.Dimensions,
+		Unit:            types.StandardUnit(mt.Unit),
+	}
+	return &cloudwatchlogs.PutMetricFilterInput{
+		LogGroupName:          &logGroupName,
+		FilterName:            &filter.Name,
+		FilterPattern:         &filter.Pattern,
+		MetricTransformations: []types.MetricTransformation{transformation},
+	}, nil
+}
@@ -17,7 +17,6 @@ package couchbase
 import (
 	"context"
 	"crypto/tls"
-	"fmt"
 	"os"
 
 	"github.com/couchbase/gocb/v2"
@@ -33,9 +32,7 @@ const SourceKind string = "couchbase"
 var _ sources.SourceConfig = Config{}
 
 func init() {
-	if !sources.Register(SourceKind, newConfig) {
-		panic(fmt.Sprintf("source kind %q already registered", SourceKind))
-	}
+	sources.MustRegister(SourceKind, newConfig)
 }
 
 func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources.SourceConfig, error) {
@@ -102,6 +99,15 @@ func (s *Source) ToConfig() sources.SourceConfig {
 	return s.Config
 }
 
+// HealthCheck runs a trivial N1QL query on the scope.
+func (s *Source) HealthCheck(ctx context.Context) error {
+	results, err := s.Scope.Query("SELECT 1", &gocb.QueryOptions{Context: ctx})
+	if err != nil {
+		return err
+	}
+	return results.Close()
+}
+
 func (s *Source) CouchbaseScope() *gocb.Scope {
 	return s.Scope
 }
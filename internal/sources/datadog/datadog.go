@@ -0,0 +1,235 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package datadog provides a source implementation for Datadog.
+//
+// This source queries metrics and searches logs through the Datadog REST API
+// of the configured site.
+package datadog
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	sourceutil "github.com/googleapis/genai-toolbox/internal/sources/util"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const SourceKind string = "datadog"
+
+// Default configuration constants
+const (
+	DefaultSite    = "datadoghq.com" // Default Datadog site (US1)
+	DefaultTimeout = 30              // Default request timeout in seconds
+)
+
+// validate interface
+var _ sources.SourceConfig = Config{}
+
+func init() {
+	sources.MustRegister(SourceKind, newConfig)
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources.SourceConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+
+	for _, secret := range []struct {
+		field string
+		value *string
+		file  string
+	}{
+		{"apiKey", &actual.APIKey, actual.APIKeyFile},
+		{"appKey", &actual.AppKey, actual.AppKeyFile},
+	} {
+		resolved, err := sourceutil.ResolveSecret(secret.field, *secret.value, secret.file)
+		if err != nil {
+			return nil, fmt.Errorf("source %q (%s): unable to resolve secret: %w", name, SourceKind, err)
+		}
+		*secret.value = resolved
+		if *secret.value == "" {
+			return nil, fmt.Errorf("source %q (%s): %s or %sFile is required", name, SourceKind, secret.field, secret.field)
+		}
+	}
+
+	if strings.Contains(actual.Site, "/") {
+		return nil, fmt.Errorf("source %q (%s): site %q must be a bare domain such as %q or %q", name, SourceKind, actual.Site, DefaultSite, "datadoghq.eu")
+	}
+	if actual.Timeout < 0 {
+		return nil, fmt.Errorf("source %q (%s): timeout must not be negative", name, SourceKind)
+	}
+	return actual, nil
+}
+
+// Config represents the configuration for a Datadog source.
+type Config struct {
+	Name       string `yaml:"name" validate:"required"`
+	Kind       string `yaml:"kind" validate:"required"`
+	APIKey     string `yaml:"apiKey"`     // Required unless apiKeyFile is set: Datadog API key
+	APIKeyFile string `yaml:"apiKeyFile"` // Optional: file to read apiKey from
+	AppKey     string `yaml:"appKey"`     // Required unless appKeyFile is set: Datadog application key, needed to read data
+	AppKeyFile string `yaml:"appKeyFile"` // Optional: file to read appKey from
+	Site       string `yaml:"site"`       // Optional: Datadog site, e.g. datadoghq.eu (default: datadoghq.com)
+	Timeout    int    `yaml:"timeout"`    // Optional: request timeout in seconds (default: 30)
+}
+
+func (r Config) SourceConfigKind() string {
+	return SourceKind
+}
+
+func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.Source, error) {
+	//nolint:all // Reassigned ctx
+	ctx, span := sources.InitConnectionSpan(ctx, tracer, SourceKind, r.Name)
+	defer span.End()
+
+	timeout := r.Timeout
+	if timeout == 0 {
+		timeout = DefaultTimeout
+	}
+	s := &Source{
+		Config:     r,
+		HTTPClient: &http.Client{Timeout: time.Duration(timeout) * time.Second},
+		baseURL:    apiURL(r.Site),
+	}
+	if err := s.HealthCheck(ctx); err != nil {
+		return nil, fmt.Errorf("source %q (%s): unable to connect successfully: %w", r.Name, SourceKind, err)
+	}
+	return s, nil
+}
+
+var _ sources.Source = &Source{}
+
+// Source represents a Datadog source.
+type Source struct {
+	Config
+	HTTPClient *http.Client
+	baseURL    string // API URL of the configured site, replaced in tests
+}
+
+func (s *Source) SourceKind() string {
+	return SourceKind
+}
+
+func (s *Source) ToConfig() sources.SourceConfig {
+	return s.Config
+}
+
+// HealthCheck verifies that the API key is accepted by the configured site.
+func (s *Source) HealthCheck(ctx context.Context) error {
+	body, err := s.doRequest(ctx, http.MethodGet, "/api/v1/validate", nil, nil)
+	if err != nil {
+		return err
+	}
+	var resp struct {
+		Valid bool `json:"valid"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	if !resp.Valid {
+		return fmt.Errorf("API key is not valid for site %q", s.site())
+	}
+	return nil
+}
+
+// Close closes the HTTP client's idle connections.
+func (s *Source) Close() error {
+	if s.HTTPClient != nil {
+		s.HTTPClient.CloseIdleConnections()
+	}
+	return nil
+}
+
+func (s *Source) site() string {
+	if s.Site == "" {
+		return DefaultSite
+	}
+	return s.Site
+}
+
+// apiURL returns the API URL of site.
+func apiURL(site string) string {
+	if site == "" {
+		site = DefaultSite
+	}
+	return "https://api." + site
+}
+
+// errorResponse is the body of a Datadog API error.
+type errorResponse struct {
+	Errors []string `json:"errors"`
+}
+
+// doRequest sends an authenticated request to the Datadog API and returns the
+// response body. A non-nil payload is sent as JSON.
+func (s *Source) doRequest(ctx context.Context, method, path string, params url.Values, payload any) ([]byte, error) {
+	reqURL := s.baseURL + path
+	if len(params) > 0 {
+		reqURL += "?" + params.Encode()
+	}
+
+	var body io.Reader
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode request: %w", err)
+		}
+		body = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, reqURL, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("DD-API-KEY", s.APIKey)
+	req.Header.Set("DD-APPLICATION-KEY", s.AppKey)
+	req.Header.Set("Accept", "application/json")
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	client := s.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", sources.ClassifyError(err))
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg := strings.TrimSpace(string(respBody))
+		var errResp errorResponse
+		if json.Unmarshal(respBody, &errResp) == nil && len(errResp.Errors) > 0 {
+			msg = strings.Join(errResp.Errors, "; ")
+		}
+		return nil, sources.NewStatusError(resp.StatusCode, "request failed with status %d: %s", resp.StatusCode, msg)
+	}
+	return respBody, nil
+}
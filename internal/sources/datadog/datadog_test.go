@@ -0,0 +1,200 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/goccy/go-yaml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFromYamlDatadog(t *testing.T) {
+	tests := []struct {
+		name        string
+		yamlContent string
+		wantErr     string
+		expected    Config
+	}{
+		{
+			name: "eu site",
+			yamlContent: `name: test-datadog
+kind: datadog
+apiKey: api-key
+appKey: app-key
+site: datadoghq.eu
+timeout: 10`,
+			expected: Config{
+				Name:    "test-datadog",
+				Kind:    "datadog",
+				APIKey:  "api-key",
+				AppKey:  "app-key",
+				Site:    "datadoghq.eu",
+				Timeout: 10,
+			},
+		},
+		{
+			name: "site with scheme",
+			yamlContent: `name: test-datadog
+kind: datadog
+apiKey: api-key
+appKey: app-key
+site: https://api.datadoghq.com`,
+			wantErr: "must be a bare domain",
+		},
+		{
+			name: "negative timeout",
+			yamlContent: `name: test-datadog
+kind: datadog
+apiKey: api-key
+appKey: app-key
+timeout: -1`,
+			wantErr: "timeout must not be negative",
+		},
+		{
+			name: "missing app key",
+			yamlContent: `name: test-datadog
+kind: datadog
+apiKey: api-key`,
+			wantErr: "appKey or appKeyFile is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decoder := yaml.NewDecoder(bytes.NewReader([]byte(tt.yamlContent)))
+			config, err := newConfig(context.Background(), "test-datadog", decoder)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, config)
+		})
+	}
+}
+
+func TestAPIURL(t *testing.T) {
+	assert.Equal(t, "https://api.datadoghq.com", apiURL(""))
+	assert.Equal(t, "https://api.datadoghq.eu", apiURL("datadoghq.eu"))
+}
+
+// newTestSource returns a source whose requests go to handler after the API
+// and application keys are checked.
+func newTestSource(t *testing.T, handler http.HandlerFunc) *Source {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("DD-API-KEY") != "api-key" || r.Header.Get("DD-APPLICATION-KEY") != "app-key" {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"errors":["Forbidden"]}`))
+			return
+		}
+		handler(w, r)
+	}))
+	t.Cleanup(server.Close)
+	return &Source{
+		Config:     Config{Name: "test", Kind: SourceKind, APIKey: "api-key", AppKey: "app-key"},
+		HTTPClient: server.Client(),
+		baseURL:    server.URL,
+	}
+}
+
+func TestHealthCheck(t *testing.T) {
+	s := newTestSource(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/validate", r.URL.Path)
+		_, _ = w.Write([]byte(`{"valid":true}`))
+	})
+	require.NoError(t, s.HealthCheck(context.Background()))
+
+	s.APIKey = "wrong"
+	assert.ErrorContains(t, s.HealthCheck(context.Background()), "status 403: Forbidden")
+}
+
+func TestQueryMetrics(t *testing.T) {
+	var gotQuery, gotFrom, gotTo string
+	s := newTestSource(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/query", r.URL.Path)
+		gotQuery = r.FormValue("query")
+		gotFrom = r.FormValue("from")
+		gotTo = r.FormValue("to")
+		_, _ = w.Write([]byte(`{"status":"ok","series":[{
+			"metric":"system.cpu.user","scope":"host:web-1","expression":"avg:system.cpu.user{host:web-1}",
+			"unit":[{"name":"percent"},null],
+			"pointlist":[[1714564800000,12.5],[1714564860000,null],[1714564920000,14]]
+		}]}`))
+	})
+
+	from := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	to := from.Add(5 * time.Minute)
+	series, err := s.QueryMetrics(context.Background(), "avg:system.cpu.user{*} by {host}", from, to)
+	require.NoError(t, err)
+	assert.Equal(t, "avg:system.cpu.user{*} by {host}", gotQuery)
+	assert.Equal(t, "1714564800", gotFrom)
+	assert.Equal(t, "1714565100", gotTo)
+
+	require.Len(t, series, 1)
+	assert.Equal(t, "host:web-1", series[0].Scope)
+	assert.Equal(t, "percent", series[0].Unit)
+	assert.Equal(t, []MetricPoint{
+		{Timestamp: from, Value: 12.5},
+		{Timestamp: from.Add(2 * time.Minute), Value: 14},
+	}, series[0].Points)
+
+	_, err = s.QueryMetrics(context.Background(), "avg:system.cpu.user{*}", to, from)
+	assert.ErrorContains(t, err, "to must not be before from")
+}
+
+func TestSearchLogs(t *testing.T) {
+	var requests []logsSearchRequest
+	s := newTestSource(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v2/logs/events/search", r.URL.Path)
+		var req logsSearchRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		requests = append(requests, req)
+		if req.Page.Cursor == "" {
+			_, _ = w.Write([]byte(`{"data":[{"id":"a","attributes":{"timestamp":"2024-05-01T12:00:02Z","status":"error","service":"api","host":"web-1","message":"boom","tags":["env:prod"],"attributes":{"http":{"status_code":500}}}}],"meta":{"page":{"after":"next"}}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":[{"id":"b","attributes":{"timestamp":"2024-05-01T12:00:01Z","status":"info","message":"ok"}}],"meta":{"page":{}}}`))
+	})
+
+	from := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	logs, err := s.SearchLogs(context.Background(), "service:api", from, from.Add(time.Minute), 10)
+	require.NoError(t, err)
+	require.Len(t, logs, 2)
+	assert.Equal(t, "a", logs[0].ID)
+	assert.Equal(t, "web-1", logs[0].Host)
+	assert.Equal(t, []string{"env:prod"}, logs[0].Tags)
+	assert.Equal(t, from.Add(2*time.Second), logs[0].Timestamp)
+	assert.Equal(t, "ok", logs[1].Message)
+
+	require.Len(t, requests, 2)
+	assert.Equal(t, "service:api", requests[0].Filter.Query)
+	assert.Equal(t, "2024-05-01T12:00:00Z", requests[0].Filter.From)
+	assert.Equal(t, "-timestamp", requests[0].Sort)
+	assert.Equal(t, 10, requests[0].Page.Limit)
+	assert.Equal(t, "next", requests[1].Page.Cursor)
+	assert.Equal(t, 9, requests[1].Page.Limit)
+
+	_, err = s.SearchLogs(context.Background(), "service:api", from, from, 0)
+	assert.ErrorContains(t, err, "limit must be positive")
+}
@@ -0,0 +1,203 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// MaxLogsPageSize is the largest page the logs search endpoint returns.
+const MaxLogsPageSize = 1000
+
+// MetricSeries is a timeseries returned by a metrics query.
+type MetricSeries struct {
+	Metric     string
+	Scope      string // Tags the series is grouped by, e.g. "host:web-1"
+	Expression string
+	Unit       string
+	Points     []MetricPoint
+}
+
+// MetricPoint is a single value of a timeseries.
+type MetricPoint struct {
+	Timestamp time.Time
+	Value     float64
+}
+
+type metricsResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error"`
+	Series []struct {
+		Metric     string `json:"metric"`
+		Scope      string `json:"scope"`
+		Expression string `json:"expression"`
+		Unit       []*struct {
+			Name string `json:"name"`
+		} `json:"unit"`
+		Pointlist [][]*float64 `json:"pointlist"` // [<unix milliseconds>, <value>] pairs
+	} `json:"series"`
+}
+
+// QueryMetrics runs a metrics query, e.g. "avg:system.cpu.user{env:prod} by
+// {host}", over [from, to]. Points without a value are omitted.
+func (s *Source) QueryMetrics(ctx context.Context, query string, from, to time.Time) ([]MetricSeries, error) {
+	if to.Before(from) {
+		return nil, fmt.Errorf("to must not be before from")
+	}
+	params := url.Values{
+		"query": {query},
+		"from":  {strconv.FormatInt(from.Unix(), 10)},
+		"to":    {strconv.FormatInt(to.Unix(), 10)},
+	}
+	body, err := s.doRequest(ctx, http.MethodGet, "/api/v1/query", params, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp metricsResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	if resp.Status == "error" {
+		return nil, fmt.Errorf("query failed: %s", resp.Error)
+	}
+
+	series := make([]MetricSeries, 0, len(resp.Series))
+	for _, rs := range resp.Series {
+		ms := MetricSeries{
+			Metric:     rs.Metric,
+			Scope:      rs.Scope,
+			Expression: rs.Expression,
+			Points:     []MetricPoint{},
+		}
+		if len(rs.Unit) > 0 && rs.Unit[0] != nil {
+			ms.Unit = rs.Unit[0].Name
+		}
+		for _, p := range rs.Pointlist {
+			if len(p) < 2 || p[0] == nil || p[1] == nil {
+				continue
+			}
+			ms.Points = append(ms.Points, MetricPoint{
+				Timestamp: time.UnixMilli(int64(*p[0])).UTC(),
+				Value:     *p[1],
+			})
+		}
+		series = append(series, ms)
+	}
+	return series, nil
+}
+
+// LogEvent is a log returned by a logs search.
+type LogEvent struct {
+	ID         string
+	Timestamp  time.Time
+	Status     string
+	Service    string
+	Host       string
+	Message    string
+	Tags       []string
+	Attributes map[string]any
+}
+
+type logsSearchRequest struct {
+	Filter struct {
+		Query string `json:"query"`
+		From  string `json:"from"`
+		To    string `json:"to"`
+	} `json:"filter"`
+	Sort string `json:"sort"`
+	Page struct {
+		Limit  int    `json:"limit"`
+		Cursor string `json:"cursor,omitempty"`
+	} `json:"page"`
+}
+
+type logsSearchResponse struct {
+	Data []struct {
+		ID         string `json:"id"`
+		Attributes struct {
+			Timestamp  time.Time      `json:"timestamp"`
+			Status     string         `json:"status"`
+			Service    string         `json:"service"`
+			Host       string         `json:"host"`
+			Message    string         `json:"message"`
+			Tags       []string       `json:"tags"`
+			Attributes map[string]any `json:"attributes"`
+		} `json:"attributes"`
+	} `json:"data"`
+	Meta struct {
+		Page struct {
+			After string `json:"after"`
+		} `json:"page"`
+	} `json:"meta"`
+}
+
+// SearchLogs returns at most limit logs matching query, written in the Datadog
+// log search syntax, over [from, to], newest first. Results are paged through
+// until limit logs are collected or no more match.
+func (s *Source) SearchLogs(ctx context.Context, query string, from, to time.Time, limit int) ([]LogEvent, error) {
+	if to.Before(from) {
+		return nil, fmt.Errorf("to must not be before from")
+	}
+	if limit <= 0 {
+		return nil, fmt.Errorf("limit must be positive")
+	}
+
+	var req logsSearchRequest
+	req.Filter.Query = query
+	req.Filter.From = from.UTC().Format(time.RFC3339Nano)
+	req.Filter.To = to.UTC().Format(time.RFC3339Nano)
+	req.Sort = "-timestamp"
+
+	events := []LogEvent{}
+	for len(events) < limit {
+		req.Page.Limit = min(limit-len(events), MaxLogsPageSize)
+		body, err := s.doRequest(ctx, http.MethodPost, "/api/v2/logs/events/search", nil, req)
+		if err != nil {
+			return nil, err
+		}
+
+		var resp logsSearchResponse
+		if err := json.Unmarshal(body, &resp); err != nil {
+			return nil, fmt.Errorf("failed to decode response: %w", err)
+		}
+		for _, d := range resp.Data {
+			events = append(events, LogEvent{
+				ID:         d.ID,
+				Timestamp:  d.Attributes.Timestamp,
+				Status:     d.Attributes.Status,
+				Service:    d.Attributes.Service,
+				Host:       d.Attributes.Host,
+				Message:    d.Attributes.Message,
+				Tags:       d.Attributes.Tags,
+				Attributes: d.Attributes.Attributes,
+			})
+		}
+		if resp.Meta.Page.After == "" || len(resp.Data) == 0 {
+			break
+		}
+		req.Page.Cursor = resp.Meta.Page.After
+	}
+	if len(events) > limit {
+		events = events[:limit]
+	}
+	return events, nil
+}
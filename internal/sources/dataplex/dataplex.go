@@ -19,11 +19,13 @@ import (
 	"fmt"
 
 	dataplexapi "cloud.google.com/go/dataplex/apiv1"
+	dataplexpb "cloud.google.com/go/dataplex/apiv1/dataplexpb"
 	"github.com/goccy/go-yaml"
 	"github.com/googleapis/genai-toolbox/internal/sources"
 	"github.com/googleapis/genai-toolbox/internal/util"
 	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/oauth2/google"
+	"google.golang.org/api/iterator"
 	"google.golang.org/api/option"
 )
 
@@ -33,9 +35,7 @@ const SourceKind string = "dataplex"
 var _ sources.SourceConfig = Config{}
 
 func init() {
-	if !sources.Register(SourceKind, newConfig) {
-		panic(fmt.Sprintf("source kind %q already registered", SourceKind))
-	}
+	sources.MustRegister(SourceKind, newConfig)
 }
 
 func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources.SourceConfig, error) {
@@ -88,6 +88,18 @@ func (s *Source) ToConfig() sources.SourceConfig {
 	return s.Config
 }
 
+// HealthCheck lists an entry group in the project.
+func (s *Source) HealthCheck(ctx context.Context) error {
+	_, err := s.Client.ListEntryGroups(ctx, &dataplexpb.ListEntryGroupsRequest{
+		Parent:   fmt.Sprintf("projects/%s/locations/global", s.Project),
+		PageSize: 1,
+	}).Next()
+	if err != nil && err != iterator.Done {
+		return err
+	}
+	return nil
+}
+
 func (s *Source) ProjectID() string {
 	return s.Project
 }
@@ -35,9 +35,7 @@ const SourceKind string = "dgraph"
 var _ sources.SourceConfig = Config{}
 
 func init() {
-	if !sources.Register(SourceKind, newConfig) {
-		panic(fmt.Sprintf("source kind %q already registered", SourceKind))
-	}
+	sources.MustRegister(SourceKind, newConfig)
 }
 
 func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources.SourceConfig, error) {
@@ -110,6 +108,11 @@ func (s *Source) ToConfig() sources.SourceConfig {
 	return s.Config
 }
 
+// HealthCheck queries the Dgraph /health endpoint.
+func (s *Source) HealthCheck(ctx context.Context) error {
+	return s.Client.healthCheck()
+}
+
 func (s *Source) DgraphClient() *DgraphClient {
 	return s.Client
 }
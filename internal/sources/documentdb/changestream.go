@@ -0,0 +1,187 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package documentdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Change stream retry settings
+const (
+	WatchRetryDelay    = 500 * time.Millisecond // Delay before reopening a change stream after a transient error
+	MaxWatchRetryDelay = 30 * time.Second       // Maximum delay between reopen attempts
+	CloseStreamTimeout = 5 * time.Second        // Time allowed to close a change stream after ctx is done
+)
+
+// changeStream is the subset of *mongo.ChangeStream used by Watch.
+type changeStream interface {
+	Next(ctx context.Context) bool
+	Decode(val any) error
+	ResumeToken() bson.Raw
+	Err() error
+	Close(ctx context.Context) error
+}
+
+// watchAPI opens change streams, resuming after resumeAfter if it is set.
+type watchAPI interface {
+	Watch(ctx context.Context, database, collection string, pipeline mongo.Pipeline, resumeAfter bson.Raw) (changeStream, error)
+}
+
+// clientWatcher opens change streams with a MongoDB client.
+type clientWatcher struct {
+	client *mongo.Client
+}
+
+func (w clientWatcher) Watch(ctx context.Context, database, collection string, pipeline mongo.Pipeline, resumeAfter bson.Raw) (changeStream, error) {
+	opts := options.ChangeStream().SetFullDocument(options.UpdateLookup)
+	if resumeAfter != nil {
+		opts.SetResumeAfter(resumeAfter)
+	}
+	stream, err := w.client.Database(database).Collection(collection).Watch(ctx, pipeline, opts)
+	if err != nil {
+		return nil, err
+	}
+	return stream, nil
+}
+
+// Namespace identifies the collection a change event applies to.
+type Namespace struct {
+	Database   string `bson:"db"`
+	Collection string `bson:"coll"`
+}
+
+// UpdateDescription lists the fields changed by an update event.
+type UpdateDescription struct {
+	UpdatedFields bson.M   `bson:"updatedFields"`
+	RemovedFields []string `bson:"removedFields"`
+}
+
+// ChangeEvent is a decoded change stream event. FullDocument is the current
+// version of the document for insert, replace, and update events.
+type ChangeEvent struct {
+	ResumeToken       bson.Raw            `bson:"_id"`
+	OperationType     string              `bson:"operationType"`
+	Namespace         Namespace           `bson:"ns"`
+	DocumentKey       bson.M              `bson:"documentKey"`
+	FullDocument      bson.M              `bson:"fullDocument"`
+	UpdateDescription *UpdateDescription  `bson:"updateDescription"`
+	ClusterTime       primitive.Timestamp `bson:"clusterTime"`
+}
+
+// Watch opens a change stream on database.collection, filtered by pipeline,
+// and emits decoded events until ctx is done. Transient errors reopen the
+// stream from the last resume token with backoff; other errors are sent on the
+// error channel. Both channels are closed when watching stops.
+func (s *Source) Watch(ctx context.Context, database, collection string, pipeline mongo.Pipeline) (<-chan ChangeEvent, <-chan error, error) {
+	if pipeline == nil {
+		pipeline = mongo.Pipeline{}
+	}
+	stream, err := s.watcher.Watch(ctx, database, collection, pipeline, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to watch %s.%s: %w", database, collection, err)
+	}
+
+	events := make(chan ChangeEvent)
+	errc := make(chan error, 1)
+	go func() {
+		defer close(events)
+		defer close(errc)
+		if err := s.consumeChangeStream(ctx, stream, database, collection, pipeline, events); err != nil {
+			errc <- err
+		}
+	}()
+	return events, errc, nil
+}
+
+// consumeChangeStream sends events from stream until ctx is done or a
+// non-transient error occurs, reopening the stream after transient errors.
+func (s *Source) consumeChangeStream(ctx context.Context, stream changeStream, database, collection string, pipeline mongo.Pipeline, events chan<- ChangeEvent) error {
+	var token bson.Raw
+	delay := WatchRetryDelay
+	for {
+		for stream.Next(ctx) {
+			var event ChangeEvent
+			if err := stream.Decode(&event); err != nil {
+				closeChangeStream(ctx, stream)
+				return fmt.Errorf("unable to decode change event: %w", err)
+			}
+			token = stream.ResumeToken()
+			select {
+			case events <- event:
+			case <-ctx.Done():
+				closeChangeStream(ctx, stream)
+				return nil
+			}
+			delay = WatchRetryDelay
+		}
+		err := stream.Err()
+		if t := stream.ResumeToken(); t != nil {
+			token = t
+		}
+		closeChangeStream(ctx, stream)
+
+		// Reopen the stream until it succeeds or a non-transient error occurs
+		for {
+			if ctx.Err() != nil {
+				return nil
+			}
+			if err == nil {
+				// The stream was invalidated, e.g. by dropping the collection
+				return fmt.Errorf("change stream on %s.%s was closed", database, collection)
+			}
+			if !isTransientError(err) {
+				return fmt.Errorf("change stream on %s.%s failed: %w", database, collection, err)
+			}
+
+			timer := time.NewTimer(delay)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return nil
+			case <-timer.C:
+			}
+			delay = min(delay*2, MaxWatchRetryDelay)
+
+			stream, err = s.watcher.Watch(ctx, database, collection, pipeline, token)
+			if err == nil {
+				break
+			}
+		}
+	}
+}
+
+// closeChangeStream closes stream, allowing it time to close after ctx is done.
+func closeChangeStream(ctx context.Context, stream changeStream) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), CloseStreamTimeout)
+	defer cancel()
+	_ = stream.Close(ctx)
+}
+
+// isTransientError reports whether a change stream can be resumed after err.
+func isTransientError(err error) bool {
+	if mongo.IsNetworkError(err) || mongo.IsTimeout(err) {
+		return true
+	}
+	var serverErr mongo.ServerError
+	return errors.As(err, &serverErr) && serverErr.HasErrorLabel("ResumableChangeStreamError")
+}
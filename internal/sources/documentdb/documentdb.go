@@ -20,28 +20,32 @@ package documentdb
 
 import (
 	"context"
-	"crypto/tls"
-	"crypto/x509"
 	"fmt"
-	"os"
+	"strconv"
+	"time"
 
 	"github.com/goccy/go-yaml"
 	"github.com/googleapis/genai-toolbox/internal/sources"
+	sourceutil "github.com/googleapis/genai-toolbox/internal/sources/util"
 	"github.com/googleapis/genai-toolbox/internal/util"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readconcern"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+	"go.mongodb.org/mongo-driver/mongo/writeconcern"
 	"go.opentelemetry.io/otel/trace"
 )
 
 const SourceKind string = "documentdb"
 
+// HealthCheckTimeout is the time allowed for a HealthCheck ping.
+const HealthCheckTimeout = 2 * time.Second
+
 // validate interface
 var _ sources.SourceConfig = Config{}
 
 func init() {
-	if !sources.Register(SourceKind, newConfig) {
-		panic(fmt.Sprintf("source kind %q already registered", SourceKind))
-	}
+	sources.MustRegister(SourceKind, newConfig)
 }
 
 func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources.SourceConfig, error) {
@@ -53,10 +57,18 @@ func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources
 }
 
 type Config struct {
-	Name      string `yaml:"name" validate:"required"`
-	Kind      string `yaml:"kind" validate:"required"`
-	Uri       string `yaml:"uri" validate:"required"` // DocumentDB connection URI
-	TLSCAFile string `yaml:"tlsCAFile"`               // Path to CA certificate for TLS
+	Name            string `yaml:"name" validate:"required"`
+	Kind            string `yaml:"kind" validate:"required"`
+	Uri             string `yaml:"uri" validate:"required"` // DocumentDB connection URI
+	TLSCAFile       string `yaml:"tlsCAFile"`               // Path to CA certificate for TLS
+	ReadPreference  string `yaml:"readPreference"`          // e.g. primary, secondaryPreferred, nearest
+	ReadConcern     string `yaml:"readConcern"`             // e.g. local, majority
+	WriteConcern    string `yaml:"writeConcern"`            // "majority", a node count, or a tag set name
+	MaxPoolSize     uint64 `yaml:"maxPoolSize"`             // Optional: max connections per server (driver default 100)
+	MinPoolSize     uint64 `yaml:"minPoolSize"`             // Optional: min connections kept open per server
+	MaxConnIdleTime string `yaml:"maxConnIdleTime"`         // Optional: max time a connection stays idle, e.g. "5m"
+	RetryWrites     bool   `yaml:"retryWrites"`             // Optional: retry failed writes (default false, DocumentDB doesn't support retryable writes)
+	RetryReads      *bool  `yaml:"retryReads"`              // Optional: retry failed reads (default: driver default)
 }
 
 func (r Config) SourceConfigKind() string {
@@ -64,7 +76,7 @@ func (r Config) SourceConfigKind() string {
 }
 
 func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.Source, error) {
-	client, err := initDocumentDBClient(ctx, tracer, r.Name, r.Uri, r.TLSCAFile)
+	client, err := initDocumentDBClient(ctx, tracer, r)
 	if err != nil {
 		return nil, fmt.Errorf("source %q (%s): unable to create DocumentDB client: %w", r.Name, SourceKind, err)
 	}
@@ -76,8 +88,9 @@ func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.So
 	}
 
 	s := &Source{
-		Config: r,
-		Client: client,
+		Config:  r,
+		Client:  client,
+		watcher: clientWatcher{client: client},
 	}
 	return s, nil
 }
@@ -86,7 +99,8 @@ var _ sources.Source = &Source{}
 
 type Source struct {
 	Config
-	Client *mongo.Client
+	Client  *mongo.Client
+	watcher watchAPI // Opens change streams with Client, replaced in tests
 }
 
 func (s *Source) SourceKind() string {
@@ -102,17 +116,32 @@ func (s *Source) DocumentDBClient() *mongo.Client {
 	return s.Client
 }
 
-// Close disconnects from DocumentDB and releases resources.
+// HealthCheck pings the cluster, failing if it doesn't respond within
+// HealthCheckTimeout. It is intended for liveness probes.
+func (s *Source) HealthCheck(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, HealthCheckTimeout)
+	defer cancel()
+	return s.Client.Ping(ctx, nil)
+}
+
+// Close calls CloseWithContext with a sources.DefaultCloseTimeout deadline.
 func (s *Source) Close() error {
+	ctx, cancel := context.WithTimeout(context.Background(), sources.DefaultCloseTimeout)
+	defer cancel()
+	return s.CloseWithContext(ctx)
+}
+
+// CloseWithContext disconnects from DocumentDB and releases resources.
+func (s *Source) CloseWithContext(ctx context.Context) error {
 	if s.Client != nil {
-		return s.Client.Disconnect(context.Background())
+		return s.Client.Disconnect(ctx)
 	}
 	return nil
 }
 
-func initDocumentDBClient(ctx context.Context, tracer trace.Tracer, name, uri, tlsCAFile string) (*mongo.Client, error) {
+func initDocumentDBClient(ctx context.Context, tracer trace.Tracer, r Config) (*mongo.Client, error) {
 	// Start a tracing span
-	ctx, span := sources.InitConnectionSpan(ctx, tracer, SourceKind, name)
+	ctx, span := sources.InitConnectionSpan(ctx, tracer, SourceKind, r.Name)
 	defer span.End()
 
 	userAgent, err := util.UserAgentFromContext(ctx)
@@ -120,17 +149,9 @@ func initDocumentDBClient(ctx context.Context, tracer trace.Tracer, name, uri, t
 		userAgent = "genai-toolbox"
 	}
 
-	// Create client options
-	clientOpts := options.Client().ApplyURI(uri).SetAppName(userAgent)
-
-	// DocumentDB requires TLS
-	if tlsCAFile != "" {
-		// Set TLS config with CA file
-		tlsConfig, err := loadTLSConfig(tlsCAFile)
-		if err != nil {
-			return nil, fmt.Errorf("unable to load TLS config: %w", err)
-		}
-		clientOpts.SetTLSConfig(tlsConfig)
+	clientOpts, err := clientOptions(r, userAgent)
+	if err != nil {
+		return nil, err
 	}
 
 	// Create a new MongoDB client (DocumentDB is MongoDB-compatible)
@@ -142,26 +163,84 @@ func initDocumentDBClient(ctx context.Context, tracer trace.Tracer, name, uri, t
 	return client, nil
 }
 
-// loadTLSConfig loads TLS configuration from a CA certificate file.
-// Uses os.ReadFile instead of deprecated ioutil.ReadFile (Go 1.16+).
-func loadTLSConfig(caFile string) (*tls.Config, error) {
-	tlsConfig := &tls.Config{}
+// clientOptions builds the MongoDB client options for r. Read preference,
+// read/write concerns, retry, and pool settings in the config take precedence
+// over those in the URI.
+func clientOptions(r Config, appName string) (*options.ClientOptions, error) {
+	clientOpts := options.Client().ApplyURI(r.Uri).SetAppName(appName)
 
-	if caFile != "" {
-		certs := x509.NewCertPool()
+	// DocumentDB requires TLS
+	if r.TLSCAFile != "" {
+		// Set TLS config with CA file
+		tlsConfig, err := sourceutil.LoadTLSConfig(r.TLSCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load TLS config: %w", err)
+		}
+		clientOpts.SetTLSConfig(tlsConfig)
+	}
 
-		// Use os.ReadFile instead of deprecated ioutil.ReadFile
-		pemData, err := os.ReadFile(caFile)
+	if r.ReadPreference != "" {
+		mode, err := readpref.ModeFromString(r.ReadPreference)
 		if err != nil {
-			return nil, fmt.Errorf("unable to read CA file: %w", err)
+			return nil, fmt.Errorf("invalid read preference %q: %w", r.ReadPreference, err)
 		}
+		pref, err := readpref.New(mode)
+		if err != nil {
+			return nil, fmt.Errorf("invalid read preference %q: %w", r.ReadPreference, err)
+		}
+		clientOpts.SetReadPreference(pref)
+	}
 
-		if !certs.AppendCertsFromPEM(pemData) {
-			return nil, fmt.Errorf("failed to append CA certificate")
+	if r.ReadConcern != "" {
+		switch r.ReadConcern {
+		case "local", "available", "majority", "linearizable", "snapshot":
+		default:
+			return nil, fmt.Errorf("invalid read concern %q", r.ReadConcern)
 		}
+		clientOpts.SetReadConcern(&readconcern.ReadConcern{Level: r.ReadConcern})
+	}
+
+	if r.WriteConcern != "" {
+		clientOpts.SetWriteConcern(parseWriteConcern(r.WriteConcern))
+	}
+
+	// The driver retries writes by default, which DocumentDB rejects
+	clientOpts.SetRetryWrites(r.RetryWrites)
+	if r.RetryReads != nil {
+		clientOpts.SetRetryReads(*r.RetryReads)
+	}
 
-		tlsConfig.RootCAs = certs
+	if r.MaxPoolSize != 0 {
+		if r.MinPoolSize > r.MaxPoolSize {
+			return nil, fmt.Errorf("minPoolSize %d is greater than maxPoolSize %d", r.MinPoolSize, r.MaxPoolSize)
+		}
+		clientOpts.SetMaxPoolSize(r.MaxPoolSize)
+	}
+	if r.MinPoolSize != 0 {
+		clientOpts.SetMinPoolSize(r.MinPoolSize)
+	}
+	if r.MaxConnIdleTime != "" {
+		d, err := time.ParseDuration(r.MaxConnIdleTime)
+		if err != nil {
+			return nil, fmt.Errorf("invalid maxConnIdleTime %q: %w", r.MaxConnIdleTime, err)
+		}
+		if d < 0 {
+			return nil, fmt.Errorf("invalid maxConnIdleTime %q: must not be negative", r.MaxConnIdleTime)
+		}
+		clientOpts.SetMaxConnIdleTime(d)
 	}
 
-	return tlsConfig, nil
+	return clientOpts, nil
+}
+
+// parseWriteConcern parses a write concern "w" value: "majority", a number of
+// nodes, or otherwise the name of a custom tag set.
+func parseWriteConcern(w string) *writeconcern.WriteConcern {
+	if w == "majority" {
+		return writeconcern.Majority()
+	}
+	if n, err := strconv.Atoi(w); err == nil {
+		return &writeconcern.WriteConcern{W: n}
+	}
+	return writeconcern.Custom(w)
 }
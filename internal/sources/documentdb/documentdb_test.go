@@ -17,10 +17,17 @@ package documentdb
 import (
 	"bytes"
 	"context"
+	"errors"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/goccy/go-yaml"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
 )
 
 func TestParseFromYamlDocumentDB(t *testing.T) {
@@ -56,6 +63,24 @@ tlsCAFile: /path/to/ca-cert.pem`,
 				TLSCAFile: "/path/to/ca-cert.pem",
 			},
 		},
+		{
+			name: "valid configuration with read and write concerns",
+			yamlContent: `name: test-documentdb
+kind: documentdb
+uri: mongodb://docdb-cluster.cluster-abc123.us-east-1.docdb.amazonaws.com:27017
+readPreference: secondaryPreferred
+readConcern: majority
+writeConcern: majority`,
+			wantErr: false,
+			expected: Config{
+				Name:           "test-documentdb",
+				Kind:           "documentdb",
+				Uri:            "mongodb://docdb-cluster.cluster-abc123.us-east-1.docdb.amazonaws.com:27017",
+				ReadPreference: "secondaryPreferred",
+				ReadConcern:    "majority",
+				WriteConcern:   "majority",
+			},
+		},
 		{
 			name: "valid configuration with localhost",
 			yamlContent: `name: local-documentdb
@@ -85,6 +110,9 @@ uri: mongodb://localhost:27017`,
 				if tt.expected.TLSCAFile != "" {
 					assert.Equal(t, tt.expected.TLSCAFile, config.(Config).TLSCAFile)
 				}
+				assert.Equal(t, tt.expected.ReadPreference, config.(Config).ReadPreference)
+				assert.Equal(t, tt.expected.ReadConcern, config.(Config).ReadConcern)
+				assert.Equal(t, tt.expected.WriteConcern, config.(Config).WriteConcern)
 			}
 		})
 	}
@@ -129,3 +157,208 @@ func TestSourceKindDocumentDB(t *testing.T) {
 	source := Source{Config: config}
 	assert.Equal(t, SourceKind, source.SourceKind())
 }
+
+func TestClientOptions(t *testing.T) {
+	base := Config{Name: "test", Kind: SourceKind, Uri: "mongodb://localhost:27017/?readPreference=primary"}
+
+	t.Run("defaults to the URI", func(t *testing.T) {
+		opts, err := clientOptions(base, "genai-toolbox")
+		require.NoError(t, err)
+		assert.Equal(t, readpref.PrimaryMode, opts.ReadPreference.Mode())
+		assert.Nil(t, opts.ReadConcern)
+		assert.Nil(t, opts.WriteConcern)
+		assert.False(t, *opts.RetryWrites)
+		assert.Nil(t, opts.RetryReads)
+	})
+
+	t.Run("retries", func(t *testing.T) {
+		r := base
+		r.Uri = "mongodb://localhost:27017/?retryWrites=true&retryReads=true"
+		retryReads := false
+		r.RetryReads = &retryReads
+		opts, err := clientOptions(r, "genai-toolbox")
+		require.NoError(t, err)
+		assert.False(t, *opts.RetryWrites)
+		assert.False(t, *opts.RetryReads)
+
+		r.RetryWrites = true
+		opts, err = clientOptions(r, "genai-toolbox")
+		require.NoError(t, err)
+		assert.True(t, *opts.RetryWrites)
+	})
+
+	t.Run("overrides the URI", func(t *testing.T) {
+		r := base
+		r.ReadPreference = "secondaryPreferred"
+		r.ReadConcern = "majority"
+		r.WriteConcern = "majority"
+		opts, err := clientOptions(r, "genai-toolbox")
+		require.NoError(t, err)
+		assert.Equal(t, readpref.SecondaryPreferredMode, opts.ReadPreference.Mode())
+		assert.Equal(t, "majority", opts.ReadConcern.Level)
+		assert.Equal(t, "majority", opts.WriteConcern.W)
+	})
+
+	t.Run("numeric and tagged write concerns", func(t *testing.T) {
+		assert.Equal(t, 2, parseWriteConcern("2").W)
+		assert.Equal(t, "dc-east", parseWriteConcern("dc-east").W)
+	})
+
+	t.Run("connection pool", func(t *testing.T) {
+		r := base
+		r.MaxPoolSize = 50
+		r.MinPoolSize = 5
+		r.MaxConnIdleTime = "5m"
+		opts, err := clientOptions(r, "genai-toolbox")
+		require.NoError(t, err)
+		assert.Equal(t, uint64(50), *opts.MaxPoolSize)
+		assert.Equal(t, uint64(5), *opts.MinPoolSize)
+		assert.Equal(t, 5*time.Minute, *opts.MaxConnIdleTime)
+	})
+
+	t.Run("invalid values", func(t *testing.T) {
+		r := base
+		r.ReadPreference = "secondaryOnly"
+		_, err := clientOptions(r, "genai-toolbox")
+		assert.Error(t, err)
+
+		r = base
+		r.ReadConcern = "strong"
+		_, err = clientOptions(r, "genai-toolbox")
+		assert.Error(t, err)
+
+		r = base
+		r.MaxPoolSize = 5
+		r.MinPoolSize = 10
+		_, err = clientOptions(r, "genai-toolbox")
+		assert.Error(t, err)
+
+		r = base
+		r.MaxConnIdleTime = "soon"
+		_, err = clientOptions(r, "genai-toolbox")
+		assert.Error(t, err)
+	})
+}
+
+// fakeChangeStream serves events, then fails with err.
+type fakeChangeStream struct {
+	events  []bson.M
+	err     error
+	current int
+	closed  bool
+}
+
+func (f *fakeChangeStream) Next(ctx context.Context) bool {
+	if ctx.Err() != nil || f.current >= len(f.events) {
+		return false
+	}
+	f.current++
+	return true
+}
+
+func (f *fakeChangeStream) Decode(val any) error {
+	raw, err := bson.Marshal(f.events[f.current-1])
+	if err != nil {
+		return err
+	}
+	return bson.Unmarshal(raw, val)
+}
+
+func (f *fakeChangeStream) ResumeToken() bson.Raw {
+	if f.current == 0 {
+		return nil
+	}
+	raw, _ := bson.Marshal(f.events[f.current-1]["_id"])
+	return raw
+}
+
+func (f *fakeChangeStream) Err() error {
+	if f.current < len(f.events) {
+		return nil
+	}
+	return f.err
+}
+
+func (f *fakeChangeStream) Close(ctx context.Context) error {
+	f.closed = true
+	return nil
+}
+
+// fakeWatcher opens streams in order, recording the resume token of each.
+type fakeWatcher struct {
+	streams     []*fakeChangeStream
+	resumeAfter []bson.Raw
+}
+
+func (f *fakeWatcher) Watch(ctx context.Context, database, collection string, pipeline mongo.Pipeline, resumeAfter bson.Raw) (changeStream, error) {
+	f.resumeAfter = append(f.resumeAfter, resumeAfter)
+	stream := f.streams[0]
+	f.streams = f.streams[1:]
+	return stream, nil
+}
+
+func changeEvent(token, op string, id int) bson.M {
+	return bson.M{
+		"_id":           bson.M{"_data": token},
+		"operationType": op,
+		"ns":            bson.M{"db": "shop", "coll": "orders"},
+		"documentKey":   bson.M{"_id": id},
+		"fullDocument":  bson.M{"_id": id, "status": "new"},
+	}
+}
+
+func TestWatchResumesAfterTransientError(t *testing.T) {
+	resumable := mongo.CommandError{Code: 6, Message: "host unreachable", Labels: []string{"ResumableChangeStreamError"}}
+	first := &fakeChangeStream{events: []bson.M{changeEvent("tok-1", "insert", 1)}, err: resumable}
+	second := &fakeChangeStream{events: []bson.M{changeEvent("tok-2", "update", 2)}, err: errors.New("cursor killed")}
+	watcher := &fakeWatcher{streams: []*fakeChangeStream{first, second}}
+	source := &Source{Config: Config{Name: "test"}, watcher: watcher}
+
+	events, errc, err := source.Watch(context.Background(), "shop", "orders", nil)
+	require.NoError(t, err)
+
+	var got []ChangeEvent
+	for event := range events {
+		got = append(got, event)
+	}
+	require.Len(t, got, 2)
+	assert.Equal(t, "insert", got[0].OperationType)
+	assert.Equal(t, Namespace{Database: "shop", Collection: "orders"}, got[0].Namespace)
+	assert.Equal(t, "new", got[0].FullDocument["status"])
+	assert.Equal(t, "update", got[1].OperationType)
+
+	// The second stream resumes after the last event of the first
+	require.Len(t, watcher.resumeAfter, 2)
+	assert.Nil(t, watcher.resumeAfter[0])
+	assert.Equal(t, "tok-1", watcher.resumeAfter[1].Lookup("_data").StringValue())
+	assert.True(t, first.closed)
+	assert.True(t, second.closed)
+
+	// The non-transient error ends the watch
+	err = <-errc
+	assert.ErrorContains(t, err, "cursor killed")
+}
+
+func TestWatchStopsOnCancel(t *testing.T) {
+	stream := &fakeChangeStream{events: []bson.M{changeEvent("tok-1", "insert", 1), changeEvent("tok-2", "insert", 2)}}
+	source := &Source{Config: Config{Name: "test"}, watcher: &fakeWatcher{streams: []*fakeChangeStream{stream}}}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	events, errc, err := source.Watch(ctx, "shop", "orders", nil)
+	require.NoError(t, err)
+
+	event := <-events
+	assert.Equal(t, "tok-1", event.ResumeToken.Lookup("_data").StringValue())
+	cancel()
+	for range events {
+	}
+	assert.NoError(t, <-errc)
+	assert.True(t, stream.closed)
+}
+
+func TestIsTransientError(t *testing.T) {
+	assert.True(t, isTransientError(mongo.CommandError{Labels: []string{"NetworkError"}}))
+	assert.True(t, isTransientError(fmt.Errorf("wrapped: %w", mongo.CommandError{Labels: []string{"ResumableChangeStreamError"}})))
+	assert.False(t, isTransientError(mongo.CommandError{Code: 13, Message: "unauthorized"}))
+	assert.False(t, isTransientError(errors.New("boom")))
+}
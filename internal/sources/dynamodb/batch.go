@@ -0,0 +1,140 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dynamodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// Batch constants
+const (
+	MaxBatchWriteItems = 25                    // Most requests BatchWriteItem accepts
+	MaxBatchGetKeys    = 100                   // Most keys BatchGetItem accepts
+	MaxBatchRetries    = 8                     // Retries of unprocessed items before giving up
+	BatchRetryDelay    = 50 * time.Millisecond // Initial delay before retrying unprocessed items
+	MaxBatchRetryDelay = 5 * time.Second       // Upper bound for the retry delay
+)
+
+// BatchWrite puts and deletes items in table, splitting them into batches of
+// MaxBatchWriteItems. Items DynamoDB leaves unprocessed, usually because of
+// throttling, are retried with exponential backoff. puts are structs or maps
+// marshaled with attributevalue; deletes are primary keys.
+func (s *Source) BatchWrite(ctx context.Context, table string, puts []any, deletes []map[string]any) error {
+	requests := make([]types.WriteRequest, 0, len(puts)+len(deletes))
+	for i, item := range puts {
+		av, err := attributevalue.MarshalMap(item)
+		if err != nil {
+			return fmt.Errorf("unable to marshal put %d: %w", i, err)
+		}
+		requests = append(requests, types.WriteRequest{PutRequest: &types.PutRequest{Item: av}})
+	}
+	for i, key := range deletes {
+		av, err := attributevalue.MarshalMap(key)
+		if err != nil {
+			return fmt.Errorf("unable to marshal delete %d: %w", i, err)
+		}
+		requests = append(requests, types.WriteRequest{DeleteRequest: &types.DeleteRequest{Key: av}})
+	}
+
+	for start := 0; start < len(requests); start += MaxBatchWriteItems {
+		pending := requests[start:min(start+MaxBatchWriteItems, len(requests))]
+		err := retryUnprocessed(ctx, func() (int, error) {
+			out, err := s.api.BatchWriteItem(ctx, &dynamodb.BatchWriteItemInput{
+				RequestItems: map[string][]types.WriteRequest{table: pending},
+			})
+			if err != nil {
+				return 0, err
+			}
+			pending = out.UnprocessedItems[table]
+			return len(pending), nil
+		})
+		if err != nil {
+			return fmt.Errorf("unable to batch write to table %s: %w", table, err)
+		}
+	}
+	return nil
+}
+
+// BatchGet reads the items with keys from table, splitting them into batches of
+// MaxBatchGetKeys, and unmarshals them to Go values. Keys DynamoDB leaves
+// unprocessed are retried with exponential backoff. Items are returned in no
+// particular order, and missing keys are omitted.
+func (s *Source) BatchGet(ctx context.Context, table string, keys []map[string]any) ([]map[string]any, error) {
+	keyAVs := make([]map[string]types.AttributeValue, len(keys))
+	for i, key := range keys {
+		av, err := attributevalue.MarshalMap(key)
+		if err != nil {
+			return nil, fmt.Errorf("unable to marshal key %d: %w", i, err)
+		}
+		keyAVs[i] = av
+	}
+
+	results := []map[string]any{}
+	for start := 0; start < len(keyAVs); start += MaxBatchGetKeys {
+		pending := keyAVs[start:min(start+MaxBatchGetKeys, len(keyAVs))]
+		err := retryUnprocessed(ctx, func() (int, error) {
+			out, err := s.api.BatchGetItem(ctx, &dynamodb.BatchGetItemInput{
+				RequestItems: map[string]types.KeysAndAttributes{table: {Keys: pending}},
+			})
+			if err != nil {
+				return 0, err
+			}
+			items, err := unmarshalItems(out.Responses[table])
+			if err != nil {
+				return 0, err
+			}
+			results = append(results, items...)
+			pending = out.UnprocessedKeys[table].Keys
+			return len(pending), nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("unable to batch get from table %s: %w", table, err)
+		}
+	}
+	return results, nil
+}
+
+// retryUnprocessed calls send until it reports no unprocessed items, waiting
+// with exponential backoff between calls.
+func retryUnprocessed(ctx context.Context, send func() (unprocessed int, err error)) error {
+	delay := BatchRetryDelay
+	for attempt := 0; ; attempt++ {
+		unprocessed, err := send()
+		if err != nil {
+			return err
+		}
+		if unprocessed == 0 {
+			return nil
+		}
+		if attempt == MaxBatchRetries {
+			return fmt.Errorf("%d items still unprocessed after %d retries", unprocessed, MaxBatchRetries)
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+		delay = min(delay*2, MaxBatchRetryDelay)
+	}
+}
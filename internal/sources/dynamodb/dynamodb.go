@@ -22,9 +22,8 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/aws/aws-sdk-go-v2/config"
-	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodbstreams"
 	"github.com/goccy/go-yaml"
 	"github.com/googleapis/genai-toolbox/internal/sources"
 	sourceutil "github.com/googleapis/genai-toolbox/internal/sources/util"
@@ -37,9 +36,7 @@ const SourceKind string = "dynamodb"
 var _ sources.SourceConfig = Config{}
 
 func init() {
-	if !sources.Register(SourceKind, newConfig) {
-		panic(fmt.Sprintf("source kind %q already registered", SourceKind))
-	}
+	sources.MustRegister(SourceKind, newConfig)
 }
 
 func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources.SourceConfig, error) {
@@ -47,17 +44,47 @@ func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources
 	if err := decoder.DecodeContext(ctx, &actual); err != nil {
 		return nil, err
 	}
+
+	for _, secret := range []struct {
+		field string
+		value *string
+		file  string
+	}{
+		{"secretAccessKey", &actual.SecretAccessKey, actual.SecretAccessKeyFile},
+		{"sessionToken", &actual.SessionToken, actual.SessionTokenFile},
+	} {
+		resolved, err := sourceutil.ResolveSecret(secret.field, *secret.value, secret.file)
+		if err != nil {
+			return nil, fmt.Errorf("source %q (%s): unable to resolve secret: %w", name, SourceKind, err)
+		}
+		*secret.value = resolved
+	}
+
+	if err := sourceutil.ValidateAWSEndpoint(actual.Endpoint, actual.UseFIPS, actual.UseDualStack); err != nil {
+		return nil, fmt.Errorf("source %q (%s): %w", name, SourceKind, err)
+	}
+	if actual.MaxRetries < 0 {
+		return nil, fmt.Errorf("source %q (%s): maxRetries must not be negative", name, SourceKind)
+	}
 	return actual, nil
 }
 
 type Config struct {
-	Name            string `yaml:"name" validate:"required"`
-	Kind            string `yaml:"kind" validate:"required"`
-	Region          string `yaml:"region" validate:"required"`
-	Endpoint        string `yaml:"endpoint"` // Optional: for DynamoDB Local
-	AccessKeyID     string `yaml:"accessKeyId"`
-	SecretAccessKey string `yaml:"secretAccessKey"`
-	SessionToken    string `yaml:"sessionToken"`
+	Name                string `yaml:"name" validate:"required"`
+	Kind                string `yaml:"kind" validate:"required"`
+	Region              string `yaml:"region"`   // Optional: resolved from AWS_REGION, the shared config, or EC2 instance metadata if unset
+	Endpoint            string `yaml:"endpoint"` // Optional: for DynamoDB Local
+	AccessKeyID         string `yaml:"accessKeyId"`
+	SecretAccessKey     string `yaml:"secretAccessKey"`
+	SecretAccessKeyFile string `yaml:"secretAccessKeyFile"`
+	SessionToken        string `yaml:"sessionToken"`
+	SessionTokenFile    string `yaml:"sessionTokenFile"`
+	RoleARN             string `yaml:"roleArn"`         // Optional: role to assume with the resolved credentials
+	RoleSessionName     string `yaml:"roleSessionName"` // Optional: session name for the assumed role
+	ExternalID          string `yaml:"externalId"`      // Optional: external ID required by the role trust policy
+	UseFIPS             bool   `yaml:"useFIPS"`         // Optional: use FIPS endpoints
+	UseDualStack        bool   `yaml:"useDualStack"`    // Optional: use dual-stack (IPv4 and IPv6) endpoints
+	MaxRetries          int    `yaml:"maxRetries"`      // Optional: retries after the first attempt, e.g. on throttling (default: 2)
 }
 
 func (r Config) SourceConfigKind() string {
@@ -65,7 +92,7 @@ func (r Config) SourceConfigKind() string {
 }
 
 func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.Source, error) {
-	client, err := initDynamoDBClient(ctx, tracer, r.Name, r.Region, r.Endpoint, r.AccessKeyID, r.SecretAccessKey, r.SessionToken)
+	client, streamsClient, err := initDynamoDBClient(ctx, tracer, r)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create DynamoDB client: %w", err)
 	}
@@ -79,8 +106,10 @@ func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.So
 	}
 
 	s := &Source{
-		Config: r,
-		Client: client,
+		Config:  r,
+		Client:  client,
+		api:     client,
+		streams: streamsClient,
 	}
 	return s, nil
 }
@@ -89,7 +118,9 @@ var _ sources.Source = &Source{}
 
 type Source struct {
 	Config
-	Client *dynamodb.Client
+	Client  *dynamodb.Client
+	api     dynamoAPI  // Client, replaced in tests
+	streams streamsAPI // DynamoDB Streams client, replaced in tests
 }
 
 func (s *Source) SourceKind() string {
@@ -100,6 +131,14 @@ func (s *Source) ToConfig() sources.SourceConfig {
 	return s.Config
 }
 
+// HealthCheck lists a single table, as Initialize does.
+func (s *Source) HealthCheck(ctx context.Context) error {
+	_, err := s.Client.ListTables(ctx, &dynamodb.ListTablesInput{
+		Limit: sourceutil.Int32Ptr(1),
+	})
+	return err
+}
+
 // DynamoDBClient returns the underlying AWS DynamoDB client for direct API access.
 func (s *Source) DynamoDBClient() *dynamodb.Client {
 	return s.Client
@@ -108,41 +147,35 @@ func (s *Source) DynamoDBClient() *dynamodb.Client {
 // Close is not needed for this source because AWS SDK v2 clients manage
 // their own connection pooling and cleanup automatically.
 
-func initDynamoDBClient(ctx context.Context, tracer trace.Tracer, name, region, endpoint, accessKeyID, secretAccessKey, sessionToken string) (*dynamodb.Client, error) {
+func initDynamoDBClient(ctx context.Context, tracer trace.Tracer, r Config) (*dynamodb.Client, *dynamodbstreams.Client, error) {
 	//nolint:all // Reassigned ctx
-	ctx, span := sources.InitConnectionSpan(ctx, tracer, SourceKind, name)
+	ctx, span := sources.InitConnectionSpan(ctx, tracer, SourceKind, r.Name)
 	defer span.End()
 
-	// Build AWS config load options
-	configOpts := []func(*config.LoadOptions) error{
-		config.WithRegion(region),
-	}
-
-	// Use explicit credentials if provided
-	if accessKeyID != "" && secretAccessKey != "" {
-		configOpts = append(configOpts, config.WithCredentialsProvider(
-			credentials.NewStaticCredentialsProvider(accessKeyID, secretAccessKey, sessionToken),
-		))
-	}
-
-	// Load AWS configuration
-	cfg, err := config.LoadDefaultConfig(ctx, configOpts...)
+	// The endpoint applies to both clients, since DynamoDB Local also serves streams
+	cfg, err := sourceutil.LoadAWSConfig(ctx, sourceutil.AWSOptions{
+		Region:          r.Region,
+		AccessKeyID:     r.AccessKeyID,
+		SecretAccessKey: r.SecretAccessKey,
+		SessionToken:    r.SessionToken,
+		Endpoint:        r.Endpoint,
+		RoleARN:         r.RoleARN,
+		RoleSessionName: r.RoleSessionName,
+		ExternalID:      r.ExternalID,
+		UseFIPS:         r.UseFIPS,
+		UseDualStack:    r.UseDualStack,
+		MaxRetries:      r.MaxRetries,
+	})
 	if err != nil {
-		return nil, fmt.Errorf("unable to load AWS config: %w", err)
+		return nil, nil, err
 	}
-
-	// Create DynamoDB client options
-	opts := []func(*dynamodb.Options){}
-
-	// Add custom endpoint if specified (for DynamoDB Local)
-	if endpoint != "" {
-		opts = append(opts, func(o *dynamodb.Options) {
-			o.BaseEndpoint = &endpoint
-		})
+	if cfg.Region == "" {
+		return nil, nil, fmt.Errorf("unable to determine AWS region, set region in the source config or AWS_REGION")
 	}
 
-	// Create the DynamoDB client
-	client := dynamodb.NewFromConfig(cfg, opts...)
+	// Create the DynamoDB and DynamoDB Streams clients
+	client := dynamodb.NewFromConfig(cfg)
+	streamsClient := dynamodbstreams.NewFromConfig(cfg)
 
-	return client, nil
+	return client, streamsClient, nil
 }
@@ -17,10 +17,22 @@ package dynamodb
 import (
 	"bytes"
 	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodbstreams"
+	streamtypes "github.com/aws/aws-sdk-go-v2/service/dynamodbstreams/types"
 	"github.com/goccy/go-yaml"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestDynamoDBConfig(t *testing.T) {
@@ -56,6 +68,52 @@ endpoint: http://localhost:8000`,
 				Endpoint: "http://localhost:8000",
 			},
 		},
+		{
+			name: "valid configuration with assumed role",
+			yamlContent: `name: test-dynamodb
+kind: dynamodb
+region: us-east-1
+roleArn: arn:aws:iam::123456789012:role/toolbox
+roleSessionName: toolbox-session
+externalId: my-external-id`,
+			wantErr: false,
+			expected: Config{
+				Name:            "test-dynamodb",
+				Kind:            "dynamodb",
+				Region:          "us-east-1",
+				RoleARN:         "arn:aws:iam::123456789012:role/toolbox",
+				RoleSessionName: "toolbox-session",
+				ExternalID:      "my-external-id",
+			},
+		},
+		{
+			name: "valid configuration with FIPS and dual-stack endpoints",
+			yamlContent: `name: test-dynamodb
+kind: dynamodb
+region: us-east-1
+useFIPS: true
+useDualStack: true`,
+			wantErr: false,
+			expected: Config{
+				Name:         "test-dynamodb",
+				Kind:         "dynamodb",
+				Region:       "us-east-1",
+				UseFIPS:      true,
+				UseDualStack: true,
+			},
+		},
+		{
+			name: "custom endpoint with FIPS",
+			yamlContent: `name: test-dynamodb
+kind: dynamodb
+region: us-east-1
+endpoint: http://localhost:4566
+useFIPS: true`,
+			wantErr: true,
+			expected: Config{
+				Name: "test-dynamodb",
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -69,6 +127,11 @@ endpoint: http://localhost:8000`,
 				assert.NoError(t, err)
 				assert.Equal(t, tt.expected.Name, config.(Config).Name)
 				assert.Equal(t, tt.expected.Region, config.(Config).Region)
+				assert.Equal(t, tt.expected.RoleARN, config.(Config).RoleARN)
+				assert.Equal(t, tt.expected.RoleSessionName, config.(Config).RoleSessionName)
+				assert.Equal(t, tt.expected.ExternalID, config.(Config).ExternalID)
+				assert.Equal(t, tt.expected.UseFIPS, config.(Config).UseFIPS)
+				assert.Equal(t, tt.expected.UseDualStack, config.(Config).UseDualStack)
 			}
 		})
 	}
@@ -85,3 +148,878 @@ func TestSourceKind(t *testing.T) {
 	source := Source{Config: config}
 	assert.Equal(t, SourceKind, source.SourceKind())
 }
+
+// fakeDynamoClient serves canned responses for the item helpers.
+type fakeDynamoClient struct {
+	queryPages []*dynamodb.QueryOutput
+	queryReqs  []dynamodb.QueryInput
+	scanPages  []*dynamodb.ScanOutput
+	scanReqs   []dynamodb.ScanInput
+	stmtPages  []*dynamodb.ExecuteStatementOutput
+	stmtReqs   []dynamodb.ExecuteStatementInput
+	table      map[string]map[string]types.AttributeValue
+
+	// unprocessed is how many items of each batch call to leave unprocessed
+	unprocessed []int
+	batchSizes  []int
+	deleted     []string
+
+	transactWrite *dynamodb.TransactWriteItemsInput
+	transactErr   error
+
+	updateReq *dynamodb.UpdateItemInput
+	updateErr error
+
+	// descriptions are returned by successive DescribeTable calls, repeating the last
+	descriptions  []*types.TableDescription
+	describeCalls int
+	created       *dynamodb.CreateTableInput
+
+	// segments holds the items of each parallel scan segment, served one per
+	// page; segmentErrs fails the scan of a segment
+	mu          sync.Mutex
+	segments    map[int32][]map[string]types.AttributeValue
+	segmentErrs map[int32]error
+	maxSegments int32
+}
+
+func (f *fakeDynamoClient) Query(ctx context.Context, params *dynamodb.QueryInput, optFns ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error) {
+	f.queryReqs = append(f.queryReqs, *params)
+	return f.queryPages[len(f.queryReqs)-1], nil
+}
+
+func (f *fakeDynamoClient) Scan(ctx context.Context, params *dynamodb.ScanInput, optFns ...func(*dynamodb.Options)) (*dynamodb.ScanOutput, error) {
+	if params.Segment != nil {
+		return f.scanSegment(params)
+	}
+	f.scanReqs = append(f.scanReqs, *params)
+	return f.scanPages[len(f.scanReqs)-1], nil
+}
+
+func (f *fakeDynamoClient) scanSegment(params *dynamodb.ScanInput) (*dynamodb.ScanOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	segment := *params.Segment
+	f.maxSegments = max(f.maxSegments, *params.TotalSegments)
+	if err := f.segmentErrs[segment]; err != nil {
+		return nil, err
+	}
+	// The start key is the index of the next item of the segment
+	start := 0
+	if key, ok := params.ExclusiveStartKey["next"]; ok {
+		start, _ = strconv.Atoi(key.(*types.AttributeValueMemberN).Value)
+	}
+	items := f.segments[segment]
+	if start >= len(items) {
+		return &dynamodb.ScanOutput{}, nil
+	}
+	out := &dynamodb.ScanOutput{Items: items[start : start+1]}
+	if start+1 < len(items) {
+		out.LastEvaluatedKey = map[string]types.AttributeValue{"next": &types.AttributeValueMemberN{Value: strconv.Itoa(start + 1)}}
+	}
+	return out, nil
+}
+
+func (f *fakeDynamoClient) ExecuteStatement(ctx context.Context, params *dynamodb.ExecuteStatementInput, optFns ...func(*dynamodb.Options)) (*dynamodb.ExecuteStatementOutput, error) {
+	f.stmtReqs = append(f.stmtReqs, *params)
+	return f.stmtPages[len(f.stmtReqs)-1], nil
+}
+
+func (f *fakeDynamoClient) PutItem(ctx context.Context, params *dynamodb.PutItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error) {
+	if f.table == nil {
+		f.table = map[string]map[string]types.AttributeValue{}
+	}
+	id := params.Item["id"].(*types.AttributeValueMemberS).Value
+	f.table[id] = params.Item
+	return &dynamodb.PutItemOutput{}, nil
+}
+
+func (f *fakeDynamoClient) GetItem(ctx context.Context, params *dynamodb.GetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.GetItemOutput, error) {
+	id := params.Key["id"].(*types.AttributeValueMemberS).Value
+	return &dynamodb.GetItemOutput{Item: f.table[id]}, nil
+}
+
+// UpdateItem applies the #uN = :uN placeholders built by UpdateItem, removing
+// attributes without a value.
+func (f *fakeDynamoClient) UpdateItem(ctx context.Context, params *dynamodb.UpdateItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.UpdateItemOutput, error) {
+	f.updateReq = params
+	if f.updateErr != nil {
+		return nil, f.updateErr
+	}
+	if f.table == nil {
+		f.table = map[string]map[string]types.AttributeValue{}
+	}
+	id := params.Key["id"].(*types.AttributeValueMemberS).Value
+	current, ok := f.table[id]
+	if !ok {
+		current = map[string]types.AttributeValue{"id": params.Key["id"]}
+		f.table[id] = current
+	}
+	for placeholder, attr := range params.ExpressionAttributeNames {
+		if !strings.HasPrefix(placeholder, "#u") {
+			continue
+		}
+		if value, ok := params.ExpressionAttributeValues[":"+placeholder[1:]]; ok {
+			current[attr] = value
+		} else {
+			delete(current, attr)
+		}
+	}
+	return &dynamodb.UpdateItemOutput{Attributes: current}, nil
+}
+
+// leaveUnprocessed returns how many of n requests the next batch call should
+// leave unprocessed, recording the batch size.
+func (f *fakeDynamoClient) leaveUnprocessed(n int) int {
+	call := len(f.batchSizes)
+	f.batchSizes = append(f.batchSizes, n)
+	if call < len(f.unprocessed) {
+		return min(f.unprocessed[call], n)
+	}
+	return 0
+}
+
+func (f *fakeDynamoClient) BatchWriteItem(ctx context.Context, params *dynamodb.BatchWriteItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.BatchWriteItemOutput, error) {
+	for table, requests := range params.RequestItems {
+		skip := f.leaveUnprocessed(len(requests))
+		for _, req := range requests[skip:] {
+			if req.PutRequest != nil {
+				_, _ = f.PutItem(ctx, &dynamodb.PutItemInput{Item: req.PutRequest.Item})
+			} else {
+				f.deleted = append(f.deleted, req.DeleteRequest.Key["id"].(*types.AttributeValueMemberS).Value)
+			}
+		}
+		if skip > 0 {
+			return &dynamodb.BatchWriteItemOutput{UnprocessedItems: map[string][]types.WriteRequest{table: requests[:skip]}}, nil
+		}
+	}
+	return &dynamodb.BatchWriteItemOutput{}, nil
+}
+
+func (f *fakeDynamoClient) BatchGetItem(ctx context.Context, params *dynamodb.BatchGetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.BatchGetItemOutput, error) {
+	out := &dynamodb.BatchGetItemOutput{Responses: map[string][]map[string]types.AttributeValue{}}
+	for table, keys := range params.RequestItems {
+		skip := f.leaveUnprocessed(len(keys.Keys))
+		for _, key := range keys.Keys[skip:] {
+			if item, ok := f.table[key["id"].(*types.AttributeValueMemberS).Value]; ok {
+				out.Responses[table] = append(out.Responses[table], item)
+			}
+		}
+		if skip > 0 {
+			out.UnprocessedKeys = map[string]types.KeysAndAttributes{table: {Keys: keys.Keys[:skip]}}
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeDynamoClient) TransactWriteItems(ctx context.Context, params *dynamodb.TransactWriteItemsInput, optFns ...func(*dynamodb.Options)) (*dynamodb.TransactWriteItemsOutput, error) {
+	f.transactWrite = params
+	if f.transactErr != nil {
+		return nil, f.transactErr
+	}
+	return &dynamodb.TransactWriteItemsOutput{}, nil
+}
+
+func (f *fakeDynamoClient) TransactGetItems(ctx context.Context, params *dynamodb.TransactGetItemsInput, optFns ...func(*dynamodb.Options)) (*dynamodb.TransactGetItemsOutput, error) {
+	out := &dynamodb.TransactGetItemsOutput{}
+	for _, get := range params.TransactItems {
+		out.Responses = append(out.Responses, types.ItemResponse{Item: f.table[get.Get.Key["id"].(*types.AttributeValueMemberS).Value]})
+	}
+	return out, nil
+}
+
+func (f *fakeDynamoClient) DescribeTable(ctx context.Context, params *dynamodb.DescribeTableInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DescribeTableOutput, error) {
+	call := min(f.describeCalls, len(f.descriptions)-1)
+	f.describeCalls++
+	return &dynamodb.DescribeTableOutput{Table: f.descriptions[call]}, nil
+}
+
+func (f *fakeDynamoClient) CreateTable(ctx context.Context, params *dynamodb.CreateTableInput, optFns ...func(*dynamodb.Options)) (*dynamodb.CreateTableOutput, error) {
+	f.created = params
+	return &dynamodb.CreateTableOutput{}, nil
+}
+
+func item(id string, count string) map[string]types.AttributeValue {
+	return map[string]types.AttributeValue{
+		"id":    &types.AttributeValueMemberS{Value: id},
+		"count": &types.AttributeValueMemberN{Value: count},
+	}
+}
+
+func TestQuery(t *testing.T) {
+	lastKey := map[string]types.AttributeValue{"id": &types.AttributeValueMemberS{Value: "a"}}
+	client := &fakeDynamoClient{queryPages: []*dynamodb.QueryOutput{
+		{Items: []map[string]types.AttributeValue{item("a", "1")}, ScannedCount: 2, LastEvaluatedKey: lastKey},
+		{Items: []map[string]types.AttributeValue{item("b", "2")}, ScannedCount: 1},
+	}}
+	source := &Source{Config: Config{Name: "test"}, api: client}
+
+	result, err := source.Query(context.Background(), &QueryRequest{
+		Table:        "orders",
+		IndexName:    "by-customer",
+		KeyCondition: Expr{Expression: "customer = :c", Values: map[string]any{":c": "alice"}},
+		Filter:       Expr{Expression: "#s = :s", Names: map[string]string{"#s": "status"}, Values: map[string]any{":s": "open"}},
+		Descending:   true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []map[string]any{{"id": "a", "count": 1.0}, {"id": "b", "count": 2.0}}, result.Items)
+	assert.Equal(t, 3, result.ScannedCount)
+
+	require.Len(t, client.queryReqs, 2)
+	req := client.queryReqs[0]
+	assert.Equal(t, "orders", *req.TableName)
+	assert.Equal(t, "by-customer", *req.IndexName)
+	assert.Equal(t, "#s = :s", *req.FilterExpression)
+	assert.False(t, *req.ScanIndexForward)
+	assert.Equal(t, map[string]string{"#s": "status"}, req.ExpressionAttributeNames)
+	assert.Equal(t, &types.AttributeValueMemberS{Value: "alice"}, req.ExpressionAttributeValues[":c"])
+	assert.Equal(t, &types.AttributeValueMemberS{Value: "open"}, req.ExpressionAttributeValues[":s"])
+	assert.Nil(t, req.ExclusiveStartKey)
+	assert.Equal(t, lastKey, client.queryReqs[1].ExclusiveStartKey)
+}
+
+func TestQueryLimit(t *testing.T) {
+	lastKey := map[string]types.AttributeValue{"id": &types.AttributeValueMemberS{Value: "b"}}
+	client := &fakeDynamoClient{queryPages: []*dynamodb.QueryOutput{
+		{Items: []map[string]types.AttributeValue{item("a", "1"), item("b", "2")}, LastEvaluatedKey: lastKey},
+	}}
+	source := &Source{Config: Config{Name: "test"}, api: client}
+
+	result, err := source.Query(context.Background(), &QueryRequest{
+		Table:        "orders",
+		KeyCondition: Expr{Expression: "id = :id", Values: map[string]any{":id": "a"}},
+		Limit:        1,
+	})
+	require.NoError(t, err)
+	assert.Len(t, result.Items, 1)
+	assert.Len(t, client.queryReqs, 1)
+	assert.Equal(t, aws.Int32(1), client.queryReqs[0].Limit)
+
+	_, err = source.Query(context.Background(), &QueryRequest{Table: "orders"})
+	require.Error(t, err)
+}
+
+func TestQueryLimitAcrossPages(t *testing.T) {
+	lastKey := map[string]types.AttributeValue{"id": &types.AttributeValueMemberS{Value: "a"}}
+	// The filter dropped items from the first page, so a second page is needed
+	client := &fakeDynamoClient{queryPages: []*dynamodb.QueryOutput{
+		{Items: []map[string]types.AttributeValue{item("a", "1")}, LastEvaluatedKey: lastKey},
+		{Items: []map[string]types.AttributeValue{item("b", "2"), item("c", "3")}, LastEvaluatedKey: lastKey},
+	}}
+	source := &Source{Config: Config{Name: "test"}, api: client}
+
+	result, err := source.Query(context.Background(), &QueryRequest{
+		Table:        "orders",
+		KeyCondition: Expr{Expression: "id = :id", Values: map[string]any{":id": "a"}},
+		Filter:       Expr{Expression: "#c > :c", Names: map[string]string{"#c": "count"}, Values: map[string]any{":c": 0}},
+		Limit:        3,
+	})
+	require.NoError(t, err)
+	assert.Len(t, result.Items, 3)
+	require.Len(t, client.queryReqs, 2)
+	assert.Equal(t, aws.Int32(3), client.queryReqs[0].Limit)
+	assert.Equal(t, aws.Int32(2), client.queryReqs[1].Limit)
+}
+
+func TestScanAll(t *testing.T) {
+	lastKey := map[string]types.AttributeValue{"id": &types.AttributeValueMemberS{Value: "a"}}
+	client := &fakeDynamoClient{scanPages: []*dynamodb.ScanOutput{
+		{Items: []map[string]types.AttributeValue{item("a", "1")}, LastEvaluatedKey: lastKey},
+		{Items: []map[string]types.AttributeValue{}},
+		{Items: []map[string]types.AttributeValue{item("c", "3")}},
+	}}
+	// An empty page with a LastEvaluatedKey still has more to read
+	client.scanPages[1].LastEvaluatedKey = lastKey
+	source := &Source{Config: Config{Name: "test"}, api: client}
+
+	items, err := source.ScanAll(context.Background(), "orders", Expr{})
+	require.NoError(t, err)
+	assert.Equal(t, []map[string]any{{"id": "a", "count": 1.0}, {"id": "c", "count": 3.0}}, items)
+	require.Len(t, client.scanReqs, 3)
+	assert.Nil(t, client.scanReqs[0].FilterExpression)
+	assert.Nil(t, client.scanReqs[0].ExpressionAttributeValues)
+}
+
+func TestExecutePartiQL(t *testing.T) {
+	client := &fakeDynamoClient{stmtPages: []*dynamodb.ExecuteStatementOutput{
+		{Items: []map[string]types.AttributeValue{item("a", "1")}, NextToken: aws.String("page-2")},
+		{Items: []map[string]types.AttributeValue{item("b", "2")}},
+	}}
+	source := &Source{Config: Config{Name: "test"}, api: client}
+
+	items, err := source.ExecutePartiQL(context.Background(), `SELECT * FROM "orders" WHERE customer = ? AND total > ?`, []any{"alice", 10})
+	require.NoError(t, err)
+	assert.Equal(t, []map[string]any{{"id": "a", "count": 1.0}, {"id": "b", "count": 2.0}}, items)
+
+	require.Len(t, client.stmtReqs, 2)
+	assert.Equal(t, []types.AttributeValue{
+		&types.AttributeValueMemberS{Value: "alice"},
+		&types.AttributeValueMemberN{Value: "10"},
+	}, client.stmtReqs[0].Parameters)
+	assert.Nil(t, client.stmtReqs[0].NextToken)
+	assert.Equal(t, "page-2", aws.ToString(client.stmtReqs[1].NextToken))
+}
+
+func TestPutGetItem(t *testing.T) {
+	type order struct {
+		ID    string   `dynamodbav:"id"`
+		Total float64  `dynamodbav:"total"`
+		Tags  []string `dynamodbav:"tags,omitempty"`
+	}
+	client := &fakeDynamoClient{}
+	source := &Source{Config: Config{Name: "test"}, api: client}
+	ctx := context.Background()
+
+	require.NoError(t, source.PutItem(ctx, "orders", order{ID: "o-1", Total: 12.5, Tags: []string{"rush"}}))
+	assert.Equal(t, &types.AttributeValueMemberN{Value: "12.5"}, client.table["o-1"]["total"])
+
+	var got order
+	found, err := source.GetItem(ctx, "orders", map[string]any{"id": "o-1"}, &got)
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, order{ID: "o-1", Total: 12.5, Tags: []string{"rush"}}, got)
+
+	missing := order{ID: "unchanged"}
+	found, err = source.GetItem(ctx, "orders", map[string]any{"id": "o-2"}, &missing)
+	require.NoError(t, err)
+	assert.False(t, found)
+	assert.Equal(t, "unchanged", missing.ID)
+}
+
+func TestUpdateItem(t *testing.T) {
+	client := &fakeDynamoClient{table: map[string]map[string]types.AttributeValue{
+		"o-1": {
+			"id":     &types.AttributeValueMemberS{Value: "o-1"},
+			"status": &types.AttributeValueMemberS{Value: "pending"},
+			"note":   &types.AttributeValueMemberS{Value: "leave at door"},
+		},
+	}}
+	source := &Source{Config: Config{Name: "test"}, api: client}
+	ctx := context.Background()
+
+	condition := &Expr{
+		Expression: "#s = :pending",
+		Names:      map[string]string{"#s": "status"},
+		Values:     map[string]any{":pending": "pending"},
+	}
+	item, err := source.UpdateItem(ctx, "orders", map[string]any{"id": "o-1"},
+		map[string]any{"status": "shipped", "name": "Alice", "note": nil}, condition)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"id": "o-1", "status": "shipped", "name": "Alice"}, item)
+
+	req := client.updateReq
+	assert.Equal(t, "SET #u0 = :u0, #u2 = :u2 REMOVE #u1", aws.ToString(req.UpdateExpression))
+	assert.Equal(t, "#s = :pending", aws.ToString(req.ConditionExpression))
+	assert.Equal(t, map[string]string{"#u0": "name", "#u1": "note", "#u2": "status", "#s": "status"}, req.ExpressionAttributeNames)
+	assert.Len(t, req.ExpressionAttributeValues, 3)
+	assert.Equal(t, types.ReturnValueAllNew, req.ReturnValues)
+
+	// Removing only needs no values, which DynamoDB rejects if empty
+	_, err = source.UpdateItem(ctx, "orders", map[string]any{"id": "o-1"}, map[string]any{"name": nil}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "REMOVE #u0", aws.ToString(client.updateReq.UpdateExpression))
+	assert.Nil(t, client.updateReq.ExpressionAttributeValues)
+	assert.Nil(t, client.updateReq.ConditionExpression)
+
+	_, err = source.UpdateItem(ctx, "orders", map[string]any{"id": "o-1"}, nil, nil)
+	assert.ErrorContains(t, err, "at least one update is required")
+	_, err = source.UpdateItem(ctx, "orders", map[string]any{"id": "o-1"}, map[string]any{"a": 1},
+		&Expr{Expression: "#u0 = :x", Names: map[string]string{"#u0": "b"}, Values: map[string]any{":x": 1}})
+	assert.ErrorContains(t, err, "reserved for updates")
+
+	client.updateErr = &types.ConditionalCheckFailedException{Message: aws.String("The conditional request failed")}
+	_, err = source.UpdateItem(ctx, "orders", map[string]any{"id": "o-1"}, map[string]any{"status": "shipped"}, condition)
+	var failed *types.ConditionalCheckFailedException
+	assert.ErrorAs(t, err, &failed)
+}
+
+func TestBatchWrite(t *testing.T) {
+	// 30 puts need two batches; the first leaves 5 items unprocessed once
+	client := &fakeDynamoClient{unprocessed: []int{5}}
+	source := &Source{Config: Config{Name: "test"}, api: client}
+
+	var puts []any
+	for i := range 30 {
+		puts = append(puts, map[string]any{"id": fmt.Sprintf("o-%d", i)})
+	}
+	err := source.BatchWrite(context.Background(), "orders", puts, []map[string]any{{"id": "old"}})
+	require.NoError(t, err)
+	assert.Len(t, client.table, 30)
+	assert.Equal(t, []string{"old"}, client.deleted)
+	assert.Equal(t, []int{25, 5, 6}, client.batchSizes)
+}
+
+func TestBatchWriteHonorsContext(t *testing.T) {
+	unprocessed := make([]int, MaxBatchRetries+1)
+	for i := range unprocessed {
+		unprocessed[i] = 1
+	}
+	client := &fakeDynamoClient{unprocessed: unprocessed}
+	source := &Source{Config: Config{Name: "test"}, api: client}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	err := source.BatchWrite(ctx, "orders", []any{map[string]any{"id": "o-1"}}, nil)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestBatchGet(t *testing.T) {
+	client := &fakeDynamoClient{unprocessed: []int{0, 3}}
+	source := &Source{Config: Config{Name: "test"}, api: client}
+	var keys []map[string]any
+	for i := range 120 {
+		id := fmt.Sprintf("o-%d", i)
+		require.NoError(t, source.PutItem(context.Background(), "orders", map[string]any{"id": id}))
+		keys = append(keys, map[string]any{"id": id})
+	}
+	keys = append(keys, map[string]any{"id": "missing"})
+
+	items, err := source.BatchGet(context.Background(), "orders", keys)
+	require.NoError(t, err)
+	assert.Len(t, items, 120)
+	assert.Equal(t, []int{100, 21, 3}, client.batchSizes)
+}
+
+func TestTransactWrite(t *testing.T) {
+	client := &fakeDynamoClient{}
+	source := &Source{Config: Config{Name: "test"}, api: client}
+
+	err := source.TransactWrite(context.Background(), []TransactWriteItem{
+		{Table: "orders", Put: map[string]any{"id": "o-1", "total": 10}, Condition: Expr{Expression: "attribute_not_exists(id)"}},
+		{Table: "accounts", Key: map[string]any{"id": "alice"}, Update: &Expr{Expression: "SET balance = balance - :t", Values: map[string]any{":t": 10}}, Condition: Expr{Expression: "balance >= :t"}},
+		{Table: "carts", Key: map[string]any{"id": "c-1"}, Delete: true},
+		{Table: "customers", Key: map[string]any{"id": "alice"}, Condition: Expr{Expression: "attribute_exists(id)"}},
+	})
+	require.NoError(t, err)
+	items := client.transactWrite.TransactItems
+	require.Len(t, items, 4)
+	assert.Equal(t, "attribute_not_exists(id)", *items[0].Put.ConditionExpression)
+	assert.Equal(t, "SET balance = balance - :t", *items[1].Update.UpdateExpression)
+	assert.Equal(t, &types.AttributeValueMemberN{Value: "10"}, items[1].Update.ExpressionAttributeValues[":t"])
+	assert.NotNil(t, items[2].Delete)
+	assert.Nil(t, items[2].Delete.ConditionExpression)
+	assert.NotNil(t, items[3].ConditionCheck)
+
+	err = source.TransactWrite(context.Background(), []TransactWriteItem{{Table: "orders", Put: map[string]any{"id": "o-1"}, Delete: true}})
+	require.Error(t, err)
+	err = source.TransactWrite(context.Background(), []TransactWriteItem{{Table: "orders", Key: map[string]any{"id": "o-1"}}})
+	require.Error(t, err)
+}
+
+func TestTransactWriteCancelled(t *testing.T) {
+	exception := &types.TransactionCanceledException{
+		Message: aws.String("Transaction cancelled, please refer cancellation reasons for specific reasons [None, ConditionalCheckFailed]"),
+		CancellationReasons: []types.CancellationReason{
+			{Code: aws.String("None")},
+			{
+				Code:    aws.String("ConditionalCheckFailed"),
+				Message: aws.String("The conditional request failed"),
+				Item:    map[string]types.AttributeValue{"id": &types.AttributeValueMemberS{Value: "alice"}, "balance": &types.AttributeValueMemberN{Value: "5"}},
+			},
+		},
+	}
+	client := &fakeDynamoClient{transactErr: exception}
+	source := &Source{Config: Config{Name: "test"}, api: client}
+
+	err := source.TransactWrite(context.Background(), []TransactWriteItem{
+		{Table: "orders", Put: map[string]any{"id": "o-1"}},
+		{Table: "accounts", Key: map[string]any{"id": "alice"}, Condition: Expr{Expression: "balance >= :t", Values: map[string]any{":t": 10}}},
+	})
+	var cancelled *TransactionCanceledError
+	require.True(t, errors.As(err, &cancelled))
+	assert.Equal(t, []CancellationReason{{
+		Index:   1,
+		Code:    "ConditionalCheckFailed",
+		Message: "The conditional request failed",
+		Item:    map[string]any{"id": "alice", "balance": 5.0},
+	}}, cancelled.Reasons)
+	assert.Equal(t, "transaction cancelled: item 1: ConditionalCheckFailed: The conditional request failed", err.Error())
+	assert.ErrorIs(t, err, exception)
+}
+
+func TestTransactGet(t *testing.T) {
+	client := &fakeDynamoClient{table: map[string]map[string]types.AttributeValue{"a": item("a", "1")}}
+	source := &Source{Config: Config{Name: "test"}, api: client}
+
+	items, err := source.TransactGet(context.Background(), []TransactGetItem{
+		{Table: "orders", Key: map[string]any{"id": "a"}},
+		{Table: "orders", Key: map[string]any{"id": "missing"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []map[string]any{{"id": "a", "count": 1.0}, nil}, items)
+}
+
+// fakeStreamsClient serves a stream whose open shard splits: shard-1 holds one
+// record and closes, and its child shard-2 holds another.
+type fakeStreamsClient struct {
+	mu        sync.Mutex
+	describes int
+	iterators map[string]streamtypes.ShardIteratorType
+}
+
+func (f *fakeStreamsClient) DescribeStream(ctx context.Context, params *dynamodbstreams.DescribeStreamInput, optFns ...func(*dynamodbstreams.Options)) (*dynamodbstreams.DescribeStreamOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.describes++
+	closed := &streamtypes.SequenceNumberRange{StartingSequenceNumber: aws.String("1"), EndingSequenceNumber: aws.String("9")}
+	open := &streamtypes.SequenceNumberRange{StartingSequenceNumber: aws.String("10")}
+	// The first describe is split across two pages
+	if aws.ToString(params.ExclusiveStartShardId) == "shard-0" {
+		return &dynamodbstreams.DescribeStreamOutput{StreamDescription: &streamtypes.StreamDescription{
+			Shards: []streamtypes.Shard{{ShardId: aws.String("shard-1"), ParentShardId: aws.String("shard-0"), SequenceNumberRange: open}},
+		}}, nil
+	}
+	if f.describes == 1 {
+		return &dynamodbstreams.DescribeStreamOutput{StreamDescription: &streamtypes.StreamDescription{
+			Shards:               []streamtypes.Shard{{ShardId: aws.String("shard-0"), SequenceNumberRange: closed}},
+			LastEvaluatedShardId: aws.String("shard-0"),
+		}}, nil
+	}
+	return &dynamodbstreams.DescribeStreamOutput{StreamDescription: &streamtypes.StreamDescription{
+		Shards: []streamtypes.Shard{
+			{ShardId: aws.String("shard-0"), SequenceNumberRange: closed},
+			{ShardId: aws.String("shard-1"), ParentShardId: aws.String("shard-0"), SequenceNumberRange: closed},
+			{ShardId: aws.String("shard-2"), ParentShardId: aws.String("shard-1"), SequenceNumberRange: open},
+		},
+	}}, nil
+}
+
+func (f *fakeStreamsClient) GetShardIterator(ctx context.Context, params *dynamodbstreams.GetShardIteratorInput, optFns ...func(*dynamodbstreams.Options)) (*dynamodbstreams.GetShardIteratorOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.iterators == nil {
+		f.iterators = map[string]streamtypes.ShardIteratorType{}
+	}
+	f.iterators[*params.ShardId] = params.ShardIteratorType
+	return &dynamodbstreams.GetShardIteratorOutput{ShardIterator: aws.String(*params.ShardId + "/0")}, nil
+}
+
+func (f *fakeStreamsClient) GetRecords(ctx context.Context, params *dynamodbstreams.GetRecordsInput, optFns ...func(*dynamodbstreams.Options)) (*dynamodbstreams.GetRecordsOutput, error) {
+	keys := func(id string) map[string]streamtypes.AttributeValue {
+		return map[string]streamtypes.AttributeValue{"id": &streamtypes.AttributeValueMemberS{Value: id}}
+	}
+	switch *params.ShardIterator {
+	case "shard-1/0":
+		// The shard is closed after its last record
+		return &dynamodbstreams.GetRecordsOutput{Records: []streamtypes.Record{{
+			EventID:   aws.String("e-1"),
+			EventName: streamtypes.OperationTypeInsert,
+			Dynamodb: &streamtypes.StreamRecord{
+				SequenceNumber: aws.String("10"),
+				Keys:           keys("a"),
+				NewImage: map[string]streamtypes.AttributeValue{
+					"id":   &streamtypes.AttributeValueMemberS{Value: "a"},
+					"qty":  &streamtypes.AttributeValueMemberN{Value: "2"},
+					"tags": &streamtypes.AttributeValueMemberSS{Value: []string{"x"}},
+					"meta": &streamtypes.AttributeValueMemberM{Value: map[string]streamtypes.AttributeValue{"ok": &streamtypes.AttributeValueMemberBOOL{Value: true}}},
+				},
+			},
+		}}}, nil
+	case "shard-2/0":
+		return &dynamodbstreams.GetRecordsOutput{
+			Records: []streamtypes.Record{{
+				EventID:   aws.String("e-2"),
+				EventName: streamtypes.OperationTypeRemove,
+				Dynamodb: &streamtypes.StreamRecord{
+					SequenceNumber: aws.String("20"),
+					Keys:           keys("a"),
+					OldImage:       map[string]streamtypes.AttributeValue{"id": &streamtypes.AttributeValueMemberS{Value: "a"}, "gone": &streamtypes.AttributeValueMemberNULL{Value: true}},
+				},
+			}},
+			NextShardIterator: aws.String("shard-2/1"),
+		}, nil
+	default:
+		// Open shard with no new records
+		return &dynamodbstreams.GetRecordsOutput{NextShardIterator: params.ShardIterator}, nil
+	}
+}
+
+func TestStreamRecords(t *testing.T) {
+	client := &fakeStreamsClient{}
+	source := &Source{Config: Config{Name: "test"}, streams: client}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	records, errs, err := source.StreamRecords(ctx, "arn:aws:dynamodb:us-east-1:123456789012:table/orders/stream/1")
+	require.NoError(t, err)
+
+	first := <-records
+	assert.Equal(t, StreamRecord{
+		EventID:        "e-1",
+		EventName:      "INSERT",
+		ShardID:        "shard-1",
+		SequenceNumber: "10",
+		Keys:           map[string]any{"id": "a"},
+		NewImage:       map[string]any{"id": "a", "qty": 2.0, "tags": []string{"x"}, "meta": map[string]any{"ok": true}},
+	}, first)
+
+	// The child shard is read once its parent is closed
+	second := <-records
+	assert.Equal(t, "e-2", second.EventID)
+	assert.Equal(t, "REMOVE", second.EventName)
+	assert.Equal(t, map[string]any{"id": "a", "gone": nil}, second.OldImage)
+	assert.Nil(t, second.NewImage)
+
+	cancel()
+	for range records {
+	}
+	for err := range errs {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	client.mu.Lock()
+	defer client.mu.Unlock()
+	assert.Equal(t, map[string]streamtypes.ShardIteratorType{
+		"shard-1": streamtypes.ShardIteratorTypeLatest,
+		"shard-2": streamtypes.ShardIteratorTypeTrimHorizon,
+	}, client.iterators)
+}
+
+// fakeCheckpointStreamsClient serves a stream with a closed shard-0 holding
+// records 1 and 2, and its open child shard-1 holding record 10.
+type fakeCheckpointStreamsClient struct {
+	mu        sync.Mutex
+	iterators map[string]string
+}
+
+func (f *fakeCheckpointStreamsClient) DescribeStream(ctx context.Context, params *dynamodbstreams.DescribeStreamInput, optFns ...func(*dynamodbstreams.Options)) (*dynamodbstreams.DescribeStreamOutput, error) {
+	return &dynamodbstreams.DescribeStreamOutput{StreamDescription: &streamtypes.StreamDescription{
+		Shards: []streamtypes.Shard{
+			{ShardId: aws.String("shard-1"), ParentShardId: aws.String("shard-0"), SequenceNumberRange: &streamtypes.SequenceNumberRange{StartingSequenceNumber: aws.String("10")}},
+			{ShardId: aws.String("shard-0"), SequenceNumberRange: &streamtypes.SequenceNumberRange{StartingSequenceNumber: aws.String("1"), EndingSequenceNumber: aws.String("2")}},
+		},
+	}}, nil
+}
+
+func (f *fakeCheckpointStreamsClient) GetShardIterator(ctx context.Context, params *dynamodbstreams.GetShardIteratorInput, optFns ...func(*dynamodbstreams.Options)) (*dynamodbstreams.GetShardIteratorOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.iterators == nil {
+		f.iterators = map[string]string{}
+	}
+	position := string(params.ShardIteratorType)
+	if params.SequenceNumber != nil {
+		position += ":" + *params.SequenceNumber
+	}
+	f.iterators[*params.ShardId] = position
+	return &dynamodbstreams.GetShardIteratorOutput{ShardIterator: aws.String(*params.ShardId + "/" + position)}, nil
+}
+
+func (f *fakeCheckpointStreamsClient) GetRecords(ctx context.Context, params *dynamodbstreams.GetRecordsInput, optFns ...func(*dynamodbstreams.Options)) (*dynamodbstreams.GetRecordsOutput, error) {
+	record := func(seq string) streamtypes.Record {
+		return streamtypes.Record{
+			EventID:   aws.String("e-" + seq),
+			EventName: streamtypes.OperationTypeModify,
+			Dynamodb: &streamtypes.StreamRecord{
+				SequenceNumber: aws.String(seq),
+				Keys:           map[string]streamtypes.AttributeValue{"id": &streamtypes.AttributeValueMemberS{Value: "a"}},
+			},
+		}
+	}
+	switch *params.ShardIterator {
+	case "shard-0/TRIM_HORIZON":
+		return &dynamodbstreams.GetRecordsOutput{Records: []streamtypes.Record{record("1"), record("2")}}, nil
+	case "shard-0/AFTER_SEQUENCE_NUMBER:1":
+		return &dynamodbstreams.GetRecordsOutput{Records: []streamtypes.Record{record("2")}}, nil
+	case "shard-0/AFTER_SEQUENCE_NUMBER:2":
+		return &dynamodbstreams.GetRecordsOutput{}, nil
+	case "shard-1/TRIM_HORIZON":
+		return &dynamodbstreams.GetRecordsOutput{Records: []streamtypes.Record{record("10")}, NextShardIterator: aws.String("shard-1/idle")}, nil
+	default:
+		return &dynamodbstreams.GetRecordsOutput{NextShardIterator: params.ShardIterator}, nil
+	}
+}
+
+func TestStreamRecordsWithCheckpoint(t *testing.T) {
+	const streamArn = "arn:aws:dynamodb:us-east-1:123456789012:table/orders/stream/1"
+	client := &fakeCheckpointStreamsClient{}
+	source := &Source{Config: Config{Name: "test"}, streams: client}
+	store := NewMemoryCheckpointStore()
+	require.NoError(t, store.Set(context.Background(), streamArn, "shard-0", "1"))
+
+	// The reader resumes after the checkpoint, and reads the child shard once
+	// its parent is finished
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	var handled []string
+	err := source.StreamRecordsWithCheckpoint(ctx, streamArn, store, func(r StreamRecord) error {
+		handled = append(handled, r.ShardID+"@"+r.SequenceNumber)
+		if r.SequenceNumber == "10" {
+			cancel()
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"shard-0@2", "shard-1@10"}, handled)
+	assert.Equal(t, map[string]string{"shard-0": "AFTER_SEQUENCE_NUMBER:1", "shard-1": "TRIM_HORIZON"}, client.iterators)
+	for shardID, want := range map[string]string{"shard-0": "2", "shard-1": "10"} {
+		got, err := store.Get(context.Background(), streamArn, shardID)
+		require.NoError(t, err)
+		assert.Equal(t, want, got, shardID)
+	}
+
+	// A failed record is not checkpointed, so it is delivered again on restart
+	store = NewMemoryCheckpointStore()
+	ctx, cancel = context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	err = source.StreamRecordsWithCheckpoint(ctx, streamArn, store, func(r StreamRecord) error {
+		return fmt.Errorf("boom")
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "handler failed on shard shard-0 at 1")
+	assert.Equal(t, "TRIM_HORIZON", client.iterators["shard-0"])
+	got, err := store.Get(context.Background(), streamArn, "shard-0")
+	require.NoError(t, err)
+	assert.Empty(t, got)
+
+	err = source.StreamRecordsWithCheckpoint(context.Background(), streamArn, nil, func(StreamRecord) error { return nil })
+	require.Error(t, err)
+}
+
+func TestDescribeTable(t *testing.T) {
+	client := &fakeDynamoClient{descriptions: []*types.TableDescription{{
+		TableName:   aws.String("orders"),
+		TableStatus: types.TableStatusActive,
+		ItemCount:   aws.Int64(42),
+		KeySchema: []types.KeySchemaElement{
+			{AttributeName: aws.String("customer"), KeyType: types.KeyTypeHash},
+			{AttributeName: aws.String("id"), KeyType: types.KeyTypeRange},
+		},
+		AttributeDefinitions: []types.AttributeDefinition{
+			{AttributeName: aws.String("customer"), AttributeType: types.ScalarAttributeTypeS},
+			{AttributeName: aws.String("id"), AttributeType: types.ScalarAttributeTypeN},
+		},
+		GlobalSecondaryIndexes: []types.GlobalSecondaryIndexDescription{{
+			IndexName:   aws.String("by-id"),
+			IndexStatus: types.IndexStatusActive,
+			KeySchema:   []types.KeySchemaElement{{AttributeName: aws.String("id"), KeyType: types.KeyTypeHash}},
+			Projection:  &types.Projection{ProjectionType: types.ProjectionTypeKeysOnly},
+		}},
+		BillingModeSummary: &types.BillingModeSummary{BillingMode: types.BillingModePayPerRequest},
+	}}}
+	source := &Source{Config: Config{Name: "test"}, api: client}
+
+	info, err := source.DescribeTable(context.Background(), "orders")
+	require.NoError(t, err)
+	assert.Equal(t, "orders", info.Name)
+	assert.Equal(t, "customer", info.PartitionKey)
+	assert.Equal(t, "id", info.SortKey)
+	assert.Equal(t, map[string]string{"customer": "S", "id": "N"}, info.AttributeDefinitions)
+	assert.Equal(t, []IndexInfo{{Name: "by-id", PartitionKey: "id", ProjectionType: "KEYS_ONLY", Status: "ACTIVE"}}, info.GlobalSecondaryIndexes)
+	assert.Equal(t, int64(42), info.ItemCount)
+	assert.Equal(t, "PAY_PER_REQUEST", info.BillingMode)
+}
+
+func TestCreateTable(t *testing.T) {
+	creating := &types.TableDescription{TableName: aws.String("orders"), TableStatus: types.TableStatusCreating}
+	backfilling := &types.TableDescription{
+		TableName:              aws.String("orders"),
+		TableStatus:            types.TableStatusActive,
+		GlobalSecondaryIndexes: []types.GlobalSecondaryIndexDescription{{IndexName: aws.String("by-status"), IndexStatus: types.IndexStatusCreating}},
+	}
+	active := &types.TableDescription{
+		TableName:              aws.String("orders"),
+		TableStatus:            types.TableStatusActive,
+		GlobalSecondaryIndexes: []types.GlobalSecondaryIndexDescription{{IndexName: aws.String("by-status"), IndexStatus: types.IndexStatusActive}},
+	}
+	client := &fakeDynamoClient{descriptions: []*types.TableDescription{creating, backfilling, active}}
+	source := &Source{Config: Config{Name: "test"}, api: client}
+
+	err := source.CreateTable(context.Background(), TableDefinition{
+		Name:         "orders",
+		PartitionKey: KeyAttribute{Name: "customer", Type: "S"},
+		SortKey:      &KeyAttribute{Name: "id", Type: "N"},
+		GlobalSecondaryIndexes: []IndexDefinition{
+			{Name: "by-status", PartitionKey: KeyAttribute{Name: "status", Type: "S"}, SortKey: &KeyAttribute{Name: "id", Type: "N"}},
+		},
+		WaitUntilActive: true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 3, client.describeCalls)
+
+	req := client.created
+	require.NotNil(t, req)
+	assert.Equal(t, types.BillingModePayPerRequest, req.BillingMode)
+	assert.Nil(t, req.ProvisionedThroughput)
+	// id is a key of both the table and the index but is defined once
+	require.Len(t, req.AttributeDefinitions, 3)
+	require.Len(t, req.GlobalSecondaryIndexes, 1)
+	assert.Equal(t, types.ProjectionTypeAll, req.GlobalSecondaryIndexes[0].Projection.ProjectionType)
+	assert.Equal(t, types.KeyTypeRange, req.KeySchema[1].KeyType)
+}
+
+func TestCreateTableErrors(t *testing.T) {
+	tcs := []struct {
+		desc string
+		def  TableDefinition
+		want string
+	}{
+		{
+			desc: "missing name",
+			def:  TableDefinition{PartitionKey: KeyAttribute{Name: "id", Type: "S"}},
+			want: "table name is required",
+		},
+		{
+			desc: "bad key type",
+			def:  TableDefinition{Name: "t", PartitionKey: KeyAttribute{Name: "id", Type: "BOOL"}},
+			want: `key attribute "id" has unsupported type "BOOL"`,
+		},
+		{
+			desc: "conflicting key types",
+			def: TableDefinition{
+				Name:                   "t",
+				PartitionKey:           KeyAttribute{Name: "id", Type: "S"},
+				GlobalSecondaryIndexes: []IndexDefinition{{Name: "i", PartitionKey: KeyAttribute{Name: "id", Type: "N"}}},
+			},
+			want: `key attribute "id" is defined as both S and N`,
+		},
+		{
+			desc: "provisioned without capacity",
+			def:  TableDefinition{Name: "t", PartitionKey: KeyAttribute{Name: "id", Type: "S"}, BillingMode: "PROVISIONED"},
+			want: "read and write capacity are required",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			client := &fakeDynamoClient{}
+			source := &Source{Config: Config{Name: "test"}, api: client}
+			err := source.CreateTable(context.Background(), tc.def)
+			require.ErrorContains(t, err, tc.want)
+			assert.Nil(t, client.created)
+		})
+	}
+}
+
+func TestParallelScan(t *testing.T) {
+	client := &fakeDynamoClient{segments: map[int32][]map[string]types.AttributeValue{
+		0: {item("a", "1"), item("b", "2")},
+		2: {item("c", "3")},
+		3: {item("d", "4"), item("e", "5"), item("f", "6")},
+	}}
+	source := &Source{Config: Config{Name: "test"}, api: client}
+
+	items, err := source.ParallelScan(context.Background(), "orders", 4, Expr{})
+	require.NoError(t, err)
+	ids := []any{}
+	for _, it := range items {
+		ids = append(ids, it["id"])
+	}
+	assert.Equal(t, []any{"a", "b", "c", "d", "e", "f"}, ids)
+	assert.Equal(t, int32(4), client.maxSegments)
+}
+
+func TestParallelScanErrors(t *testing.T) {
+	source := &Source{Config: Config{Name: "test"}, api: &fakeDynamoClient{}}
+	_, err := source.ParallelScan(context.Background(), "orders", 0, Expr{})
+	require.ErrorContains(t, err, "segments must be between 1 and")
+	_, err = source.ParallelScan(context.Background(), "", 2, Expr{})
+	require.ErrorContains(t, err, "table is required")
+
+	client := &fakeDynamoClient{
+		segments:    map[int32][]map[string]types.AttributeValue{0: {item("a", "1")}},
+		segmentErrs: map[int32]error{1: errors.New("throttled")},
+	}
+	source = &Source{Config: Config{Name: "test"}, api: client}
+	_, err = source.ParallelScan(context.Background(), "orders", 2, Expr{})
+	require.ErrorContains(t, err, "segment 1: unable to scan table orders: throttled")
+}
@@ -0,0 +1,171 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dynamodb
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// PutItem writes item to table, replacing any item with the same key. item is a
+// struct (using `dynamodbav` tags) or a map, marshaled with attributevalue.
+func (s *Source) PutItem(ctx context.Context, table string, item any) error {
+	av, err := attributevalue.MarshalMap(item)
+	if err != nil {
+		return fmt.Errorf("unable to marshal item: %w", err)
+	}
+	if _, err := s.api.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(table),
+		Item:      av,
+	}); err != nil {
+		return fmt.Errorf("unable to put item in table %s: %w", table, err)
+	}
+	return nil
+}
+
+// GetItem reads the item with key from table into out, which must be a pointer
+// to a struct or map. It reports false, leaving out unchanged, if no such item
+// exists.
+func (s *Source) GetItem(ctx context.Context, table string, key map[string]any, out any) (bool, error) {
+	keyAV, err := attributevalue.MarshalMap(key)
+	if err != nil {
+		return false, fmt.Errorf("unable to marshal key: %w", err)
+	}
+	res, err := s.api.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(table),
+		Key:       keyAV,
+	})
+	if err != nil {
+		return false, fmt.Errorf("unable to get item from table %s: %w", table, err)
+	}
+	if len(res.Item) == 0 {
+		return false, nil
+	}
+	if err := attributevalue.UnmarshalMap(res.Item, out); err != nil {
+		return false, fmt.Errorf("unable to unmarshal item: %w", err)
+	}
+	return true, nil
+}
+
+// UpdateItem updates the attributes of the item with key in table and returns
+// the item as it is after the update. Each entry of updates sets an attribute
+// to a value, marshaled with attributevalue, or removes it if the value is nil.
+// Attribute names are always passed as placeholders, so reserved words such as
+// "status" or "name" need no escaping. If condition is non-nil, the update only
+// happens if it holds; otherwise the error wraps a
+// *types.ConditionalCheckFailedException. The item is created if it doesn't
+// exist.
+//
+//	source.UpdateItem(ctx, "orders", map[string]any{"id": "o-1"},
+//		map[string]any{"status": "shipped", "note": nil},
+//		&Expr{Expression: "#s = :pending", Names: map[string]string{"#s": "status"}, Values: map[string]any{":pending": "pending"}})
+func (s *Source) UpdateItem(ctx context.Context, table string, key map[string]any, updates map[string]any, condition *Expr) (map[string]any, error) {
+	update, err := buildUpdateExpr(updates)
+	if err != nil {
+		return nil, err
+	}
+	exprs := []Expr{update}
+	var conditionExpr *string
+	if condition != nil && condition.Expression != "" {
+		for placeholder := range condition.Names {
+			if _, ok := update.Names[placeholder]; ok {
+				return nil, fmt.Errorf("condition placeholder %s is reserved for updates", placeholder)
+			}
+		}
+		for placeholder := range condition.Values {
+			if _, ok := update.Values[placeholder]; ok {
+				return nil, fmt.Errorf("condition placeholder %s is reserved for updates", placeholder)
+			}
+		}
+		exprs = append(exprs, *condition)
+		conditionExpr = aws.String(condition.Expression)
+	}
+	names, values, err := buildPlaceholders(exprs...)
+	if err != nil {
+		return nil, err
+	}
+	keyAV, err := attributevalue.MarshalMap(key)
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal key: %w", err)
+	}
+
+	res, err := s.api.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName:                 aws.String(table),
+		Key:                       keyAV,
+		UpdateExpression:          aws.String(update.Expression),
+		ConditionExpression:       conditionExpr,
+		ExpressionAttributeNames:  names,
+		ExpressionAttributeValues: values,
+		ReturnValues:              types.ReturnValueAllNew,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to update item in table %s: %w", table, err)
+	}
+	item := map[string]any{}
+	if err := attributevalue.UnmarshalMap(res.Attributes, &item); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal item: %w", err)
+	}
+	return item, nil
+}
+
+// buildUpdateExpr builds a SET/REMOVE update expression from updates, naming
+// attributes #uN and values :uN in sorted attribute order.
+func buildUpdateExpr(updates map[string]any) (Expr, error) {
+	if len(updates) == 0 {
+		return Expr{}, fmt.Errorf("at least one update is required")
+	}
+	attrs := make([]string, 0, len(updates))
+	for attr := range updates {
+		if attr == "" {
+			return Expr{}, fmt.Errorf("attribute name must not be empty")
+		}
+		attrs = append(attrs, attr)
+	}
+	sort.Strings(attrs)
+
+	expr := Expr{Names: map[string]string{}}
+	var sets, removes []string
+	for i, attr := range attrs {
+		name := fmt.Sprintf("#u%d", i)
+		expr.Names[name] = attr
+		if updates[attr] == nil {
+			removes = append(removes, name)
+			continue
+		}
+		value := fmt.Sprintf(":u%d", i)
+		if expr.Values == nil {
+			expr.Values = map[string]any{}
+		}
+		expr.Values[value] = updates[attr]
+		sets = append(sets, name+" = "+value)
+	}
+
+	var clauses []string
+	if len(sets) > 0 {
+		clauses = append(clauses, "SET "+strings.Join(sets, ", "))
+	}
+	if len(removes) > 0 {
+		clauses = append(clauses, "REMOVE "+strings.Join(removes, ", "))
+	}
+	expr.Expression = strings.Join(clauses, " ")
+	return expr, nil
+}
@@ -0,0 +1,339 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dynamodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// dynamoAPI is the subset of the DynamoDB API used by the item and table helpers.
+type dynamoAPI interface {
+	Query(ctx context.Context, params *dynamodb.QueryInput, optFns ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error)
+	Scan(ctx context.Context, params *dynamodb.ScanInput, optFns ...func(*dynamodb.Options)) (*dynamodb.ScanOutput, error)
+	ExecuteStatement(ctx context.Context, params *dynamodb.ExecuteStatementInput, optFns ...func(*dynamodb.Options)) (*dynamodb.ExecuteStatementOutput, error)
+	PutItem(ctx context.Context, params *dynamodb.PutItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error)
+	GetItem(ctx context.Context, params *dynamodb.GetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.GetItemOutput, error)
+	UpdateItem(ctx context.Context, params *dynamodb.UpdateItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.UpdateItemOutput, error)
+	BatchWriteItem(ctx context.Context, params *dynamodb.BatchWriteItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.BatchWriteItemOutput, error)
+	BatchGetItem(ctx context.Context, params *dynamodb.BatchGetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.BatchGetItemOutput, error)
+	TransactWriteItems(ctx context.Context, params *dynamodb.TransactWriteItemsInput, optFns ...func(*dynamodb.Options)) (*dynamodb.TransactWriteItemsOutput, error)
+	TransactGetItems(ctx context.Context, params *dynamodb.TransactGetItemsInput, optFns ...func(*dynamodb.Options)) (*dynamodb.TransactGetItemsOutput, error)
+	DescribeTable(ctx context.Context, params *dynamodb.DescribeTableInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DescribeTableOutput, error)
+	CreateTable(ctx context.Context, params *dynamodb.CreateTableInput, optFns ...func(*dynamodb.Options)) (*dynamodb.CreateTableOutput, error)
+}
+
+// Expr is a DynamoDB condition or filter expression with its placeholders.
+// Values are plain Go values, marshaled with attributevalue.
+//
+//	Expr{
+//		Expression: "#s = :status",
+//		Names:      map[string]string{"#s": "status"},
+//		Values:     map[string]any{":status": "error"},
+//	}
+type Expr struct {
+	Expression string
+	Names      map[string]string // Optional: #name placeholders
+	Values     map[string]any    // Optional: :value placeholders
+}
+
+// QueryRequest describes a Query against a table or index.
+type QueryRequest struct {
+	Table                string
+	IndexName            string // Optional: query a secondary index
+	KeyCondition         Expr
+	Filter               Expr   // Optional: applied after the key condition
+	ProjectionExpression string // Optional: attributes to return
+	Descending           bool   // Return items in descending sort key order
+	ConsistentRead       bool   // Not supported on global secondary indexes
+	Limit                int    // Optional: stop after this many items (default: all)
+}
+
+// QueryResult holds the items returned by Query.
+type QueryResult struct {
+	Items        []map[string]any
+	ScannedCount int // Items read before the filter was applied
+}
+
+// Query runs req, following LastEvaluatedKey across pages until every matching
+// item (or req.Limit items) has been read, and unmarshals the items to Go values.
+func (s *Source) Query(ctx context.Context, req *QueryRequest) (*QueryResult, error) {
+	if req.Table == "" {
+		return nil, fmt.Errorf("table is required")
+	}
+	if req.KeyCondition.Expression == "" {
+		return nil, fmt.Errorf("key condition is required")
+	}
+	names, values, err := buildPlaceholders(req.KeyCondition, req.Filter)
+	if err != nil {
+		return nil, err
+	}
+
+	input := &dynamodb.QueryInput{
+		TableName:                 aws.String(req.Table),
+		KeyConditionExpression:    aws.String(req.KeyCondition.Expression),
+		ExpressionAttributeNames:  names,
+		ExpressionAttributeValues: values,
+		ScanIndexForward:          aws.Bool(!req.Descending),
+		ConsistentRead:            aws.Bool(req.ConsistentRead),
+	}
+	if req.IndexName != "" {
+		input.IndexName = aws.String(req.IndexName)
+	}
+	if req.Filter.Expression != "" {
+		input.FilterExpression = aws.String(req.Filter.Expression)
+	}
+	if req.ProjectionExpression != "" {
+		input.ProjectionExpression = aws.String(req.ProjectionExpression)
+	}
+
+	result := &QueryResult{Items: []map[string]any{}}
+	for {
+		if req.Limit > 0 {
+			// Only read as many items as are still needed, so a limited query
+			// doesn't consume read capacity for items it would discard
+			input.Limit = aws.Int32(int32(req.Limit - len(result.Items)))
+		}
+		out, err := s.api.Query(ctx, input)
+		if err != nil {
+			return nil, fmt.Errorf("unable to query table %s: %w", req.Table, err)
+		}
+		items, err := unmarshalItems(out.Items)
+		if err != nil {
+			return nil, err
+		}
+		result.Items = append(result.Items, items...)
+		result.ScannedCount += int(out.ScannedCount)
+
+		if req.Limit > 0 && len(result.Items) >= req.Limit {
+			result.Items = result.Items[:req.Limit]
+			return result, nil
+		}
+		if len(out.LastEvaluatedKey) == 0 {
+			return result, nil
+		}
+		input.ExclusiveStartKey = out.LastEvaluatedKey
+	}
+}
+
+// Scan constants
+const (
+	MaxScanSegments = 1000000 // Most segments DynamoDB splits a parallel scan into
+	MaxScanWorkers  = 16      // Most segments ParallelScan reads at once
+)
+
+// ScanAll reads every item of table that matches filter, following
+// LastEvaluatedKey across pages, and unmarshals the items to Go values. An empty
+// filter returns every item.
+func (s *Source) ScanAll(ctx context.Context, table string, filter Expr) ([]map[string]any, error) {
+	input, err := buildScanInput(table, filter)
+	if err != nil {
+		return nil, err
+	}
+	return s.scanPages(ctx, input)
+}
+
+// ParallelScan reads every item of table that matches filter like ScanAll, but
+// splits the table into segments scanned concurrently by at most
+// MaxScanWorkers goroutines. Items are returned in segment order. If any
+// segment fails, the remaining ones are cancelled and the errors of every
+// failed segment are returned together.
+func (s *Source) ParallelScan(ctx context.Context, table string, segments int, filter Expr) ([]map[string]any, error) {
+	if segments < 1 || segments > MaxScanSegments {
+		return nil, fmt.Errorf("segments must be between 1 and %d", MaxScanSegments)
+	}
+	base, err := buildScanInput(table, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	scanCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg      sync.WaitGroup
+		results = make([][]map[string]any, segments)
+		errs    = make([]error, segments)
+		next    = make(chan int)
+	)
+	for range min(segments, MaxScanWorkers) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for segment := range next {
+				input := *base
+				input.Segment = aws.Int32(int32(segment))
+				input.TotalSegments = aws.Int32(int32(segments))
+				items, err := s.scanPages(scanCtx, &input)
+				if err != nil {
+					errs[segment] = err
+					cancel()
+					continue
+				}
+				results[segment] = items
+			}
+		}()
+	}
+	for segment := range segments {
+		if scanCtx.Err() != nil {
+			break
+		}
+		next <- segment
+	}
+	close(next)
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	var failed []error
+	for segment, err := range errs {
+		// Segments cancelled because another one failed add nothing
+		if err == nil || errors.Is(err, context.Canceled) {
+			continue
+		}
+		failed = append(failed, fmt.Errorf("segment %d: %w", segment, err))
+	}
+	if len(failed) > 0 {
+		return nil, errors.Join(failed...)
+	}
+
+	merged := []map[string]any{}
+	for _, items := range results {
+		merged = append(merged, items...)
+	}
+	return merged, nil
+}
+
+func buildScanInput(table string, filter Expr) (*dynamodb.ScanInput, error) {
+	if table == "" {
+		return nil, fmt.Errorf("table is required")
+	}
+	names, values, err := buildPlaceholders(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	input := &dynamodb.ScanInput{
+		TableName:                 aws.String(table),
+		ExpressionAttributeNames:  names,
+		ExpressionAttributeValues: values,
+	}
+	if filter.Expression != "" {
+		input.FilterExpression = aws.String(filter.Expression)
+	}
+	return input, nil
+}
+
+// scanPages runs input, following LastEvaluatedKey across pages, and
+// unmarshals the items to Go values.
+func (s *Source) scanPages(ctx context.Context, input *dynamodb.ScanInput) ([]map[string]any, error) {
+	table := aws.ToString(input.TableName)
+	results := []map[string]any{}
+	for {
+		out, err := s.api.Scan(ctx, input)
+		if err != nil {
+			return nil, fmt.Errorf("unable to scan table %s: %w", table, err)
+		}
+		items, err := unmarshalItems(out.Items)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, items...)
+
+		if len(out.LastEvaluatedKey) == 0 {
+			return results, nil
+		}
+		input.ExclusiveStartKey = out.LastEvaluatedKey
+	}
+}
+
+// ExecutePartiQL runs a PartiQL statement with positional ? parameters,
+// following NextToken across pages, and unmarshals the returned items to Go
+// values. Statements that don't return items, such as INSERT, return none.
+//
+//	source.ExecutePartiQL(ctx, `SELECT * FROM "orders" WHERE customer = ?`, []any{"alice"})
+func (s *Source) ExecutePartiQL(ctx context.Context, statement string, params []any) ([]map[string]any, error) {
+	input := &dynamodb.ExecuteStatementInput{Statement: aws.String(statement)}
+	for i, param := range params {
+		av, err := attributevalue.Marshal(param)
+		if err != nil {
+			return nil, fmt.Errorf("unable to marshal parameter %d: %w", i+1, err)
+		}
+		input.Parameters = append(input.Parameters, av)
+	}
+
+	results := []map[string]any{}
+	for {
+		out, err := s.api.ExecuteStatement(ctx, input)
+		if err != nil {
+			return nil, fmt.Errorf("unable to execute statement: %w", err)
+		}
+		items, err := unmarshalItems(out.Items)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, items...)
+
+		if aws.ToString(out.NextToken) == "" {
+			return results, nil
+		}
+		input.NextToken = out.NextToken
+	}
+}
+
+// buildPlaceholders merges the placeholders of exprs, marshaling the values.
+// It returns nil maps when there are no placeholders, as DynamoDB rejects empty
+// ones.
+func buildPlaceholders(exprs ...Expr) (map[string]string, map[string]types.AttributeValue, error) {
+	var (
+		names  map[string]string
+		values map[string]types.AttributeValue
+	)
+	for _, expr := range exprs {
+		for placeholder, name := range expr.Names {
+			if names == nil {
+				names = map[string]string{}
+			}
+			names[placeholder] = name
+		}
+		for placeholder, value := range expr.Values {
+			av, err := attributevalue.Marshal(value)
+			if err != nil {
+				return nil, nil, fmt.Errorf("unable to marshal value for %s: %w", placeholder, err)
+			}
+			if values == nil {
+				values = map[string]types.AttributeValue{}
+			}
+			values[placeholder] = av
+		}
+	}
+	return names, values, nil
+}
+
+func unmarshalItems(items []map[string]types.AttributeValue) ([]map[string]any, error) {
+	results := make([]map[string]any, 0, len(items))
+	if err := attributevalue.UnmarshalListOfMaps(items, &results); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal items: %w", err)
+	}
+	return results, nil
+}
@@ -0,0 +1,477 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dynamodb
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodbstreams"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodbstreams/types"
+)
+
+// Stream polling constants
+const (
+	StreamPollInterval     = time.Second      // Delay between GetRecords calls on an idle shard
+	ShardDiscoveryInterval = 10 * time.Second // Delay between DescribeStream calls looking for new shards
+)
+
+// streamsAPI is the subset of the DynamoDB Streams API used by StreamRecords.
+type streamsAPI interface {
+	DescribeStream(ctx context.Context, params *dynamodbstreams.DescribeStreamInput, optFns ...func(*dynamodbstreams.Options)) (*dynamodbstreams.DescribeStreamOutput, error)
+	GetShardIterator(ctx context.Context, params *dynamodbstreams.GetShardIteratorInput, optFns ...func(*dynamodbstreams.Options)) (*dynamodbstreams.GetShardIteratorOutput, error)
+	GetRecords(ctx context.Context, params *dynamodbstreams.GetRecordsInput, optFns ...func(*dynamodbstreams.Options)) (*dynamodbstreams.GetRecordsOutput, error)
+}
+
+// StreamRecord is a change to an item, read from a DynamoDB stream. Images are
+// unmarshaled to Go values the same way as the other item helpers.
+type StreamRecord struct {
+	EventID        string
+	EventName      string // INSERT, MODIFY, or REMOVE
+	ShardID        string
+	SequenceNumber string
+	CreatedAt      time.Time // Approximate time of the change
+	Keys           map[string]any
+	NewImage       map[string]any // nil for REMOVE, or if the stream view type omits it
+	OldImage       map[string]any // nil for INSERT, or if the stream view type omits it
+}
+
+// StreamRecords reads changes from the stream with streamArn, starting with the
+// changes made after it is called. Records of a shard are read only after its
+// parent shard has been read completely, so changes to an item are delivered
+// in order across shard splits.
+//
+// The record channel is closed when ctx is done or reading fails; in the latter
+// case the error is sent on the error channel first. The returned error reports
+// a failure to describe the stream.
+func (s *Source) StreamRecords(ctx context.Context, streamArn string) (<-chan StreamRecord, <-chan error, error) {
+	shards, err := s.describeShards(ctx, streamArn)
+	if err != nil {
+		return nil, nil, err
+	}
+	records := make(chan StreamRecord)
+	errs := make(chan error, 1)
+	go s.consumeStream(ctx, streamArn, shards, startFromLatest, records, errs)
+	return records, errs, nil
+}
+
+// CheckpointStore persists the sequence number of the last record handled in
+// each shard of a stream, so that reading can resume after a restart.
+type CheckpointStore interface {
+	// Get returns the checkpoint of a shard, or "" if it has none.
+	Get(ctx context.Context, streamArn, shardID string) (string, error)
+	// Set records sequenceNumber as the checkpoint of a shard.
+	Set(ctx context.Context, streamArn, shardID, sequenceNumber string) error
+}
+
+// MemoryCheckpointStore is a CheckpointStore that keeps checkpoints in memory,
+// for a single process. It is safe for concurrent use.
+type MemoryCheckpointStore struct {
+	mu          sync.Mutex
+	checkpoints map[string]string
+}
+
+var _ CheckpointStore = &MemoryCheckpointStore{}
+
+// NewMemoryCheckpointStore returns an empty MemoryCheckpointStore.
+func NewMemoryCheckpointStore() *MemoryCheckpointStore {
+	return &MemoryCheckpointStore{checkpoints: map[string]string{}}
+}
+
+func (m *MemoryCheckpointStore) Get(ctx context.Context, streamArn, shardID string) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.checkpoints[streamArn+"/"+shardID], nil
+}
+
+func (m *MemoryCheckpointStore) Set(ctx context.Context, streamArn, shardID, sequenceNumber string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.checkpoints[streamArn+"/"+shardID] = sequenceNumber
+	return nil
+}
+
+// StreamRecordsWithCheckpoint reads changes from the stream with streamArn and
+// passes each one to handler, one at a time. After handler returns nil, the
+// record's sequence number is saved in store as the checkpoint of its shard.
+// Shards with a checkpoint are read from the record after it, and shards
+// without one from their oldest record, so a restarted reader resumes where
+// the previous one left off. Records are delivered at least once: a record
+// whose checkpoint was not saved is delivered again after a restart.
+//
+// As with StreamRecords, records of a shard are read only after its parent
+// shard has been read completely. StreamRecordsWithCheckpoint blocks until ctx
+// is cancelled, in which case it returns nil, or until handler, store, or
+// reading the stream returns an error.
+func (s *Source) StreamRecordsWithCheckpoint(ctx context.Context, streamArn string, store CheckpointStore, handler func(StreamRecord) error) error {
+	if store == nil {
+		return fmt.Errorf("a checkpoint store is required")
+	}
+	shards, err := s.describeShards(ctx, streamArn)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	records := make(chan StreamRecord)
+	errs := make(chan error, 1)
+	go s.consumeStream(ctx, streamArn, shards, startFromCheckpoint(streamArn, store), records, errs)
+
+	// Stop the readers and wait for them to exit before returning
+	stop := func() {
+		cancel()
+		for range records {
+		}
+	}
+	for record := range records {
+		if err := handler(record); err != nil {
+			stop()
+			return fmt.Errorf("handler failed on shard %s at %s: %w", record.ShardID, record.SequenceNumber, err)
+		}
+		if err := store.Set(ctx, streamArn, record.ShardID, record.SequenceNumber); err != nil {
+			stop()
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("unable to save checkpoint %s of shard %s: %w", record.SequenceNumber, record.ShardID, err)
+		}
+	}
+	return <-errs
+}
+
+// startFromCheckpoint reads every shard from the record after its checkpoint
+// in store, or from its oldest record if it has none.
+func startFromCheckpoint(streamArn string, store CheckpointStore) shardStart {
+	return func(ctx context.Context, shard types.Shard, initial bool) (shardPosition, bool, error) {
+		shardID := aws.ToString(shard.ShardId)
+		seq, err := store.Get(ctx, streamArn, shardID)
+		if err != nil {
+			return shardPosition{}, false, fmt.Errorf("unable to get checkpoint of shard %s: %w", shardID, err)
+		}
+		if seq == "" {
+			return shardPosition{iteratorType: types.ShardIteratorTypeTrimHorizon}, false, nil
+		}
+		return shardPosition{iteratorType: types.ShardIteratorTypeAfterSequenceNumber, sequenceNumber: seq}, false, nil
+	}
+}
+
+// shardPosition is where reading of a shard starts.
+type shardPosition struct {
+	iteratorType   types.ShardIteratorType
+	sequenceNumber string // Set for AFTER_SEQUENCE_NUMBER
+}
+
+// shardStart returns the position to read a shard from, or skip if the shard
+// holds nothing to read. initial is true for the shards of the stream when
+// reading begins, and false for shards discovered later.
+type shardStart func(ctx context.Context, shard types.Shard, initial bool) (pos shardPosition, skip bool, err error)
+
+// startFromLatest reads only the changes made after reading begins: closed
+// shards only hold earlier changes, so they are skipped, and open shards are
+// read from their latest record. Shards discovered later are read from the
+// start.
+func startFromLatest(ctx context.Context, shard types.Shard, initial bool) (shardPosition, bool, error) {
+	if !initial {
+		return shardPosition{iteratorType: types.ShardIteratorTypeTrimHorizon}, false, nil
+	}
+	if shard.SequenceNumberRange != nil && shard.SequenceNumberRange.EndingSequenceNumber != nil {
+		return shardPosition{}, true, nil
+	}
+	return shardPosition{iteratorType: types.ShardIteratorTypeLatest}, false, nil
+}
+
+// consumeStream starts a reader for each shard at the position returned by
+// start, discovering new shards as existing ones close and as
+// ShardDiscoveryInterval passes. A shard is read only once its parent, if
+// still in the stream, is finished.
+func (s *Source) consumeStream(ctx context.Context, streamArn string, initial []types.Shard, start shardStart, records chan<- StreamRecord, errs chan<- error) {
+	ctx, cancel := context.WithCancel(ctx)
+	var wg sync.WaitGroup
+	defer func() {
+		cancel()
+		wg.Wait()
+		close(records)
+		close(errs)
+	}()
+
+	fail := func(err error) {
+		select {
+		case errs <- err:
+		default:
+		}
+		cancel()
+	}
+	known := map[string]bool{}    // Shards being read or skipped
+	finished := map[string]bool{} // Shards read completely or skipped
+	finishedCh := make(chan string)
+	read := func(shardID string, pos shardPosition) {
+		known[shardID] = true
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := s.readShard(ctx, streamArn, shardID, pos, records, finishedCh); err != nil && ctx.Err() == nil {
+				fail(err)
+			}
+		}()
+	}
+
+	// Skipped shards are resolved first, so that their children can be read
+	// right away. Children of shards that are being read wait for discovery.
+	inStream := map[string]bool{}
+	positions := make([]shardPosition, len(initial))
+	for i, shard := range initial {
+		shardID := aws.ToString(shard.ShardId)
+		inStream[shardID] = true
+		pos, skip, err := start(ctx, shard, true)
+		if err != nil {
+			fail(err)
+			return
+		}
+		if skip {
+			known[shardID] = true
+			finished[shardID] = true
+		}
+		positions[i] = pos
+	}
+	for i, shard := range initial {
+		shardID := aws.ToString(shard.ShardId)
+		parentID := aws.ToString(shard.ParentShardId)
+		if known[shardID] || (parentID != "" && inStream[parentID] && !finished[parentID]) {
+			continue
+		}
+		read(shardID, positions[i])
+	}
+
+	ticker := time.NewTicker(ShardDiscoveryInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case shardID := <-finishedCh:
+			finished[shardID] = true
+		case <-ticker.C:
+		}
+
+		shards, err := s.describeShards(ctx, streamArn)
+		if err != nil {
+			if ctx.Err() == nil {
+				fail(err)
+			}
+			return
+		}
+		// New shards are read once their parent is finished. A parent that
+		// has aged out of the stream no longer holds records.
+		inStream := map[string]bool{}
+		for _, shard := range shards {
+			inStream[aws.ToString(shard.ShardId)] = true
+		}
+		for _, shard := range shards {
+			shardID := aws.ToString(shard.ShardId)
+			parentID := aws.ToString(shard.ParentShardId)
+			if known[shardID] || (parentID != "" && inStream[parentID] && !finished[parentID]) {
+				continue
+			}
+			pos, skip, err := start(ctx, shard, false)
+			if err != nil {
+				if ctx.Err() == nil {
+					fail(err)
+				}
+				return
+			}
+			if skip {
+				known[shardID] = true
+				finished[shardID] = true
+				continue
+			}
+			read(shardID, pos)
+		}
+	}
+}
+
+// readShard sends the records of a shard until the shard is closed, then
+// reports it on finished.
+func (s *Source) readShard(ctx context.Context, streamArn, shardID string, pos shardPosition, records chan<- StreamRecord, finished chan<- string) error {
+	input := &dynamodbstreams.GetShardIteratorInput{
+		StreamArn:         aws.String(streamArn),
+		ShardId:           aws.String(shardID),
+		ShardIteratorType: pos.iteratorType,
+	}
+	if pos.sequenceNumber != "" {
+		input.SequenceNumber = aws.String(pos.sequenceNumber)
+	}
+	it, err := s.streams.GetShardIterator(ctx, input)
+	if err != nil {
+		return fmt.Errorf("unable to get iterator for shard %s: %w", shardID, err)
+	}
+
+	iterator := it.ShardIterator
+	for iterator != nil {
+		out, err := s.streams.GetRecords(ctx, &dynamodbstreams.GetRecordsInput{ShardIterator: iterator})
+		if err != nil {
+			return fmt.Errorf("unable to get records from shard %s: %w", shardID, err)
+		}
+		for _, r := range out.Records {
+			record, err := toStreamRecord(shardID, r)
+			if err != nil {
+				return err
+			}
+			select {
+			case records <- record:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		// A nil iterator means the shard is closed and fully read
+		iterator = out.NextShardIterator
+		if len(out.Records) == 0 && iterator != nil {
+			timer := time.NewTimer(StreamPollInterval)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return ctx.Err()
+			case <-timer.C:
+			}
+		}
+	}
+
+	select {
+	case finished <- shardID:
+	case <-ctx.Done():
+	}
+	return nil
+}
+
+// describeShards returns every shard of the stream, following
+// LastEvaluatedShardId across pages.
+func (s *Source) describeShards(ctx context.Context, streamArn string) ([]types.Shard, error) {
+	var (
+		shards  []types.Shard
+		startID *string
+	)
+	for {
+		out, err := s.streams.DescribeStream(ctx, &dynamodbstreams.DescribeStreamInput{
+			StreamArn:             aws.String(streamArn),
+			ExclusiveStartShardId: startID,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("unable to describe stream %s: %w", streamArn, err)
+		}
+		if out.StreamDescription == nil {
+			return shards, nil
+		}
+		shards = append(shards, out.StreamDescription.Shards...)
+		if aws.ToString(out.StreamDescription.LastEvaluatedShardId) == "" {
+			return shards, nil
+		}
+		startID = out.StreamDescription.LastEvaluatedShardId
+	}
+}
+
+func toStreamRecord(shardID string, r types.Record) (StreamRecord, error) {
+	record := StreamRecord{
+		EventID:   aws.ToString(r.EventID),
+		EventName: string(r.EventName),
+		ShardID:   shardID,
+	}
+	if r.Dynamodb == nil {
+		return record, nil
+	}
+	record.SequenceNumber = aws.ToString(r.Dynamodb.SequenceNumber)
+	record.CreatedAt = aws.ToTime(r.Dynamodb.ApproximateCreationDateTime)
+
+	var err error
+	if record.Keys, err = streamItem(r.Dynamodb.Keys); err != nil {
+		return StreamRecord{}, fmt.Errorf("unable to unmarshal keys of record %s: %w", record.EventID, err)
+	}
+	if record.NewImage, err = streamItem(r.Dynamodb.NewImage); err != nil {
+		return StreamRecord{}, fmt.Errorf("unable to unmarshal new image of record %s: %w", record.EventID, err)
+	}
+	if record.OldImage, err = streamItem(r.Dynamodb.OldImage); err != nil {
+		return StreamRecord{}, fmt.Errorf("unable to unmarshal old image of record %s: %w", record.EventID, err)
+	}
+	return record, nil
+}
+
+// streamItem converts a stream image to Go values. Stream records use their own
+// AttributeValue type, which attributevalue can't decode, so this mirrors its
+// defaults: numbers become float64 and sets become slices.
+func streamItem(item map[string]types.AttributeValue) (map[string]any, error) {
+	if len(item) == 0 {
+		return nil, nil
+	}
+	result := make(map[string]any, len(item))
+	for name, av := range item {
+		v, err := streamValue(av)
+		if err != nil {
+			return nil, fmt.Errorf("attribute %s: %w", name, err)
+		}
+		result[name] = v
+	}
+	return result, nil
+}
+
+func streamValue(av types.AttributeValue) (any, error) {
+	switch v := av.(type) {
+	case *types.AttributeValueMemberS:
+		return v.Value, nil
+	case *types.AttributeValueMemberN:
+		return strconv.ParseFloat(v.Value, 64)
+	case *types.AttributeValueMemberB:
+		return v.Value, nil
+	case *types.AttributeValueMemberBOOL:
+		return v.Value, nil
+	case *types.AttributeValueMemberNULL:
+		return nil, nil
+	case *types.AttributeValueMemberM:
+		m, err := streamItem(v.Value)
+		if m == nil && err == nil {
+			m = map[string]any{}
+		}
+		return m, err
+	case *types.AttributeValueMemberL:
+		list := make([]any, len(v.Value))
+		for i, elem := range v.Value {
+			value, err := streamValue(elem)
+			if err != nil {
+				return nil, err
+			}
+			list[i] = value
+		}
+		return list, nil
+	case *types.AttributeValueMemberSS:
+		return v.Value, nil
+	case *types.AttributeValueMemberNS:
+		numbers := make([]float64, len(v.Value))
+		for i, n := range v.Value {
+			f, err := strconv.ParseFloat(n, 64)
+			if err != nil {
+				return nil, err
+			}
+			numbers[i] = f
+		}
+		return numbers, nil
+	case *types.AttributeValueMemberBS:
+		return v.Value, nil
+	default:
+		return nil, fmt.Errorf("unsupported attribute value type %T", av)
+	}
+}
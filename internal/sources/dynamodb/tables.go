@@ -0,0 +1,315 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dynamodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// Table constants
+const (
+	TablePollInterval    = 500 * time.Millisecond // Initial delay between DescribeTable calls while waiting
+	MaxTablePollInterval = 5 * time.Second        // Upper bound for the poll delay
+	DefaultTableWait     = 5 * time.Minute        // Default limit on waiting for a table to become active
+)
+
+// TableInfo describes a table as returned by DescribeTable.
+type TableInfo struct {
+	Name                   string
+	Status                 string // e.g. CREATING, ACTIVE, UPDATING
+	BillingMode            string // PROVISIONED or PAY_PER_REQUEST
+	PartitionKey           string
+	SortKey                string            // Empty if the table has no sort key
+	KeySchema              []KeyElement      // Primary key attributes, partition key first
+	AttributeDefinitions   map[string]string // Attribute name to type (S, N or B)
+	GlobalSecondaryIndexes []IndexInfo
+	LocalSecondaryIndexes  []IndexInfo
+	ItemCount              int64 // Updated by DynamoDB roughly every six hours
+	SizeBytes              int64 // Updated by DynamoDB roughly every six hours
+	StreamArn              string
+}
+
+// KeyElement is an attribute of a primary or index key.
+type KeyElement struct {
+	AttributeName string
+	KeyType       string // HASH or RANGE
+}
+
+// IndexInfo describes a secondary index.
+type IndexInfo struct {
+	Name           string
+	PartitionKey   string
+	SortKey        string
+	ProjectionType string // ALL, KEYS_ONLY or INCLUDE
+	Status         string // Empty for local secondary indexes
+}
+
+// KeyAttribute is a key attribute and its type.
+type KeyAttribute struct {
+	Name string
+	Type string // S, N or B
+}
+
+// IndexDefinition describes a global secondary index to create with a table.
+type IndexDefinition struct {
+	Name             string
+	PartitionKey     KeyAttribute
+	SortKey          *KeyAttribute // Optional
+	ProjectionType   string        // Optional: ALL, KEYS_ONLY or INCLUDE (default: ALL)
+	NonKeyAttributes []string      // Attributes to project when ProjectionType is INCLUDE
+}
+
+// TableDefinition describes a table to create with CreateTable.
+type TableDefinition struct {
+	Name                   string
+	PartitionKey           KeyAttribute
+	SortKey                *KeyAttribute // Optional
+	GlobalSecondaryIndexes []IndexDefinition
+	BillingMode            string        // Optional: PAY_PER_REQUEST or PROVISIONED (default: PAY_PER_REQUEST)
+	ReadCapacity           int64         // Required for PROVISIONED, applied to the table and its indexes
+	WriteCapacity          int64         // Required for PROVISIONED, applied to the table and its indexes
+	WaitUntilActive        bool          // Wait for the table and its indexes to become ACTIVE
+	WaitTimeout            time.Duration // Optional: limit on waiting (default: DefaultTableWait)
+}
+
+// DescribeTable returns the key schema, attribute definitions, secondary
+// indexes and approximate size of table.
+func (s *Source) DescribeTable(ctx context.Context, table string) (*TableInfo, error) {
+	out, err := s.api.DescribeTable(ctx, &dynamodb.DescribeTableInput{TableName: aws.String(table)})
+	if err != nil {
+		return nil, fmt.Errorf("unable to describe table %s: %w", table, err)
+	}
+	return toTableInfo(out.Table), nil
+}
+
+// CreateTable creates the table described by def. If def.WaitUntilActive is
+// set, it then polls the table with exponential backoff until it and all of
+// its global secondary indexes are ACTIVE, or def.WaitTimeout passes.
+func (s *Source) CreateTable(ctx context.Context, def TableDefinition) error {
+	input, err := buildCreateTableInput(def)
+	if err != nil {
+		return err
+	}
+	if _, err := s.api.CreateTable(ctx, input); err != nil {
+		return fmt.Errorf("unable to create table %s: %w", def.Name, err)
+	}
+	if !def.WaitUntilActive {
+		return nil
+	}
+
+	timeout := def.WaitTimeout
+	if timeout == 0 {
+		timeout = DefaultTableWait
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	if err := s.waitForTable(ctx, def.Name); err != nil {
+		return fmt.Errorf("table %s did not become active: %w", def.Name, err)
+	}
+	return nil
+}
+
+// waitForTable polls table until it and its global secondary indexes are
+// ACTIVE.
+func (s *Source) waitForTable(ctx context.Context, table string) error {
+	delay := TablePollInterval
+	for {
+		info, err := s.DescribeTable(ctx, table)
+		if err != nil {
+			return err
+		}
+		if info.active() {
+			return nil
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+		delay = min(delay*2, MaxTablePollInterval)
+	}
+}
+
+func (t *TableInfo) active() bool {
+	if t.Status != string(types.TableStatusActive) {
+		return false
+	}
+	for _, idx := range t.GlobalSecondaryIndexes {
+		if idx.Status != string(types.IndexStatusActive) {
+			return false
+		}
+	}
+	return true
+}
+
+func buildCreateTableInput(def TableDefinition) (*dynamodb.CreateTableInput, error) {
+	if def.Name == "" {
+		return nil, fmt.Errorf("table name is required")
+	}
+
+	billing := types.BillingMode(def.BillingMode)
+	switch billing {
+	case "":
+		billing = types.BillingModePayPerRequest
+	case types.BillingModePayPerRequest, types.BillingModeProvisioned:
+	default:
+		return nil, fmt.Errorf("unsupported billing mode %q: must be %s or %s", def.BillingMode, types.BillingModePayPerRequest, types.BillingModeProvisioned)
+	}
+	var throughput *types.ProvisionedThroughput
+	if billing == types.BillingModeProvisioned {
+		if def.ReadCapacity <= 0 || def.WriteCapacity <= 0 {
+			return nil, fmt.Errorf("read and write capacity are required for %s billing", types.BillingModeProvisioned)
+		}
+		throughput = &types.ProvisionedThroughput{
+			ReadCapacityUnits:  aws.Int64(def.ReadCapacity),
+			WriteCapacityUnits: aws.Int64(def.WriteCapacity),
+		}
+	}
+
+	// Every key attribute of the table and its indexes must be defined
+	// exactly once, with a consistent type.
+	attrTypes := map[string]string{}
+	input := &dynamodb.CreateTableInput{
+		TableName:             aws.String(def.Name),
+		BillingMode:           billing,
+		ProvisionedThroughput: throughput,
+	}
+	defineKey := func(pk KeyAttribute, sk *KeyAttribute) ([]types.KeySchemaElement, error) {
+		keys := []KeyAttribute{pk}
+		if sk != nil {
+			keys = append(keys, *sk)
+		}
+		schema := make([]types.KeySchemaElement, 0, len(keys))
+		for i, key := range keys {
+			if key.Name == "" {
+				return nil, fmt.Errorf("key attribute name is required")
+			}
+			switch types.ScalarAttributeType(key.Type) {
+			case types.ScalarAttributeTypeS, types.ScalarAttributeTypeN, types.ScalarAttributeTypeB:
+			default:
+				return nil, fmt.Errorf("key attribute %q has unsupported type %q: must be S, N or B", key.Name, key.Type)
+			}
+			if prev, ok := attrTypes[key.Name]; ok && prev != key.Type {
+				return nil, fmt.Errorf("key attribute %q is defined as both %s and %s", key.Name, prev, key.Type)
+			} else if !ok {
+				attrTypes[key.Name] = key.Type
+				input.AttributeDefinitions = append(input.AttributeDefinitions, types.AttributeDefinition{
+					AttributeName: aws.String(key.Name),
+					AttributeType: types.ScalarAttributeType(key.Type),
+				})
+			}
+			keyType := types.KeyTypeHash
+			if i == 1 {
+				keyType = types.KeyTypeRange
+			}
+			schema = append(schema, types.KeySchemaElement{AttributeName: aws.String(key.Name), KeyType: keyType})
+		}
+		return schema, nil
+	}
+
+	schema, err := defineKey(def.PartitionKey, def.SortKey)
+	if err != nil {
+		return nil, err
+	}
+	input.KeySchema = schema
+
+	for _, idx := range def.GlobalSecondaryIndexes {
+		if idx.Name == "" {
+			return nil, fmt.Errorf("index name is required")
+		}
+		schema, err := defineKey(idx.PartitionKey, idx.SortKey)
+		if err != nil {
+			return nil, fmt.Errorf("index %s: %w", idx.Name, err)
+		}
+		projection := types.ProjectionType(idx.ProjectionType)
+		if projection == "" {
+			projection = types.ProjectionTypeAll
+		}
+		gsi := types.GlobalSecondaryIndex{
+			IndexName:             aws.String(idx.Name),
+			KeySchema:             schema,
+			Projection:            &types.Projection{ProjectionType: projection},
+			ProvisionedThroughput: throughput,
+		}
+		if projection == types.ProjectionTypeInclude {
+			gsi.Projection.NonKeyAttributes = idx.NonKeyAttributes
+		}
+		input.GlobalSecondaryIndexes = append(input.GlobalSecondaryIndexes, gsi)
+	}
+	return input, nil
+}
+
+func toTableInfo(t *types.TableDescription) *TableInfo {
+	info := &TableInfo{
+		Name:                 aws.ToString(t.TableName),
+		Status:               string(t.TableStatus),
+		BillingMode:          string(types.BillingModeProvisioned),
+		ItemCount:            aws.ToInt64(t.ItemCount),
+		SizeBytes:            aws.ToInt64(t.TableSizeBytes),
+		StreamArn:            aws.ToString(t.LatestStreamArn),
+		AttributeDefinitions: make(map[string]string, len(t.AttributeDefinitions)),
+	}
+	// Tables created before on-demand billing existed have no summary
+	if t.BillingModeSummary != nil && t.BillingModeSummary.BillingMode != "" {
+		info.BillingMode = string(t.BillingModeSummary.BillingMode)
+	}
+	for _, ad := range t.AttributeDefinitions {
+		info.AttributeDefinitions[aws.ToString(ad.AttributeName)] = string(ad.AttributeType)
+	}
+	for _, k := range t.KeySchema {
+		info.KeySchema = append(info.KeySchema, KeyElement{AttributeName: aws.ToString(k.AttributeName), KeyType: string(k.KeyType)})
+	}
+	info.PartitionKey, info.SortKey = keyNames(t.KeySchema)
+
+	for _, gsi := range t.GlobalSecondaryIndexes {
+		idx := IndexInfo{Name: aws.ToString(gsi.IndexName), Status: string(gsi.IndexStatus)}
+		idx.PartitionKey, idx.SortKey = keyNames(gsi.KeySchema)
+		if gsi.Projection != nil {
+			idx.ProjectionType = string(gsi.Projection.ProjectionType)
+		}
+		info.GlobalSecondaryIndexes = append(info.GlobalSecondaryIndexes, idx)
+	}
+	for _, lsi := range t.LocalSecondaryIndexes {
+		idx := IndexInfo{Name: aws.ToString(lsi.IndexName)}
+		idx.PartitionKey, idx.SortKey = keyNames(lsi.KeySchema)
+		if lsi.Projection != nil {
+			idx.ProjectionType = string(lsi.Projection.ProjectionType)
+		}
+		info.LocalSecondaryIndexes = append(info.LocalSecondaryIndexes, idx)
+	}
+	return info
+}
+
+// keyNames returns the partition and sort key attributes of a key schema.
+func keyNames(schema []types.KeySchemaElement) (partition, sort string) {
+	for _, k := range schema {
+		switch k.KeyType {
+		case types.KeyTypeHash:
+			partition = aws.ToString(k.AttributeName)
+		case types.KeyTypeRange:
+			sort = aws.ToString(k.AttributeName)
+		}
+	}
+	return partition, sort
+}
@@ -0,0 +1,240 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dynamodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// TransactWriteItem is one action of TransactWrite. Set exactly one of Put,
+// Update, or Delete, or only Condition for a condition check.
+type TransactWriteItem struct {
+	Table     string
+	Put       any            // Item to put: a struct or map, marshaled with attributevalue
+	Update    *Expr          // Update expression applied to the item with Key
+	Delete    bool           // Delete the item with Key
+	Key       map[string]any // Primary key, for Update, Delete, and condition checks
+	Condition Expr           // Optional: the action fails unless this holds
+}
+
+// TransactGetItem is one read of TransactGet.
+type TransactGetItem struct {
+	Table                string
+	Key                  map[string]any
+	ProjectionExpression string // Optional: attributes to return
+}
+
+// CancellationReason explains why one item of a cancelled transaction failed.
+type CancellationReason struct {
+	Index   int            // Position of the item in the transaction
+	Code    string         // e.g. ConditionalCheckFailed, TransactionConflict, ValidationError
+	Message string         // Optional: detail from DynamoDB
+	Item    map[string]any // Optional: the current item, for ConditionalCheckFailed
+}
+
+// TransactionCanceledError is returned when DynamoDB cancels a transaction. It
+// lists only the items that caused the cancellation.
+type TransactionCanceledError struct {
+	Reasons []CancellationReason
+	err     *types.TransactionCanceledException
+}
+
+func (e *TransactionCanceledError) Error() string {
+	if len(e.Reasons) == 0 {
+		return "transaction cancelled: " + e.err.ErrorMessage()
+	}
+	details := make([]string, len(e.Reasons))
+	for i, r := range e.Reasons {
+		details[i] = fmt.Sprintf("item %d: %s", r.Index, r.Code)
+		if r.Message != "" {
+			details[i] += ": " + r.Message
+		}
+	}
+	return "transaction cancelled: " + strings.Join(details, "; ")
+}
+
+func (e *TransactionCanceledError) Unwrap() error {
+	return e.err
+}
+
+// TransactWrite applies items atomically: either every action succeeds or none
+// do. If DynamoDB cancels the transaction the error is a
+// *TransactionCanceledError.
+func (s *Source) TransactWrite(ctx context.Context, items []TransactWriteItem) error {
+	input := &dynamodb.TransactWriteItemsInput{}
+	for i, item := range items {
+		action, err := buildTransactWriteItem(item)
+		if err != nil {
+			return fmt.Errorf("item %d: %w", i, err)
+		}
+		input.TransactItems = append(input.TransactItems, action)
+	}
+	if _, err := s.api.TransactWriteItems(ctx, input); err != nil {
+		return transactError("unable to write transaction", err)
+	}
+	return nil
+}
+
+// TransactGet reads items atomically and unmarshals them to Go values, in the
+// same order as items. Missing items are returned as nil. If DynamoDB cancels
+// the transaction the error is a *TransactionCanceledError.
+func (s *Source) TransactGet(ctx context.Context, items []TransactGetItem) ([]map[string]any, error) {
+	input := &dynamodb.TransactGetItemsInput{}
+	for i, item := range items {
+		key, err := attributevalue.MarshalMap(item.Key)
+		if err != nil {
+			return nil, fmt.Errorf("item %d: unable to marshal key: %w", i, err)
+		}
+		get := &types.Get{TableName: aws.String(item.Table), Key: key}
+		if item.ProjectionExpression != "" {
+			get.ProjectionExpression = aws.String(item.ProjectionExpression)
+		}
+		input.TransactItems = append(input.TransactItems, types.TransactGetItem{Get: get})
+	}
+
+	out, err := s.api.TransactGetItems(ctx, input)
+	if err != nil {
+		return nil, transactError("unable to read transaction", err)
+	}
+	results := make([]map[string]any, len(out.Responses))
+	for i, response := range out.Responses {
+		if len(response.Item) == 0 {
+			continue
+		}
+		if err := attributevalue.UnmarshalMap(response.Item, &results[i]); err != nil {
+			return nil, fmt.Errorf("item %d: unable to unmarshal item: %w", i, err)
+		}
+	}
+	return results, nil
+}
+
+func buildTransactWriteItem(item TransactWriteItem) (types.TransactWriteItem, error) {
+	actions := 0
+	for _, set := range []bool{item.Put != nil, item.Update != nil, item.Delete} {
+		if set {
+			actions++
+		}
+	}
+	if actions > 1 {
+		return types.TransactWriteItem{}, errors.New("set only one of Put, Update, or Delete")
+	}
+	if actions == 0 && item.Condition.Expression == "" {
+		return types.TransactWriteItem{}, errors.New("set one of Put, Update, Delete, or Condition")
+	}
+
+	var (
+		update    *string
+		exprs     = []Expr{item.Condition}
+		condition *string
+	)
+	if item.Update != nil {
+		update = aws.String(item.Update.Expression)
+		exprs = append(exprs, *item.Update)
+	}
+	if item.Condition.Expression != "" {
+		condition = aws.String(item.Condition.Expression)
+	}
+	names, values, err := buildPlaceholders(exprs...)
+	if err != nil {
+		return types.TransactWriteItem{}, err
+	}
+	table := aws.String(item.Table)
+	// Return the current item on condition failure so it appears in the
+	// cancellation reasons
+	onFailure := types.ReturnValuesOnConditionCheckFailureAllOld
+
+	if item.Put != nil {
+		av, err := attributevalue.MarshalMap(item.Put)
+		if err != nil {
+			return types.TransactWriteItem{}, fmt.Errorf("unable to marshal item: %w", err)
+		}
+		return types.TransactWriteItem{Put: &types.Put{
+			TableName:                           table,
+			Item:                                av,
+			ConditionExpression:                 condition,
+			ExpressionAttributeNames:            names,
+			ExpressionAttributeValues:           values,
+			ReturnValuesOnConditionCheckFailure: onFailure,
+		}}, nil
+	}
+
+	key, err := attributevalue.MarshalMap(item.Key)
+	if err != nil {
+		return types.TransactWriteItem{}, fmt.Errorf("unable to marshal key: %w", err)
+	}
+	switch {
+	case item.Update != nil:
+		return types.TransactWriteItem{Update: &types.Update{
+			TableName:                           table,
+			Key:                                 key,
+			UpdateExpression:                    update,
+			ConditionExpression:                 condition,
+			ExpressionAttributeNames:            names,
+			ExpressionAttributeValues:           values,
+			ReturnValuesOnConditionCheckFailure: onFailure,
+		}}, nil
+	case item.Delete:
+		return types.TransactWriteItem{Delete: &types.Delete{
+			TableName:                           table,
+			Key:                                 key,
+			ConditionExpression:                 condition,
+			ExpressionAttributeNames:            names,
+			ExpressionAttributeValues:           values,
+			ReturnValuesOnConditionCheckFailure: onFailure,
+		}}, nil
+	default:
+		return types.TransactWriteItem{ConditionCheck: &types.ConditionCheck{
+			TableName:                           table,
+			Key:                                 key,
+			ConditionExpression:                 condition,
+			ExpressionAttributeNames:            names,
+			ExpressionAttributeValues:           values,
+			ReturnValuesOnConditionCheckFailure: onFailure,
+		}}, nil
+	}
+}
+
+// transactError decodes a TransactionCanceledException into a
+// *TransactionCanceledError, keeping only the reasons that caused the
+// cancellation. Other errors are wrapped with msg.
+func transactError(msg string, err error) error {
+	var cancelled *types.TransactionCanceledException
+	if !errors.As(err, &cancelled) {
+		return fmt.Errorf("%s: %w", msg, err)
+	}
+
+	result := &TransactionCanceledError{err: cancelled}
+	for i, reason := range cancelled.CancellationReasons {
+		code := aws.ToString(reason.Code)
+		if code == "" || code == "None" {
+			continue
+		}
+		r := CancellationReason{Index: i, Code: code, Message: aws.ToString(reason.Message)}
+		if len(reason.Item) > 0 {
+			// The item is informational, so a failure to decode it is ignored
+			_ = attributevalue.UnmarshalMap(reason.Item, &r.Item)
+		}
+		result.Reasons = append(result.Reasons, r)
+	}
+	return result
+}
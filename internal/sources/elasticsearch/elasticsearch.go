@@ -15,8 +15,11 @@
 package elasticsearch
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 
 	"github.com/elastic/elastic-transport-go/v8/elastictransport"
@@ -24,6 +27,7 @@ import (
 	"github.com/elastic/go-elasticsearch/v9/esapi"
 	"github.com/goccy/go-yaml"
 	"github.com/googleapis/genai-toolbox/internal/sources"
+	sourceutil "github.com/googleapis/genai-toolbox/internal/sources/util"
 	"github.com/googleapis/genai-toolbox/internal/util"
 	"go.opentelemetry.io/otel/trace"
 )
@@ -34,9 +38,7 @@ const SourceKind string = "elasticsearch"
 var _ sources.SourceConfig = Config{}
 
 func init() {
-	if !sources.Register(SourceKind, newConfig) {
-		panic(fmt.Sprintf("source kind %q already registered", SourceKind))
-	}
+	sources.MustRegister(SourceKind, newConfig)
 }
 
 func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources.SourceConfig, error) {
@@ -44,16 +46,42 @@ func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources
 	if err := decoder.DecodeContext(ctx, &actual); err != nil {
 		return nil, err
 	}
+
+	for _, secret := range []struct {
+		field string
+		value *string
+		file  string
+	}{
+		{"password", &actual.Password, actual.PasswordFile},
+		{"apikey", &actual.APIKey, actual.APIKeyFile},
+	} {
+		resolved, err := sourceutil.ResolveSecret(secret.field, *secret.value, secret.file)
+		if err != nil {
+			return nil, fmt.Errorf("source %q (%s): unable to resolve secret: %w", name, SourceKind, err)
+		}
+		*secret.value = resolved
+	}
+
+	if actual.APIKey != "" && (actual.Username != "" || actual.Password != "") {
+		return nil, fmt.Errorf("source %q (%s): apikey and username/password cannot both be set", name, SourceKind)
+	}
+	if (actual.Username == "") != (actual.Password == "") {
+		return nil, fmt.Errorf("source %q (%s): username and password must both be set", name, SourceKind)
+	}
 	return actual, nil
 }
 
 type Config struct {
-	Name      string   `yaml:"name" validate:"required"`
-	Kind      string   `yaml:"kind" validate:"required"`
-	Addresses []string `yaml:"addresses" validate:"required"`
-	Username  string   `yaml:"username"`
-	Password  string   `yaml:"password"`
-	APIKey    string   `yaml:"apikey"`
+	Name         string   `yaml:"name" validate:"required"`
+	Kind         string   `yaml:"kind" validate:"required"`
+	Addresses    []string `yaml:"addresses" validate:"required"`
+	Username     string   `yaml:"username"`
+	Password     string   `yaml:"password"`
+	PasswordFile string   `yaml:"passwordFile"`
+	APIKey       string   `yaml:"apikey"`
+	APIKeyFile   string   `yaml:"apikeyFile"`
+	TLSCAFile    string   `yaml:"tlsCAFile"` // Optional: PEM CA bundle used to verify the cluster's certificate
+	Index        string   `yaml:"index"`     // Optional: default index for Search and Count
 }
 
 func (c Config) SourceConfigKind() string {
@@ -111,6 +139,18 @@ func (c Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.So
 		return nil, fmt.Errorf("elasticsearch source %q requires either username/password or an API key", c.Name)
 	}
 
+	if c.TLSCAFile != "" {
+		tlsConfig, err := sourceutil.LoadTLSConfig(c.TLSCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("source %q (%s): unable to load TLS config: %w", c.Name, SourceKind, err)
+		}
+		// Clone the default transport so proxy settings, timeouts and HTTP/2
+		// are kept
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.TLSClientConfig = tlsConfig
+		cfg.Transport = transport
+	}
+
 	client, err := elasticsearch.NewBaseClient(cfg)
 	if err != nil {
 		return nil, err
@@ -146,6 +186,107 @@ func (s *Source) ToConfig() sources.SourceConfig {
 	return s.Config
 }
 
+// HealthCheck requests cluster info, as Initialize does.
+func (s *Source) HealthCheck(ctx context.Context) error {
+	res, err := esapi.InfoRequest{
+		Instrument: s.Client.InstrumentationEnabled(),
+	}.Do(ctx, s.Client)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return fmt.Errorf("elasticsearch connection failed: status %d", res.StatusCode)
+	}
+	return nil
+}
+
 func (s *Source) ElasticsearchClient() EsClient {
 	return s.Client
 }
+
+// Search runs a search request against index and returns the raw response.
+// query is the request body, e.g. {"query": {"match": {"title": "toolbox"}}};
+// a nil query matches all documents. An empty index means the configured
+// default index.
+func (s *Source) Search(ctx context.Context, index string, query map[string]any) (json.RawMessage, error) {
+	index, body, err := s.requestArgs(index, query)
+	if err != nil {
+		return nil, err
+	}
+	res, err := esapi.SearchRequest{
+		Index:      []string{index},
+		Body:       body,
+		Instrument: s.Client.InstrumentationEnabled(),
+	}.Do(ctx, s.Client)
+	if err != nil {
+		return nil, fmt.Errorf("unable to search index %q: %w", index, sources.ClassifyError(err))
+	}
+	resp, err := readResponse(res)
+	if err != nil {
+		return nil, fmt.Errorf("unable to search index %q: %w", index, err)
+	}
+	return json.RawMessage(resp), nil
+}
+
+// Count returns the number of documents in index that match query. A nil query
+// counts all documents. An empty index means the configured default index.
+func (s *Source) Count(ctx context.Context, index string, query map[string]any) (int64, error) {
+	index, body, err := s.requestArgs(index, query)
+	if err != nil {
+		return 0, err
+	}
+	res, err := esapi.CountRequest{
+		Index:      []string{index},
+		Body:       body,
+		Instrument: s.Client.InstrumentationEnabled(),
+	}.Do(ctx, s.Client)
+	if err != nil {
+		return 0, fmt.Errorf("unable to count documents in index %q: %w", index, sources.ClassifyError(err))
+	}
+	resp, err := readResponse(res)
+	if err != nil {
+		return 0, fmt.Errorf("unable to count documents in index %q: %w", index, err)
+	}
+
+	var out struct {
+		Count int64 `json:"count"`
+	}
+	if err := json.Unmarshal(resp, &out); err != nil {
+		return 0, fmt.Errorf("failed to decode count response: %w", err)
+	}
+	return out.Count, nil
+}
+
+// requestArgs resolves the default index and encodes query as a request body.
+func (s *Source) requestArgs(index string, query map[string]any) (string, io.Reader, error) {
+	if index == "" {
+		index = s.Index
+	}
+	if index == "" {
+		return "", nil, fmt.Errorf("no index specified and no default index configured")
+	}
+	if query == nil {
+		return index, nil, nil
+	}
+	body, err := json.Marshal(query)
+	if err != nil {
+		return "", nil, fmt.Errorf("unable to encode query: %w", err)
+	}
+	return index, bytes.NewReader(body), nil
+}
+
+// readResponse reads and closes the body of res, returning an error for
+// non-2xx responses.
+func readResponse(res *esapi.Response) ([]byte, error) {
+	defer res.Body.Close()
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	if res.IsError() {
+		return nil, sources.NewStatusError(res.StatusCode, "request failed with status %d: %s", res.StatusCode, string(body))
+	}
+	return body, nil
+}
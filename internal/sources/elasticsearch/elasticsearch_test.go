@@ -15,12 +15,21 @@
 package elasticsearch_test
 
 import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
 	yaml "github.com/goccy/go-yaml"
 	"github.com/google/go-cmp/cmp"
 	"github.com/googleapis/genai-toolbox/internal/server"
 	"github.com/googleapis/genai-toolbox/internal/sources/elasticsearch"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/util"
+	"go.opentelemetry.io/otel/trace/noop"
 )
 
 func TestParseFromYamlElasticsearch(t *testing.T) {
@@ -48,6 +57,31 @@ func TestParseFromYamlElasticsearch(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc: "basic auth with CA file and default index",
+			in: `
+            sources:
+              my-es-instance:
+                kind: elasticsearch
+                addresses:
+                  - https://es.internal:9200
+                username: elastic
+                password: changeme
+                tlsCAFile: /etc/ssl/es-ca.pem
+                index: logs-*
+            `,
+			want: server.SourceConfigs{
+				"my-es-instance": elasticsearch.Config{
+					Name:      "my-es-instance",
+					Kind:      elasticsearch.SourceKind,
+					Addresses: []string{"https://es.internal:9200"},
+					Username:  "elastic",
+					Password:  "changeme",
+					TLSCAFile: "/etc/ssl/es-ca.pem",
+					Index:     "logs-*",
+				},
+			},
+		},
 	}
 	for _, tc := range tcs {
 		t.Run(tc.desc, func(t *testing.T) {
@@ -64,3 +98,151 @@ func TestParseFromYamlElasticsearch(t *testing.T) {
 		})
 	}
 }
+
+func TestFailParseFromYamlElasticsearch(t *testing.T) {
+	tcs := []struct {
+		desc string
+		in   string
+		err  string
+	}{
+		{
+			desc: "api key and basic auth",
+			in: `
+			sources:
+				my-es-instance:
+					kind: elasticsearch
+					addresses:
+						- http://localhost:9200
+					apikey: somekey
+					username: elastic
+					password: changeme
+			`,
+			err: "apikey and username/password cannot both be set",
+		},
+		{
+			desc: "username without password",
+			in: `
+			sources:
+				my-es-instance:
+					kind: elasticsearch
+					addresses:
+						- http://localhost:9200
+					username: elastic
+			`,
+			err: "username and password must both be set",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Sources server.SourceConfigs `yaml:"sources"`
+			}{}
+			err := yaml.Unmarshal(testutils.FormatYaml(tc.in), &got)
+			if err == nil {
+				t.Fatalf("expect parsing to fail")
+			}
+			if !strings.Contains(err.Error(), tc.err) {
+				t.Fatalf("unexpected error: got %q, want substring %q", err.Error(), tc.err)
+			}
+		})
+	}
+}
+
+// newTestSource initializes a source against a fake cluster that serves
+// handler for the logs index.
+func newTestSource(t *testing.T, handler http.HandlerFunc) *elasticsearch.Source {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Elastic-Product", "Elasticsearch")
+		_, _ = w.Write([]byte(`{"version":{"number":"8.15.0"}}`))
+	})
+	mux.HandleFunc("/logs/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Elastic-Product", "Elasticsearch")
+		w.Header().Set("Content-Type", "application/json")
+		handler(w, r)
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	cfg := elasticsearch.Config{
+		Name:      "test",
+		Kind:      elasticsearch.SourceKind,
+		Addresses: []string{server.URL},
+		APIKey:    "somekey",
+		Index:     "logs",
+	}
+	ctx := util.WithUserAgent(context.Background(), "test")
+	src, err := cfg.Initialize(ctx, noop.NewTracerProvider().Tracer("test"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return src.(*elasticsearch.Source)
+}
+
+func TestSearch(t *testing.T) {
+	var gotPath, gotAuth string
+	var gotBody map[string]any
+	s := newTestSource(t, func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotAuth = r.Header.Get("Authorization")
+		body, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(body, &gotBody)
+		_, _ = w.Write([]byte(`{"hits":{"total":{"value":1},"hits":[{"_id":"1"}]}}`))
+	})
+
+	query := map[string]any{"query": map[string]any{"match": map[string]any{"level": "error"}}}
+	resp, err := s.Search(context.Background(), "", query)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gotPath != "/logs/_search" {
+		t.Errorf("path = %q, want /logs/_search", gotPath)
+	}
+	if gotAuth != "ApiKey somekey" {
+		t.Errorf("Authorization = %q, want ApiKey somekey", gotAuth)
+	}
+	if diff := cmp.Diff(query, gotBody); diff != "" {
+		t.Errorf("unexpected request body (-want +got):\n%s", diff)
+	}
+	if !strings.Contains(string(resp), `"_id":"1"`) {
+		t.Errorf("unexpected response: %s", resp)
+	}
+}
+
+func TestCount(t *testing.T) {
+	var gotPath string
+	s := newTestSource(t, func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		_, _ = w.Write([]byte(`{"count":42,"_shards":{"total":1,"successful":1}}`))
+	})
+
+	count, err := s.Count(context.Background(), "logs", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gotPath != "/logs/_count" {
+		t.Errorf("path = %q, want /logs/_count", gotPath)
+	}
+	if count != 42 {
+		t.Errorf("count = %d, want 42", count)
+	}
+}
+
+func TestSearchErrors(t *testing.T) {
+	s := newTestSource(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error":{"type":"parsing_exception"},"status":400}`))
+	})
+
+	_, err := s.Search(context.Background(), "logs", map[string]any{"query": "bad"})
+	if err == nil || !strings.Contains(err.Error(), "parsing_exception") {
+		t.Fatalf("expected parsing_exception error, got %v", err)
+	}
+
+	s.Index = ""
+	_, err = s.Count(context.Background(), "", nil)
+	if err == nil || !strings.Contains(err.Error(), "no index specified") {
+		t.Fatalf("expected missing index error, got %v", err)
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sources
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+)
+
+// Error kinds returned by source query methods. Use errors.Is to check
+// whether a source error is of a kind, e.g. to retry throttled requests.
+var (
+	ErrAuth      = errors.New("authentication failed")
+	ErrNotFound  = errors.New("not found")
+	ErrThrottled = errors.New("request throttled")
+	ErrTimeout   = errors.New("request timed out")
+)
+
+// kindError tags err with an error kind without changing its message.
+type kindError struct {
+	kind error
+	err  error
+}
+
+func (e *kindError) Error() string {
+	return e.err.Error()
+}
+
+func (e *kindError) Unwrap() []error {
+	return []error{e.kind, e.err}
+}
+
+// WithKind returns err tagged so that errors.Is(err, kind) is true. The
+// message is unchanged and err stays reachable with errors.Is and errors.As.
+// It returns err unchanged if err or kind is nil.
+func WithKind(err, kind error) error {
+	if err == nil || kind == nil {
+		return err
+	}
+	return &kindError{kind: kind, err: err}
+}
+
+// KindForStatus returns the error kind for an HTTP status code, or nil if the
+// status doesn't map to one.
+func KindForStatus(statusCode int) error {
+	switch statusCode {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return ErrAuth
+	case http.StatusNotFound:
+		return ErrNotFound
+	case http.StatusTooManyRequests:
+		return ErrThrottled
+	case http.StatusRequestTimeout, http.StatusGatewayTimeout:
+		return ErrTimeout
+	}
+	return nil
+}
+
+// NewStatusError formats an error for an HTTP response with statusCode and
+// tags it with the kind for the status.
+func NewStatusError(statusCode int, format string, args ...any) error {
+	return WithKind(fmt.Errorf(format, args...), KindForStatus(statusCode))
+}
+
+// ClassifyError tags a request error with ErrTimeout if it is a context
+// deadline or network timeout. Other errors are returned unchanged.
+func ClassifyError(err error) error {
+	if err == nil || errors.Is(err, ErrTimeout) {
+		return err
+	}
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+		return WithKind(err, ErrTimeout)
+	}
+	return err
+}
@@ -0,0 +1,99 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sources_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/googleapis/genai-toolbox/internal/sources"
+)
+
+func TestNewStatusError(t *testing.T) {
+	tcs := []struct {
+		status int
+		want   error
+	}{
+		{status: http.StatusUnauthorized, want: sources.ErrAuth},
+		{status: http.StatusForbidden, want: sources.ErrAuth},
+		{status: http.StatusNotFound, want: sources.ErrNotFound},
+		{status: http.StatusTooManyRequests, want: sources.ErrThrottled},
+		{status: http.StatusGatewayTimeout, want: sources.ErrTimeout},
+		{status: http.StatusBadRequest},
+	}
+	kinds := []error{sources.ErrAuth, sources.ErrNotFound, sources.ErrThrottled, sources.ErrTimeout}
+	for _, tc := range tcs {
+		t.Run(http.StatusText(tc.status), func(t *testing.T) {
+			err := sources.NewStatusError(tc.status, "request failed with status %d: %s", tc.status, "body")
+			if want := fmt.Sprintf("request failed with status %d: body", tc.status); err.Error() != want {
+				t.Errorf("got message %q, want %q", err, want)
+			}
+			for _, kind := range kinds {
+				if got := errors.Is(err, kind); got != (kind == tc.want) {
+					t.Errorf("errors.Is(err, %q) = %t", kind, got)
+				}
+			}
+		})
+	}
+}
+
+func TestWithKind(t *testing.T) {
+	if sources.WithKind(nil, sources.ErrAuth) != nil {
+		t.Error("expected nil for a nil error")
+	}
+	base := io.ErrUnexpectedEOF
+	if got := sources.WithKind(base, nil); got != base {
+		t.Errorf("expected the error unchanged for a nil kind, got %v", got)
+	}
+
+	err := fmt.Errorf("query failed: %w", sources.WithKind(base, sources.ErrThrottled))
+	if !errors.Is(err, sources.ErrThrottled) || !errors.Is(err, base) {
+		t.Errorf("expected %v to match both the kind and the wrapped error", err)
+	}
+	if err.Error() != "query failed: unexpected EOF" {
+		t.Errorf("unexpected message: %q", err)
+	}
+}
+
+func TestClassifyError(t *testing.T) {
+	if sources.ClassifyError(nil) != nil {
+		t.Error("expected nil for a nil error")
+	}
+	if err := sources.ClassifyError(io.EOF); errors.Is(err, sources.ErrTimeout) {
+		t.Errorf("unexpected timeout kind for %v", err)
+	}
+	if err := sources.ClassifyError(context.DeadlineExceeded); !errors.Is(err, sources.ErrTimeout) {
+		t.Errorf("expected timeout kind for %v", err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(100 * time.Millisecond)
+	}))
+	defer server.Close()
+	client := &http.Client{Timeout: 10 * time.Millisecond}
+	_, err := client.Get(server.URL)
+	if err == nil {
+		t.Fatal("expected client timeout")
+	}
+	if err := sources.ClassifyError(err); !errors.Is(err, sources.ErrTimeout) {
+		t.Errorf("expected timeout kind for %v", err)
+	}
+}
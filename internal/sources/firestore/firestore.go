@@ -24,6 +24,7 @@ import (
 	"github.com/googleapis/genai-toolbox/internal/util"
 	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/api/firebaserules/v1"
+	"google.golang.org/api/iterator"
 	"google.golang.org/api/option"
 )
 
@@ -33,9 +34,7 @@ const SourceKind string = "firestore"
 var _ sources.SourceConfig = Config{}
 
 func init() {
-	if !sources.Register(SourceKind, newConfig) {
-		panic(fmt.Sprintf("source kind %q already registered", SourceKind))
-	}
+	sources.MustRegister(SourceKind, newConfig)
 }
 
 func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources.SourceConfig, error) {
@@ -97,6 +96,15 @@ func (s *Source) ToConfig() sources.SourceConfig {
 	return s.Config
 }
 
+// HealthCheck lists a top-level collection in the database.
+func (s *Source) HealthCheck(ctx context.Context) error {
+	_, err := s.Client.Collections(ctx).Next()
+	if err != nil && err != iterator.Done {
+		return err
+	}
+	return nil
+}
+
 func (s *Source) FirestoreClient() *firestore.Client {
 	return s.Client
 }
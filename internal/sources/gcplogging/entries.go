@@ -0,0 +1,118 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcplogging
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	logging "google.golang.org/api/logging/v2"
+)
+
+// MaxPageSize is the largest page the Logging API returns for entries.list.
+const MaxPageSize = 1000
+
+// LogEntry is a single log entry, with its payload decoded.
+type LogEntry struct {
+	InsertID       string
+	LogName        string
+	Timestamp      time.Time
+	Severity       string
+	ResourceType   string
+	ResourceLabels map[string]string
+	Labels         map[string]string
+	Trace          string
+	SpanID         string
+	TextPayload    string         // Set for plain-text entries
+	JSONPayload    map[string]any // Set for structured entries
+	ProtoPayload   map[string]any // Set for entries such as audit logs
+}
+
+// ListEntries returns up to limit entries from the project, newest first,
+// that match filter. The filter uses the advanced logs query syntax, e.g.
+// `severity>=ERROR AND resource.type="k8s_container"`; an empty filter matches
+// every entry. Without a timestamp restriction the API only searches the last
+// 24 hours.
+func (s *Source) ListEntries(ctx context.Context, filter string, limit int) ([]LogEntry, error) {
+	if limit <= 0 {
+		return nil, fmt.Errorf("limit must be positive")
+	}
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	req := &logging.ListLogEntriesRequest{
+		ResourceNames: []string{s.parent()},
+		Filter:        filter,
+		OrderBy:       "timestamp desc",
+	}
+	var entries []LogEntry
+	for {
+		req.PageSize = int64(min(limit-len(entries), MaxPageSize))
+		resp, err := s.Service.Entries.List(req).Context(ctx).Do()
+		if err != nil {
+			return nil, fmt.Errorf("unable to list log entries: %w", err)
+		}
+		for _, e := range resp.Entries {
+			entry, err := toLogEntry(e)
+			if err != nil {
+				return nil, err
+			}
+			entries = append(entries, entry)
+			if len(entries) == limit {
+				return entries, nil
+			}
+		}
+		if resp.NextPageToken == "" {
+			return entries, nil
+		}
+		req.PageToken = resp.NextPageToken
+	}
+}
+
+func toLogEntry(e *logging.LogEntry) (LogEntry, error) {
+	entry := LogEntry{
+		InsertID:    e.InsertId,
+		LogName:     e.LogName,
+		Severity:    e.Severity,
+		Labels:      e.Labels,
+		Trace:       e.Trace,
+		SpanID:      e.SpanId,
+		TextPayload: e.TextPayload,
+	}
+	if e.Timestamp != "" {
+		ts, err := time.Parse(time.RFC3339Nano, e.Timestamp)
+		if err != nil {
+			return LogEntry{}, fmt.Errorf("entry %q: invalid timestamp %q: %w", e.InsertId, e.Timestamp, err)
+		}
+		entry.Timestamp = ts
+	}
+	if e.Resource != nil {
+		entry.ResourceType = e.Resource.Type
+		entry.ResourceLabels = e.Resource.Labels
+	}
+	if len(e.JsonPayload) > 0 {
+		if err := json.Unmarshal(e.JsonPayload, &entry.JSONPayload); err != nil {
+			return LogEntry{}, fmt.Errorf("entry %q: unable to decode JSON payload: %w", e.InsertId, err)
+		}
+	}
+	if len(e.ProtoPayload) > 0 {
+		if err := json.Unmarshal(e.ProtoPayload, &entry.ProtoPayload); err != nil {
+			return LogEntry{}, fmt.Errorf("entry %q: unable to decode proto payload: %w", e.InsertId, err)
+		}
+	}
+	return entry, nil
+}
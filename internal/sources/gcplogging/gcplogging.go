@@ -0,0 +1,156 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package gcplogging provides a source implementation for Google Cloud
+// Logging. It reads log entries with the Logging API's advanced filter syntax.
+package gcplogging
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/util"
+	"go.opentelemetry.io/otel/trace"
+	logging "google.golang.org/api/logging/v2"
+	"google.golang.org/api/option"
+)
+
+const SourceKind string = "gcplogging"
+
+const (
+	DefaultTimeout = 30 // Default request timeout in seconds
+)
+
+// validate interface
+var _ sources.SourceConfig = Config{}
+
+func init() {
+	sources.MustRegister(SourceKind, newConfig)
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources.SourceConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	if actual.Timeout < 0 {
+		return nil, fmt.Errorf("source %q (%s): timeout must not be negative", name, SourceKind)
+	}
+	return actual, nil
+}
+
+type Config struct {
+	Name            string `yaml:"name" validate:"required"`
+	Kind            string `yaml:"kind" validate:"required"`
+	Project         string `yaml:"project" validate:"required"` // Project whose logs are read
+	CredentialsFile string `yaml:"credentialsFile"`             // Optional: service account JSON key (default: Application Default Credentials)
+	Timeout         int    `yaml:"timeout"`                     // Optional: request timeout in seconds (default: 30)
+	Endpoint        string `yaml:"endpoint"`                    // Optional: override the Logging API endpoint, e.g. for testing
+}
+
+func (r Config) SourceConfigKind() string {
+	return SourceKind
+}
+
+func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.Source, error) {
+	service, err := initLoggingService(ctx, tracer, r)
+	if err != nil {
+		return nil, fmt.Errorf("source %q (%s): unable to create Logging client: %w", r.Name, SourceKind, err)
+	}
+
+	timeout := r.Timeout
+	if timeout == 0 {
+		timeout = DefaultTimeout
+	}
+	s := &Source{
+		Config:  r,
+		Service: service,
+		timeout: time.Duration(timeout) * time.Second,
+	}
+	if err := s.HealthCheck(ctx); err != nil {
+		return nil, fmt.Errorf("source %q (%s): unable to connect successfully: %w", r.Name, SourceKind, err)
+	}
+	return s, nil
+}
+
+var _ sources.Source = &Source{}
+
+type Source struct {
+	Config
+	Service *logging.Service
+	timeout time.Duration
+}
+
+func (s *Source) SourceKind() string {
+	return SourceKind
+}
+
+func (s *Source) ToConfig() sources.SourceConfig {
+	return s.Config
+}
+
+// HealthCheck lists at most one log name in the project, which requires the
+// same read permission as listing entries.
+func (s *Source) HealthCheck(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+	_, err := s.Service.Projects.Logs.List(s.parent()).PageSize(1).Context(ctx).Do()
+	return err
+}
+
+// LoggingService returns the underlying Logging API client for direct access.
+func (s *Source) LoggingService() *logging.Service {
+	return s.Service
+}
+
+// Close is a no-op; the Logging API client holds no resources that need
+// releasing.
+func (s *Source) Close() error {
+	return nil
+}
+
+// parent returns the resource name of the configured project.
+func (s *Source) parent() string {
+	return "projects/" + s.Project
+}
+
+func initLoggingService(ctx context.Context, tracer trace.Tracer, r Config) (*logging.Service, error) {
+	ctx, span := sources.InitConnectionSpan(ctx, tracer, SourceKind, r.Name)
+	defer span.End()
+
+	userAgent, err := util.UserAgentFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := []option.ClientOption{
+		option.WithUserAgent(userAgent),
+		option.WithScopes(logging.LoggingReadScope),
+	}
+	if r.CredentialsFile != "" {
+		opts = append(opts, option.WithCredentialsFile(r.CredentialsFile))
+	}
+	if r.Endpoint != "" {
+		opts = append(opts, option.WithEndpoint(r.Endpoint))
+		// A custom endpoint is usually a local fake with no credentials
+		if r.CredentialsFile == "" {
+			opts = append(opts, option.WithoutAuthentication())
+		}
+	}
+
+	return logging.NewService(ctx, opts...)
+}
@@ -0,0 +1,169 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcplogging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace/noop"
+	logging "google.golang.org/api/logging/v2"
+)
+
+func TestParseFromYamlGCPLogging(t *testing.T) {
+	tests := []struct {
+		name        string
+		yamlContent string
+		expected    Config
+	}{
+		{
+			name: "basic configuration",
+			yamlContent: `name: test-logging
+kind: gcplogging
+project: my-project`,
+			expected: Config{
+				Name:    "test-logging",
+				Kind:    "gcplogging",
+				Project: "my-project",
+			},
+		},
+		{
+			name: "credentials file and timeout",
+			yamlContent: `name: test-logging
+kind: gcplogging
+project: my-project
+credentialsFile: /secrets/sa.json
+timeout: 10`,
+			expected: Config{
+				Name:            "test-logging",
+				Kind:            "gcplogging",
+				Project:         "my-project",
+				CredentialsFile: "/secrets/sa.json",
+				Timeout:         10,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decoder := yaml.NewDecoder(bytes.NewReader([]byte(tt.yamlContent)))
+			config, err := newConfig(context.Background(), "test-logging", decoder)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, config)
+		})
+	}
+}
+
+func TestParseFromYamlGCPLoggingNegativeTimeout(t *testing.T) {
+	decoder := yaml.NewDecoder(bytes.NewReader([]byte(`name: test-logging
+kind: gcplogging
+project: my-project
+timeout: -1`)))
+	_, err := newConfig(context.Background(), "test-logging", decoder)
+	require.ErrorContains(t, err, "timeout must not be negative")
+}
+
+// newTestSource initializes a source against a fake Logging API that serves
+// entries from pages, one page per request.
+func newTestSource(t *testing.T, pages [][]*logging.LogEntry, requests *[]logging.ListLogEntriesRequest) *Source {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v2/projects/my-project/logs", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"logNames":["projects/my-project/logs/app"]}`))
+	})
+	mux.HandleFunc("/v2/entries:list", func(w http.ResponseWriter, r *http.Request) {
+		var req logging.ListLogEntriesRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		*requests = append(*requests, req)
+
+		resp := logging.ListLogEntriesResponse{}
+		page := len(*requests) - 1
+		if page < len(pages) {
+			resp.Entries = pages[page]
+		}
+		if page < len(pages)-1 {
+			resp.NextPageToken = "next"
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp)
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	ctx := util.WithUserAgent(context.Background(), "test")
+	cfg := Config{Name: "test", Kind: SourceKind, Project: "my-project", Endpoint: server.URL + "/"}
+	src, err := cfg.Initialize(ctx, noop.NewTracerProvider().Tracer("test"))
+	require.NoError(t, err)
+	return src.(*Source)
+}
+
+func TestListEntries(t *testing.T) {
+	pages := [][]*logging.LogEntry{
+		{
+			{
+				InsertId:    "a",
+				LogName:     "projects/my-project/logs/app",
+				Timestamp:   "2025-01-02T03:04:05.5Z",
+				Severity:    "ERROR",
+				Resource:    &logging.MonitoredResource{Type: "k8s_container", Labels: map[string]string{"namespace_name": "prod"}},
+				JsonPayload: []byte(`{"message":"boom","code":500}`),
+			},
+		},
+		{
+			{InsertId: "b", Severity: "ERROR", TextPayload: "second"},
+			{InsertId: "c", Severity: "ERROR", TextPayload: "third"},
+		},
+	}
+	var requests []logging.ListLogEntriesRequest
+	s := newTestSource(t, pages, &requests)
+
+	entries, err := s.ListEntries(context.Background(), "severity>=ERROR", 2)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+
+	assert.Equal(t, "a", entries[0].InsertID)
+	assert.Equal(t, time.Date(2025, 1, 2, 3, 4, 5, 500000000, time.UTC), entries[0].Timestamp)
+	assert.Equal(t, "k8s_container", entries[0].ResourceType)
+	assert.Equal(t, "prod", entries[0].ResourceLabels["namespace_name"])
+	assert.Equal(t, map[string]any{"message": "boom", "code": float64(500)}, entries[0].JSONPayload)
+	assert.Equal(t, "second", entries[1].TextPayload)
+
+	require.Len(t, requests, 2)
+	assert.Equal(t, []string{"projects/my-project"}, requests[0].ResourceNames)
+	assert.Equal(t, "severity>=ERROR", requests[0].Filter)
+	assert.Equal(t, "timestamp desc", requests[0].OrderBy)
+	assert.Equal(t, int64(2), requests[0].PageSize)
+	assert.Equal(t, int64(1), requests[1].PageSize)
+	assert.Equal(t, "next", requests[1].PageToken)
+}
+
+func TestListEntriesInvalidLimit(t *testing.T) {
+	s := &Source{Config: Config{Project: "my-project"}}
+	_, err := s.ListEntries(context.Background(), "", 0)
+	require.ErrorContains(t, err, "limit must be positive")
+}
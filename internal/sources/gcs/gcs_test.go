@@ -0,0 +1,124 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcs
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestParseFromYamlGCS(t *testing.T) {
+	tests := []struct {
+		name        string
+		yamlContent string
+		expected    Config
+	}{
+		{
+			name: "basic configuration",
+			yamlContent: `name: test-gcs
+kind: gcs
+bucket: my-bucket`,
+			expected: Config{
+				Name:   "test-gcs",
+				Kind:   "gcs",
+				Bucket: "my-bucket",
+			},
+		},
+		{
+			name: "credentials file and emulator endpoint",
+			yamlContent: `name: test-gcs
+kind: gcs
+bucket: my-bucket
+credentialsFile: /secrets/sa.json
+endpoint: http://localhost:4443/storage/v1/`,
+			expected: Config{
+				Name:            "test-gcs",
+				Kind:            "gcs",
+				Bucket:          "my-bucket",
+				CredentialsFile: "/secrets/sa.json",
+				Endpoint:        "http://localhost:4443/storage/v1/",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decoder := yaml.NewDecoder(bytes.NewReader([]byte(tt.yamlContent)))
+			config, err := newConfig(context.Background(), "test-gcs", decoder)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, config)
+		})
+	}
+}
+
+func TestSourceKindGCS(t *testing.T) {
+	s := &Source{}
+	assert.Equal(t, SourceKind, s.SourceKind())
+	assert.Equal(t, SourceKind, Config{}.SourceConfigKind())
+}
+
+// newTestSource initializes a source against a fake JSON API server for
+// my-bucket, which holds a single object.
+func newTestSource(t *testing.T) *Source {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/storage/v1/b/my-bucket/o", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"kind":"storage#objects","items":[{"name":"logs/a.txt","bucket":"my-bucket","size":"12","updated":"2025-01-02T03:04:05Z","etag":"abc"}]}`))
+	})
+	mux.HandleFunc("/storage/v1/b/my-bucket/o/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error":{"code":404,"message":"No such object"}}`))
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	ctx := util.WithUserAgent(context.Background(), "test")
+	cfg := Config{Name: "test", Kind: SourceKind, Bucket: "my-bucket", Endpoint: server.URL + "/storage/v1/"}
+	src, err := cfg.Initialize(ctx, noop.NewTracerProvider().Tracer("test"))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = src.(*Source).Close() })
+	return src.(*Source)
+}
+
+func TestListObjects(t *testing.T) {
+	s := newTestSource(t)
+
+	objects, err := s.ListObjects(context.Background(), "", "logs/")
+	require.NoError(t, err)
+	require.Len(t, objects, 1)
+	assert.Equal(t, "logs/a.txt", objects[0].Key)
+	assert.Equal(t, int64(12), objects[0].Size)
+	assert.Equal(t, "abc", objects[0].ETag)
+}
+
+func TestHeadObjectNotFound(t *testing.T) {
+	s := newTestSource(t)
+
+	info, ok, err := s.HeadObject(context.Background(), "", "missing.txt")
+	require.NoError(t, err)
+	assert.False(t, ok)
+	assert.Nil(t, info)
+}
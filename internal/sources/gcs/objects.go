@@ -0,0 +1,127 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"google.golang.org/api/iterator"
+)
+
+// ObjectInfo describes an object in a bucket.
+type ObjectInfo struct {
+	Key          string
+	Size         int64
+	LastModified time.Time
+	ETag         string
+}
+
+// ListObjects returns every object in bucket whose name starts with prefix.
+// An empty bucket means the configured default bucket.
+func (s *Source) ListObjects(ctx context.Context, bucket, prefix string) ([]ObjectInfo, error) {
+	bucket = s.bucketOrDefault(bucket)
+
+	objects := []ObjectInfo{}
+	it := s.Client.Bucket(bucket).Objects(ctx, &storage.Query{Prefix: prefix})
+	for {
+		attrs, err := it.Next()
+		if errors.Is(err, iterator.Done) {
+			return objects, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("unable to list objects in %s: %w", bucket, err)
+		}
+		objects = append(objects, objectInfo(attrs))
+	}
+}
+
+// HeadObject returns the metadata of key. It reports false, with no error, if
+// the object doesn't exist. An empty bucket means the configured default
+// bucket.
+func (s *Source) HeadObject(ctx context.Context, bucket, key string) (*ObjectInfo, bool, error) {
+	bucket = s.bucketOrDefault(bucket)
+	attrs, err := s.Client.Bucket(bucket).Object(key).Attrs(ctx)
+	if err != nil {
+		if errors.Is(err, storage.ErrObjectNotExist) {
+			return nil, false, nil
+		}
+		return nil, false, fmt.Errorf("unable to get attributes of gs://%s/%s: %w", bucket, key, err)
+	}
+	info := objectInfo(attrs)
+	return &info, true, nil
+}
+
+// PutOptions controls how PutObject stores an object.
+type PutOptions struct {
+	ContentType string // Optional: MIME type of the object
+	KMSKeyName  string // Optional: Cloud KMS key used to encrypt the object (default: the bucket's key)
+}
+
+// GetObject returns the contents of key. The caller must close the returned
+// reader. An empty bucket means the configured default bucket.
+func (s *Source) GetObject(ctx context.Context, bucket, key string) (io.ReadCloser, error) {
+	bucket = s.bucketOrDefault(bucket)
+	r, err := s.Client.Bucket(bucket).Object(key).NewReader(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get gs://%s/%s: %w", bucket, key, err)
+	}
+	return r, nil
+}
+
+// PutObject writes body to key. An empty bucket means the configured default
+// bucket.
+func (s *Source) PutObject(ctx context.Context, bucket, key string, body io.Reader, opts PutOptions) error {
+	bucket = s.bucketOrDefault(bucket)
+
+	// Cancelling the context aborts the upload if copying fails
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	w := s.Client.Bucket(bucket).Object(key).NewWriter(ctx)
+	w.ContentType = opts.ContentType
+	w.KMSKeyName = opts.KMSKeyName
+	if _, err := io.Copy(w, body); err != nil {
+		cancel()
+		_ = w.Close()
+		return fmt.Errorf("unable to put gs://%s/%s: %w", bucket, key, err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("unable to put gs://%s/%s: %w", bucket, key, err)
+	}
+	return nil
+}
+
+// bucketOrDefault returns bucket, or the configured default bucket if bucket
+// is empty.
+func (s *Source) bucketOrDefault(bucket string) string {
+	if bucket != "" {
+		return bucket
+	}
+	return s.Bucket
+}
+
+func objectInfo(attrs *storage.ObjectAttrs) ObjectInfo {
+	return ObjectInfo{
+		Key:          attrs.Name,
+		Size:         attrs.Size,
+		LastModified: attrs.Updated,
+		ETag:         attrs.Etag,
+	}
+}
@@ -21,14 +21,18 @@ package honeycomb
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/goccy/go-yaml"
 	"github.com/googleapis/genai-toolbox/internal/sources"
+	sourceutil "github.com/googleapis/genai-toolbox/internal/sources/util"
 	"go.opentelemetry.io/otel/trace"
 )
 
@@ -36,20 +40,24 @@ const SourceKind string = "honeycomb"
 
 // Default configuration constants
 const (
-	DefaultBaseURL      = "https://api.honeycomb.io" // Default Honeycomb API base URL
-	DefaultTimeout      = 30                         // Default request timeout in seconds
-	DefaultMaxRetries   = 3                          // Default number of retries for failed requests
-	DefaultMaxAttempts  = 10                         // Default max attempts for polling query results
-	MaxBackoffSeconds   = 10                         // Maximum backoff time for exponential backoff
+	DefaultBaseURL     = "https://api.honeycomb.io" // Default Honeycomb API base URL
+	DefaultTimeout     = 30                         // Default request timeout in seconds
+	DefaultMaxRetries  = 3                          // Default number of retries for failed requests
+	DefaultMaxAttempts = 10                         // Default max attempts for polling query results
+	MaxBackoffSeconds  = 10                         // Maximum backoff time for exponential backoff
+	MaxBatchBytes      = 5000000                    // Maximum uncompressed body size of a batch event request
+
+	DefaultMaxIdleConns        = 100              // Default maximum idle connections in pool
+	DefaultMaxIdleConnsPerHost = 10               // Default maximum idle connections per host
+	IdleConnTimeout            = 90 * time.Second // Idle connection timeout
+	TLSHandshakeTimeout        = 10 * time.Second // TLS handshake timeout
 )
 
 // validate interface
 var _ sources.SourceConfig = Config{}
 
 func init() {
-	if !sources.Register(SourceKind, newConfig) {
-		panic(fmt.Sprintf("source kind %q already registered", SourceKind))
-	}
+	sources.MustRegister(SourceKind, newConfig)
 }
 
 func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources.SourceConfig, error) {
@@ -57,18 +65,40 @@ func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources
 	if err := decoder.DecodeContext(ctx, &actual); err != nil {
 		return nil, err
 	}
+
+	apiKey, err := sourceutil.ResolveSecret("apiKey", actual.APIKey, actual.APIKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("source %q (%s): unable to resolve secret: %w", name, SourceKind, err)
+	}
+	actual.APIKey = apiKey
+	if actual.APIKey == "" {
+		return nil, fmt.Errorf("source %q (%s): apiKey or apiKeyFile is required", name, SourceKind)
+	}
+
+	if err := sourceutil.ValidateRateLimit(actual.RequestsPerSecond, actual.Burst); err != nil {
+		return nil, fmt.Errorf("source %q (%s): %w", name, SourceKind, err)
+	}
+	if actual.MaxIdleConns < 0 || actual.MaxIdleConnsPerHost < 0 {
+		return nil, fmt.Errorf("source %q (%s): maxIdleConns and maxIdleConnsPerHost must not be negative", name, SourceKind)
+	}
 	return actual, nil
 }
 
 // Config represents the configuration for a Honeycomb source.
 type Config struct {
-	Name        string `yaml:"name" validate:"required"`
-	Kind        string `yaml:"kind" validate:"required"`
-	APIKey      string `yaml:"apiKey" validate:"required"`      // Honeycomb API key for authentication
-	Dataset     string `yaml:"dataset"`                         // Optional: default dataset
-	Environment string `yaml:"environment"`                     // Optional: environment name
-	BaseURL     string `yaml:"baseUrl"`                         // Optional: base URL (default: https://api.honeycomb.io)
-	Timeout     int    `yaml:"timeout"`                         // Optional: request timeout in seconds (default: 30)
+	Name                string  `yaml:"name" validate:"required"`
+	Kind                string  `yaml:"kind" validate:"required"`
+	APIKey              string  `yaml:"apiKey"`              // Required unless apiKeyFile is set: Honeycomb API key for authentication
+	APIKeyFile          string  `yaml:"apiKeyFile"`          // Optional: file to read apiKey from
+	Dataset             string  `yaml:"dataset"`             // Optional: default dataset
+	ExpectedEnvironment string  `yaml:"environment"`         // Optional: environment the API key must belong to; checked at startup only
+	BaseURL             string  `yaml:"baseUrl"`             // Optional: base URL (default: https://api.honeycomb.io)
+	Timeout             int     `yaml:"timeout"`             // Optional: request timeout in seconds (default: 30)
+	TLSCAFile           string  `yaml:"tlsCAFile"`           // Optional: path to CA certificates used to verify the server
+	RequestsPerSecond   float64 `yaml:"requestsPerSecond"`   // Optional: client-side rate limit, unlimited if unset
+	Burst               int     `yaml:"burst"`               // Optional: requests sent at once before the rate applies, default 1
+	MaxIdleConns        int     `yaml:"maxIdleConns"`        // Optional: maximum idle connections kept open (default: 100)
+	MaxIdleConnsPerHost int     `yaml:"maxIdleConnsPerHost"` // Optional: maximum idle connections kept open to the API host (default: 10)
 }
 
 func (r Config) SourceConfigKind() string {
@@ -76,17 +106,34 @@ func (r Config) SourceConfigKind() string {
 }
 
 func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.Source, error) {
-	client, err := initHoneycombClient(ctx, tracer, r.Name, r.APIKey, r.BaseURL, r.Timeout)
+	tlsConfig, err := sourceutil.LoadTLSConfig(r.TLSCAFile)
+	if err != nil {
+		return nil, fmt.Errorf("source %q (%s): unable to load TLS config: %w", r.Name, SourceKind, err)
+	}
+
+	client, err := initHoneycombClient(ctx, tracer, r.Name, r.APIKey, r.BaseURL, r.Timeout, r.MaxIdleConns, r.MaxIdleConnsPerHost, tlsConfig)
 	if err != nil {
 		return nil, fmt.Errorf("source %q (%s): unable to create Honeycomb client: %w", r.Name, SourceKind, err)
 	}
+	client.HTTPClient.Transport = sourceutil.NewRateLimitedTransport(client.HTTPClient.Transport, r.RequestsPerSecond, r.Burst)
 
-	// Verify the connection by listing datasets
-	_, err = client.ListDatasets(ctx)
+	// Verify the API key; this requires no particular scopes
+	auth, err := client.GetAuth(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("source %q (%s): unable to connect successfully: %w", r.Name, SourceKind, err)
 	}
 
+	// Honeycomb API keys are scoped to a single environment and the API has no
+	// way to select another one per request, so the configured environment is
+	// only checked against the key's
+	if r.ExpectedEnvironment != "" && r.ExpectedEnvironment != auth.Environment.Slug && !strings.EqualFold(r.ExpectedEnvironment, auth.Environment.Name) {
+		return nil, fmt.Errorf("source %q (%s): API key belongs to environment %q, not %q; use an API key created in the %q environment", r.Name, SourceKind, auth.Environment.Slug, r.ExpectedEnvironment, r.ExpectedEnvironment)
+	}
+
+	if !auth.HasAccess("queries") {
+		return nil, fmt.Errorf("source %q (%s): API key for team %q does not have the \"Run Queries\" permission; grant it in the key's settings", r.Name, SourceKind, auth.Team.Slug)
+	}
+
 	s := &Source{
 		Config: r,
 		Client: client,
@@ -110,6 +157,12 @@ func (s *Source) ToConfig() sources.SourceConfig {
 	return s.Config
 }
 
+// HealthCheck verifies the API key is still accepted.
+func (s *Source) HealthCheck(ctx context.Context) error {
+	_, err := s.Client.GetAuth(ctx)
+	return err
+}
+
 // HoneycombClient returns the underlying Honeycomb API client for direct API access.
 func (s *Source) HoneycombClient() *Client {
 	return s.Client
@@ -121,9 +174,7 @@ func (s *Source) Close() error {
 		return nil
 	}
 	if s.Client != nil && s.Client.HTTPClient != nil {
-		if transport, ok := s.Client.HTTPClient.Transport.(*http.Transport); ok {
-			transport.CloseIdleConnections()
-		}
+		s.Client.HTTPClient.CloseIdleConnections()
 	}
 	return nil
 }
@@ -177,23 +228,140 @@ type Order struct {
 
 // Query represents a created Honeycomb query.
 type Query struct {
-	ID          string    `json:"id"`
-	QuerySpec   QuerySpec `json:"query"`
-	Created     string    `json:"created_at"`
-	Updated     string    `json:"updated_at"`
+	ID        string    `json:"id"`
+	QuerySpec QuerySpec `json:"query"`
+	Created   string    `json:"created_at"`
+	Updated   string    `json:"updated_at"`
 }
 
 // QueryResult represents the result of a query execution.
 type QueryResult struct {
-	ID        string                   `json:"id"`
-	QueryID   string                   `json:"query_id"`
-	Complete  bool                     `json:"complete"`
-	Data      []map[string]interface{} `json:"data,omitempty"`
-	Links     map[string]string        `json:"links,omitempty"`
-	Error     string                   `json:"error,omitempty"`
+	ID       string                   `json:"id"`
+	QueryID  string                   `json:"query_id"`
+	Complete bool                     `json:"complete"`
+	Data     []map[string]interface{} `json:"data,omitempty"`
+	Links    map[string]string        `json:"links,omitempty"`
+	Error    string                   `json:"error,omitempty"`
+}
+
+// AuthInfo describes the team, environment, and permissions of an API key.
+type AuthInfo struct {
+	ID           string          `json:"id"`
+	Type         string          `json:"type"`
+	APIKeyAccess map[string]bool `json:"api_key_access"`
+	Environment  AuthScope       `json:"environment"`
+	Team         AuthScope       `json:"team"`
+}
+
+// AuthScope identifies a team or environment.
+type AuthScope struct {
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+}
+
+// HasAccess reports whether the API key was granted the given permission,
+// e.g. "queries", "events", "markers", or "triggers".
+func (a *AuthInfo) HasAccess(permission string) bool {
+	return a.APIKeyAccess[permission]
+}
+
+// Marker represents a Honeycomb marker used to annotate graphs, e.g. for deploys.
+type Marker struct {
+	ID        string `json:"id,omitempty"`
+	Message   string `json:"message,omitempty"`
+	Type      string `json:"type,omitempty"`
+	URL       string `json:"url,omitempty"`
+	StartTime int64  `json:"start_time,omitempty"` // Unix timestamp in seconds
+	EndTime   int64  `json:"end_time,omitempty"`   // Unix timestamp in seconds
+	Color     string `json:"color,omitempty"`
+	Created   string `json:"created_at,omitempty"`
+	Updated   string `json:"updated_at,omitempty"`
 }
 
-func initHoneycombClient(ctx context.Context, tracer trace.Tracer, name, apiKey, baseURL string, timeout int) (*Client, error) {
+// Trigger represents a Honeycomb trigger, which alerts when the result of a
+// query crosses a threshold. Either QueryID or an inline Query may be set.
+type Trigger struct {
+	ID          string             `json:"id,omitempty"`
+	Name        string             `json:"name"`
+	Description string             `json:"description,omitempty"`
+	Disabled    bool               `json:"disabled,omitempty"`
+	QueryID     string             `json:"query_id,omitempty"`
+	Query       *QuerySpec         `json:"query,omitempty"`
+	Threshold   TriggerThreshold   `json:"threshold"`
+	Frequency   int                `json:"frequency,omitempty"` // Evaluation interval in seconds
+	AlertType   string             `json:"alert_type,omitempty"`
+	Recipients  []TriggerRecipient `json:"recipients,omitempty"`
+	Created     string             `json:"created_at,omitempty"`
+	Updated     string             `json:"updated_at,omitempty"`
+}
+
+// TriggerThreshold represents the condition that fires a trigger.
+type TriggerThreshold struct {
+	Op    string  `json:"op"`
+	Value float64 `json:"value"`
+}
+
+// TriggerRecipient represents a notification target for a trigger.
+type TriggerRecipient struct {
+	ID     string `json:"id,omitempty"`
+	Type   string `json:"type,omitempty"`
+	Target string `json:"target,omitempty"`
+}
+
+// Column represents a column of a dataset.
+type Column struct {
+	ID          string `json:"id,omitempty"`
+	KeyName     string `json:"key_name"`
+	Type        string `json:"type"` // string, float, integer, or boolean
+	Description string `json:"description,omitempty"`
+	Hidden      bool   `json:"hidden,omitempty"`
+	LastWritten string `json:"last_written,omitempty"`
+	Created     string `json:"created_at,omitempty"`
+	Updated     string `json:"updated_at,omitempty"`
+}
+
+// DerivedColumn represents a column computed at query time from an
+// expression over other columns, e.g. IF(GTE($duration_ms, 1000), "slow", "fast").
+type DerivedColumn struct {
+	ID          string `json:"id,omitempty"`
+	Alias       string `json:"alias"`
+	Expression  string `json:"expression"`
+	Description string `json:"description,omitempty"`
+	Created     string `json:"created_at,omitempty"`
+	Updated     string `json:"updated_at,omitempty"`
+}
+
+// BatchEvent is a single event as sent to the batch endpoint.
+type BatchEvent struct {
+	Data       map[string]any `json:"data"`
+	Time       string         `json:"time,omitempty"`
+	SampleRate int            `json:"samplerate,omitempty"`
+}
+
+// EventStatus represents the ingestion status of a single event in a batch.
+type EventStatus struct {
+	Status int    `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// BatchResponse represents the per-event statuses returned for a batch, in the
+// same order as the events that were sent.
+type BatchResponse struct {
+	Statuses []EventStatus
+}
+
+// Failed returns the number of events that were not accepted.
+func (r *BatchResponse) Failed() int {
+	failed := 0
+	for _, status := range r.Statuses {
+		if status.Status != http.StatusAccepted {
+			failed++
+		}
+	}
+	return failed
+}
+
+func initHoneycombClient(ctx context.Context, tracer trace.Tracer, name, apiKey, baseURL string, timeout, maxIdleConns, maxIdleConnsPerHost int, tlsConfig *tls.Config) (*Client, error) {
 	//nolint:all // Reassigned ctx
 	ctx, span := sources.InitConnectionSpan(ctx, tracer, SourceKind, name)
 	defer span.End()
@@ -212,11 +380,28 @@ func initHoneycombClient(ctx context.Context, tracer trace.Tracer, name, apiKey,
 		timeout = DefaultTimeout
 	}
 
+	if maxIdleConns == 0 {
+		maxIdleConns = DefaultMaxIdleConns
+	}
+	if maxIdleConnsPerHost == 0 {
+		maxIdleConnsPerHost = DefaultMaxIdleConnsPerHost
+	}
+
+	// Keep connections to the API open across requests so query-heavy
+	// workloads don't pay for a TLS handshake each time
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.MaxIdleConns = maxIdleConns
+	transport.MaxIdleConnsPerHost = maxIdleConnsPerHost
+	transport.IdleConnTimeout = IdleConnTimeout
+	transport.TLSHandshakeTimeout = TLSHandshakeTimeout
+	transport.TLSClientConfig = tlsConfig
+
 	client := &Client{
 		APIKey:  apiKey,
 		BaseURL: baseURL,
 		HTTPClient: &http.Client{
-			Timeout: time.Duration(timeout) * time.Second,
+			Timeout:   time.Duration(timeout) * time.Second,
+			Transport: transport,
 		},
 	}
 
@@ -237,22 +422,26 @@ func (c *Client) doRequest(ctx context.Context, method, path string, body io.Rea
 
 	resp, err := c.HTTPClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", err)
+		return nil, fmt.Errorf("request failed: %w", sources.ClassifyError(err))
 	}
 
 	return resp, nil
 }
 
 // doRequestWithRetry wraps doRequest with retry logic for transient failures.
+// Rate limiting is always retried. Server and transport errors are retried
+// only for idempotent methods, since a POST may have been applied before the
+// failure and resending it would create duplicates. Once retries are exhausted
+// the last response is returned so callers can report its status and body.
 func (c *Client) doRequestWithRetry(ctx context.Context, method, path string, body []byte, maxRetries int) (*http.Response, error) {
 	if maxRetries == 0 {
 		maxRetries = DefaultMaxRetries
 	}
 
-	var lastErr error
 	backoff := time.Second
+	maxBackoff := MaxBackoffSeconds * time.Second
 
-	for attempt := 0; attempt <= maxRetries; attempt++ {
+	for attempt := 0; ; attempt++ {
 		var bodyReader io.Reader
 		if body != nil {
 			bodyReader = bytes.NewReader(body)
@@ -260,36 +449,116 @@ func (c *Client) doRequestWithRetry(ctx context.Context, method, path string, bo
 
 		resp, err := c.doRequest(ctx, method, path, bodyReader)
 
-		// Success or non-retryable error
-		if err == nil && resp.StatusCode < 500 {
+		if !shouldRetry(method, resp, err) {
+			return resp, err
+		}
+
+		if attempt == maxRetries {
+			if err != nil {
+				return nil, fmt.Errorf("request failed after %d attempts: %w", maxRetries+1, err)
+			}
 			return resp, nil
 		}
 
-		// Store error for potential retry
-		if err != nil {
-			lastErr = err
-		} else {
+		wait, serverWait := backoff, false
+		if err == nil {
+			// Honor the server-prescribed wait when rate limited
+			if resp.StatusCode == http.StatusTooManyRequests {
+				wait, serverWait = parseRetryAfter(resp.Header.Get("Retry-After"))
+				if !serverWait {
+					wait = backoff
+				}
+			}
 			resp.Body.Close()
-			lastErr = fmt.Errorf("server error: %d", resp.StatusCode)
 		}
 
-		// Don't sleep on last attempt
-		if attempt < maxRetries {
-			select {
-			case <-ctx.Done():
-				return nil, ctx.Err()
-			case <-time.After(backoff):
-				backoff *= 2 // Exponential backoff
-			}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(wait):
 		}
+
+		// Exponential backoff with max, only advanced when it was actually used
+		if !serverWait {
+			backoff = min(backoff*2, maxBackoff)
+		}
+	}
+}
+
+// shouldRetry reports whether a request that returned resp or err should be
+// sent again.
+func shouldRetry(method string, resp *http.Response, err error) bool {
+	if err == nil && resp.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+	if err == nil && resp.StatusCode < 500 {
+		return false
 	}
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
 
-	return nil, fmt.Errorf("request failed after %d attempts: %w", maxRetries+1, lastErr)
+// parseRetryAfter parses a Retry-After header value, which may be either a
+// number of seconds or an HTTP date. The wait is not capped: retrying before
+// the server allows it would only be throttled again, so callers bound it with
+// their context instead.
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	var d time.Duration
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		d = time.Duration(seconds) * time.Second
+	} else if t, err := http.ParseTime(value); err == nil {
+		d = max(time.Until(t), 0)
+	} else {
+		return 0, false
+	}
+	return d, true
 }
 
-// ListDatasets lists all datasets in the Honeycomb account.
-func (c *Client) ListDatasets(ctx context.Context) ([]Dataset, error) {
-	resp, err := c.doRequest(ctx, "GET", "/1/datasets", nil)
+// doJSON performs a request with retries, encoding reqBody (if any) as JSON and
+// decoding the response into respBody (if non-nil). Any non-2xx status is
+// returned as an error.
+func (c *Client) doJSON(ctx context.Context, method, path string, reqBody, respBody any) error {
+	var bodyBytes []byte
+	if reqBody != nil {
+		var err error
+		bodyBytes, err = json.Marshal(reqBody)
+		if err != nil {
+			return fmt.Errorf("failed to marshal request: %w", err)
+		}
+	}
+
+	resp, err := c.doRequestWithRetry(ctx, method, path, bodyBytes, 0)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(resp.Body)
+		return sources.NewStatusError(resp.StatusCode, "API request failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	if respBody != nil {
+		if err := json.NewDecoder(resp.Body).Decode(respBody); err != nil {
+			return fmt.Errorf("failed to decode response: %w", err)
+		}
+	}
+	return nil
+}
+
+// GetAuth validates the API key and returns its team, environment, and granted permissions.
+func (c *Client) GetAuth(ctx context.Context) (*AuthInfo, error) {
+	resp, err := c.doRequest(ctx, "GET", "/1/auth", nil)
 	if err != nil {
 		return nil, err
 	}
@@ -297,41 +566,47 @@ func (c *Client) ListDatasets(ctx context.Context) ([]Dataset, error) {
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+		return nil, sources.NewStatusError(resp.StatusCode, "API request failed with status %d: %s", resp.StatusCode, string(body))
 	}
 
-	var datasets []Dataset
-	if err := json.NewDecoder(resp.Body).Decode(&datasets); err != nil {
+	var auth AuthInfo
+	if err := json.NewDecoder(resp.Body).Decode(&auth); err != nil {
 		return nil, fmt.Errorf("failed to decode response: %w", err)
 	}
 
+	return &auth, nil
+}
+
+// ListDatasets lists all datasets in the Honeycomb account.
+func (c *Client) ListDatasets(ctx context.Context) ([]Dataset, error) {
+	var datasets []Dataset
+	if err := c.doJSON(ctx, "GET", "/1/datasets", nil, &datasets); err != nil {
+		return nil, err
+	}
 	return datasets, nil
 }
 
 // CreateQuery creates a query in the specified dataset.
 func (c *Client) CreateQuery(ctx context.Context, dataset string, spec QuerySpec) (*Query, error) {
-	bodyBytes, err := json.Marshal(spec)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal query spec: %w", err)
-	}
-
+	var query Query
 	path := fmt.Sprintf("/1/queries/%s", dataset)
-	resp, err := c.doRequest(ctx, "POST", path, bytes.NewReader(bodyBytes))
-	if err != nil {
+	if err := c.doJSON(ctx, "POST", path, spec, &query); err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
+	return &query, nil
+}
 
-	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+// GetQuery retrieves a previously created query by ID.
+func (c *Client) GetQuery(ctx context.Context, dataset, queryID string) (*Query, error) {
+	if queryID == "" {
+		return nil, fmt.Errorf("query ID is required")
 	}
 
 	var query Query
-	if err := json.NewDecoder(resp.Body).Decode(&query); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+	path := fmt.Sprintf("/1/queries/%s/%s", dataset, queryID)
+	if err := c.doJSON(ctx, "GET", path, nil, &query); err != nil {
+		return nil, err
 	}
-
 	return &query, nil
 }
 
@@ -343,50 +618,21 @@ func (c *Client) ExecuteQuery(ctx context.Context, dataset, queryID string) (*Qu
 		"disable_series": false,
 	}
 
-	bodyBytes, err := json.Marshal(requestBody)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request: %w", err)
-	}
-
+	var result QueryResult
 	path := fmt.Sprintf("/1/query_results/%s", dataset)
-	resp, err := c.doRequest(ctx, "POST", path, bytes.NewReader(bodyBytes))
-	if err != nil {
+	if err := c.doJSON(ctx, "POST", path, requestBody, &result); err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
-	}
-
-	var result QueryResult
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
-	}
-
 	return &result, nil
 }
 
 // GetQueryResult retrieves the result of a query execution.
 func (c *Client) GetQueryResult(ctx context.Context, dataset, resultID string) (*QueryResult, error) {
+	var result QueryResult
 	path := fmt.Sprintf("/1/query_results/%s/%s", dataset, resultID)
-	resp, err := c.doRequest(ctx, "GET", path, nil)
-	if err != nil {
+	if err := c.doJSON(ctx, "GET", path, nil, &result); err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
-	}
-
-	var result QueryResult
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
-	}
-
 	return &result, nil
 }
 
@@ -426,3 +672,185 @@ func (c *Client) PollQueryResult(ctx context.Context, dataset, resultID string,
 
 	return nil, fmt.Errorf("query did not complete within %d attempts", maxAttempts)
 }
+
+// SendEvents sends events to the specified dataset using the batch API.
+// Events are split into multiple requests when they exceed the batch size limit.
+func (c *Client) SendEvents(ctx context.Context, dataset string, events []map[string]any) (*BatchResponse, error) {
+	if dataset == "" {
+		return nil, fmt.Errorf("dataset is required")
+	}
+
+	// Encode each event individually so batches can be split by size
+	encoded := make([]json.RawMessage, 0, len(events))
+	for i, event := range events {
+		eventBytes, err := json.Marshal(BatchEvent{Data: event})
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal event %d: %w", i, err)
+		}
+		if len(eventBytes)+2 > MaxBatchBytes {
+			return nil, fmt.Errorf("event %d exceeds the maximum batch size of %d bytes", i, MaxBatchBytes)
+		}
+		encoded = append(encoded, eventBytes)
+	}
+
+	result := &BatchResponse{Statuses: make([]EventStatus, 0, len(events))}
+	for start := 0; start < len(encoded); {
+		// Account for the enclosing brackets and separating commas
+		end, size := start, 2
+		for end < len(encoded) && size+len(encoded[end])+1 <= MaxBatchBytes {
+			size += len(encoded[end]) + 1
+			end++
+		}
+
+		statuses, err := c.sendBatch(ctx, dataset, encoded[start:end])
+		if err != nil {
+			return nil, err
+		}
+		result.Statuses = append(result.Statuses, statuses...)
+		start = end
+	}
+
+	return result, nil
+}
+
+// sendBatch posts a single batch of pre-encoded events.
+func (c *Client) sendBatch(ctx context.Context, dataset string, batch []json.RawMessage) ([]EventStatus, error) {
+	var statuses []EventStatus
+	path := fmt.Sprintf("/1/batch/%s", dataset)
+	if err := c.doJSON(ctx, "POST", path, batch, &statuses); err != nil {
+		return nil, err
+	}
+	return statuses, nil
+}
+
+// CreateMarker creates a marker in the specified dataset. Use the dataset
+// "__all__" to create an environment-wide marker.
+func (c *Client) CreateMarker(ctx context.Context, dataset string, marker Marker) (*Marker, error) {
+	var created Marker
+	path := fmt.Sprintf("/1/markers/%s", dataset)
+	if err := c.doJSON(ctx, "POST", path, marker, &created); err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+// ListMarkers lists all markers in the specified dataset.
+func (c *Client) ListMarkers(ctx context.Context, dataset string) ([]Marker, error) {
+	var markers []Marker
+	path := fmt.Sprintf("/1/markers/%s", dataset)
+	if err := c.doJSON(ctx, "GET", path, nil, &markers); err != nil {
+		return nil, err
+	}
+	return markers, nil
+}
+
+// ListTriggers lists all triggers in the specified dataset.
+func (c *Client) ListTriggers(ctx context.Context, dataset string) ([]Trigger, error) {
+	var triggers []Trigger
+	path := fmt.Sprintf("/1/triggers/%s", dataset)
+	if err := c.doJSON(ctx, "GET", path, nil, &triggers); err != nil {
+		return nil, err
+	}
+	return triggers, nil
+}
+
+// CreateTrigger creates a trigger in the specified dataset.
+func (c *Client) CreateTrigger(ctx context.Context, dataset string, trigger Trigger) (*Trigger, error) {
+	var created Trigger
+	path := fmt.Sprintf("/1/triggers/%s", dataset)
+	if err := c.doJSON(ctx, "POST", path, trigger, &created); err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+// GetTrigger retrieves a single trigger by ID.
+func (c *Client) GetTrigger(ctx context.Context, dataset, triggerID string) (*Trigger, error) {
+	var trigger Trigger
+	path := fmt.Sprintf("/1/triggers/%s/%s", dataset, triggerID)
+	if err := c.doJSON(ctx, "GET", path, nil, &trigger); err != nil {
+		return nil, err
+	}
+	return &trigger, nil
+}
+
+// UpdateTrigger replaces the trigger identified by trigger.ID.
+func (c *Client) UpdateTrigger(ctx context.Context, dataset string, trigger Trigger) (*Trigger, error) {
+	if trigger.ID == "" {
+		return nil, fmt.Errorf("trigger ID is required")
+	}
+
+	var updated Trigger
+	path := fmt.Sprintf("/1/triggers/%s/%s", dataset, trigger.ID)
+	if err := c.doJSON(ctx, "PUT", path, trigger, &updated); err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// DeleteTrigger deletes a trigger by ID.
+func (c *Client) DeleteTrigger(ctx context.Context, dataset, triggerID string) error {
+	path := fmt.Sprintf("/1/triggers/%s/%s", dataset, triggerID)
+	return c.doJSON(ctx, "DELETE", path, nil, nil)
+}
+
+// ListColumns lists the columns of the specified dataset.
+func (c *Client) ListColumns(ctx context.Context, dataset string) ([]Column, error) {
+	var columns []Column
+	path := fmt.Sprintf("/1/columns/%s", dataset)
+	if err := c.doJSON(ctx, "GET", path, nil, &columns); err != nil {
+		return nil, err
+	}
+	return columns, nil
+}
+
+// ListDerivedColumns lists the derived columns of the specified dataset. Use
+// the dataset "__all__" for environment-wide derived columns.
+func (c *Client) ListDerivedColumns(ctx context.Context, dataset string) ([]DerivedColumn, error) {
+	var columns []DerivedColumn
+	path := fmt.Sprintf("/1/derived_columns/%s", dataset)
+	if err := c.doJSON(ctx, "GET", path, nil, &columns); err != nil {
+		return nil, err
+	}
+	return columns, nil
+}
+
+// CreateDerivedColumn creates a derived column in the specified dataset.
+func (c *Client) CreateDerivedColumn(ctx context.Context, dataset string, column DerivedColumn) (*DerivedColumn, error) {
+	var created DerivedColumn
+	path := fmt.Sprintf("/1/derived_columns/%s", dataset)
+	if err := c.doJSON(ctx, "POST", path, column, &created); err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+// GetDerivedColumn retrieves a single derived column by ID.
+func (c *Client) GetDerivedColumn(ctx context.Context, dataset, columnID string) (*DerivedColumn, error) {
+	var column DerivedColumn
+	path := fmt.Sprintf("/1/derived_columns/%s/%s", dataset, columnID)
+	if err := c.doJSON(ctx, "GET", path, nil, &column); err != nil {
+		return nil, err
+	}
+	return &column, nil
+}
+
+// UpdateDerivedColumn replaces the derived column identified by column.ID.
+func (c *Client) UpdateDerivedColumn(ctx context.Context, dataset string, column DerivedColumn) (*DerivedColumn, error) {
+	if column.ID == "" {
+		return nil, fmt.Errorf("derived column ID is required")
+	}
+
+	var updated DerivedColumn
+	path := fmt.Sprintf("/1/derived_columns/%s/%s", dataset, column.ID)
+	if err := c.doJSON(ctx, "PUT", path, column, &updated); err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// DeleteDerivedColumn deletes a derived column by ID.
+func (c *Client) DeleteDerivedColumn(ctx context.Context, dataset, columnID string) error {
+	path := fmt.Sprintf("/1/derived_columns/%s/%s", dataset, columnID)
+	return c.doJSON(ctx, "DELETE", path, nil, nil)
+}
@@ -18,11 +18,18 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"encoding/pem"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/otel/trace/noop"
@@ -70,11 +77,11 @@ dataset: my-dataset
 environment: production`,
 			wantErr: false,
 			expected: Config{
-				Name:        "test-honeycomb",
-				Kind:        "honeycomb",
-				APIKey:      "hcxik_test123456789",
-				Dataset:     "my-dataset",
-				Environment: "production",
+				Name:                "test-honeycomb",
+				Kind:                "honeycomb",
+				APIKey:              "hcxik_test123456789",
+				Dataset:             "my-dataset",
+				ExpectedEnvironment: "production",
 			},
 		},
 		{
@@ -119,7 +126,7 @@ timeout: 60`,
 				assert.Equal(t, tt.expected.Name, config.(Config).Name)
 				assert.Equal(t, tt.expected.APIKey, config.(Config).APIKey)
 				assert.Equal(t, tt.expected.Dataset, config.(Config).Dataset)
-				assert.Equal(t, tt.expected.Environment, config.(Config).Environment)
+				assert.Equal(t, tt.expected.ExpectedEnvironment, config.(Config).ExpectedEnvironment)
 				if tt.expected.BaseURL != "" {
 					assert.Equal(t, tt.expected.BaseURL, config.(Config).BaseURL)
 				}
@@ -131,6 +138,75 @@ timeout: 60`,
 	}
 }
 
+func TestHoneycombConfigAPIKeyFile(t *testing.T) {
+	keyFile := filepath.Join(t.TempDir(), "api-key")
+	require.NoError(t, os.WriteFile(keyFile, []byte("hcxik_from_file\n"), 0o600))
+
+	decoder := yaml.NewDecoder(bytes.NewReader([]byte(`name: test-honeycomb
+kind: honeycomb
+apiKeyFile: ` + keyFile)))
+	config, err := newConfig(context.Background(), "test-honeycomb", decoder)
+	require.NoError(t, err)
+	assert.Equal(t, "hcxik_from_file", config.(Config).APIKey)
+
+	decoder = yaml.NewDecoder(bytes.NewReader([]byte(`name: test-honeycomb
+kind: honeycomb
+apiKey: file:` + keyFile)))
+	config, err = newConfig(context.Background(), "test-honeycomb", decoder)
+	require.NoError(t, err)
+	assert.Equal(t, "file:"+keyFile, config.(Config).APIKey)
+
+	decoder = yaml.NewDecoder(bytes.NewReader([]byte(`name: test-honeycomb
+kind: honeycomb
+apiKey: hcxik_inline
+apiKeyFile: ` + keyFile)))
+	_, err = newConfig(context.Background(), "test-honeycomb", decoder)
+	assert.ErrorContains(t, err, "apiKey and apiKeyFile cannot both be set")
+
+	decoder = yaml.NewDecoder(bytes.NewReader([]byte(`name: test-honeycomb
+kind: honeycomb`)))
+	_, err = newConfig(context.Background(), "test-honeycomb", decoder)
+	assert.ErrorContains(t, err, "apiKey or apiKeyFile is required")
+}
+
+func TestHoneycombConfigRateLimit(t *testing.T) {
+	decoder := yaml.NewDecoder(bytes.NewReader([]byte(`name: test-honeycomb
+kind: honeycomb
+apiKey: hcxik_test123456789
+requestsPerSecond: 2.5
+burst: 5`)))
+	config, err := newConfig(context.Background(), "test-honeycomb", decoder)
+	require.NoError(t, err)
+	assert.Equal(t, 2.5, config.(Config).RequestsPerSecond)
+	assert.Equal(t, 5, config.(Config).Burst)
+
+	decoder = yaml.NewDecoder(bytes.NewReader([]byte(`name: test-honeycomb
+kind: honeycomb
+apiKey: hcxik_test123456789
+requestsPerSecond: -1`)))
+	_, err = newConfig(context.Background(), "test-honeycomb", decoder)
+	assert.Error(t, err)
+}
+
+func TestHoneycombConfigConnectionPool(t *testing.T) {
+	decoder := yaml.NewDecoder(bytes.NewReader([]byte(`name: test-honeycomb
+kind: honeycomb
+apiKey: hcxik_test123456789
+maxIdleConns: 200
+maxIdleConnsPerHost: 50`)))
+	config, err := newConfig(context.Background(), "test-honeycomb", decoder)
+	require.NoError(t, err)
+	assert.Equal(t, 200, config.(Config).MaxIdleConns)
+	assert.Equal(t, 50, config.(Config).MaxIdleConnsPerHost)
+
+	decoder = yaml.NewDecoder(bytes.NewReader([]byte(`name: test-honeycomb
+kind: honeycomb
+apiKey: hcxik_test123456789
+maxIdleConnsPerHost: -1`)))
+	_, err = newConfig(context.Background(), "test-honeycomb", decoder)
+	assert.Error(t, err)
+}
+
 func TestSourceKind(t *testing.T) {
 	config := Config{
 		Name:   "test",
@@ -145,31 +221,36 @@ func TestSourceKind(t *testing.T) {
 
 func TestInitHoneycombClient(t *testing.T) {
 	tests := []struct {
-		name       string
-		apiKey     string
-		baseURL    string
-		timeout    int
-		wantErr    bool
-		wantURL    string
-		wantAPIKey string
+		name         string
+		apiKey       string
+		baseURL      string
+		timeout      int
+		maxIdleConns int
+		wantErr      bool
+		wantURL      string
+		wantAPIKey   string
+		wantMaxIdle  int
 	}{
 		{
-			name:       "valid client with defaults",
-			apiKey:     "hcxik_test123456789",
-			baseURL:    "",
-			timeout:    0,
-			wantErr:    false,
-			wantURL:    "https://api.honeycomb.io",
-			wantAPIKey: "hcxik_test123456789",
+			name:        "valid client with defaults",
+			apiKey:      "hcxik_test123456789",
+			baseURL:     "",
+			timeout:     0,
+			wantErr:     false,
+			wantURL:     "https://api.honeycomb.io",
+			wantAPIKey:  "hcxik_test123456789",
+			wantMaxIdle: DefaultMaxIdleConns,
 		},
 		{
-			name:       "valid client with custom base URL",
-			apiKey:     "hcxik_test123456789",
-			baseURL:    "https://custom.honeycomb.io",
-			timeout:    60,
-			wantErr:    false,
-			wantURL:    "https://custom.honeycomb.io",
-			wantAPIKey: "hcxik_test123456789",
+			name:         "valid client with custom base URL",
+			apiKey:       "hcxik_test123456789",
+			baseURL:      "https://custom.honeycomb.io",
+			timeout:      60,
+			maxIdleConns: 20,
+			wantErr:      false,
+			wantURL:      "https://custom.honeycomb.io",
+			wantAPIKey:   "hcxik_test123456789",
+			wantMaxIdle:  20,
 		},
 		{
 			name:    "missing API key",
@@ -185,7 +266,7 @@ func TestInitHoneycombClient(t *testing.T) {
 			ctx := context.Background()
 			tracer := noop.NewTracerProvider().Tracer("test")
 
-			client, err := initHoneycombClient(ctx, tracer, "test", tt.apiKey, tt.baseURL, tt.timeout)
+			client, err := initHoneycombClient(ctx, tracer, "test", tt.apiKey, tt.baseURL, tt.timeout, tt.maxIdleConns, 0, nil)
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -196,6 +277,10 @@ func TestInitHoneycombClient(t *testing.T) {
 				assert.Equal(t, tt.wantURL, client.BaseURL)
 				assert.Equal(t, tt.wantAPIKey, client.APIKey)
 				assert.NotNil(t, client.HTTPClient)
+				transport, ok := client.HTTPClient.Transport.(*http.Transport)
+				require.True(t, ok)
+				assert.Equal(t, tt.wantMaxIdle, transport.MaxIdleConns)
+				assert.Equal(t, DefaultMaxIdleConnsPerHost, transport.MaxIdleConnsPerHost)
 			}
 		})
 	}
@@ -295,6 +380,48 @@ func TestCreateQuery(t *testing.T) {
 	assert.Len(t, query.QuerySpec.Calculations, 1)
 }
 
+func TestGetQuery(t *testing.T) {
+	expectedQuery := Query{
+		ID: "test-query-id",
+		QuerySpec: QuerySpec{
+			Calculations: []Calculation{{Op: "P99", Column: "duration_ms"}},
+			Breakdowns:   []string{"service.name"},
+			TimeRange:    7200,
+		},
+		Created: "2024-01-01T00:00:00Z",
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		assert.Equal(t, "test-api-key", r.Header.Get("X-Honeycomb-Team"))
+		if r.URL.Path != "/1/queries/test-dataset/test-query-id" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":"query not found"}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(expectedQuery)
+	}))
+	defer server.Close()
+
+	client := &Client{
+		APIKey:     "test-api-key",
+		BaseURL:    server.URL,
+		HTTPClient: server.Client(),
+	}
+
+	ctx := context.Background()
+	query, err := client.GetQuery(ctx, "test-dataset", "test-query-id")
+	require.NoError(t, err)
+	assert.Equal(t, &expectedQuery, query)
+
+	_, err = client.GetQuery(ctx, "test-dataset", "missing")
+	assert.ErrorContains(t, err, "404")
+
+	_, err = client.GetQuery(ctx, "test-dataset", "")
+	assert.ErrorContains(t, err, "query ID is required")
+}
+
 func TestExecuteQuery(t *testing.T) {
 	expectedResult := QueryResult{
 		ID:       "test-result-id",
@@ -475,24 +602,28 @@ func TestAPIErrorHandling(t *testing.T) {
 		statusCode     int
 		responseBody   string
 		expectedErrMsg string
+		expectedKind   error
 	}{
 		{
 			name:           "unauthorized",
 			statusCode:     http.StatusUnauthorized,
 			responseBody:   `{"error": "Invalid API key"}`,
 			expectedErrMsg: "API request failed with status 401",
+			expectedKind:   sources.ErrAuth,
 		},
 		{
 			name:           "not found",
 			statusCode:     http.StatusNotFound,
 			responseBody:   `{"error": "Dataset not found"}`,
 			expectedErrMsg: "API request failed with status 404",
+			expectedKind:   sources.ErrNotFound,
 		},
 		{
 			name:           "rate limit",
 			statusCode:     http.StatusTooManyRequests,
 			responseBody:   `{"error": "Rate limit exceeded"}`,
 			expectedErrMsg: "API request failed with status 429",
+			expectedKind:   sources.ErrThrottled,
 		},
 	}
 
@@ -500,6 +631,7 @@ func TestAPIErrorHandling(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 				w.Header().Set("Content-Type", "application/json")
+				w.Header().Set("Retry-After", "0")
 				w.WriteHeader(tt.statusCode)
 				w.Write([]byte(tt.responseBody))
 			}))
@@ -516,17 +648,626 @@ func TestAPIErrorHandling(t *testing.T) {
 
 			require.Error(t, err)
 			assert.Contains(t, err.Error(), tt.expectedErrMsg)
+			assert.ErrorIs(t, err, tt.expectedKind)
 		})
 	}
 }
 
+func TestDoRequestWithRetryRateLimited(t *testing.T) {
+	callCount := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		callCount++
+		if callCount == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := &Client{
+		APIKey:     "test-api-key",
+		BaseURL:    server.URL,
+		HTTPClient: server.Client(),
+	}
+
+	start := time.Now()
+	resp, err := client.doRequestWithRetry(context.Background(), "GET", "/1/datasets", nil, 2)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, 2, callCount)
+	// Retry-After: 0 should take precedence over the one second backoff
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestDoRequestWithRetryHonorsRetryAfter(t *testing.T) {
+	var calls []time.Time
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, time.Now())
+		if len(calls) == 1 {
+			w.Header().Set("Retry-After", "2")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`[{"name": "test-dataset", "slug": "test-dataset"}]`))
+	}))
+	defer server.Close()
+
+	client := &Client{
+		APIKey:     "test-api-key",
+		BaseURL:    server.URL,
+		HTTPClient: server.Client(),
+	}
+
+	datasets, err := client.ListDatasets(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, datasets, 1)
+	require.Len(t, calls, 2)
+	// The server-prescribed two seconds replaces the one second backoff
+	assert.GreaterOrEqual(t, calls[1].Sub(calls[0]), 2*time.Second)
+}
+
+func TestDoRequestWithRetryLongRetryAfter(t *testing.T) {
+	callCount := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		callCount++
+		w.Header().Set("Retry-After", "30")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client := &Client{
+		APIKey:     "test-api-key",
+		BaseURL:    server.URL,
+		HTTPClient: server.Client(),
+	}
+
+	// A Retry-After longer than MaxBackoffSeconds is waited out in full, so
+	// only the context deadline ends the wait and no early retry is sent.
+	ctx, cancel := context.WithTimeout(context.Background(), MaxBackoffSeconds*time.Second+time.Second)
+	defer cancel()
+	start := time.Now()
+	_, err := client.doRequestWithRetry(ctx, http.MethodGet, "/1/datasets", nil, 0)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.GreaterOrEqual(t, time.Since(start), MaxBackoffSeconds*time.Second)
+	assert.Equal(t, 1, callCount)
+}
+
+func TestDoRequestWithRetryExhausted(t *testing.T) {
+	callCount := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		callCount++
+		w.Header().Set("Retry-After", "0")
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write([]byte(`{"error": "Rate limit exceeded"}`))
+	}))
+	defer server.Close()
+
+	client := &Client{
+		APIKey:     "test-api-key",
+		BaseURL:    server.URL,
+		HTTPClient: server.Client(),
+	}
+
+	_, err := client.GetQueryResult(context.Background(), "test-dataset", "result-1")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "API request failed with status 429")
+	assert.Equal(t, DefaultMaxRetries+1, callCount)
+}
+
+func TestDoRequestWithRetryPostServerError(t *testing.T) {
+	tests := []struct {
+		name          string
+		status        int
+		expectedCalls int
+	}{
+		{name: "server error is not retried", status: http.StatusBadGateway, expectedCalls: 1},
+		{name: "rate limit is retried", status: http.StatusTooManyRequests, expectedCalls: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			callCount := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				callCount++
+				if callCount == 1 {
+					w.Header().Set("Retry-After", "0")
+					w.WriteHeader(tt.status)
+					return
+				}
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(`{"id": "m1", "message": "deploy"}`))
+			}))
+			defer server.Close()
+
+			client := &Client{
+				APIKey:     "test-api-key",
+				BaseURL:    server.URL,
+				HTTPClient: server.Client(),
+			}
+
+			_, err := client.CreateMarker(context.Background(), "test-dataset", Marker{Message: "deploy"})
+			if tt.status == http.StatusBadGateway {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "API request failed with status 502")
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.expectedCalls, callCount)
+		})
+	}
+}
+
+func TestShouldRetry(t *testing.T) {
+	transportErr := errors.New("connection reset")
+	tests := []struct {
+		method string
+		status int
+		err    error
+		want   bool
+	}{
+		{method: http.MethodGet, status: http.StatusServiceUnavailable, want: true},
+		{method: http.MethodDelete, status: http.StatusBadGateway, want: true},
+		{method: http.MethodPut, err: transportErr, want: true},
+		{method: http.MethodPost, status: http.StatusTooManyRequests, want: true},
+		{method: http.MethodPost, status: http.StatusGatewayTimeout, want: false},
+		{method: http.MethodPost, err: transportErr, want: false},
+		{method: http.MethodGet, status: http.StatusNotFound, want: false},
+	}
+
+	for _, tt := range tests {
+		var resp *http.Response
+		if tt.err == nil {
+			resp = &http.Response{StatusCode: tt.status}
+		}
+		assert.Equal(t, tt.want, shouldRetry(tt.method, resp, tt.err), "%s %d %v", tt.method, tt.status, tt.err)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		wantOK  bool
+		wantDur time.Duration
+	}{
+		{name: "empty", value: "", wantOK: false},
+		{name: "seconds", value: "5", wantOK: true, wantDur: 5 * time.Second},
+		{name: "longer than max backoff", value: "3600", wantOK: true, wantDur: time.Hour},
+		{name: "negative", value: "-1", wantOK: false},
+		{name: "past date", value: "Mon, 01 Jan 2024 00:00:00 GMT", wantOK: true, wantDur: 0},
+		{name: "invalid", value: "soon", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, ok := parseRetryAfter(tt.value)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.wantDur, d)
+		})
+	}
+}
+
+func TestSendEvents(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/1/batch/test-dataset", r.URL.Path)
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "test-api-key", r.Header.Get("X-Honeycomb-Team"))
+
+		var events []BatchEvent
+		err := json.NewDecoder(r.Body).Decode(&events)
+		assert.NoError(t, err)
+		assert.Len(t, events, 2)
+		assert.Equal(t, "GET", events[0].Data["method"])
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`[{"status":202},{"status":400,"error":"event too large"}]`))
+	}))
+	defer server.Close()
+
+	client := &Client{
+		APIKey:     "test-api-key",
+		BaseURL:    server.URL,
+		HTTPClient: server.Client(),
+	}
+
+	events := []map[string]any{
+		{"method": "GET", "duration_ms": 12.5},
+		{"method": "POST", "duration_ms": 40},
+	}
+	result, err := client.SendEvents(context.Background(), "test-dataset", events)
+
+	require.NoError(t, err)
+	require.Len(t, result.Statuses, 2)
+	assert.Equal(t, http.StatusAccepted, result.Statuses[0].Status)
+	assert.Equal(t, "event too large", result.Statuses[1].Error)
+	assert.Equal(t, 1, result.Failed())
+}
+
+func TestSendEventsChunking(t *testing.T) {
+	batchCount := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		batchCount++
+		var events []BatchEvent
+		err := json.NewDecoder(r.Body).Decode(&events)
+		assert.NoError(t, err)
+
+		statuses := make([]EventStatus, len(events))
+		for i := range statuses {
+			statuses[i] = EventStatus{Status: http.StatusAccepted}
+		}
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(statuses)
+	}))
+	defer server.Close()
+
+	client := &Client{
+		APIKey:     "test-api-key",
+		BaseURL:    server.URL,
+		HTTPClient: server.Client(),
+	}
+
+	// Three events of roughly 2MB each cannot fit in a single 5MB batch
+	payload := strings.Repeat("x", 2*1024*1024)
+	events := []map[string]any{
+		{"payload": payload},
+		{"payload": payload},
+		{"payload": payload},
+	}
+	result, err := client.SendEvents(context.Background(), "test-dataset", events)
+
+	require.NoError(t, err)
+	assert.Len(t, result.Statuses, 3)
+	assert.Equal(t, 0, result.Failed())
+	assert.Equal(t, 2, batchCount)
+}
+
+func TestMarkers(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/1/markers/test-dataset", r.URL.Path)
+		assert.Equal(t, "test-api-key", r.Header.Get("X-Honeycomb-Team"))
+
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case "POST":
+			var marker Marker
+			err := json.NewDecoder(r.Body).Decode(&marker)
+			assert.NoError(t, err)
+			assert.Equal(t, "deploy v1.2.3", marker.Message)
+			assert.Equal(t, "deploy", marker.Type)
+
+			marker.ID = "test-marker-id"
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(marker)
+		case "GET":
+			w.WriteHeader(http.StatusOK)
+			json.NewEncoder(w).Encode([]Marker{{ID: "test-marker-id", Message: "deploy v1.2.3", Type: "deploy"}})
+		default:
+			t.Errorf("unexpected method %s", r.Method)
+		}
+	}))
+	defer server.Close()
+
+	client := &Client{
+		APIKey:     "test-api-key",
+		BaseURL:    server.URL,
+		HTTPClient: server.Client(),
+	}
+
+	ctx := context.Background()
+	created, err := client.CreateMarker(ctx, "test-dataset", Marker{
+		Message:   "deploy v1.2.3",
+		Type:      "deploy",
+		URL:       "https://example.com/deploys/123",
+		StartTime: 1704067200,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "test-marker-id", created.ID)
+
+	markers, err := client.ListMarkers(ctx, "test-dataset")
+	require.NoError(t, err)
+	require.Len(t, markers, 1)
+	assert.Equal(t, "deploy v1.2.3", markers[0].Message)
+}
+
+func TestTriggers(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "test-api-key", r.Header.Get("X-Honeycomb-Team"))
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/1/triggers/test-dataset":
+			json.NewEncoder(w).Encode([]Trigger{{ID: "trigger-1", Name: "High latency"}})
+		case r.Method == "POST" && r.URL.Path == "/1/triggers/test-dataset":
+			var trigger Trigger
+			err := json.NewDecoder(r.Body).Decode(&trigger)
+			assert.NoError(t, err)
+			assert.Equal(t, "High latency", trigger.Name)
+			require.NotNil(t, trigger.Query)
+			assert.Equal(t, "P95", trigger.Query.Calculations[0].Op)
+
+			trigger.ID = "trigger-1"
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(trigger)
+		case r.Method == "GET" && r.URL.Path == "/1/triggers/test-dataset/trigger-1":
+			json.NewEncoder(w).Encode(Trigger{ID: "trigger-1", Name: "High latency", Frequency: 900})
+		case r.Method == "PUT" && r.URL.Path == "/1/triggers/test-dataset/trigger-1":
+			var trigger Trigger
+			err := json.NewDecoder(r.Body).Decode(&trigger)
+			assert.NoError(t, err)
+			json.NewEncoder(w).Encode(trigger)
+		case r.Method == "DELETE" && r.URL.Path == "/1/triggers/test-dataset/trigger-1":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := &Client{
+		APIKey:     "test-api-key",
+		BaseURL:    server.URL,
+		HTTPClient: server.Client(),
+	}
+
+	ctx := context.Background()
+	trigger := Trigger{
+		Name: "High latency",
+		Query: &QuerySpec{
+			Calculations: []Calculation{{Op: "P95", Column: "duration_ms"}},
+			TimeRange:    900,
+		},
+		Threshold:  TriggerThreshold{Op: ">", Value: 500},
+		Frequency:  900,
+		Recipients: []TriggerRecipient{{Type: "email", Target: "oncall@example.com"}},
+	}
+
+	created, err := client.CreateTrigger(ctx, "test-dataset", trigger)
+	require.NoError(t, err)
+	assert.Equal(t, "trigger-1", created.ID)
+
+	triggers, err := client.ListTriggers(ctx, "test-dataset")
+	require.NoError(t, err)
+	assert.Len(t, triggers, 1)
+
+	fetched, err := client.GetTrigger(ctx, "test-dataset", "trigger-1")
+	require.NoError(t, err)
+	assert.Equal(t, 900, fetched.Frequency)
+
+	created.Disabled = true
+	updated, err := client.UpdateTrigger(ctx, "test-dataset", *created)
+	require.NoError(t, err)
+	assert.True(t, updated.Disabled)
+
+	_, err = client.UpdateTrigger(ctx, "test-dataset", Trigger{Name: "missing id"})
+	assert.Error(t, err)
+
+	err = client.DeleteTrigger(ctx, "test-dataset", "trigger-1")
+	assert.NoError(t, err)
+}
+
+func TestColumns(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "test-api-key", r.Header.Get("X-Honeycomb-Team"))
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/1/columns/test-dataset":
+			w.Write([]byte(`[{"id":"col-1","key_name":"duration_ms","type":"float","last_written":"2025-01-02T03:04:05Z"}]`))
+		case r.Method == "GET" && r.URL.Path == "/1/derived_columns/test-dataset":
+			json.NewEncoder(w).Encode([]DerivedColumn{{ID: "dc-1", Alias: "is_slow"}})
+		case r.Method == "POST" && r.URL.Path == "/1/derived_columns/test-dataset":
+			var column DerivedColumn
+			err := json.NewDecoder(r.Body).Decode(&column)
+			assert.NoError(t, err)
+			assert.Equal(t, "is_slow", column.Alias)
+
+			column.ID = "dc-1"
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(column)
+		case r.Method == "GET" && r.URL.Path == "/1/derived_columns/test-dataset/dc-1":
+			json.NewEncoder(w).Encode(DerivedColumn{ID: "dc-1", Alias: "is_slow", Expression: "GTE($duration_ms, 1000)"})
+		case r.Method == "PUT" && r.URL.Path == "/1/derived_columns/test-dataset/dc-1":
+			var column DerivedColumn
+			err := json.NewDecoder(r.Body).Decode(&column)
+			assert.NoError(t, err)
+			json.NewEncoder(w).Encode(column)
+		case r.Method == "DELETE" && r.URL.Path == "/1/derived_columns/test-dataset/dc-1":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := &Client{
+		APIKey:     "test-api-key",
+		BaseURL:    server.URL,
+		HTTPClient: server.Client(),
+	}
+
+	ctx := context.Background()
+	columns, err := client.ListColumns(ctx, "test-dataset")
+	require.NoError(t, err)
+	require.Len(t, columns, 1)
+	assert.Equal(t, Column{ID: "col-1", KeyName: "duration_ms", Type: "float", LastWritten: "2025-01-02T03:04:05Z"}, columns[0])
+
+	created, err := client.CreateDerivedColumn(ctx, "test-dataset", DerivedColumn{Alias: "is_slow", Expression: "GTE($duration_ms, 1000)"})
+	require.NoError(t, err)
+	assert.Equal(t, "dc-1", created.ID)
+
+	derived, err := client.ListDerivedColumns(ctx, "test-dataset")
+	require.NoError(t, err)
+	assert.Len(t, derived, 1)
+
+	fetched, err := client.GetDerivedColumn(ctx, "test-dataset", "dc-1")
+	require.NoError(t, err)
+	assert.Equal(t, "GTE($duration_ms, 1000)", fetched.Expression)
+
+	created.Description = "Requests slower than a second"
+	updated, err := client.UpdateDerivedColumn(ctx, "test-dataset", *created)
+	require.NoError(t, err)
+	assert.Equal(t, "Requests slower than a second", updated.Description)
+
+	_, err = client.UpdateDerivedColumn(ctx, "test-dataset", DerivedColumn{Alias: "missing id"})
+	assert.Error(t, err)
+
+	err = client.DeleteDerivedColumn(ctx, "test-dataset", "dc-1")
+	assert.NoError(t, err)
+}
+
+func TestInitializeEnvironment(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/1/auth", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{
+			"api_key_access": {"queries": true},
+			"environment": {"name": "Production", "slug": "production"},
+			"team": {"name": "Example", "slug": "example"}
+		}`))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name        string
+		environment string
+		wantErr     string
+	}{
+		{name: "no environment configured", environment: ""},
+		{name: "matching slug", environment: "production"},
+		{name: "matching name", environment: "Production"},
+		{name: "different environment", environment: "staging", wantErr: `API key belongs to environment "production", not "staging"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := Config{
+				Name:                "test",
+				Kind:                SourceKind,
+				APIKey:              "test-api-key",
+				BaseURL:             server.URL,
+				ExpectedEnvironment: tt.environment,
+			}
+
+			source, err := config.Initialize(context.Background(), noop.NewTracerProvider().Tracer("test"))
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.NotNil(t, source)
+		})
+	}
+}
+
+func TestInitializeTLSCAFile(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{
+			"api_key_access": {"queries": true},
+			"environment": {"name": "Production", "slug": "production"},
+			"team": {"name": "Example", "slug": "example"}
+		}`))
+	}))
+	defer server.Close()
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	pemData := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	require.NoError(t, os.WriteFile(caFile, pemData, 0o600))
+
+	config := Config{
+		Name:    "test",
+		Kind:    SourceKind,
+		APIKey:  "test-api-key",
+		BaseURL: server.URL,
+	}
+
+	// The test server's certificate is not in the system roots
+	_, err := config.Initialize(context.Background(), noop.NewTracerProvider().Tracer("test"))
+	require.Error(t, err)
+
+	config.TLSCAFile = caFile
+	source, err := config.Initialize(context.Background(), noop.NewTracerProvider().Tracer("test"))
+	require.NoError(t, err)
+	assert.NotNil(t, source)
+}
+
+func TestInitializeMissingQueryAccess(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{
+			"api_key_access": {"events": true, "markers": true},
+			"environment": {"name": "Production", "slug": "production"},
+			"team": {"name": "Example", "slug": "example"}
+		}`))
+	}))
+	defer server.Close()
+
+	config := Config{
+		Name:    "test",
+		Kind:    SourceKind,
+		APIKey:  "ingest-only-key",
+		BaseURL: server.URL,
+	}
+
+	_, err := config.Initialize(context.Background(), noop.NewTracerProvider().Tracer("test"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `does not have the "Run Queries" permission`)
+}
+
+func TestGetAuth(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/1/auth", r.URL.Path)
+		assert.Equal(t, "GET", r.Method)
+		assert.Equal(t, "test-api-key", r.Header.Get("X-Honeycomb-Team"))
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{
+			"id": "key-id",
+			"type": "configuration",
+			"api_key_access": {"events": true, "markers": true, "queries": false},
+			"environment": {"name": "Production", "slug": "production"},
+			"team": {"name": "Example", "slug": "example"}
+		}`))
+	}))
+	defer server.Close()
+
+	client := &Client{
+		APIKey:     "test-api-key",
+		BaseURL:    server.URL,
+		HTTPClient: server.Client(),
+	}
+
+	auth, err := client.GetAuth(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, "example", auth.Team.Slug)
+	assert.Equal(t, "production", auth.Environment.Slug)
+	assert.True(t, auth.HasAccess("events"))
+	assert.False(t, auth.HasAccess("queries"))
+	assert.False(t, auth.HasAccess("triggers"))
+}
+
 func TestToConfig(t *testing.T) {
 	config := Config{
-		Name:        "test",
-		Kind:        "honeycomb",
-		APIKey:      "test-key",
-		Dataset:     "test-dataset",
-		Environment: "production",
+		Name:                "test",
+		Kind:                "honeycomb",
+		APIKey:              "test-key",
+		Dataset:             "test-dataset",
+		ExpectedEnvironment: "production",
 	}
 
 	source := Source{Config: config}
@@ -535,7 +1276,7 @@ func TestToConfig(t *testing.T) {
 	assert.Equal(t, config.Name, retrievedConfig.(Config).Name)
 	assert.Equal(t, config.APIKey, retrievedConfig.(Config).APIKey)
 	assert.Equal(t, config.Dataset, retrievedConfig.(Config).Dataset)
-	assert.Equal(t, config.Environment, retrievedConfig.(Config).Environment)
+	assert.Equal(t, config.ExpectedEnvironment, retrievedConfig.(Config).ExpectedEnvironment)
 }
 
 func TestHoneycombClientAccessor(t *testing.T) {
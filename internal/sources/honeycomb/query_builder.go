@@ -0,0 +1,240 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package honeycomb
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// calculationOps lists the supported calculation operators and whether each
+// one requires a column.
+var calculationOps = map[string]bool{
+	"COUNT":          false,
+	"CONCURRENCY":    false,
+	"SUM":            true,
+	"AVG":            true,
+	"COUNT_DISTINCT": true,
+	"HEATMAP":        true,
+	"MAX":            true,
+	"MIN":            true,
+	"P001":           true,
+	"P01":            true,
+	"P05":            true,
+	"P10":            true,
+	"P20":            true,
+	"P25":            true,
+	"P50":            true,
+	"P75":            true,
+	"P80":            true,
+	"P90":            true,
+	"P95":            true,
+	"P99":            true,
+	"P999":           true,
+	"RATE_AVG":       true,
+	"RATE_SUM":       true,
+	"RATE_MAX":       true,
+}
+
+// filterOps lists the supported filter operators and whether each one takes a value.
+var filterOps = map[string]bool{
+	"=":                   true,
+	"!=":                  true,
+	">":                   true,
+	">=":                  true,
+	"<":                   true,
+	"<=":                  true,
+	"starts-with":         true,
+	"does-not-start-with": true,
+	"ends-with":           true,
+	"does-not-end-with":   true,
+	"contains":            true,
+	"does-not-contain":    true,
+	"in":                  true,
+	"not-in":              true,
+	"exists":              false,
+	"does-not-exist":      false,
+}
+
+// QueryBuilder assembles a QuerySpec fluently and validates it on Build.
+//
+//	spec, err := NewQueryBuilder().
+//		P95("duration_ms").
+//		Where("service.name", "=", "checkout").
+//		GroupBy("http.route").
+//		OrderBy(Order{Op: "P95", Column: "duration_ms", Order: "descending"}).
+//		Last(2 * time.Hour).
+//		Build()
+type QueryBuilder struct {
+	spec QuerySpec
+}
+
+// NewQueryBuilder returns an empty QueryBuilder.
+func NewQueryBuilder() *QueryBuilder {
+	return &QueryBuilder{}
+}
+
+// Calculate adds a calculation with the given operator and column.
+func (b *QueryBuilder) Calculate(op, column string) *QueryBuilder {
+	b.spec.Calculations = append(b.spec.Calculations, Calculation{Op: op, Column: column})
+	return b
+}
+
+// Count adds a COUNT calculation.
+func (b *QueryBuilder) Count() *QueryBuilder {
+	return b.Calculate("COUNT", "")
+}
+
+// Sum adds a SUM calculation over column.
+func (b *QueryBuilder) Sum(column string) *QueryBuilder {
+	return b.Calculate("SUM", column)
+}
+
+// Avg adds an AVG calculation over column.
+func (b *QueryBuilder) Avg(column string) *QueryBuilder {
+	return b.Calculate("AVG", column)
+}
+
+// Max adds a MAX calculation over column.
+func (b *QueryBuilder) Max(column string) *QueryBuilder {
+	return b.Calculate("MAX", column)
+}
+
+// Min adds a MIN calculation over column.
+func (b *QueryBuilder) Min(column string) *QueryBuilder {
+	return b.Calculate("MIN", column)
+}
+
+// P50 adds a 50th percentile calculation over column.
+func (b *QueryBuilder) P50(column string) *QueryBuilder {
+	return b.Calculate("P50", column)
+}
+
+// P95 adds a 95th percentile calculation over column.
+func (b *QueryBuilder) P95(column string) *QueryBuilder {
+	return b.Calculate("P95", column)
+}
+
+// P99 adds a 99th percentile calculation over column.
+func (b *QueryBuilder) P99(column string) *QueryBuilder {
+	return b.Calculate("P99", column)
+}
+
+// Heatmap adds a HEATMAP calculation over column.
+func (b *QueryBuilder) Heatmap(column string) *QueryBuilder {
+	return b.Calculate("HEATMAP", column)
+}
+
+// Where adds a filter. The value is ignored for the exists and does-not-exist operators.
+func (b *QueryBuilder) Where(column, op string, value interface{}) *QueryBuilder {
+	b.spec.Filters = append(b.spec.Filters, Filter{Column: column, Op: op, Value: value})
+	return b
+}
+
+// GroupBy adds breakdown columns.
+func (b *QueryBuilder) GroupBy(columns ...string) *QueryBuilder {
+	b.spec.Breakdowns = append(b.spec.Breakdowns, columns...)
+	return b
+}
+
+// OrderBy adds result orderings. Set Op to order by a calculation, or only
+// Column to order by a breakdown.
+func (b *QueryBuilder) OrderBy(orders ...Order) *QueryBuilder {
+	b.spec.Orders = append(b.spec.Orders, orders...)
+	return b
+}
+
+// Granularity sets the time series bucket size.
+func (b *QueryBuilder) Granularity(d time.Duration) *QueryBuilder {
+	b.spec.Granularity = int(d / time.Second)
+	return b
+}
+
+// Last queries the trailing duration ending now.
+func (b *QueryBuilder) Last(d time.Duration) *QueryBuilder {
+	b.spec.TimeRange = int(d / time.Second)
+	return b
+}
+
+// Between queries an absolute time window.
+func (b *QueryBuilder) Between(start, end time.Time) *QueryBuilder {
+	b.spec.StartTime = start.Unix()
+	b.spec.EndTime = end.Unix()
+	return b
+}
+
+// Build validates and returns the assembled QuerySpec.
+func (b *QueryBuilder) Build() (QuerySpec, error) {
+	if err := b.spec.Validate(); err != nil {
+		return QuerySpec{}, err
+	}
+	return b.spec, nil
+}
+
+// Validate checks the query specification for invalid operators and
+// conflicting time settings. All problems found are returned together.
+func (q QuerySpec) Validate() error {
+	var errs []error
+
+	for i, calc := range q.Calculations {
+		needsColumn, ok := calculationOps[calc.Op]
+		switch {
+		case !ok:
+			errs = append(errs, fmt.Errorf("calculation %d: invalid op %q", i, calc.Op))
+		case needsColumn && calc.Column == "":
+			errs = append(errs, fmt.Errorf("calculation %d: op %s requires a column", i, calc.Op))
+		case !needsColumn && calc.Column != "":
+			errs = append(errs, fmt.Errorf("calculation %d: op %s does not take a column", i, calc.Op))
+		}
+	}
+
+	for i, filter := range q.Filters {
+		if filter.Column == "" {
+			errs = append(errs, fmt.Errorf("filter %d: column is required", i))
+		}
+		needsValue, ok := filterOps[filter.Op]
+		if !ok {
+			errs = append(errs, fmt.Errorf("filter %d: invalid op %q", i, filter.Op))
+		} else if needsValue && filter.Value == nil {
+			errs = append(errs, fmt.Errorf("filter %d: op %s requires a value", i, filter.Op))
+		}
+	}
+
+	for i, order := range q.Orders {
+		if order.Order != "" && order.Order != "ascending" && order.Order != "descending" {
+			errs = append(errs, fmt.Errorf("order %d: order must be ascending or descending, got %q", i, order.Order))
+		}
+		if order.Op != "" {
+			if _, ok := calculationOps[order.Op]; !ok {
+				errs = append(errs, fmt.Errorf("order %d: invalid op %q", i, order.Op))
+			}
+		} else if order.Column == "" {
+			errs = append(errs, fmt.Errorf("order %d: op or column is required", i))
+		}
+	}
+
+	if q.TimeRange < 0 || q.Granularity < 0 {
+		errs = append(errs, errors.New("time range and granularity must not be negative"))
+	}
+	if q.TimeRange > 0 && q.StartTime > 0 && q.EndTime > 0 {
+		errs = append(errs, errors.New("time range cannot be combined with both a start and end time"))
+	}
+	if q.StartTime > 0 && q.EndTime > 0 && q.StartTime >= q.EndTime {
+		errs = append(errs, errors.New("start time must be before end time"))
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,101 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package honeycomb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryBuilder(t *testing.T) {
+	spec, err := NewQueryBuilder().
+		Count().
+		P95("duration_ms").
+		Where("service.name", "=", "checkout").
+		Where("error", "exists", nil).
+		GroupBy("http.route").
+		OrderBy(Order{Op: "P95", Column: "duration_ms", Order: "descending"}).
+		Last(2 * time.Hour).
+		Build()
+
+	require.NoError(t, err)
+	assert.Equal(t, []Calculation{{Op: "COUNT"}, {Op: "P95", Column: "duration_ms"}}, spec.Calculations)
+	assert.Len(t, spec.Filters, 2)
+	assert.Equal(t, []string{"http.route"}, spec.Breakdowns)
+	assert.Len(t, spec.Orders, 1)
+	assert.Equal(t, 7200, spec.TimeRange)
+}
+
+func TestQueryBuilderValidation(t *testing.T) {
+	start := time.Unix(1704067200, 0)
+
+	tests := []struct {
+		name    string
+		builder *QueryBuilder
+		wantErr string
+	}{
+		{
+			name:    "invalid calculation op",
+			builder: NewQueryBuilder().Calculate("P42", "duration_ms"),
+			wantErr: `invalid op "P42"`,
+		},
+		{
+			name:    "missing calculation column",
+			builder: NewQueryBuilder().P95(""),
+			wantErr: "requires a column",
+		},
+		{
+			name:    "count with column",
+			builder: NewQueryBuilder().Calculate("COUNT", "duration_ms"),
+			wantErr: "does not take a column",
+		},
+		{
+			name:    "invalid filter op",
+			builder: NewQueryBuilder().Count().Where("status", "==", 200),
+			wantErr: `invalid op "=="`,
+		},
+		{
+			name:    "missing filter value",
+			builder: NewQueryBuilder().Count().Where("status", ">", nil),
+			wantErr: "requires a value",
+		},
+		{
+			name:    "invalid order direction",
+			builder: NewQueryBuilder().Count().OrderBy(Order{Op: "COUNT", Order: "down"}),
+			wantErr: "ascending or descending",
+		},
+		{
+			name:    "time range with start and end",
+			builder: NewQueryBuilder().Count().Last(time.Hour).Between(start, start.Add(time.Hour)),
+			wantErr: "cannot be combined",
+		},
+		{
+			name:    "start after end",
+			builder: NewQueryBuilder().Count().Between(start.Add(time.Hour), start),
+			wantErr: "start time must be before end time",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.builder.Build()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
@@ -33,9 +33,7 @@ const SourceKind string = "http"
 var _ sources.SourceConfig = Config{}
 
 func init() {
-	if !sources.Register(SourceKind, newConfig) {
-		panic(fmt.Sprintf("source kind %q already registered", SourceKind))
-	}
+	sources.MustRegister(SourceKind, newConfig)
 }
 
 func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources.SourceConfig, error) {
@@ -127,3 +125,32 @@ func (s *Source) SourceKind() string {
 func (s *Source) ToConfig() sources.SourceConfig {
 	return s.Config
 }
+
+// HealthCheck sends a GET request to the base URL with the default headers and
+// query parameters. Any response other than a 5xx counts as healthy, since the
+// base URL itself often isn't a valid endpoint.
+func (s *Source) HealthCheck(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.BaseURL, nil)
+	if err != nil {
+		return fmt.Errorf("unable to create request: %w", err)
+	}
+	query := req.URL.Query()
+	for k, v := range s.QueryParams {
+		query.Add(k, v)
+	}
+	req.URL.RawQuery = query.Encode()
+	for k, v := range s.DefaultHeaders {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := s.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return fmt.Errorf("unexpected status %d from %s", resp.StatusCode, s.BaseURL)
+	}
+	return nil
+}
@@ -0,0 +1,179 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package influxdb provides a source implementation for InfluxDB 2.x.
+//
+// Queries are written in Flux and run against the configured organization.
+// Points are written synchronously to the configured bucket.
+package influxdb
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	sourceutil "github.com/googleapis/genai-toolbox/internal/sources/util"
+	"github.com/googleapis/genai-toolbox/internal/util"
+	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+	"github.com/influxdata/influxdb-client-go/v2/domain"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const SourceKind string = "influxdb"
+
+// Default configuration constants
+const (
+	DefaultTimeout = 30 // Default request timeout in seconds
+)
+
+// validate interface
+var _ sources.SourceConfig = Config{}
+
+func init() {
+	sources.MustRegister(SourceKind, newConfig)
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources.SourceConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+
+	token, err := sourceutil.ResolveSecret("token", actual.Token, actual.TokenFile)
+	if err != nil {
+		return nil, fmt.Errorf("source %q (%s): unable to resolve secret: %w", name, SourceKind, err)
+	}
+	actual.Token = token
+	if actual.Token == "" {
+		return nil, fmt.Errorf("source %q (%s): token or tokenFile is required", name, SourceKind)
+	}
+
+	u, err := url.Parse(actual.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("source %q (%s): url %q must be an http or https URL", name, SourceKind, actual.URL)
+	}
+	if actual.Timeout < 0 {
+		return nil, fmt.Errorf("source %q (%s): timeout must not be negative", name, SourceKind)
+	}
+	return actual, nil
+}
+
+// Config represents the configuration for an InfluxDB source.
+type Config struct {
+	Name      string `yaml:"name" validate:"required"`
+	Kind      string `yaml:"kind" validate:"required"`
+	URL       string `yaml:"url" validate:"required"` // e.g., http://influxdb:8086
+	Token     string `yaml:"token"`                   // Required unless tokenFile is set: API token with read and/or write access
+	TokenFile string `yaml:"tokenFile"`               // Optional: file to read token from
+	Org       string `yaml:"org" validate:"required"` // Organization name or ID
+	Bucket    string `yaml:"bucket"`                  // Optional: bucket that WritePoint writes to
+	Timeout   int    `yaml:"timeout"`                 // Optional: request timeout in seconds (default: 30)
+	TLSCAFile string `yaml:"tlsCAFile"`               // Optional: path to CA certificate for TLS
+}
+
+func (r Config) SourceConfigKind() string {
+	return SourceKind
+}
+
+func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.Source, error) {
+	client, err := initInfluxDBClient(ctx, tracer, r)
+	if err != nil {
+		return nil, fmt.Errorf("source %q (%s): unable to create InfluxDB client: %w", r.Name, SourceKind, err)
+	}
+
+	s := &Source{
+		Config: r,
+		Client: client,
+	}
+	if err := s.HealthCheck(ctx); err != nil {
+		client.Close()
+		return nil, fmt.Errorf("source %q (%s): unable to connect successfully: %w", r.Name, SourceKind, err)
+	}
+	return s, nil
+}
+
+var _ sources.Source = &Source{}
+
+// Source represents an InfluxDB source.
+type Source struct {
+	Config
+	Client influxdb2.Client
+}
+
+func (s *Source) SourceKind() string {
+	return SourceKind
+}
+
+func (s *Source) ToConfig() sources.SourceConfig {
+	return s.Config
+}
+
+// HealthCheck calls the server's health endpoint and fails unless the server
+// reports itself as passing.
+func (s *Source) HealthCheck(ctx context.Context) error {
+	health, err := s.Client.Health(ctx)
+	if err != nil {
+		return err
+	}
+	if health.Status != domain.HealthCheckStatusPass {
+		msg := ""
+		if health.Message != nil {
+			msg = *health.Message
+		}
+		return fmt.Errorf("server reported status %q: %s", health.Status, msg)
+	}
+	return nil
+}
+
+// InfluxDBClient returns the underlying InfluxDB client for direct API access.
+func (s *Source) InfluxDBClient() influxdb2.Client {
+	return s.Client
+}
+
+// Close releases the client's idle HTTP connections.
+func (s *Source) Close() error {
+	if s.Client != nil {
+		s.Client.Close()
+	}
+	return nil
+}
+
+func initInfluxDBClient(ctx context.Context, tracer trace.Tracer, r Config) (influxdb2.Client, error) {
+	//nolint:all // Reassigned ctx
+	ctx, span := sources.InitConnectionSpan(ctx, tracer, SourceKind, r.Name)
+	defer span.End()
+
+	userAgent, err := util.UserAgentFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig, err := sourceutil.LoadTLSConfig(r.TLSCAFile)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load TLS config: %w", err)
+	}
+
+	timeout := r.Timeout
+	if timeout == 0 {
+		timeout = DefaultTimeout
+	}
+	opts := influxdb2.DefaultOptions().
+		SetHTTPRequestTimeout(uint(timeout)).
+		SetApplicationName(userAgent)
+	if tlsConfig != nil {
+		opts.SetTLSConfig(tlsConfig)
+	}
+	return influxdb2.NewClientWithOptions(r.URL, r.Token, opts), nil
+}
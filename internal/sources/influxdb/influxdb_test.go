@@ -0,0 +1,172 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package influxdb
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestParseFromYamlInfluxDB(t *testing.T) {
+	tests := []struct {
+		name        string
+		yamlContent string
+		wantErr     string
+		expected    Config
+	}{
+		{
+			name: "basic",
+			yamlContent: `name: test-influxdb
+kind: influxdb
+url: http://localhost:8086
+token: my-token
+org: my-org
+bucket: metrics
+timeout: 10`,
+			expected: Config{
+				Name:    "test-influxdb",
+				Kind:    "influxdb",
+				URL:     "http://localhost:8086",
+				Token:   "my-token",
+				Org:     "my-org",
+				Bucket:  "metrics",
+				Timeout: 10,
+			},
+		},
+		{
+			name: "invalid url",
+			yamlContent: `name: test-influxdb
+kind: influxdb
+url: localhost:8086
+token: my-token
+org: my-org`,
+			wantErr: `url "localhost:8086" must be an http or https URL`,
+		},
+		{
+			name: "negative timeout",
+			yamlContent: `name: test-influxdb
+kind: influxdb
+url: http://localhost:8086
+token: my-token
+org: my-org
+timeout: -1`,
+			wantErr: "timeout must not be negative",
+		},
+		{
+			name: "missing token",
+			yamlContent: `name: test-influxdb
+kind: influxdb
+url: http://localhost:8086
+org: my-org`,
+			wantErr: "token or tokenFile is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decoder := yaml.NewDecoder(bytes.NewReader([]byte(tt.yamlContent)))
+			config, err := newConfig(context.Background(), "test-influxdb", decoder)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, config)
+		})
+	}
+}
+
+const fluxResponse = `#datatype,string,long,dateTime:RFC3339,double,string,string
+#group,false,false,false,false,true,true
+#default,_result,,,,,
+,result,table,_time,_value,_field,_measurement
+,,0,2025-01-01T00:00:00Z,1.5,usage,cpu
+
+`
+
+// newTestSource starts a fake InfluxDB server and returns a source connected
+// to it, along with the body of the last write request.
+func newTestSource(t *testing.T, healthStatus string) (*Source, *string, error) {
+	t.Helper()
+	var written string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/health":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = io.WriteString(w, `{"name":"influxdb","status":"`+healthStatus+`","message":"ready for queries and writes"}`)
+		case "/api/v2/query":
+			w.Header().Set("Content-Type", "text/csv")
+			_, _ = io.WriteString(w, fluxResponse)
+		case "/api/v2/write":
+			body, _ := io.ReadAll(r.Body)
+			written = string(body)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	ctx := util.WithUserAgent(context.Background(), "test")
+	cfg := Config{Name: "test-influxdb", Kind: SourceKind, URL: server.URL, Token: "my-token", Org: "my-org", Bucket: "metrics"}
+	src, err := cfg.Initialize(ctx, noop.NewTracerProvider().Tracer("test"))
+	if err != nil {
+		return nil, nil, err
+	}
+	t.Cleanup(func() { _ = src.(*Source).Close() })
+	return src.(*Source), &written, nil
+}
+
+func TestInitializeHealthCheck(t *testing.T) {
+	_, _, err := newTestSource(t, "fail")
+	assert.ErrorContains(t, err, `server reported status "fail"`)
+}
+
+func TestQuery(t *testing.T) {
+	s, _, err := newTestSource(t, "pass")
+	require.NoError(t, err)
+
+	records, err := s.Query(context.Background(), `from(bucket: "metrics") |> range(start: -1h)`)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, "cpu", records[0].Measurement)
+	assert.Equal(t, "usage", records[0].Field)
+	assert.Equal(t, 1.5, records[0].Value)
+	assert.Equal(t, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), records[0].Time)
+}
+
+func TestWritePoint(t *testing.T) {
+	s, written, err := newTestSource(t, "pass")
+	require.NoError(t, err)
+
+	ts := time.Unix(1700000000, 0)
+	err = s.WritePoint(context.Background(), "cpu", map[string]any{"host": "a", "core": 1}, map[string]any{"usage": 0.5}, ts)
+	require.NoError(t, err)
+	assert.Contains(t, *written, "cpu,core=1,host=a usage=0.5 1700000000000000000")
+
+	err = s.WritePoint(context.Background(), "cpu", nil, nil, ts)
+	assert.ErrorContains(t, err, "at least one field")
+}
@@ -0,0 +1,94 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package influxdb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+	"github.com/influxdata/influxdb-client-go/v2/api/query"
+)
+
+// FluxRecord is a row of a Flux query result.
+type FluxRecord struct {
+	Table       int            // Index of the result table the row belongs to
+	Measurement string         // Value of the _measurement column, if present
+	Field       string         // Value of the _field column, if present
+	Time        time.Time      // Value of the _time column, if present
+	Value       any            // Value of the _value column, if present
+	Values      map[string]any // Every column of the row, keyed by name
+}
+
+// Query runs a Flux query against the configured organization and returns
+// every record of every result table.
+func (s *Source) Query(ctx context.Context, flux string) ([]FluxRecord, error) {
+	result, err := s.Client.QueryAPI(s.Org).Query(ctx, flux)
+	if err != nil {
+		return nil, fmt.Errorf("unable to run query: %w", err)
+	}
+	defer result.Close()
+
+	records := []FluxRecord{}
+	for result.Next() {
+		records = append(records, toFluxRecord(result.Record()))
+	}
+	if err := result.Err(); err != nil {
+		return nil, fmt.Errorf("unable to read query results: %w", err)
+	}
+	return records, nil
+}
+
+// WritePoint writes a single point to the configured bucket and waits for the
+// server to accept it. Tag values are converted to strings; field values
+// must be numbers, strings, or booleans.
+func (s *Source) WritePoint(ctx context.Context, measurement string, tags, fields map[string]any, t time.Time) error {
+	if s.Bucket == "" {
+		return fmt.Errorf("source %q (%s): WritePoint requires bucket", s.Name, SourceKind)
+	}
+	if len(fields) == 0 {
+		return fmt.Errorf("a point requires at least one field")
+	}
+
+	tagValues := make(map[string]string, len(tags))
+	for k, v := range tags {
+		tagValues[k] = fmt.Sprint(v)
+	}
+	point := influxdb2.NewPoint(measurement, tagValues, fields, t)
+	if err := s.Client.WriteAPIBlocking(s.Org, s.Bucket).WritePoint(ctx, point); err != nil {
+		return fmt.Errorf("unable to write point to bucket %q: %w", s.Bucket, err)
+	}
+	return nil
+}
+
+func toFluxRecord(r *query.FluxRecord) FluxRecord {
+	values := r.Values()
+	record := FluxRecord{
+		Table:  r.Table(),
+		Value:  values["_value"],
+		Values: values,
+	}
+	if m, ok := values["_measurement"].(string); ok {
+		record.Measurement = m
+	}
+	if f, ok := values["_field"].(string); ok {
+		record.Field = f
+	}
+	if t, ok := values["_time"].(time.Time); ok {
+		record.Time = t
+	}
+	return record
+}
@@ -0,0 +1,260 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package kafka provides a source implementation for Apache Kafka.
+//
+// The source produces to and consumes from a single topic. Consumption uses a
+// consumer group, so offsets are committed to Kafka and several toolbox
+// instances can share the work of a topic.
+package kafka
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	sourceutil "github.com/googleapis/genai-toolbox/internal/sources/util"
+	"github.com/segmentio/kafka-go"
+	"github.com/segmentio/kafka-go/sasl"
+	"github.com/segmentio/kafka-go/sasl/plain"
+	"github.com/segmentio/kafka-go/sasl/scram"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const SourceKind string = "kafka"
+
+// DefaultDialTimeout bounds how long connecting to a single broker may take.
+const DefaultDialTimeout = 10 * time.Second
+
+// Supported SASL mechanisms.
+const (
+	SASLPlain       = "PLAIN"
+	SASLScramSHA256 = "SCRAM-SHA-256"
+	SASLScramSHA512 = "SCRAM-SHA-512"
+)
+
+// validate interface
+var _ sources.SourceConfig = Config{}
+
+func init() {
+	sources.MustRegister(SourceKind, newConfig)
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources.SourceConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+
+	password, err := sourceutil.ResolveSecret("saslPassword", actual.SASLPassword, actual.SASLPasswordFile)
+	if err != nil {
+		return nil, fmt.Errorf("source %q (%s): unable to resolve secret: %w", name, SourceKind, err)
+	}
+	actual.SASLPassword = password
+
+	if len(actual.Brokers) == 0 {
+		return nil, fmt.Errorf("source %q (%s): at least one broker is required", name, SourceKind)
+	}
+	for _, broker := range actual.Brokers {
+		if !strings.Contains(broker, ":") {
+			return nil, fmt.Errorf("source %q (%s): broker %q must be in host:port form", name, SourceKind, broker)
+		}
+	}
+	switch actual.SASLMechanism {
+	case "":
+		if actual.SASLUsername != "" || actual.SASLPassword != "" {
+			return nil, fmt.Errorf("source %q (%s): saslUsername and saslPassword require saslMechanism", name, SourceKind)
+		}
+	case SASLPlain, SASLScramSHA256, SASLScramSHA512:
+		if actual.SASLUsername == "" || actual.SASLPassword == "" {
+			return nil, fmt.Errorf("source %q (%s): saslMechanism %q requires saslUsername and saslPassword", name, SourceKind, actual.SASLMechanism)
+		}
+	default:
+		return nil, fmt.Errorf("source %q (%s): unsupported saslMechanism %q, must be one of %q, %q, or %q", name, SourceKind, actual.SASLMechanism, SASLPlain, SASLScramSHA256, SASLScramSHA512)
+	}
+	if actual.TLSCAFile != "" && !actual.TLS {
+		return nil, fmt.Errorf("source %q (%s): tlsCAFile requires tls", name, SourceKind)
+	}
+	return actual, nil
+}
+
+type Config struct {
+	Name             string   `yaml:"name" validate:"required"`
+	Kind             string   `yaml:"kind" validate:"required"`
+	Brokers          []string `yaml:"brokers" validate:"required"` // Bootstrap brokers in host:port form
+	Topic            string   `yaml:"topic" validate:"required"`
+	GroupID          string   `yaml:"groupId"`       // Optional: consumer group, required to consume
+	ClientID         string   `yaml:"clientId"`      // Optional: client ID reported to the brokers
+	TLS              bool     `yaml:"tls"`           // Optional: connect to the brokers over TLS
+	TLSCAFile        string   `yaml:"tlsCAFile"`     // Optional: path to CA certificate for TLS
+	SASLMechanism    string   `yaml:"saslMechanism"` // Optional: PLAIN, SCRAM-SHA-256, or SCRAM-SHA-512
+	SASLUsername     string   `yaml:"saslUsername"`
+	SASLPassword     string   `yaml:"saslPassword"`
+	SASLPasswordFile string   `yaml:"saslPasswordFile"`
+}
+
+func (r Config) SourceConfigKind() string {
+	return SourceKind
+}
+
+func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.Source, error) {
+	dialer, writer, err := initKafkaClients(ctx, tracer, r)
+	if err != nil {
+		return nil, fmt.Errorf("source %q (%s): unable to create Kafka client: %w", r.Name, SourceKind, err)
+	}
+
+	s := &Source{
+		Config: r,
+		Dialer: dialer,
+		Writer: writer,
+	}
+	if err := s.HealthCheck(ctx); err != nil {
+		_ = writer.Close()
+		return nil, fmt.Errorf("source %q (%s): unable to connect successfully: %w", r.Name, SourceKind, err)
+	}
+	return s, nil
+}
+
+var _ sources.Source = &Source{}
+
+type Source struct {
+	Config
+	Dialer *kafka.Dialer
+	Writer *kafka.Writer
+}
+
+func (s *Source) SourceKind() string {
+	return SourceKind
+}
+
+func (s *Source) ToConfig() sources.SourceConfig {
+	return s.Config
+}
+
+// HealthCheck connects to the first reachable broker and verifies that the
+// configured topic has at least one partition.
+func (s *Source) HealthCheck(ctx context.Context) error {
+	conn, err := s.dialAny(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	partitions, err := conn.ReadPartitions(s.Topic)
+	if err != nil {
+		return fmt.Errorf("unable to read partitions of topic %q: %w", s.Topic, err)
+	}
+	if len(partitions) == 0 {
+		return fmt.Errorf("topic %q has no partitions", s.Topic)
+	}
+	return nil
+}
+
+// KafkaWriter returns the underlying Kafka writer for direct API access.
+func (s *Source) KafkaWriter() *kafka.Writer {
+	return s.Writer
+}
+
+// Close flushes pending messages and closes the writer.
+func (s *Source) Close() error {
+	if s.Writer == nil {
+		return nil
+	}
+	return s.Writer.Close()
+}
+
+// dialAny connects to the configured brokers in order and returns the first
+// successful connection.
+func (s *Source) dialAny(ctx context.Context) (*kafka.Conn, error) {
+	var errs []error
+	for _, broker := range s.Brokers {
+		conn, err := s.Dialer.DialContext(ctx, "tcp", broker)
+		if err == nil {
+			return conn, nil
+		}
+		errs = append(errs, fmt.Errorf("broker %s: %w", broker, err))
+	}
+	return nil, fmt.Errorf("unable to reach any broker: %w", errors.Join(errs...))
+}
+
+// saslMechanism returns the SASL mechanism for r, or nil if SASL is disabled.
+func saslMechanism(r Config) (sasl.Mechanism, error) {
+	switch r.SASLMechanism {
+	case SASLPlain:
+		return plain.Mechanism{Username: r.SASLUsername, Password: r.SASLPassword}, nil
+	case SASLScramSHA256:
+		return scram.Mechanism(scram.SHA256, r.SASLUsername, r.SASLPassword)
+	case SASLScramSHA512:
+		return scram.Mechanism(scram.SHA512, r.SASLUsername, r.SASLPassword)
+	default:
+		return nil, nil
+	}
+}
+
+// tlsConfig returns the TLS configuration for r, or nil if TLS is disabled.
+func tlsConfig(r Config) (*tls.Config, error) {
+	if !r.TLS {
+		return nil, nil
+	}
+	if r.TLSCAFile == "" {
+		return &tls.Config{}, nil
+	}
+	return sourceutil.LoadTLSConfig(r.TLSCAFile)
+}
+
+func initKafkaClients(ctx context.Context, tracer trace.Tracer, r Config) (*kafka.Dialer, *kafka.Writer, error) {
+	//nolint:all // Reassigned ctx
+	ctx, span := sources.InitConnectionSpan(ctx, tracer, SourceKind, r.Name)
+	defer span.End()
+
+	mechanism, err := saslMechanism(r)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to configure SASL: %w", err)
+	}
+	tlsCfg, err := tlsConfig(r)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to load TLS config: %w", err)
+	}
+
+	clientID := r.ClientID
+	if clientID == "" {
+		clientID = "genai-toolbox"
+	}
+
+	dialer := &kafka.Dialer{
+		ClientID:      clientID,
+		Timeout:       DefaultDialTimeout,
+		DualStack:     true,
+		TLS:           tlsCfg,
+		SASLMechanism: mechanism,
+	}
+	writer := &kafka.Writer{
+		Addr:         kafka.TCP(r.Brokers...),
+		Topic:        r.Topic,
+		Balancer:     &kafka.Hash{},
+		RequiredAcks: kafka.RequireAll,
+		Transport: &kafka.Transport{
+			ClientID:    clientID,
+			DialTimeout: DefaultDialTimeout,
+			TLS:         tlsCfg,
+			SASL:        mechanism,
+		},
+	}
+	return dialer, writer, nil
+}
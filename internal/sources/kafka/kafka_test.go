@@ -0,0 +1,204 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/goccy/go-yaml"
+	"github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFromYamlKafka(t *testing.T) {
+	tests := []struct {
+		name        string
+		yamlContent string
+		wantErr     string
+		expected    Config
+	}{
+		{
+			name: "plaintext",
+			yamlContent: `name: test-kafka
+kind: kafka
+brokers:
+  - localhost:9092
+topic: events
+groupId: toolbox`,
+			expected: Config{
+				Name:    "test-kafka",
+				Kind:    "kafka",
+				Brokers: []string{"localhost:9092"},
+				Topic:   "events",
+				GroupID: "toolbox",
+			},
+		},
+		{
+			name: "tls with scram",
+			yamlContent: `name: test-kafka
+kind: kafka
+brokers:
+  - b-1.example.com:9096
+  - b-2.example.com:9096
+topic: events
+tls: true
+tlsCAFile: /certs/ca.pem
+saslMechanism: SCRAM-SHA-512
+saslUsername: toolbox
+saslPassword: secret`,
+			expected: Config{
+				Name:          "test-kafka",
+				Kind:          "kafka",
+				Brokers:       []string{"b-1.example.com:9096", "b-2.example.com:9096"},
+				Topic:         "events",
+				TLS:           true,
+				TLSCAFile:     "/certs/ca.pem",
+				SASLMechanism: SASLScramSHA512,
+				SASLUsername:  "toolbox",
+				SASLPassword:  "secret",
+			},
+		},
+		{
+			name: "no brokers",
+			yamlContent: `name: test-kafka
+kind: kafka
+topic: events`,
+			wantErr: "at least one broker is required",
+		},
+		{
+			name: "broker without port",
+			yamlContent: `name: test-kafka
+kind: kafka
+brokers:
+  - localhost
+topic: events`,
+			wantErr: `broker "localhost" must be in host:port form`,
+		},
+		{
+			name: "unsupported sasl mechanism",
+			yamlContent: `name: test-kafka
+kind: kafka
+brokers:
+  - localhost:9092
+topic: events
+saslMechanism: GSSAPI
+saslUsername: toolbox
+saslPassword: secret`,
+			wantErr: `unsupported saslMechanism "GSSAPI"`,
+		},
+		{
+			name: "sasl mechanism without password",
+			yamlContent: `name: test-kafka
+kind: kafka
+brokers:
+  - localhost:9092
+topic: events
+saslMechanism: PLAIN
+saslUsername: toolbox`,
+			wantErr: `saslMechanism "PLAIN" requires saslUsername and saslPassword`,
+		},
+		{
+			name: "credentials without sasl mechanism",
+			yamlContent: `name: test-kafka
+kind: kafka
+brokers:
+  - localhost:9092
+topic: events
+saslUsername: toolbox`,
+			wantErr: "saslUsername and saslPassword require saslMechanism",
+		},
+		{
+			name: "ca file without tls",
+			yamlContent: `name: test-kafka
+kind: kafka
+brokers:
+  - localhost:9092
+topic: events
+tlsCAFile: /certs/ca.pem`,
+			wantErr: "tlsCAFile requires tls",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decoder := yaml.NewDecoder(bytes.NewReader([]byte(tt.yamlContent)))
+			config, err := newConfig(context.Background(), "test-kafka", decoder)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, config)
+		})
+	}
+}
+
+func TestSASLMechanism(t *testing.T) {
+	for _, name := range []string{SASLPlain, SASLScramSHA256, SASLScramSHA512} {
+		m, err := saslMechanism(Config{SASLMechanism: name, SASLUsername: "toolbox", SASLPassword: "secret"})
+		require.NoError(t, err)
+		assert.Equal(t, name, m.Name())
+	}
+
+	m, err := saslMechanism(Config{})
+	require.NoError(t, err)
+	assert.Nil(t, m)
+}
+
+func TestTLSConfig(t *testing.T) {
+	cfg, err := tlsConfig(Config{})
+	require.NoError(t, err)
+	assert.Nil(t, cfg)
+
+	cfg, err = tlsConfig(Config{TLS: true})
+	require.NoError(t, err)
+	assert.NotNil(t, cfg)
+
+	_, err = tlsConfig(Config{TLS: true, TLSCAFile: "/does/not/exist.pem"})
+	assert.ErrorContains(t, err, "unable to read CA file")
+}
+
+func TestToMessage(t *testing.T) {
+	now := time.Now()
+	got := toMessage(kafka.Message{
+		Topic:     "events",
+		Partition: 2,
+		Offset:    42,
+		Key:       []byte("k"),
+		Value:     []byte("v"),
+		Headers:   []kafka.Header{{Key: "trace-id", Value: []byte("abc")}},
+		Time:      now,
+	})
+	assert.Equal(t, Message{
+		Topic:     "events",
+		Partition: 2,
+		Offset:    42,
+		Key:       []byte("k"),
+		Value:     []byte("v"),
+		Headers:   map[string][]byte{"trace-id": []byte("abc")},
+		Time:      now,
+	}, got)
+}
+
+func TestConsumeRequiresGroupID(t *testing.T) {
+	s := &Source{Config: Config{Name: "test-kafka", Topic: "events"}}
+	err := s.Consume(context.Background(), func(Message) error { return nil })
+	assert.ErrorContains(t, err, "Consume requires groupId")
+	assert.NoError(t, s.Close())
+}
@@ -0,0 +1,103 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// Message is a record read from the configured topic.
+type Message struct {
+	Topic     string
+	Partition int
+	Offset    int64
+	Key       []byte
+	Value     []byte
+	Headers   map[string][]byte
+	Time      time.Time
+}
+
+// Consume reads messages from the configured topic as a member of the
+// configured consumer group and passes each one to handler. A message's
+// offset is committed only after handler returns nil, so messages are
+// delivered at least once.
+//
+// Consume blocks until ctx is cancelled, in which case it returns nil, or
+// until handler or the reader returns an error.
+func (s *Source) Consume(ctx context.Context, handler func(Message) error) error {
+	if s.GroupID == "" {
+		return fmt.Errorf("source %q (%s): Consume requires groupId", s.Name, SourceKind)
+	}
+
+	reader := kafka.NewReader(kafka.ReaderConfig{
+		Brokers: s.Brokers,
+		GroupID: s.GroupID,
+		Topic:   s.Topic,
+		Dialer:  s.Dialer,
+	})
+	defer reader.Close()
+
+	for {
+		msg, err := reader.FetchMessage(ctx)
+		if err != nil {
+			if ctx.Err() != nil && errors.Is(err, ctx.Err()) {
+				return nil
+			}
+			return fmt.Errorf("unable to fetch message from topic %q: %w", s.Topic, err)
+		}
+		if err := handler(toMessage(msg)); err != nil {
+			return fmt.Errorf("handler failed on %s/%d@%d: %w", msg.Topic, msg.Partition, msg.Offset, err)
+		}
+		if err := reader.CommitMessages(ctx, msg); err != nil {
+			if ctx.Err() != nil && errors.Is(err, ctx.Err()) {
+				return nil
+			}
+			return fmt.Errorf("unable to commit offset %d of %s/%d: %w", msg.Offset, msg.Topic, msg.Partition, err)
+		}
+	}
+}
+
+// Produce writes a message with key and value to the configured topic and
+// waits for all in-sync replicas to acknowledge it. Messages with the same
+// key are written to the same partition.
+func (s *Source) Produce(ctx context.Context, key, value []byte) error {
+	if err := s.Writer.WriteMessages(ctx, kafka.Message{Key: key, Value: value}); err != nil {
+		return fmt.Errorf("unable to produce to topic %q: %w", s.Topic, err)
+	}
+	return nil
+}
+
+func toMessage(msg kafka.Message) Message {
+	out := Message{
+		Topic:     msg.Topic,
+		Partition: msg.Partition,
+		Offset:    msg.Offset,
+		Key:       msg.Key,
+		Value:     msg.Value,
+		Time:      msg.Time,
+	}
+	if len(msg.Headers) > 0 {
+		out.Headers = make(map[string][]byte, len(msg.Headers))
+		for _, h := range msg.Headers {
+			out.Headers[h.Key] = h.Value
+		}
+	}
+	return out
+}
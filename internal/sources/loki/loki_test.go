@@ -0,0 +1,202 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loki
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/goccy/go-yaml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestParseFromYamlLoki(t *testing.T) {
+	tests := []struct {
+		name        string
+		yamlContent string
+		wantErr     string
+		expected    Config
+	}{
+		{
+			name: "basic configuration",
+			yamlContent: `name: test-loki
+kind: loki
+url: http://loki:3100`,
+			expected: Config{
+				Name: "test-loki",
+				Kind: "loki",
+				URL:  "http://loki:3100",
+			},
+		},
+		{
+			name: "tenant, basic auth and timeout",
+			yamlContent: `name: test-loki
+kind: loki
+url: https://logs.example.com
+tenant: team-a
+username: reader
+password: secret
+timeout: 10`,
+			expected: Config{
+				Name:     "test-loki",
+				Kind:     "loki",
+				URL:      "https://logs.example.com",
+				Tenant:   "team-a",
+				Username: "reader",
+				Password: "secret",
+				Timeout:  10,
+			},
+		},
+		{
+			name: "username without password",
+			yamlContent: `name: test-loki
+kind: loki
+url: http://loki:3100
+username: reader`,
+			wantErr: "username and password must both be set",
+		},
+		{
+			name: "negative timeout",
+			yamlContent: `name: test-loki
+kind: loki
+url: http://loki:3100
+timeout: -1`,
+			wantErr: "timeout must not be negative",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decoder := yaml.NewDecoder(bytes.NewReader([]byte(tt.yamlContent)))
+			config, err := newConfig(context.Background(), "test-loki", decoder)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, config)
+		})
+	}
+}
+
+func newTestSource(t *testing.T, handler http.HandlerFunc) *Source {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ready", func(w http.ResponseWriter, r *http.Request) {
+		user, pass, ok := r.BasicAuth()
+		if !ok || user != "reader" || pass != "secret" || r.Header.Get("X-Scope-OrgID") != "team-a" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte("ready\n"))
+	})
+	mux.HandleFunc("/loki/api/v1/", handler)
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	cfg := Config{Name: "test", Kind: SourceKind, URL: server.URL, Tenant: "team-a", Username: "reader", Password: "secret"}
+	src, err := cfg.Initialize(context.Background(), noop.NewTracerProvider().Tracer("test"))
+	require.NoError(t, err)
+	return src.(*Source)
+}
+
+func TestQueryRange(t *testing.T) {
+	var gotPath, gotQuery, gotStart, gotEnd, gotLimit, gotTenant string
+	s := newTestSource(t, func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotQuery = r.FormValue("query")
+		gotStart = r.FormValue("start")
+		gotEnd = r.FormValue("end")
+		gotLimit = r.FormValue("limit")
+		gotTenant = r.Header.Get("X-Scope-OrgID")
+		_, _ = w.Write([]byte(`{"status":"success","data":{"resultType":"streams","result":[
+			{"stream":{"app":"api"},"values":[["1714564830000000000","GET /health 200"],["1714564800000000000","GET / 200"]]},
+			{"stream":{"app":"db"},"values":[["1714564815000000000","checkpoint complete"]]}
+		]}}`))
+	})
+
+	start := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	end := start.Add(time.Minute)
+	entries, err := s.QueryRange(context.Background(), `{app=~"api|db"}`, start, end, 100)
+	require.NoError(t, err)
+	assert.Equal(t, "/loki/api/v1/query_range", gotPath)
+	assert.Equal(t, `{app=~"api|db"}`, gotQuery)
+	assert.Equal(t, "1714564800000000000", gotStart)
+	assert.Equal(t, "1714564860000000000", gotEnd)
+	assert.Equal(t, "100", gotLimit)
+	assert.Equal(t, "team-a", gotTenant)
+
+	require.Len(t, entries, 3)
+	assert.Equal(t, LogEntry{Timestamp: start.Add(30 * time.Second), Labels: map[string]string{"app": "api"}, Line: "GET /health 200"}, entries[0])
+	assert.Equal(t, "checkpoint complete", entries[1].Line)
+	assert.Equal(t, start, entries[2].Timestamp)
+
+	_, err = s.QueryRange(context.Background(), `{app="api"}`, end, start, 0)
+	assert.ErrorContains(t, err, "end must not be before start")
+	_, err = s.QueryRange(context.Background(), `{app="api"}`, start, end, -1)
+	assert.ErrorContains(t, err, "limit must not be negative")
+}
+
+func TestQueryRangeErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		wantErr string
+	}{
+		{
+			name:    "bad query",
+			status:  http.StatusBadRequest,
+			body:    "parse error at line 1, col 1: syntax error: unexpected IDENTIFIER\n",
+			wantErr: "status 400: parse error",
+		},
+		{
+			name:    "metric query",
+			status:  http.StatusOK,
+			body:    `{"status":"success","data":{"resultType":"matrix","result":[]}}`,
+			wantErr: `unsupported result type "matrix"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestSource(t, func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			})
+			start := time.Now().Add(-time.Hour)
+			_, err := s.QueryRange(context.Background(), `{app="api"}`, start, start.Add(time.Hour), 0)
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestInitializeNotReady(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = w.Write([]byte("Ingester not ready: waiting for 15s after being ready\n"))
+	}))
+	defer server.Close()
+
+	cfg := Config{Name: "test", Kind: SourceKind, URL: server.URL}
+	_, err := cfg.Initialize(context.Background(), noop.NewTracerProvider().Tracer("test"))
+	assert.ErrorContains(t, err, "unable to connect successfully")
+}
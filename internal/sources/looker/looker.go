@@ -37,9 +37,7 @@ const SourceKind string = "looker"
 var _ sources.SourceConfig = Config{}
 
 func init() {
-	if !sources.Register(SourceKind, newConfig) {
-		panic(fmt.Sprintf("source kind %q already registered", SourceKind))
-	}
+	sources.MustRegister(SourceKind, newConfig)
 }
 
 func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources.SourceConfig, error) {
@@ -160,6 +158,16 @@ func (s *Source) ToConfig() sources.SourceConfig {
 	return s.Config
 }
 
+// HealthCheck fetches the current user. With client OAuth there are no
+// server-side credentials to check, so it always succeeds.
+func (s *Source) HealthCheck(ctx context.Context) error {
+	if s.UseClientAuthorization() {
+		return nil
+	}
+	_, err := s.Client.Me("id", s.ApiSettings)
+	return err
+}
+
 func (s *Source) GetApiSettings() *rtl.ApiSettings {
 	return s.ApiSettings
 }
@@ -0,0 +1,159 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package mock provides an in-memory source that returns canned results, so
+// tool pipelines can be tested without a live backend.
+package mock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"strings"
+	"sync"
+
+	"github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const SourceKind string = "mock"
+
+// validate interface
+var _ sources.SourceConfig = Config{}
+
+func init() {
+	sources.MustRegister(SourceKind, newConfig)
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources.SourceConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool, len(actual.Responses))
+	for i, r := range actual.Responses {
+		query := normalizeQuery(r.Query)
+		if query == "" {
+			return nil, fmt.Errorf("source %q (%s): responses[%d] has no query", name, SourceKind, i)
+		}
+		if seen[query] {
+			return nil, fmt.Errorf("source %q (%s): duplicate response for query %q", name, SourceKind, r.Query)
+		}
+		seen[query] = true
+	}
+	return actual, nil
+}
+
+// Config represents the configuration for a mock source.
+type Config struct {
+	Name      string     `yaml:"name" validate:"required"`
+	Kind      string     `yaml:"kind" validate:"required"`
+	Responses []Response `yaml:"responses"` // Canned results, matched by query
+}
+
+// Response is the canned result for a query. If Error is set, querying
+// returns an error with that message instead of Rows.
+type Response struct {
+	Query string           `yaml:"query" validate:"required"`
+	Rows  []map[string]any `yaml:"rows"`
+	Error string           `yaml:"error"`
+}
+
+func (r Config) SourceConfigKind() string {
+	return SourceKind
+}
+
+func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.Source, error) {
+	//nolint:all // Reassigned ctx
+	ctx, span := sources.InitConnectionSpan(ctx, tracer, SourceKind, r.Name)
+	defer span.End()
+
+	responses := make(map[string]Response, len(r.Responses))
+	for _, resp := range r.Responses {
+		responses[normalizeQuery(resp.Query)] = resp
+	}
+
+	s := &Source{
+		Config:    r,
+		responses: responses,
+	}
+	return s, nil
+}
+
+var _ sources.Source = &Source{}
+
+// Source is an in-memory source that answers queries from its configured
+// responses and records every query it receives.
+type Source struct {
+	Config
+	responses map[string]Response
+
+	mu      sync.Mutex
+	queries []string
+}
+
+func (s *Source) SourceKind() string {
+	return SourceKind
+}
+
+func (s *Source) ToConfig() sources.SourceConfig {
+	return s.Config
+}
+
+// HealthCheck always succeeds.
+func (s *Source) HealthCheck(ctx context.Context) error {
+	return nil
+}
+
+// Query returns the canned rows for query. Queries are matched exactly after
+// trimming surrounding whitespace. A query without a configured response
+// returns an error that matches sources.ErrNotFound.
+func (s *Source) Query(ctx context.Context, query string) ([]map[string]any, error) {
+	s.mu.Lock()
+	s.queries = append(s.queries, query)
+	s.mu.Unlock()
+
+	if err := ctx.Err(); err != nil {
+		return nil, sources.ClassifyError(err)
+	}
+
+	resp, ok := s.responses[normalizeQuery(query)]
+	if !ok {
+		return nil, sources.WithKind(fmt.Errorf("no mock response for query %q", query), sources.ErrNotFound)
+	}
+	if resp.Error != "" {
+		return nil, errors.New(resp.Error)
+	}
+
+	// Copy rows so callers can't modify the configured response
+	rows := make([]map[string]any, len(resp.Rows))
+	for i, row := range resp.Rows {
+		rows[i] = maps.Clone(row)
+	}
+	return rows, nil
+}
+
+// Queries returns the queries received so far, in order.
+func (s *Source) Queries() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.queries...)
+}
+
+func normalizeQuery(query string) string {
+	return strings.TrimSpace(query)
+}
@@ -0,0 +1,193 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mock_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/sources/mock"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestParseFromYamlMock(t *testing.T) {
+	tcs := []struct {
+		desc string
+		in   string
+		want server.SourceConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+            sources:
+                my-mock:
+                    kind: mock
+                    responses:
+                        - query: SELECT name FROM users
+                          rows:
+                            - name: alice
+                            - name: bob
+                        - query: SELECT broken
+                          error: syntax error
+            `,
+			want: map[string]sources.SourceConfig{
+				"my-mock": mock.Config{
+					Name: "my-mock",
+					Kind: mock.SourceKind,
+					Responses: []mock.Response{
+						{
+							Query: "SELECT name FROM users",
+							Rows:  []map[string]any{{"name": "alice"}, {"name": "bob"}},
+						},
+						{
+							Query: "SELECT broken",
+							Error: "syntax error",
+						},
+					},
+				},
+			},
+		},
+		{
+			desc: "no responses",
+			in: `
+            sources:
+                my-mock:
+                    kind: mock
+            `,
+			want: map[string]sources.SourceConfig{
+				"my-mock": mock.Config{
+					Name: "my-mock",
+					Kind: mock.SourceKind,
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Sources server.SourceConfigs `yaml:"sources"`
+			}{}
+			// Parse contents
+			err := yaml.Unmarshal(testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if !cmp.Equal(tc.want, got.Sources) {
+				t.Fatalf("incorrect parse: want %v, got %v", tc.want, got.Sources)
+			}
+		})
+	}
+}
+
+func TestFailParseFromYamlMock(t *testing.T) {
+	tcs := []struct {
+		desc string
+		in   string
+		err  string
+	}{
+		{
+			desc: "duplicate query",
+			in: `
+            sources:
+                my-mock:
+                    kind: mock
+                    responses:
+                        - query: SELECT 1
+                        - query: " SELECT 1 "
+            `,
+			err: "duplicate response for query",
+		},
+		{
+			desc: "blank query",
+			in: `
+            sources:
+                my-mock:
+                    kind: mock
+                    responses:
+                        - query: "  "
+                          error: boom
+            `,
+			err: "responses[0] has no query",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Sources server.SourceConfigs `yaml:"sources"`
+			}{}
+			err := yaml.Unmarshal(testutils.FormatYaml(tc.in), &got)
+			if err == nil {
+				t.Fatalf("expect parsing to fail")
+			}
+			if !strings.Contains(err.Error(), tc.err) {
+				t.Fatalf("unexpected error: got %q, want substring %q", err, tc.err)
+			}
+		})
+	}
+}
+
+func TestQuery(t *testing.T) {
+	cfg := mock.Config{
+		Name: "my-mock",
+		Kind: mock.SourceKind,
+		Responses: []mock.Response{
+			{Query: "SELECT name FROM users", Rows: []map[string]any{{"name": "alice"}}},
+			{Query: "SELECT broken", Error: "syntax error"},
+		},
+	}
+	src, err := cfg.Initialize(context.Background(), noop.NewTracerProvider().Tracer("test"))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	s := src.(*mock.Source)
+	ctx := context.Background()
+
+	rows, err := s.Query(ctx, "  SELECT name FROM users\n")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if diff := cmp.Diff([]map[string]any{{"name": "alice"}}, rows); diff != "" {
+		t.Fatalf("unexpected rows (-want +got):\n%s", diff)
+	}
+
+	// Returned rows don't alias the configured response
+	rows[0]["name"] = "mallory"
+	rows, err = s.Query(ctx, "SELECT name FROM users")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if rows[0]["name"] != "alice" {
+		t.Fatalf("configured response was modified: got %v", rows[0]["name"])
+	}
+
+	if _, err := s.Query(ctx, "SELECT broken"); err == nil || err.Error() != "syntax error" {
+		t.Fatalf("got error %v, want %q", err, "syntax error")
+	}
+	if _, err := s.Query(ctx, "SELECT unknown"); !errors.Is(err, sources.ErrNotFound) {
+		t.Fatalf("got error %v, want ErrNotFound", err)
+	}
+
+	want := []string{"  SELECT name FROM users\n", "SELECT name FROM users", "SELECT broken", "SELECT unknown"}
+	if diff := cmp.Diff(want, s.Queries()); diff != "" {
+		t.Fatalf("unexpected queries (-want +got):\n%s", diff)
+	}
+}
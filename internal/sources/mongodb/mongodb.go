@@ -32,9 +32,7 @@ const SourceKind string = "mongodb"
 var _ sources.SourceConfig = Config{}
 
 func init() {
-	if !sources.Register(SourceKind, newConfig) {
-		panic(fmt.Sprintf("source kind %q already registered", SourceKind))
-	}
+	sources.MustRegister(SourceKind, newConfig)
 }
 
 func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources.SourceConfig, error) {
@@ -89,6 +87,11 @@ func (s *Source) ToConfig() sources.SourceConfig {
 	return s.Config
 }
 
+// HealthCheck pings the MongoDB deployment.
+func (s *Source) HealthCheck(ctx context.Context) error {
+	return s.Client.Ping(ctx, nil)
+}
+
 func (s *Source) MongoClient() *mongo.Client {
 	return s.Client
 }
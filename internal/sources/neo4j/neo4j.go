@@ -32,9 +32,7 @@ const SourceKind string = "neo4j"
 var _ sources.SourceConfig = Config{}
 
 func init() {
-	if !sources.Register(SourceKind, newConfig) {
-		panic(fmt.Sprintf("source kind %q already registered", SourceKind))
-	}
+	sources.MustRegister(SourceKind, newConfig)
 }
 
 func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources.SourceConfig, error) {
@@ -94,6 +92,11 @@ func (s *Source) ToConfig() sources.SourceConfig {
 	return s.Config
 }
 
+// HealthCheck verifies the driver can reach the Neo4j server.
+func (s *Source) HealthCheck(ctx context.Context) error {
+	return s.Driver.VerifyConnectivity(ctx)
+}
+
 func (s *Source) Neo4jDriver() neo4j.DriverWithContext {
 	return s.Driver
 }
@@ -14,39 +14,47 @@
 
 // Package neptune provides a source implementation for AWS Neptune graph database.
 //
-// This source provides Gremlin connectivity to Amazon Neptune clusters.
+// This source provides Gremlin connectivity to Amazon Neptune clusters, along with
+// openCypher and SPARQL queries over Neptune's HTTPS endpoints.
 // It supports both standard authentication and IAM authentication with SigV4 signing.
 package neptune
 
 import (
+	"bytes"
 	"context"
-	"crypto/sha256"
-	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"log/slog"
 	"net/http"
 	"net/url"
 	"strings"
+	"sync"
 	"time"
 
+	gremlingo "github.com/apache/tinkerpop/gremlin-go/v3/driver"
 	"github.com/aws/aws-sdk-go-v2/aws"
-	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
-	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/goccy/go-yaml"
-	gremlingo "github.com/apache/tinkerpop/gremlin-go/v3/driver"
 	"github.com/googleapis/genai-toolbox/internal/sources"
+	sourceutil "github.com/googleapis/genai-toolbox/internal/sources/util"
 	"go.opentelemetry.io/otel/trace"
 )
 
 const SourceKind string = "neptune"
 
+const (
+	DefaultHTTPTimeout = 30 * time.Second // Default timeout for openCypher, SPARQL, and loader requests
+
+	credentialRetrieveAttempts = 3                      // Attempts to retrieve credentials before giving up
+	credentialRetryBackoff     = 100 * time.Millisecond // Initial backoff between credential retrieval attempts
+)
+
 // validate interface
 var _ sources.SourceConfig = Config{}
 
 func init() {
-	if !sources.Register(SourceKind, newConfig) {
-		panic(fmt.Sprintf("source kind %q already registered", SourceKind))
-	}
+	sources.MustRegister(SourceKind, newConfig)
 }
 
 func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources.SourceConfig, error) {
@@ -54,14 +62,23 @@ func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources
 	if err := decoder.DecodeContext(ctx, &actual); err != nil {
 		return nil, err
 	}
+
+	if actual.QueryTimeout != "" {
+		d, err := time.ParseDuration(actual.QueryTimeout)
+		if err != nil || d < time.Millisecond {
+			return nil, fmt.Errorf("source %q (%s): invalid queryTimeout %q: must be a duration of at least 1ms such as \"30s\"", name, SourceKind, actual.QueryTimeout)
+		}
+	}
 	return actual, nil
 }
 
 type Config struct {
-	Name     string `yaml:"name" validate:"required"`
-	Kind     string `yaml:"kind" validate:"required"`
-	Endpoint string `yaml:"endpoint" validate:"required"` // wss://your-neptune-endpoint:8182/gremlin
-	UseIAM   bool   `yaml:"useIAM"`                        // Enable IAM authentication
+	Name           string `yaml:"name" validate:"required"`
+	Kind           string `yaml:"kind" validate:"required"`
+	Endpoint       string `yaml:"endpoint" validate:"required"` // wss://your-neptune-endpoint:8182/gremlin
+	ReaderEndpoint string `yaml:"readerEndpoint"`               // Optional: wss://your-neptune-reader-endpoint:8182/gremlin
+	UseIAM         bool   `yaml:"useIAM"`                       // Enable IAM authentication
+	QueryTimeout   string `yaml:"queryTimeout"`                 // Optional: limit on Gremlin query time, e.g. "30s" (default: the server's limit)
 }
 
 func (r Config) SourceConfigKind() string {
@@ -69,14 +86,29 @@ func (r Config) SourceConfigKind() string {
 }
 
 func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.Source, error) {
-	driver, err := initNeptuneDriver(ctx, tracer, r.Name, r.Endpoint, r.UseIAM)
+	driver, authProvider, err := initNeptuneDriver(ctx, tracer, r.Name, r.Endpoint, r.UseIAM)
 	if err != nil {
 		return nil, fmt.Errorf("source %q (%s): unable to create Neptune driver: %w", r.Name, SourceKind, err)
 	}
 
+	// Connect to the reader endpoint so read traffic can be routed to replicas
+	var readerDriver *gremlingo.DriverRemoteConnection
+	var readerAuthProvider *neptuneIAMAuthProvider
+	if r.ReaderEndpoint != "" {
+		readerDriver, readerAuthProvider, err = initNeptuneDriver(ctx, tracer, r.Name, r.ReaderEndpoint, r.UseIAM)
+		if err != nil {
+			driver.Close()
+			return nil, fmt.Errorf("source %q (%s): unable to create Neptune reader driver: %w", r.Name, SourceKind, err)
+		}
+	}
+
 	s := &Source{
-		Config: r,
-		Driver: driver,
+		Config:             r,
+		Driver:             driver,
+		Reader:             readerDriver,
+		HTTPClient:         &http.Client{Timeout: DefaultHTTPTimeout},
+		authProvider:       authProvider,
+		readerAuthProvider: readerAuthProvider,
 	}
 	return s, nil
 }
@@ -85,7 +117,15 @@ var _ sources.Source = &Source{}
 
 type Source struct {
 	Config
-	Driver *gremlingo.DriverRemoteConnection
+	Driver     *gremlingo.DriverRemoteConnection
+	Reader     *gremlingo.DriverRemoteConnection // Connection to the reader endpoint; nil if not configured
+	HTTPClient *http.Client                      // Client for Neptune's HTTPS query endpoints
+
+	// authProvider signs requests when IAM authentication is enabled; nil otherwise.
+	authProvider *neptuneIAMAuthProvider
+	// readerAuthProvider signs connections to the reader endpoint when IAM
+	// authentication is enabled and a readerEndpoint is configured; nil otherwise.
+	readerAuthProvider *neptuneIAMAuthProvider
 }
 
 func (s *Source) SourceKind() string {
@@ -96,16 +136,351 @@ func (s *Source) ToConfig() sources.SourceConfig {
 	return s.Config
 }
 
+// HealthCheck queries the Neptune status endpoint and reports whether the most
+// recent reader connection failed IAM signing, since reader traversals would
+// otherwise only fail with an opaque 403.
+func (s *Source) HealthCheck(ctx context.Context) error {
+	if _, err := s.doHTTPRequest(ctx, http.MethodGet, "/status", nil, nil); err != nil {
+		return err
+	}
+	if s.readerAuthProvider != nil {
+		if err := s.readerAuthProvider.signError(); err != nil {
+			return fmt.Errorf("IAM request signing failed for the reader endpoint: %w", err)
+		}
+	}
+	return nil
+}
+
 // NeptuneDriver returns the underlying Gremlin driver for direct graph operations.
+// It is connected to the writer endpoint.
 func (s *Source) NeptuneDriver() *gremlingo.DriverRemoteConnection {
 	return s.Driver
 }
 
+// ReaderDriver returns the Gremlin driver connected to the reader endpoint, for
+// read-only traversals that can be served by replicas. If no readerEndpoint is
+// configured, the writer driver is returned.
+func (s *Source) ReaderDriver() *gremlingo.DriverRemoteConnection {
+	if s.Reader != nil {
+		return s.Reader
+	}
+	return s.Driver
+}
+
+// G returns a traversal source bound to the writer endpoint, for building
+// traversals with Gremlin-Go, e.g.
+//
+//	results, err := source.G().V().HasLabel("person").Values("name").ToList()
+func (s *Source) G() *gremlingo.GraphTraversalSource {
+	return gremlingo.Traversal_().WithRemote(s.Driver)
+}
+
+// ReaderG returns a traversal source bound to the reader endpoint, or to the
+// writer endpoint if no readerEndpoint is configured. Use it only for
+// traversals that don't modify the graph.
+func (s *Source) ReaderG() *gremlingo.GraphTraversalSource {
+	return gremlingo.Traversal_().WithRemote(s.ReaderDriver())
+}
+
+// SubmitGremlin submits a string Gremlin query to the writer endpoint and
+// returns the results. Values that originate from user input should be passed
+// as bindings and referenced by name in the query rather than concatenated
+// into it, e.g. g.V().has('person', 'name', name) with bindings {"name": ...}.
+//
+// If queryTimeout is configured, it is sent as the query's evaluation timeout,
+// so Neptune stops the traversal, and SubmitGremlin also stops waiting for the
+// results once it elapses.
+func (s *Source) SubmitGremlin(ctx context.Context, query string, bindings map[string]any) ([]any, error) {
+	if s.Driver == nil {
+		return nil, fmt.Errorf("neptune driver is not initialized")
+	}
+
+	builder := new(gremlingo.RequestOptionsBuilder).SetBindings(bindings)
+	if timeout := s.queryTimeout(); timeout > 0 {
+		builder.SetEvaluationTimeout(int(timeout.Milliseconds()))
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	options := builder.Create()
+	resultSet, err := s.Driver.SubmitWithOptions(query, options)
+	if err != nil {
+		return nil, s.withSignError(fmt.Errorf("failed to submit Gremlin query: %w", err))
+	}
+
+	// ResultSet.All blocks until the response completes, so wait for it in the
+	// background in order to honor context cancellation.
+	type allResults struct {
+		results []*gremlingo.Result
+		err     error
+	}
+	done := make(chan allResults, 1)
+	go func() {
+		results, err := resultSet.All()
+		done <- allResults{results: results, err: err}
+	}()
+
+	select {
+	case <-ctx.Done():
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return nil, fmt.Errorf("gremlin query did not complete in time: %w", ctx.Err())
+		}
+		return nil, ctx.Err()
+	case r := <-done:
+		if r.err != nil {
+			return nil, s.withSignError(fmt.Errorf("failed to execute Gremlin query: %w", r.err))
+		}
+		values := make([]any, 0, len(r.results))
+		for _, result := range r.results {
+			values = append(values, result.GetInterface())
+		}
+		return values, nil
+	}
+}
+
+// ExecuteOpenCypher runs an openCypher query against the cluster's HTTPS
+// endpoint and returns the result rows.
+func (s *Source) ExecuteOpenCypher(ctx context.Context, query string, params map[string]any) ([]map[string]any, error) {
+	form := url.Values{}
+	form.Set("query", query)
+	if len(params) > 0 {
+		paramBytes, err := json.Marshal(params)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal openCypher parameters: %w", err)
+		}
+		form.Set("parameters", string(paramBytes))
+	}
+
+	header := http.Header{}
+	header.Set("Content-Type", "application/x-www-form-urlencoded")
+	respBody, err := s.doHTTPRequest(ctx, "POST", "/openCypher", []byte(form.Encode()), header)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Results []map[string]any `json:"results"`
+	}
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return nil, fmt.Errorf("failed to decode openCypher response: %w", err)
+	}
+	return result.Results, nil
+}
+
+// ExecuteSPARQL runs a SPARQL query against the cluster's /sparql endpoint and
+// returns the raw SPARQL JSON results.
+func (s *Source) ExecuteSPARQL(ctx context.Context, query string) (json.RawMessage, error) {
+	form := url.Values{}
+	form.Set("query", query)
+
+	header := http.Header{}
+	header.Set("Content-Type", "application/x-www-form-urlencoded")
+	header.Set("Accept", "application/sparql-results+json")
+	respBody, err := s.doHTTPRequest(ctx, "POST", "/sparql", []byte(form.Encode()), header)
+	if err != nil {
+		return nil, err
+	}
+	return json.RawMessage(respBody), nil
+}
+
+// BulkLoadRequest describes a Neptune bulk load job. String-valued flags take
+// Neptune's "TRUE"/"FALSE" form; see the Neptune loader documentation for details.
+type BulkLoadRequest struct {
+	Source       string `json:"source"`                 // S3 URI of the file or folder to load
+	Format       string `json:"format"`                 // csv, opencypher, ntriples, nquads, rdfxml, or turtle
+	IAMRoleARN   string `json:"iamRoleArn"`             // Role Neptune assumes to read from S3
+	Region       string `json:"region"`                 // Region of the S3 bucket; defaults to the cluster region
+	Mode         string `json:"mode,omitempty"`         // NEW, RESUME, or AUTO
+	FailOnError  string `json:"failOnError,omitempty"`  // TRUE or FALSE
+	Parallelism  string `json:"parallelism,omitempty"`  // LOW, MEDIUM, HIGH, or OVERSUBSCRIBE
+	QueueRequest string `json:"queueRequest,omitempty"` // TRUE or FALSE
+
+	UpdateSingleCardinalityProperties string `json:"updateSingleCardinalityProperties,omitempty"`
+}
+
+// LoadStatus reports the progress of a bulk load job.
+type LoadStatus struct {
+	Status                 string           `json:"status"`
+	FullURI                string           `json:"fullUri"`
+	RunNumber              int              `json:"runNumber"`
+	RetryNumber            int              `json:"retryNumber"`
+	StartTime              int64            `json:"startTime"`
+	TotalTimeSpent         int64            `json:"totalTimeSpent"`
+	TotalRecords           int64            `json:"totalRecords"`
+	TotalDuplicates        int64            `json:"totalDuplicates"`
+	ParsingErrors          int64            `json:"parsingErrors"`
+	DatatypeMismatchErrors int64            `json:"datatypeMismatchErrors"`
+	InsertErrors           int64            `json:"insertErrors"`
+	FeedCount              []map[string]int `json:"-"`
+}
+
+// StartBulkLoad submits a bulk load job to the Neptune loader and returns its load ID.
+func (s *Source) StartBulkLoad(ctx context.Context, req BulkLoadRequest) (string, error) {
+	if req.Source == "" || req.Format == "" || req.IAMRoleARN == "" {
+		return "", fmt.Errorf("source, format, and iamRoleArn are required for a bulk load")
+	}
+	if req.Region == "" {
+		req.Region = s.region()
+		if req.Region == "" {
+			return "", fmt.Errorf("region is required for a bulk load")
+		}
+	}
+
+	body, err := json.Marshal(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal bulk load request: %w", err)
+	}
+
+	header := http.Header{}
+	header.Set("Content-Type", "application/json")
+	respBody, err := s.doHTTPRequest(ctx, "POST", "/loader", body, header)
+	if err != nil {
+		return "", err
+	}
+
+	var result struct {
+		Payload struct {
+			LoadID string `json:"loadId"`
+		} `json:"payload"`
+	}
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return "", fmt.Errorf("failed to decode bulk load response: %w", err)
+	}
+	return result.Payload.LoadID, nil
+}
+
+// GetBulkLoadStatus returns the status of a bulk load job.
+func (s *Source) GetBulkLoadStatus(ctx context.Context, loadID string) (*LoadStatus, error) {
+	if loadID == "" {
+		return nil, fmt.Errorf("load ID is required")
+	}
+
+	respBody, err := s.doHTTPRequest(ctx, "GET", "/loader/"+url.PathEscape(loadID), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Payload struct {
+			FeedCount     []map[string]int `json:"feedCount"`
+			OverallStatus LoadStatus       `json:"overallStatus"`
+		} `json:"payload"`
+	}
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return nil, fmt.Errorf("failed to decode bulk load status: %w", err)
+	}
+
+	status := result.Payload.OverallStatus
+	status.FeedCount = result.Payload.FeedCount
+	return &status, nil
+}
+
+// queryTimeout returns the configured query timeout, or 0 if there is none.
+func (s *Source) queryTimeout() time.Duration {
+	// Validated by newConfig
+	d, _ := time.ParseDuration(s.QueryTimeout)
+	return d
+}
+
+// region returns the AWS region of the cluster, if known.
+func (s *Source) region() string {
+	if s.authProvider != nil && s.authProvider.region != "" {
+		return s.authProvider.region
+	}
+	if u, err := url.Parse(s.Endpoint); err == nil {
+		return extractRegionFromEndpoint(u.Hostname())
+	}
+	return ""
+}
+
+// httpEndpoint derives the HTTPS URL for path from the Gremlin WebSocket endpoint,
+// e.g. wss://host:8182/gremlin becomes https://host:8182/openCypher.
+func httpEndpoint(endpoint, path string) (string, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse Neptune endpoint %q: %w", endpoint, err)
+	}
+	switch u.Scheme {
+	case "wss", "https":
+		u.Scheme = "https"
+	case "ws", "http":
+		u.Scheme = "http"
+	default:
+		return "", fmt.Errorf("unsupported Neptune endpoint scheme %q", u.Scheme)
+	}
+	u.Path = path
+	u.RawQuery = ""
+	return u.String(), nil
+}
+
+// doHTTPRequest sends a request to one of Neptune's HTTPS endpoints, signing it
+// when IAM authentication is enabled, and returns the response body.
+func (s *Source) doHTTPRequest(ctx context.Context, method, path string, body []byte, header http.Header) ([]byte, error) {
+	reqURL, err := httpEndpoint(s.Endpoint, path)
+	if err != nil {
+		return nil, err
+	}
+
+	var bodyReader io.Reader
+	if body != nil {
+		bodyReader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, reqURL, bodyReader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	for key, values := range header {
+		for _, v := range values {
+			req.Header.Add(key, v)
+		}
+	}
+
+	if s.authProvider != nil {
+		if err := s.authProvider.signRequest(ctx, req, body); err != nil {
+			return nil, fmt.Errorf("failed to sign request for Neptune IAM auth: %w", err)
+		}
+	}
+
+	client := s.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", sources.ClassifyError(err))
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, sources.NewStatusError(resp.StatusCode, "request failed with status %d: %s", resp.StatusCode, string(respBody))
+	}
+	return respBody, nil
+}
+
+// withSignError attaches the most recent IAM signing failure to err. Without it
+// a connection opened without auth headers only surfaces as an opaque 403.
+func (s *Source) withSignError(err error) error {
+	if s.authProvider == nil {
+		return err
+	}
+	if signErr := s.authProvider.signError(); signErr != nil {
+		return fmt.Errorf("%w (IAM request signing failed: %w)", err, signErr)
+	}
+	return err
+}
+
 // Close closes the Neptune Gremlin connection and releases resources.
 func (s *Source) Close() error {
 	if s.Driver != nil {
 		s.Driver.Close() // Close() doesn't return error, logs errors internally
 	}
+	if s.Reader != nil {
+		s.Reader.Close()
+	}
 	return nil
 }
 
@@ -118,22 +493,14 @@ type neptuneIAMAuthProvider struct {
 	host        string
 	region      string
 	logger      *slog.Logger
+
+	mu      sync.Mutex
+	lastErr error // Error from the most recent GetHeader call; nil if it succeeded
 }
 
 // GetHeader returns HTTP headers for Neptune IAM authentication.
 // It generates a SigV4-signed Authorization header for each request.
 func (p *neptuneIAMAuthProvider) GetHeader() http.Header {
-	// Retrieve current AWS credentials (may refresh if expired)
-	creds, err := p.credentials.Retrieve(p.ctx)
-	if err != nil {
-		if p.logger != nil {
-			p.logger.ErrorContext(p.ctx, "Failed to retrieve AWS credentials for Neptune IAM auth",
-				"error", err,
-				"endpoint", p.endpoint)
-		}
-		return http.Header{}
-	}
-
 	// Create an HTTP request for SigV4 signing
 	// Neptune WebSocket connections require signing as HTTP GET requests
 	httpURL := strings.Replace(p.endpoint, "wss://", "https://", 1)
@@ -146,43 +513,97 @@ func (p *neptuneIAMAuthProvider) GetHeader() http.Header {
 				"error", err,
 				"endpoint", httpURL)
 		}
+		p.setSignError(err)
 		return http.Header{}
 	}
 
 	// Set required headers for Neptune
 	req.Header.Set("Host", p.host)
 
-	// Create SigV4 signer with "neptune-db" service name
-	// Neptune requires the service name to be "neptune-db" (not "neptune")
-	signer := v4.NewSigner()
-
-	// Compute payload hash (empty for GET request)
-	payloadHash := sha256.Sum256([]byte(""))
-	payloadHashStr := hex.EncodeToString(payloadHash[:])
-
-	// Sign the request using AWS SigV4
-	err = signer.SignHTTP(p.ctx, creds, req, payloadHashStr, "neptune-db", p.region, time.Now())
-	if err != nil {
+	// Sign the request using AWS SigV4 (empty payload for GET request)
+	if err := p.signRequest(p.ctx, req, nil); err != nil {
 		if p.logger != nil {
 			p.logger.ErrorContext(p.ctx, "Failed to sign request for Neptune IAM auth",
 				"error", err,
+				"endpoint", p.endpoint,
 				"region", p.region,
 				"service", "neptune-db")
 		}
+		p.setSignError(err)
 		return http.Header{}
 	}
+	p.setSignError(nil)
 
 	// Return the signed headers
 	// The Authorization header contains the SigV4 signature
 	return req.Header
 }
 
+// signRequest adds SigV4 authentication headers for the given payload to req.
+func (p *neptuneIAMAuthProvider) signRequest(ctx context.Context, req *http.Request, payload []byte) error {
+	// Retrieve current AWS credentials (cached; refreshed only near expiry)
+	creds, err := p.retrieveCredentials(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Neptune requires the service name to be "neptune-db" (not "neptune")
+	return sourceutil.SignAWSRequest(ctx, creds, req, payload, "neptune-db", p.region)
+}
+
+// retrieveCredentials retrieves credentials, retrying with backoff so that a
+// transient failure (e.g. IMDS throttling during a refresh) doesn't fail the request.
+func (p *neptuneIAMAuthProvider) retrieveCredentials(ctx context.Context) (aws.Credentials, error) {
+	var lastErr error
+	backoff := credentialRetryBackoff
+	for attempt := 0; attempt < credentialRetrieveAttempts; attempt++ {
+		creds, err := p.credentials.Retrieve(ctx)
+		if err == nil {
+			return creds, nil
+		}
+		lastErr = err
+
+		if p.logger != nil {
+			p.logger.WarnContext(ctx, "Failed to retrieve AWS credentials for Neptune IAM auth",
+				"error", err,
+				"endpoint", p.endpoint,
+				"attempt", attempt+1)
+		}
+
+		if attempt < credentialRetrieveAttempts-1 {
+			select {
+			case <-ctx.Done():
+				return aws.Credentials{}, ctx.Err()
+			case <-time.After(backoff):
+				backoff *= 2
+			}
+		}
+	}
+	return aws.Credentials{}, fmt.Errorf("failed to retrieve AWS credentials after %d attempts: %w", credentialRetrieveAttempts, lastErr)
+}
+
+// setSignError records the outcome of the most recent GetHeader call, which has
+// no way to return an error to the Gremlin driver itself.
+func (p *neptuneIAMAuthProvider) setSignError(err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.lastErr = err
+}
+
+// signError returns the error from the most recent GetHeader call, or nil if
+// the connection headers were signed successfully.
+func (p *neptuneIAMAuthProvider) signError() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.lastErr
+}
+
 // GetBasicAuth returns false as Neptune IAM authentication does not use basic auth.
 func (p *neptuneIAMAuthProvider) GetBasicAuth() (ok bool, username, password string) {
 	return false, "", ""
 }
 
-func initNeptuneDriver(ctx context.Context, tracer trace.Tracer, name, endpoint string, useIAM bool) (*gremlingo.DriverRemoteConnection, error) {
+func initNeptuneDriver(ctx context.Context, tracer trace.Tracer, name, endpoint string, useIAM bool) (*gremlingo.DriverRemoteConnection, *neptuneIAMAuthProvider, error) {
 	//nolint:all // Reassigned ctx
 	ctx, span := sources.InitConnectionSpan(ctx, tracer, SourceKind, name)
 	defer span.End()
@@ -191,42 +612,56 @@ func initNeptuneDriver(ctx context.Context, tracer trace.Tracer, name, endpoint
 	if !useIAM {
 		driver, err := gremlingo.NewDriverRemoteConnection(endpoint)
 		if err != nil {
-			return nil, fmt.Errorf("unable to create Neptune driver: %w", err)
+			return nil, nil, fmt.Errorf("unable to create Neptune driver: %w", err)
 		}
-		return driver, nil
+		return driver, nil, nil
 	}
 
 	// IAM Authentication is enabled - implement SigV4 signing for Neptune WebSocket connections
-	// Load AWS configuration using default credential chain
-	// This supports: environment variables, shared config/credentials files, IAM roles, etc.
-	cfg, err := config.LoadDefaultConfig(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("unable to load AWS config for IAM auth: %w", err)
-	}
-
 	// Parse the Neptune endpoint to extract host
 	parsedURL, err := url.Parse(endpoint)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse Neptune endpoint %q: %w", endpoint, err)
+		return nil, nil, fmt.Errorf("failed to parse Neptune endpoint %q: %w", endpoint, err)
 	}
 
 	// Extract AWS region from the Neptune endpoint hostname
 	// Neptune endpoints follow format: cluster-id.cluster-hash.region.neptune.amazonaws.com
 	region := extractRegionFromEndpoint(parsedURL.Host)
+
+	// Load AWS configuration using default credential chain
+	// This supports: environment variables, shared config/credentials files, IAM roles, etc.
+	cfg, err := sourceutil.LoadAWSConfig(ctx, sourceutil.AWSOptions{Region: region})
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to set up IAM auth: %w", err)
+	}
 	if region == "" {
 		// Fallback to AWS config region if extraction fails
 		region = cfg.Region
 		if region == "" {
-			return nil, fmt.Errorf("unable to determine AWS region from endpoint %q and no region in AWS config", endpoint)
+			return nil, nil, fmt.Errorf("unable to determine AWS region from endpoint %q and no region in AWS config", endpoint)
 		}
 	}
 
+	// Cache credentials until shortly before they expire so that new connections
+	// don't hit the credential chain (and IMDS) every time they are signed
+	credentials := cfg.Credentials
+	if _, ok := credentials.(*aws.CredentialsCache); !ok {
+		credentials = aws.NewCredentialsCache(credentials)
+	}
+
+	// Fail fast if no credentials are available, rather than producing
+	// unsigned requests that Neptune rejects with a 403
+	if _, err := credentials.Retrieve(ctx); err != nil {
+		return nil, nil, fmt.Errorf("unable to retrieve AWS credentials for IAM auth: %w", err)
+	}
+
 	// Create the IAM authentication provider
 	// This provider implements gremlingo.AuthInfoProvider and dynamically
-	// generates SigV4-signed headers for each WebSocket connection
+	// generates SigV4-signed headers for each WebSocket connection.
+	// The provider outlives Initialize, so it must not inherit its cancellation.
 	authProvider := &neptuneIAMAuthProvider{
-		ctx:         ctx,
-		credentials: cfg.Credentials,
+		ctx:         context.WithoutCancel(ctx),
+		credentials: credentials,
 		endpoint:    endpoint,
 		host:        parsedURL.Host,
 		region:      region,
@@ -243,10 +678,17 @@ func initNeptuneDriver(ctx context.Context, tracer trace.Tracer, name, endpoint
 		},
 	)
 	if err != nil {
-		return nil, fmt.Errorf("unable to create Neptune driver with IAM auth: %w", err)
+		return nil, nil, fmt.Errorf("unable to create Neptune driver with IAM auth: %w", err)
+	}
+
+	// GetHeader cannot report errors to the driver, so check whether the
+	// initial connection was signed rather than leaving it unauthenticated
+	if err := authProvider.signError(); err != nil {
+		driver.Close()
+		return nil, nil, fmt.Errorf("unable to sign Neptune connection for IAM auth: %w", err)
 	}
 
-	return driver, nil
+	return driver, authProvider, nil
 }
 
 // extractRegionFromEndpoint extracts the AWS region from a Neptune endpoint hostname.
@@ -17,10 +17,18 @@ package neptune
 import (
 	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
+	gremlingo "github.com/apache/tinkerpop/gremlin-go/v3/driver"
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/goccy/go-yaml"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestParseFromYamlNeptune(t *testing.T) {
@@ -57,6 +65,34 @@ useIAM: true`,
 				UseIAM:   true,
 			},
 		},
+		{
+			name: "valid configuration with reader endpoint",
+			yamlContent: `name: test-neptune
+kind: neptune
+endpoint: wss://my-neptune.cluster-abc123.us-east-1.neptune.amazonaws.com:8182/gremlin
+readerEndpoint: wss://my-neptune.cluster-ro-abc123.us-east-1.neptune.amazonaws.com:8182/gremlin`,
+			wantErr: false,
+			expected: Config{
+				Name:           "test-neptune",
+				Kind:           "neptune",
+				Endpoint:       "wss://my-neptune.cluster-abc123.us-east-1.neptune.amazonaws.com:8182/gremlin",
+				ReaderEndpoint: "wss://my-neptune.cluster-ro-abc123.us-east-1.neptune.amazonaws.com:8182/gremlin",
+			},
+		},
+		{
+			name: "valid configuration with query timeout",
+			yamlContent: `name: test-neptune
+kind: neptune
+endpoint: wss://my-neptune.cluster-abc123.us-east-1.neptune.amazonaws.com:8182/gremlin
+queryTimeout: 45s`,
+			wantErr: false,
+			expected: Config{
+				Name:         "test-neptune",
+				Kind:         "neptune",
+				Endpoint:     "wss://my-neptune.cluster-abc123.us-east-1.neptune.amazonaws.com:8182/gremlin",
+				QueryTimeout: "45s",
+			},
+		},
 		{
 			name: "valid configuration with localhost",
 			yamlContent: `name: local-neptune
@@ -84,7 +120,9 @@ endpoint: ws://localhost:8182/gremlin`,
 				assert.Equal(t, tt.expected.Name, config.(Config).Name)
 				assert.Equal(t, tt.expected.Kind, config.(Config).Kind)
 				assert.Equal(t, tt.expected.Endpoint, config.(Config).Endpoint)
+				assert.Equal(t, tt.expected.ReaderEndpoint, config.(Config).ReaderEndpoint)
 				assert.Equal(t, tt.expected.UseIAM, config.(Config).UseIAM)
+				assert.Equal(t, tt.expected.QueryTimeout, config.(Config).QueryTimeout)
 			}
 		})
 	}
@@ -107,6 +145,20 @@ endpoint: [invalid yaml syntax`,
   kind: neptune
     endpoint: wss://localhost:8182/gremlin`,
 		},
+		{
+			name: "invalid query timeout",
+			yamlContent: `name: test-neptune
+kind: neptune
+endpoint: wss://localhost:8182/gremlin
+queryTimeout: soon`,
+		},
+		{
+			name: "non-positive query timeout",
+			yamlContent: `name: test-neptune
+kind: neptune
+endpoint: wss://localhost:8182/gremlin
+queryTimeout: 0s`,
+		},
 	}
 
 	for _, tt := range tests {
@@ -129,3 +181,333 @@ func TestSourceKindNeptune(t *testing.T) {
 	source := Source{Config: config}
 	assert.Equal(t, SourceKind, source.SourceKind())
 }
+
+func TestReaderDriver(t *testing.T) {
+	writer := &gremlingo.DriverRemoteConnection{}
+	reader := &gremlingo.DriverRemoteConnection{}
+
+	source := Source{Config: Config{Name: "test", Kind: "neptune"}, Driver: writer}
+	assert.Same(t, writer, source.ReaderDriver(), "should fall back to the writer without a reader endpoint")
+
+	source.Reader = reader
+	assert.Same(t, reader, source.ReaderDriver())
+	assert.Same(t, writer, source.NeptuneDriver())
+}
+
+func TestTraversalSources(t *testing.T) {
+	source := Source{Config: Config{Name: "test", Kind: "neptune"}, Driver: &gremlingo.DriverRemoteConnection{}}
+
+	// Building a traversal doesn't touch the connection until it is iterated
+	assert.NotNil(t, source.G().V().HasLabel("person"))
+	assert.NotNil(t, source.ReaderG().V().HasLabel("person"))
+}
+
+// newTestSource returns a Source whose HTTP endpoints point at server.
+func newTestSource(server *httptest.Server) *Source {
+	return &Source{
+		Config: Config{
+			Name:     "test",
+			Kind:     "neptune",
+			Endpoint: strings.Replace(server.URL, "http://", "ws://", 1) + "/gremlin",
+		},
+		HTTPClient: server.Client(),
+	}
+}
+
+func TestHTTPEndpoint(t *testing.T) {
+	tests := []struct {
+		name     string
+		endpoint string
+		path     string
+		want     string
+		wantErr  bool
+	}{
+		{
+			name:     "secure websocket",
+			endpoint: "wss://my-neptune.cluster-abc123.us-east-1.neptune.amazonaws.com:8182/gremlin",
+			path:     "/openCypher",
+			want:     "https://my-neptune.cluster-abc123.us-east-1.neptune.amazonaws.com:8182/openCypher",
+		},
+		{
+			name:     "plain websocket",
+			endpoint: "ws://localhost:8182/gremlin",
+			path:     "/sparql",
+			want:     "http://localhost:8182/sparql",
+		},
+		{
+			name:     "unsupported scheme",
+			endpoint: "ftp://localhost:8182/gremlin",
+			path:     "/openCypher",
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := httpEndpoint(tt.endpoint, tt.path)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestExecuteOpenCypher(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/openCypher", r.URL.Path)
+		assert.Equal(t, "POST", r.Method)
+		assert.Empty(t, r.Header.Get("Authorization"))
+
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "MATCH (p:person {name: $name}) RETURN p.age AS age", r.PostForm.Get("query"))
+
+		var params map[string]any
+		require.NoError(t, json.Unmarshal([]byte(r.PostForm.Get("parameters")), &params))
+		assert.Equal(t, "marko", params["name"])
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"results":[{"age":29}]}`))
+	}))
+	defer server.Close()
+
+	source := newTestSource(server)
+	rows, err := source.ExecuteOpenCypher(context.Background(),
+		"MATCH (p:person {name: $name}) RETURN p.age AS age",
+		map[string]any{"name": "marko"})
+
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.Equal(t, float64(29), rows[0]["age"])
+}
+
+func TestExecuteOpenCypherIAM(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth := r.Header.Get("Authorization")
+		assert.True(t, strings.HasPrefix(auth, "AWS4-HMAC-SHA256"), "missing SigV4 signature: %q", auth)
+		assert.Contains(t, auth, "/us-east-1/neptune-db/aws4_request")
+		w.Write([]byte(`{"results":[]}`))
+	}))
+	defer server.Close()
+
+	source := newTestSource(server)
+	source.authProvider = &neptuneIAMAuthProvider{
+		ctx: context.Background(),
+		credentials: aws.CredentialsProviderFunc(func(ctx context.Context) (aws.Credentials, error) {
+			return aws.Credentials{AccessKeyID: "AKID", SecretAccessKey: "SECRET"}, nil
+		}),
+		endpoint: source.Endpoint,
+		host:     strings.TrimPrefix(server.URL, "http://"),
+		region:   "us-east-1",
+	}
+
+	rows, err := source.ExecuteOpenCypher(context.Background(), "MATCH (n) RETURN n LIMIT 1", nil)
+	require.NoError(t, err)
+	assert.Empty(t, rows)
+}
+
+func TestExecuteOpenCypherError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"code":"MalformedQueryException"}`))
+	}))
+	defer server.Close()
+
+	source := newTestSource(server)
+	_, err := source.ExecuteOpenCypher(context.Background(), "MATCH (", nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status 400")
+}
+
+func TestExecuteSPARQL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/sparql", r.URL.Path)
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "application/sparql-results+json", r.Header.Get("Accept"))
+
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "SELECT ?s WHERE { ?s ?p ?o } LIMIT 1", r.PostForm.Get("query"))
+
+		w.Header().Set("Content-Type", "application/sparql-results+json")
+		w.Write([]byte(`{"head":{"vars":["s"]},"results":{"bindings":[{"s":{"type":"uri","value":"http://example.org/a"}}]}}`))
+	}))
+	defer server.Close()
+
+	source := newTestSource(server)
+	raw, err := source.ExecuteSPARQL(context.Background(), "SELECT ?s WHERE { ?s ?p ?o } LIMIT 1")
+	require.NoError(t, err)
+
+	var result struct {
+		Head struct {
+			Vars []string `json:"vars"`
+		} `json:"head"`
+	}
+	require.NoError(t, json.Unmarshal(raw, &result))
+	assert.Equal(t, []string{"s"}, result.Head.Vars)
+}
+
+func TestBulkLoad(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/loader":
+			var req BulkLoadRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, "s3://bucket/graph/", req.Source)
+			assert.Equal(t, "csv", req.Format)
+			assert.Equal(t, "us-west-2", req.Region)
+			w.Write([]byte(`{"status":"200 OK","payload":{"loadId":"load-123"}}`))
+		case r.Method == "GET" && r.URL.Path == "/loader/load-123":
+			w.Write([]byte(`{"status":"200 OK","payload":{
+				"feedCount":[{"LOAD_COMPLETED":1}],
+				"overallStatus":{"fullUri":"s3://bucket/graph/","runNumber":1,"status":"LOAD_COMPLETED","totalRecords":42,"insertErrors":0}
+			}}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	source := newTestSource(server)
+	ctx := context.Background()
+
+	loadID, err := source.StartBulkLoad(ctx, BulkLoadRequest{
+		Source:     "s3://bucket/graph/",
+		Format:     "csv",
+		IAMRoleARN: "arn:aws:iam::123456789012:role/NeptuneLoadFromS3",
+		Region:     "us-west-2",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "load-123", loadID)
+
+	status, err := source.GetBulkLoadStatus(ctx, loadID)
+	require.NoError(t, err)
+	assert.Equal(t, "LOAD_COMPLETED", status.Status)
+	assert.Equal(t, int64(42), status.TotalRecords)
+	assert.Equal(t, []map[string]int{{"LOAD_COMPLETED": 1}}, status.FeedCount)
+}
+
+func TestStartBulkLoadValidation(t *testing.T) {
+	source := &Source{Config: Config{Endpoint: "ws://localhost:8182/gremlin"}}
+
+	_, err := source.StartBulkLoad(context.Background(), BulkLoadRequest{Source: "s3://bucket/graph/"})
+	assert.ErrorContains(t, err, "required")
+
+	// The region cannot be derived from a localhost endpoint
+	_, err = source.StartBulkLoad(context.Background(), BulkLoadRequest{
+		Source:     "s3://bucket/graph/",
+		Format:     "csv",
+		IAMRoleARN: "arn:aws:iam::123456789012:role/NeptuneLoadFromS3",
+	})
+	assert.ErrorContains(t, err, "region is required")
+}
+
+func TestRetrieveCredentialsRetries(t *testing.T) {
+	calls := 0
+	provider := &neptuneIAMAuthProvider{
+		ctx: context.Background(),
+		credentials: aws.CredentialsProviderFunc(func(ctx context.Context) (aws.Credentials, error) {
+			calls++
+			if calls < credentialRetrieveAttempts {
+				return aws.Credentials{}, errors.New("throttled")
+			}
+			return aws.Credentials{AccessKeyID: "AKID", SecretAccessKey: "SECRET"}, nil
+		}),
+		endpoint: "wss://localhost:8182/gremlin",
+		host:     "localhost:8182",
+		region:   "us-east-1",
+	}
+
+	header := provider.GetHeader()
+	assert.Equal(t, credentialRetrieveAttempts, calls)
+	assert.True(t, strings.HasPrefix(header.Get("Authorization"), "AWS4-HMAC-SHA256"))
+}
+
+func TestRetrieveCredentialsFailure(t *testing.T) {
+	provider := &neptuneIAMAuthProvider{
+		ctx: context.Background(),
+		credentials: aws.CredentialsProviderFunc(func(ctx context.Context) (aws.Credentials, error) {
+			return aws.Credentials{}, errors.New("no credentials")
+		}),
+		region: "us-east-1",
+	}
+
+	_, err := provider.retrieveCredentials(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no credentials")
+}
+
+func TestSubmitGremlinWithoutDriver(t *testing.T) {
+	source := &Source{Config: Config{Name: "test", Kind: "neptune"}}
+	_, err := source.SubmitGremlin(context.Background(), "g.V().has('name', name)", map[string]any{"name": "marko"})
+	assert.ErrorContains(t, err, "not initialized")
+}
+
+func TestGetHeaderRecordsSignError(t *testing.T) {
+	fail := true
+	provider := &neptuneIAMAuthProvider{
+		ctx: context.Background(),
+		credentials: aws.CredentialsProviderFunc(func(ctx context.Context) (aws.Credentials, error) {
+			if fail {
+				return aws.Credentials{}, errors.New("no credentials")
+			}
+			return aws.Credentials{AccessKeyID: "AKID", SecretAccessKey: "SECRET"}, nil
+		}),
+		endpoint: "wss://mycluster.cluster-abc123.us-east-1.neptune.amazonaws.com:8182/gremlin",
+		host:     "mycluster.cluster-abc123.us-east-1.neptune.amazonaws.com:8182",
+		region:   "us-east-1",
+	}
+	source := &Source{authProvider: provider}
+
+	header := provider.GetHeader()
+	assert.Empty(t, header.Get("Authorization"))
+	require.Error(t, provider.signError())
+
+	err := source.withSignError(errors.New("failed to execute Gremlin query: 403"))
+	assert.Contains(t, err.Error(), "IAM request signing failed")
+	assert.Contains(t, err.Error(), "no credentials")
+
+	// A successful signing attempt clears the recorded error
+	fail = false
+	header = provider.GetHeader()
+	assert.NotEmpty(t, header.Get("Authorization"))
+	assert.NoError(t, provider.signError())
+}
+
+func TestHealthCheckReportsReaderSignError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/status", r.URL.Path)
+		w.Write([]byte(`{"status":"healthy"}`))
+	}))
+	defer server.Close()
+
+	fail := true
+	source := newTestSource(server)
+	source.readerAuthProvider = &neptuneIAMAuthProvider{
+		ctx: context.Background(),
+		credentials: aws.CredentialsProviderFunc(func(ctx context.Context) (aws.Credentials, error) {
+			if fail {
+				return aws.Credentials{}, errors.New("no credentials")
+			}
+			return aws.Credentials{AccessKeyID: "AKID", SecretAccessKey: "SECRET"}, nil
+		}),
+		endpoint: "wss://mycluster.cluster-ro-abc123.us-east-1.neptune.amazonaws.com:8182/gremlin",
+		host:     "mycluster.cluster-ro-abc123.us-east-1.neptune.amazonaws.com:8182",
+		region:   "us-east-1",
+	}
+	require.NoError(t, source.HealthCheck(context.Background()))
+
+	// A reader connection that couldn't be signed makes the source unhealthy
+	source.readerAuthProvider.GetHeader()
+	err := source.HealthCheck(context.Background())
+	require.ErrorContains(t, err, "reader endpoint")
+	assert.ErrorContains(t, err, "no credentials")
+
+	// and signing a later reader connection recovers it
+	fail = false
+	source.readerAuthProvider.GetHeader()
+	assert.NoError(t, source.HealthCheck(context.Background()))
+}
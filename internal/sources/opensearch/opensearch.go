@@ -0,0 +1,261 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package opensearch provides a source implementation for OpenSearch, including
+// Amazon OpenSearch Service domains secured with IAM (SigV4) authentication.
+package opensearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	sourceutil "github.com/googleapis/genai-toolbox/internal/sources/util"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const SourceKind string = "opensearch"
+
+const (
+	DefaultTimeout = 30 * time.Second // Default request timeout
+
+	signingService           = "es"   // SigV4 service name for OpenSearch Service domains
+	serverlessSigningService = "aoss" // SigV4 service name for OpenSearch Serverless collections
+)
+
+// validate interface
+var _ sources.SourceConfig = Config{}
+
+func init() {
+	sources.MustRegister(SourceKind, newConfig)
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources.SourceConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+
+	password, err := sourceutil.ResolveSecret("password", actual.Password, actual.PasswordFile)
+	if err != nil {
+		return nil, fmt.Errorf("source %q (%s): unable to resolve secret: %w", name, SourceKind, err)
+	}
+	actual.Password = password
+
+	if actual.UseIAM && (actual.Username != "" || actual.Password != "") {
+		return nil, fmt.Errorf("source %q (%s): useIAM and username/password cannot both be set", name, SourceKind)
+	}
+	if (actual.Username == "") != (actual.Password == "") {
+		return nil, fmt.Errorf("source %q (%s): username and password must both be set", name, SourceKind)
+	}
+	if actual.Serverless && !actual.UseIAM {
+		return nil, fmt.Errorf("source %q (%s): serverless requires useIAM", name, SourceKind)
+	}
+	return actual, nil
+}
+
+// Config represents the configuration for an OpenSearch source.
+type Config struct {
+	Name         string `yaml:"name" validate:"required"`
+	Kind         string `yaml:"kind" validate:"required"`
+	Endpoint     string `yaml:"endpoint" validate:"required"` // e.g., https://search-mydomain-abc123.us-east-1.es.amazonaws.com
+	Username     string `yaml:"username"`                     // Optional: for basic authentication
+	Password     string `yaml:"password"`                     // Optional: for basic authentication
+	PasswordFile string `yaml:"passwordFile"`                 // Optional: file to read password from
+	UseIAM       bool   `yaml:"useIAM"`                       // Optional: sign requests with AWS SigV4
+	Region       string `yaml:"region"`                       // Optional: AWS region for IAM, derived from the endpoint if unset
+	Serverless   bool   `yaml:"serverless"`                   // Optional: the endpoint is an OpenSearch Serverless collection
+	Index        string `yaml:"index"`                        // Optional: default index to search
+}
+
+func (r Config) SourceConfigKind() string {
+	return SourceKind
+}
+
+func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.Source, error) {
+	//nolint:all // Reassigned ctx
+	ctx, span := sources.InitConnectionSpan(ctx, tracer, SourceKind, r.Name)
+	defer span.End()
+
+	endpoint, err := url.Parse(r.Endpoint)
+	if err != nil || (endpoint.Scheme != "http" && endpoint.Scheme != "https") || endpoint.Host == "" {
+		return nil, fmt.Errorf("source %q (%s): endpoint %q must be an http or https URL", r.Name, SourceKind, r.Endpoint)
+	}
+
+	s := &Source{
+		Config:     r,
+		HTTPClient: &http.Client{Timeout: DefaultTimeout},
+	}
+
+	if r.UseIAM {
+		region := r.Region
+		if region == "" {
+			region = extractRegionFromEndpoint(endpoint.Hostname())
+		}
+		cfg, err := sourceutil.LoadAWSConfig(ctx, sourceutil.AWSOptions{Region: region})
+		if err != nil {
+			return nil, fmt.Errorf("source %q (%s): unable to set up IAM auth: %w", r.Name, SourceKind, err)
+		}
+		if cfg.Region == "" {
+			return nil, fmt.Errorf("source %q (%s): unable to determine AWS region from endpoint %q and no region configured", r.Name, SourceKind, r.Endpoint)
+		}
+		s.credentials = cfg.Credentials
+		s.region = cfg.Region
+	}
+
+	if err := s.HealthCheck(ctx); err != nil {
+		return nil, fmt.Errorf("source %q (%s): unable to connect successfully: %w", r.Name, SourceKind, err)
+	}
+	return s, nil
+}
+
+var _ sources.Source = &Source{}
+
+// Source represents an OpenSearch source.
+type Source struct {
+	Config
+	HTTPClient *http.Client
+
+	// credentials sign requests when IAM authentication is enabled; nil otherwise.
+	credentials aws.CredentialsProvider
+	region      string
+}
+
+func (s *Source) SourceKind() string {
+	return SourceKind
+}
+
+func (s *Source) ToConfig() sources.SourceConfig {
+	return s.Config
+}
+
+// HealthCheck requests the cluster health. OpenSearch Serverless has no
+// cluster health API, so collections are checked by listing indexes instead.
+func (s *Source) HealthCheck(ctx context.Context) error {
+	path := "/_cluster/health"
+	if s.Serverless {
+		path = "/_cat/indices?format=json"
+	}
+	_, err := s.doRequest(ctx, http.MethodGet, path, nil)
+	return err
+}
+
+// Search runs a search request against index and returns the raw response.
+// query is the JSON request body, e.g. {"query":{"match":{"title":"toolbox"}}}.
+// An empty index means the configured default index.
+func (s *Source) Search(ctx context.Context, index, query string) (json.RawMessage, error) {
+	if index == "" {
+		index = s.Index
+	}
+	if index == "" {
+		return nil, fmt.Errorf("no index specified and no default index configured")
+	}
+	if query != "" && !json.Valid([]byte(query)) {
+		return nil, fmt.Errorf("query is not valid JSON")
+	}
+
+	var body []byte
+	if query != "" {
+		body = []byte(query)
+	}
+	resp, err := s.doRequest(ctx, http.MethodPost, "/"+url.PathEscape(index)+"/_search", body)
+	if err != nil {
+		return nil, fmt.Errorf("unable to search index %q: %w", index, err)
+	}
+	return json.RawMessage(resp), nil
+}
+
+// Close closes the HTTP client's idle connections.
+func (s *Source) Close() error {
+	if s.HTTPClient != nil {
+		s.HTTPClient.CloseIdleConnections()
+	}
+	return nil
+}
+
+// doRequest sends a request to the OpenSearch endpoint, authenticating it with
+// basic auth or SigV4, and returns the response body.
+func (s *Source) doRequest(ctx context.Context, method, path string, body []byte) ([]byte, error) {
+	reqURL := strings.TrimSuffix(s.Endpoint, "/") + path
+
+	var bodyReader io.Reader
+	if body != nil {
+		bodyReader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, reqURL, bodyReader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	switch {
+	case s.credentials != nil:
+		creds, err := s.credentials.Retrieve(ctx)
+		if err != nil {
+			return nil, sources.WithKind(fmt.Errorf("failed to retrieve AWS credentials: %w", err), sources.ErrAuth)
+		}
+		service := signingService
+		if s.Serverless {
+			service = serverlessSigningService
+		}
+		if err := sourceutil.SignAWSRequest(ctx, creds, req, body, service, s.region); err != nil {
+			return nil, fmt.Errorf("failed to sign request: %w", err)
+		}
+	case s.Username != "":
+		req.SetBasicAuth(s.Username, s.Password)
+	}
+
+	client := s.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", sources.ClassifyError(err))
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, sources.NewStatusError(resp.StatusCode, "request failed with status %d: %s", resp.StatusCode, string(respBody))
+	}
+	return respBody, nil
+}
+
+// extractRegionFromEndpoint extracts the AWS region from an OpenSearch Service
+// hostname, e.g. search-mydomain-abc123.us-east-1.es.amazonaws.com or
+// abc123.us-east-1.aoss.amazonaws.com.
+func extractRegionFromEndpoint(host string) string {
+	parts := strings.Split(host, ".")
+	for i := 1; i < len(parts); i++ {
+		if parts[i] == signingService || parts[i] == serverlessSigningService {
+			return parts[i-1]
+		}
+	}
+	return ""
+}
@@ -0,0 +1,204 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opensearch
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestParseFromYamlOpenSearch(t *testing.T) {
+	tests := []struct {
+		name        string
+		yamlContent string
+		wantErr     string
+		expected    Config
+	}{
+		{
+			name: "basic auth",
+			yamlContent: `name: test-opensearch
+kind: opensearch
+endpoint: https://localhost:9200
+username: admin
+password: secret
+index: logs`,
+			expected: Config{
+				Name:     "test-opensearch",
+				Kind:     "opensearch",
+				Endpoint: "https://localhost:9200",
+				Username: "admin",
+				Password: "secret",
+				Index:    "logs",
+			},
+		},
+		{
+			name: "IAM auth",
+			yamlContent: `name: test-opensearch
+kind: opensearch
+endpoint: https://search-logs-abc123.us-east-1.es.amazonaws.com
+useIAM: true`,
+			expected: Config{
+				Name:     "test-opensearch",
+				Kind:     "opensearch",
+				Endpoint: "https://search-logs-abc123.us-east-1.es.amazonaws.com",
+				UseIAM:   true,
+			},
+		},
+		{
+			name: "IAM and basic auth",
+			yamlContent: `name: test-opensearch
+kind: opensearch
+endpoint: https://localhost:9200
+useIAM: true
+username: admin
+password: secret`,
+			wantErr: "useIAM and username/password cannot both be set",
+		},
+		{
+			name: "username without password",
+			yamlContent: `name: test-opensearch
+kind: opensearch
+endpoint: https://localhost:9200
+username: admin`,
+			wantErr: "username and password must both be set",
+		},
+		{
+			name: "serverless without IAM",
+			yamlContent: `name: test-opensearch
+kind: opensearch
+endpoint: https://abc123.us-east-1.aoss.amazonaws.com
+serverless: true`,
+			wantErr: "serverless requires useIAM",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decoder := yaml.NewDecoder(bytes.NewReader([]byte(tt.yamlContent)))
+			config, err := newConfig(context.Background(), "test-opensearch", decoder)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, config)
+		})
+	}
+}
+
+func TestSearch(t *testing.T) {
+	var gotPath, gotBody, gotUser, gotPass string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotUser, gotPass, _ = r.BasicAuth()
+		switch r.URL.Path {
+		case "/_cluster/health":
+			_, _ = w.Write([]byte(`{"status":"green"}`))
+		case "/logs/_search":
+			gotPath = r.URL.Path
+			body, _ := io.ReadAll(r.Body)
+			gotBody = string(body)
+			_, _ = w.Write([]byte(`{"hits":{"total":{"value":1},"hits":[{"_id":"1"}]}}`))
+		default:
+			http.Error(w, `{"error":"index_not_found_exception"}`, http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	cfg := Config{Name: "test", Kind: SourceKind, Endpoint: server.URL, Username: "admin", Password: "secret", Index: "logs"}
+	src, err := cfg.Initialize(context.Background(), noop.NewTracerProvider().Tracer("test"))
+	require.NoError(t, err)
+	s := src.(*Source)
+	assert.Equal(t, "admin", gotUser)
+	assert.Equal(t, "secret", gotPass)
+
+	resp, err := s.Search(context.Background(), "", `{"query":{"match_all":{}}}`)
+	require.NoError(t, err)
+	assert.Equal(t, "/logs/_search", gotPath)
+	assert.Equal(t, `{"query":{"match_all":{}}}`, gotBody)
+	assert.JSONEq(t, `{"hits":{"total":{"value":1},"hits":[{"_id":"1"}]}}`, string(resp))
+
+	_, err = s.Search(context.Background(), "missing", "")
+	assert.ErrorIs(t, err, sources.ErrNotFound)
+
+	_, err = s.Search(context.Background(), "logs", "{not json")
+	assert.ErrorContains(t, err, "not valid JSON")
+}
+
+func TestSearchWithoutIndex(t *testing.T) {
+	s := &Source{Config: Config{Name: "test", Endpoint: "http://localhost:9200"}}
+	_, err := s.Search(context.Background(), "", "")
+	assert.ErrorContains(t, err, "no index specified")
+}
+
+func TestIAMSigning(t *testing.T) {
+	var gotAuth, gotToken string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		gotToken = r.Header.Get("X-Amz-Security-Token")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	for _, tc := range []struct {
+		serverless bool
+		service    string
+	}{
+		{serverless: false, service: "es"},
+		{serverless: true, service: "aoss"},
+	} {
+		s := &Source{
+			Config:      Config{Name: "test", Endpoint: server.URL, UseIAM: true, Serverless: tc.serverless, Index: "logs"},
+			HTTPClient:  server.Client(),
+			credentials: credentials.NewStaticCredentialsProvider("AKID", "SECRET", "TOKEN"),
+			region:      "us-west-2",
+		}
+		_, err := s.Search(context.Background(), "", `{"size":1}`)
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(gotAuth, "AWS4-HMAC-SHA256 Credential=AKID/"), gotAuth)
+		assert.Contains(t, gotAuth, "/us-west-2/"+tc.service+"/aws4_request")
+		assert.Equal(t, "TOKEN", gotToken)
+	}
+}
+
+func TestExtractRegionFromEndpoint(t *testing.T) {
+	tests := map[string]string{
+		"search-logs-abc123.us-east-1.es.amazonaws.com": "us-east-1",
+		"vpc-logs-abc123.eu-west-2.es.amazonaws.com":    "eu-west-2",
+		"abc123.ap-southeast-1.aoss.amazonaws.com":      "ap-southeast-1",
+		"localhost": "",
+	}
+	for host, want := range tests {
+		assert.Equal(t, want, extractRegionFromEndpoint(host), host)
+	}
+}
+
+func TestSourceKindOpenSearch(t *testing.T) {
+	config := Config{Name: "test", Kind: SourceKind, Endpoint: "http://localhost:9200"}
+	assert.Equal(t, SourceKind, config.SourceConfigKind())
+	source := Source{Config: config}
+	assert.Equal(t, SourceKind, source.SourceKind())
+}
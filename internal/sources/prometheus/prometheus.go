@@ -0,0 +1,346 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package prometheus provides a source implementation for querying metrics
+// from Prometheus and servers implementing its HTTP API.
+package prometheus
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	sourceutil "github.com/googleapis/genai-toolbox/internal/sources/util"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const SourceKind string = "prometheus"
+
+// Default configuration constants
+const (
+	DefaultTimeout = 30 // Default request timeout in seconds
+)
+
+// Result types returned by the Prometheus query API.
+const (
+	ResultTypeVector = "vector"
+	ResultTypeMatrix = "matrix"
+	ResultTypeScalar = "scalar"
+	ResultTypeString = "string"
+)
+
+// validate interface
+var _ sources.SourceConfig = Config{}
+
+func init() {
+	sources.MustRegister(SourceKind, newConfig)
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources.SourceConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+
+	bearerToken, err := sourceutil.ResolveSecret("bearerToken", actual.BearerToken, actual.BearerTokenFile)
+	if err != nil {
+		return nil, fmt.Errorf("source %q (%s): unable to resolve secret: %w", name, SourceKind, err)
+	}
+	actual.BearerToken = bearerToken
+
+	if actual.Timeout < 0 {
+		return nil, fmt.Errorf("source %q (%s): timeout must not be negative", name, SourceKind)
+	}
+	return actual, nil
+}
+
+// Config represents the configuration for a Prometheus source.
+type Config struct {
+	Name            string `yaml:"name" validate:"required"`
+	Kind            string `yaml:"kind" validate:"required"`
+	URL             string `yaml:"url" validate:"required"` // e.g., http://prometheus:9090
+	BearerToken     string `yaml:"bearerToken"`             // Optional: sent as an Authorization bearer token
+	BearerTokenFile string `yaml:"bearerTokenFile"`         // Optional: file to read bearerToken from
+	Timeout         int    `yaml:"timeout"`                 // Optional: request timeout in seconds (default: 30)
+}
+
+func (r Config) SourceConfigKind() string {
+	return SourceKind
+}
+
+func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.Source, error) {
+	//nolint:all // Reassigned ctx
+	ctx, span := sources.InitConnectionSpan(ctx, tracer, SourceKind, r.Name)
+	defer span.End()
+
+	u, err := url.Parse(r.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("source %q (%s): url %q must be an http or https URL", r.Name, SourceKind, r.URL)
+	}
+
+	timeout := r.Timeout
+	if timeout == 0 {
+		timeout = DefaultTimeout
+	}
+	s := &Source{
+		Config:     r,
+		HTTPClient: &http.Client{Timeout: time.Duration(timeout) * time.Second},
+	}
+	if err := s.HealthCheck(ctx); err != nil {
+		return nil, fmt.Errorf("source %q (%s): unable to connect successfully: %w", r.Name, SourceKind, err)
+	}
+	return s, nil
+}
+
+var _ sources.Source = &Source{}
+
+// Source represents a Prometheus source.
+type Source struct {
+	Config
+	HTTPClient *http.Client
+}
+
+func (s *Source) SourceKind() string {
+	return SourceKind
+}
+
+func (s *Source) ToConfig() sources.SourceConfig {
+	return s.Config
+}
+
+// HealthCheck calls the server's health endpoint.
+func (s *Source) HealthCheck(ctx context.Context) error {
+	_, err := s.doRequest(ctx, http.MethodGet, "/-/healthy", nil)
+	return err
+}
+
+// Close closes the HTTP client's idle connections.
+func (s *Source) Close() error {
+	if s.HTTPClient != nil {
+		s.HTTPClient.CloseIdleConnections()
+	}
+	return nil
+}
+
+// QueryResult is the parsed result of a PromQL query.
+type QueryResult struct {
+	ResultType string   // One of the ResultType constants
+	Series     []Series // Vector and matrix results; vector series have a single sample
+	Scalar     *Sample  // Scalar results
+	String     string   // String results
+	Warnings   []string // Warnings reported by the server, e.g. for partial data
+}
+
+// Series is a time series identified by its labels.
+type Series struct {
+	Metric  map[string]string
+	Samples []Sample
+}
+
+// Sample is a single value of a time series.
+type Sample struct {
+	Timestamp time.Time
+	Value     float64
+}
+
+// Query evaluates an instant query at t. A zero t means the server's current time.
+func (s *Source) Query(ctx context.Context, promql string, t time.Time) (*QueryResult, error) {
+	params := url.Values{"query": {promql}}
+	if !t.IsZero() {
+		params.Set("time", formatTime(t))
+	}
+	return s.query(ctx, "/api/v1/query", params)
+}
+
+// QueryRange evaluates a range query from start to end at the given resolution step.
+func (s *Source) QueryRange(ctx context.Context, promql string, start, end time.Time, step time.Duration) (*QueryResult, error) {
+	if step <= 0 {
+		return nil, fmt.Errorf("step must be positive")
+	}
+	if end.Before(start) {
+		return nil, fmt.Errorf("end must not be before start")
+	}
+	params := url.Values{
+		"query": {promql},
+		"start": {formatTime(start)},
+		"end":   {formatTime(end)},
+		"step":  {strconv.FormatFloat(step.Seconds(), 'f', -1, 64)},
+	}
+	return s.query(ctx, "/api/v1/query_range", params)
+}
+
+// apiResponse is the envelope of Prometheus API responses.
+type apiResponse struct {
+	Status    string          `json:"status"`
+	Data      json.RawMessage `json:"data"`
+	ErrorType string          `json:"errorType"`
+	Error     string          `json:"error"`
+	Warnings  []string        `json:"warnings"`
+}
+
+type queryData struct {
+	ResultType string          `json:"resultType"`
+	Result     json.RawMessage `json:"result"`
+}
+
+type seriesData struct {
+	Metric map[string]string `json:"metric"`
+	Value  []any             `json:"value"`  // Vector results
+	Values [][]any           `json:"values"` // Matrix results
+}
+
+// query sends a query as a form-encoded POST, which avoids URL length limits
+// on long PromQL expressions, and parses the result.
+func (s *Source) query(ctx context.Context, path string, params url.Values) (*QueryResult, error) {
+	body, err := s.doRequest(ctx, http.MethodPost, path, params)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp apiResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	var data queryData
+	if err := json.Unmarshal(resp.Data, &data); err != nil {
+		return nil, fmt.Errorf("failed to decode query data: %w", err)
+	}
+
+	result := &QueryResult{ResultType: data.ResultType, Warnings: resp.Warnings}
+	switch data.ResultType {
+	case ResultTypeVector, ResultTypeMatrix:
+		var series []seriesData
+		if err := json.Unmarshal(data.Result, &series); err != nil {
+			return nil, fmt.Errorf("failed to decode %s result: %w", data.ResultType, err)
+		}
+		for _, sd := range series {
+			values := sd.Values
+			if data.ResultType == ResultTypeVector {
+				values = [][]any{sd.Value}
+			}
+			samples := make([]Sample, 0, len(values))
+			for _, v := range values {
+				sample, err := parseSample(v)
+				if err != nil {
+					return nil, err
+				}
+				samples = append(samples, sample)
+			}
+			result.Series = append(result.Series, Series{Metric: sd.Metric, Samples: samples})
+		}
+	case ResultTypeScalar:
+		var v []any
+		if err := json.Unmarshal(data.Result, &v); err != nil {
+			return nil, fmt.Errorf("failed to decode scalar result: %w", err)
+		}
+		sample, err := parseSample(v)
+		if err != nil {
+			return nil, err
+		}
+		result.Scalar = &sample
+	case ResultTypeString:
+		var v []any
+		if err := json.Unmarshal(data.Result, &v); err != nil || len(v) != 2 {
+			return nil, fmt.Errorf("failed to decode string result: %s", string(data.Result))
+		}
+		result.String, _ = v[1].(string)
+	default:
+		return nil, fmt.Errorf("unsupported result type %q", data.ResultType)
+	}
+	return result, nil
+}
+
+// parseSample parses a [<unix seconds>, "<value>"] pair.
+func parseSample(v []any) (Sample, error) {
+	if len(v) != 2 {
+		return Sample{}, fmt.Errorf("malformed sample %v", v)
+	}
+	ts, ok := v[0].(float64)
+	if !ok {
+		return Sample{}, fmt.Errorf("malformed sample timestamp %v", v[0])
+	}
+	str, ok := v[1].(string)
+	if !ok {
+		return Sample{}, fmt.Errorf("malformed sample value %v", v[1])
+	}
+	value, err := strconv.ParseFloat(str, 64)
+	if err != nil {
+		return Sample{}, fmt.Errorf("malformed sample value %q: %w", str, err)
+	}
+	// Prometheus timestamps have millisecond precision
+	return Sample{Timestamp: time.UnixMilli(int64(math.Round(ts * 1000))).UTC(), Value: value}, nil
+}
+
+// formatTime formats t as Unix seconds with millisecond precision.
+func formatTime(t time.Time) string {
+	return strconv.FormatFloat(float64(t.UnixMilli())/1000, 'f', -1, 64)
+}
+
+// doRequest sends a request to the Prometheus server and returns the response
+// body. Form values are sent as the request body.
+func (s *Source) doRequest(ctx context.Context, method, path string, form url.Values) ([]byte, error) {
+	reqURL := strings.TrimSuffix(s.URL, "/") + path
+
+	var bodyReader io.Reader
+	if form != nil {
+		bodyReader = strings.NewReader(form.Encode())
+	}
+	req, err := http.NewRequestWithContext(ctx, method, reqURL, bodyReader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	if form != nil {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+	if s.BearerToken != "" {
+		req.Header.Set("Authorization", "Bearer "+s.BearerToken)
+	}
+
+	client := s.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", sources.ClassifyError(err))
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		// Query errors carry the reason in the response envelope
+		var apiErr apiResponse
+		if json.Unmarshal(body, &apiErr) == nil && apiErr.Error != "" {
+			err := sources.NewStatusError(resp.StatusCode, "query failed with status %d: %s: %s", resp.StatusCode, apiErr.ErrorType, apiErr.Error)
+			if apiErr.ErrorType == "timeout" {
+				err = sources.WithKind(err, sources.ErrTimeout)
+			}
+			return nil, err
+		}
+		return nil, sources.NewStatusError(resp.StatusCode, "request failed with status %d: %s", resp.StatusCode, string(body))
+	}
+	return body, nil
+}
@@ -0,0 +1,235 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheus
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestParseFromYamlPrometheus(t *testing.T) {
+	tests := []struct {
+		name        string
+		yamlContent string
+		wantErr     string
+		expected    Config
+	}{
+		{
+			name: "basic configuration",
+			yamlContent: `name: test-prometheus
+kind: prometheus
+url: http://prometheus:9090`,
+			expected: Config{
+				Name: "test-prometheus",
+				Kind: "prometheus",
+				URL:  "http://prometheus:9090",
+			},
+		},
+		{
+			name: "bearer token and timeout",
+			yamlContent: `name: test-prometheus
+kind: prometheus
+url: https://prometheus.example.com
+bearerToken: my-token
+timeout: 10`,
+			expected: Config{
+				Name:        "test-prometheus",
+				Kind:        "prometheus",
+				URL:         "https://prometheus.example.com",
+				BearerToken: "my-token",
+				Timeout:     10,
+			},
+		},
+		{
+			name: "negative timeout",
+			yamlContent: `name: test-prometheus
+kind: prometheus
+url: http://prometheus:9090
+timeout: -1`,
+			wantErr: "timeout must not be negative",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decoder := yaml.NewDecoder(bytes.NewReader([]byte(tt.yamlContent)))
+			config, err := newConfig(context.Background(), "test-prometheus", decoder)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, config)
+		})
+	}
+}
+
+func newTestSource(t *testing.T, handler http.HandlerFunc) *Source {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/-/healthy", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer my-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte("Prometheus Server is Healthy.\n"))
+	})
+	mux.HandleFunc("/api/v1/", handler)
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	cfg := Config{Name: "test", Kind: SourceKind, URL: server.URL, BearerToken: "my-token"}
+	src, err := cfg.Initialize(context.Background(), noop.NewTracerProvider().Tracer("test"))
+	require.NoError(t, err)
+	return src.(*Source)
+}
+
+func TestQuery(t *testing.T) {
+	var gotPath, gotQuery, gotTime string
+	s := newTestSource(t, func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotQuery = r.FormValue("query")
+		gotTime = r.FormValue("time")
+		_, _ = w.Write([]byte(`{"status":"success","data":{"resultType":"vector","result":[
+			{"metric":{"__name__":"up","job":"api"},"value":[1714564800.5,"1"]},
+			{"metric":{"__name__":"up","job":"db"},"value":[1714564800.5,"0"]}
+		]},"warnings":["partial data"]}`))
+	})
+
+	ts := time.Date(2024, 5, 1, 12, 0, 0, 500_000_000, time.UTC)
+	result, err := s.Query(context.Background(), "up", ts)
+	require.NoError(t, err)
+	assert.Equal(t, "/api/v1/query", gotPath)
+	assert.Equal(t, "up", gotQuery)
+	assert.Equal(t, "1714564800.5", gotTime)
+
+	assert.Equal(t, ResultTypeVector, result.ResultType)
+	assert.Equal(t, []string{"partial data"}, result.Warnings)
+	require.Len(t, result.Series, 2)
+	assert.Equal(t, map[string]string{"__name__": "up", "job": "api"}, result.Series[0].Metric)
+	assert.Equal(t, []Sample{{Timestamp: ts, Value: 1}}, result.Series[0].Samples)
+	assert.Equal(t, 0.0, result.Series[1].Samples[0].Value)
+}
+
+func TestQueryRange(t *testing.T) {
+	var gotPath, gotStart, gotEnd, gotStep string
+	s := newTestSource(t, func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotStart = r.FormValue("start")
+		gotEnd = r.FormValue("end")
+		gotStep = r.FormValue("step")
+		_, _ = w.Write([]byte(`{"status":"success","data":{"resultType":"matrix","result":[
+			{"metric":{"job":"api"},"values":[[1714564800,"0.5"],[1714564830,"NaN"]]}
+		]}}`))
+	})
+
+	start := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	end := start.Add(time.Minute)
+	result, err := s.QueryRange(context.Background(), "rate(http_requests_total[5m])", start, end, 30*time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, "/api/v1/query_range", gotPath)
+	assert.Equal(t, "1714564800", gotStart)
+	assert.Equal(t, "1714564860", gotEnd)
+	assert.Equal(t, "30", gotStep)
+
+	assert.Equal(t, ResultTypeMatrix, result.ResultType)
+	require.Len(t, result.Series, 1)
+	require.Len(t, result.Series[0].Samples, 2)
+	assert.Equal(t, start.Add(30*time.Second), result.Series[0].Samples[1].Timestamp)
+	assert.Equal(t, 0.5, result.Series[0].Samples[0].Value)
+	assert.NotEqual(t, result.Series[0].Samples[1].Value, result.Series[0].Samples[1].Value) // NaN
+
+	_, err = s.QueryRange(context.Background(), "up", end, start, time.Second)
+	assert.ErrorContains(t, err, "end must not be before start")
+	_, err = s.QueryRange(context.Background(), "up", start, end, 0)
+	assert.ErrorContains(t, err, "step must be positive")
+}
+
+func TestQueryScalar(t *testing.T) {
+	s := newTestSource(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"status":"success","data":{"resultType":"scalar","result":[1714564800,"42"]}}`))
+	})
+
+	result, err := s.Query(context.Background(), "scalar(42)", time.Time{})
+	require.NoError(t, err)
+	require.NotNil(t, result.Scalar)
+	assert.Equal(t, 42.0, result.Scalar.Value)
+}
+
+func TestQueryErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		body     string
+		wantErr  string
+		wantKind error
+	}{
+		{
+			name:    "bad query",
+			status:  http.StatusBadRequest,
+			body:    `{"status":"error","errorType":"bad_data","error":"parse error: unexpected end of input"}`,
+			wantErr: "bad_data: parse error: unexpected end of input",
+		},
+		{
+			name:     "query timeout",
+			status:   http.StatusServiceUnavailable,
+			body:     `{"status":"error","errorType":"timeout","error":"query timed out in expression evaluation"}`,
+			wantErr:  "query timed out",
+			wantKind: sources.ErrTimeout,
+		},
+		{
+			name:     "unauthorized",
+			status:   http.StatusUnauthorized,
+			body:     `unauthorized`,
+			wantErr:  "status 401",
+			wantKind: sources.ErrAuth,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestSource(t, func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			})
+			_, err := s.Query(context.Background(), "up", time.Time{})
+			require.ErrorContains(t, err, tt.wantErr)
+			if tt.wantKind != nil {
+				assert.ErrorIs(t, err, tt.wantKind)
+			}
+		})
+	}
+}
+
+func TestInitializeUnhealthy(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	cfg := Config{Name: "test", Kind: SourceKind, URL: server.URL}
+	_, err := cfg.Initialize(context.Background(), noop.NewTracerProvider().Tracer("test"))
+	assert.ErrorContains(t, err, "unable to connect successfully")
+}
@@ -0,0 +1,236 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package qldb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/amzn/ion-go/ion"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/qldbsession"
+	"github.com/aws/aws-sdk-go-v2/service/qldbsession/types"
+)
+
+// Session and OCC retry constants
+const (
+	MaxOCCRetries     = 4                     // Retries of a transaction that hit an OCC conflict
+	OCCRetryDelay     = 10 * time.Millisecond // Initial delay before retrying a conflicting transaction
+	MaxOCCRetryDelay  = 5 * time.Second       // Upper bound for the retry delay
+	EndSessionTimeout = 5 * time.Second       // Time allowed to end a session after its context is done
+)
+
+// sessionAPI is the subset of the QLDB Session API used by Execute.
+type sessionAPI interface {
+	SendCommand(ctx context.Context, params *qldbsession.SendCommandInput, optFns ...func(*qldbsession.Options)) (*qldbsession.SendCommandOutput, error)
+}
+
+// Execute runs a PartiQL statement with positional ? parameters in its own
+// transaction and returns the resulting documents. Parameters are serialized to
+// Ion, and the returned Ion documents are decoded to Go values (Ion decimals
+// and timestamps decode to *ion.Decimal and ion.Timestamp). If the commit hits
+// an optimistic concurrency conflict the transaction is retried with backoff.
+func (s *Source) Execute(ctx context.Context, statement string, params ...any) ([]map[string]any, error) {
+	values, err := s.executeIon(ctx, statement, params)
+	if err != nil {
+		return nil, err
+	}
+	docs := make([]map[string]any, len(values))
+	for i, value := range values {
+		if docs[i], err = decodeDocument(value); err != nil {
+			return nil, err
+		}
+	}
+	return docs, nil
+}
+
+// executeIon runs statement in its own transaction, retrying OCC conflicts, and
+// returns the resulting Ion binary values.
+func (s *Source) executeIon(ctx context.Context, statement string, params []any) ([][]byte, error) {
+	session, err := s.startSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer session.end(ctx)
+
+	delay := OCCRetryDelay
+	for attempt := 0; ; attempt++ {
+		results, err := session.executeTransaction(ctx, statement, params)
+		var occ *types.OccConflictException
+		if !errors.As(err, &occ) || attempt == MaxOCCRetries {
+			return results, err
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+		delay = min(delay*2, MaxOCCRetryDelay)
+	}
+}
+
+// session is a QLDB session on the configured ledger.
+type session struct {
+	api   sessionAPI
+	token *string
+}
+
+func (s *Source) startSession(ctx context.Context) (*session, error) {
+	out, err := s.sessionAPI.SendCommand(ctx, &qldbsession.SendCommandInput{
+		StartSession: &types.StartSessionRequest{LedgerName: aws.String(s.LedgerName)},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to start session on ledger %s: %w", s.LedgerName, err)
+	}
+	if out.StartSession == nil {
+		return nil, fmt.Errorf("unable to start session on ledger %s: empty response", s.LedgerName)
+	}
+	return &session{api: s.sessionAPI, token: out.StartSession.SessionToken}, nil
+}
+
+// end ends the session, even if ctx is done.
+func (ss *session) end(ctx context.Context) {
+	endCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), EndSessionTimeout)
+	defer cancel()
+	_, _ = ss.api.SendCommand(endCtx, &qldbsession.SendCommandInput{
+		SessionToken: ss.token,
+		EndSession:   &types.EndSessionRequest{},
+	})
+}
+
+// executeTransaction runs statement in a new transaction and commits it. The
+// transaction is aborted if any step fails.
+func (ss *session) executeTransaction(ctx context.Context, statement string, params []any) ([][]byte, error) {
+	out, err := ss.api.SendCommand(ctx, &qldbsession.SendCommandInput{
+		SessionToken:     ss.token,
+		StartTransaction: &types.StartTransactionRequest{},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to start transaction: %w", err)
+	}
+	if out.StartTransaction == nil {
+		return nil, errors.New("unable to start transaction: empty response")
+	}
+	txID := out.StartTransaction.TransactionId
+
+	results, digest, err := ss.executeStatement(ctx, txID, statement, params)
+	if err == nil {
+		_, err = ss.api.SendCommand(ctx, &qldbsession.SendCommandInput{
+			SessionToken:      ss.token,
+			CommitTransaction: &types.CommitTransactionRequest{TransactionId: txID, CommitDigest: digest},
+		})
+		if err == nil {
+			return results, nil
+		}
+		err = fmt.Errorf("unable to commit transaction: %w", err)
+	}
+
+	// QLDB discards the transaction after an OCC conflict, so only abort
+	// others. Abort errors are ignored; the transaction expires on its own.
+	var occ *types.OccConflictException
+	if !errors.As(err, &occ) {
+		abortCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), EndSessionTimeout)
+		defer cancel()
+		_, _ = ss.api.SendCommand(abortCtx, &qldbsession.SendCommandInput{
+			SessionToken:     ss.token,
+			AbortTransaction: &types.AbortTransactionRequest{},
+		})
+	}
+	return nil, err
+}
+
+// executeStatement runs statement and reads every page of its results. It also
+// returns the commit digest: the transaction ID's Ion hash dotted with the hash
+// of the statement and its parameters.
+func (ss *session) executeStatement(ctx context.Context, txID *string, statement string, params []any) ([][]byte, []byte, error) {
+	digest, err := ionHash(aws.ToString(txID))
+	if err != nil {
+		return nil, nil, err
+	}
+	statementHash, err := ionHash(statement)
+	if err != nil {
+		return nil, nil, err
+	}
+	holders := make([]types.ValueHolder, len(params))
+	for i, param := range params {
+		paramHash, err := ionHash(param)
+		if err != nil {
+			return nil, nil, fmt.Errorf("parameter %d: %w", i+1, err)
+		}
+		statementHash = dotHash(statementHash, paramHash)
+		binary, err := ion.MarshalBinary(param)
+		if err != nil {
+			return nil, nil, fmt.Errorf("parameter %d: unable to marshal to Ion: %w", i+1, err)
+		}
+		holders[i] = types.ValueHolder{IonBinary: binary}
+	}
+	digest = dotHash(digest, statementHash)
+
+	out, err := ss.api.SendCommand(ctx, &qldbsession.SendCommandInput{
+		SessionToken: ss.token,
+		ExecuteStatement: &types.ExecuteStatementRequest{
+			TransactionId: txID,
+			Statement:     aws.String(statement),
+			Parameters:    holders,
+		},
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to execute statement: %w", err)
+	}
+	if out.ExecuteStatement == nil {
+		return nil, nil, errors.New("unable to execute statement: empty response")
+	}
+
+	var results [][]byte
+	page := out.ExecuteStatement.FirstPage
+	for page != nil {
+		for _, value := range page.Values {
+			results = append(results, value.IonBinary)
+		}
+		if aws.ToString(page.NextPageToken) == "" {
+			break
+		}
+		out, err := ss.api.SendCommand(ctx, &qldbsession.SendCommandInput{
+			SessionToken: ss.token,
+			FetchPage:    &types.FetchPageRequest{TransactionId: txID, NextPageToken: page.NextPageToken},
+		})
+		if err != nil {
+			return nil, nil, fmt.Errorf("unable to fetch page: %w", err)
+		}
+		if out.FetchPage == nil {
+			return nil, nil, errors.New("unable to fetch page: empty response")
+		}
+		page = out.FetchPage.Page
+	}
+	return results, digest, nil
+}
+
+// decodeDocument decodes an Ion struct returned by a statement.
+func decodeDocument(binary []byte) (map[string]any, error) {
+	var value any
+	if err := ion.Unmarshal(binary, &value); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal Ion result: %w", err)
+	}
+	doc, ok := value.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("statement returned a %T, not a document; use SELECT * or SELECT VALUE {...}", value)
+	}
+	return doc, nil
+}
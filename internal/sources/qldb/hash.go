@@ -0,0 +1,72 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package qldb
+
+import (
+	"crypto/sha256"
+	"fmt"
+
+	"github.com/amzn/ion-go/ion"
+	ionhash "github.com/amzn/ion-hash-go"
+)
+
+// ionHash returns the Ion hash (SHA-256) of value, as QLDB computes it for
+// transaction IDs, statements, and parameters.
+func ionHash(value any) ([]byte, error) {
+	text, err := ion.MarshalText(value)
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal value to Ion: %w", err)
+	}
+	reader, err := ionhash.NewHashReader(ion.NewReaderBytes(text), ionhash.NewCryptoHasherProvider(ionhash.SHA256))
+	if err != nil {
+		return nil, err
+	}
+	// Step onto and then past the value so it is hashed
+	reader.Next()
+	reader.Next()
+	if err := reader.Err(); err != nil {
+		return nil, err
+	}
+	return reader.Sum(nil)
+}
+
+// dotHash combines two hashes the way QLDB does: the hashes are ordered with
+// compareHashes, concatenated, and hashed. An empty hash is the identity.
+func dotHash(a, b []byte) []byte {
+	if len(a) == 0 {
+		return b
+	}
+	if len(b) == 0 {
+		return a
+	}
+	var joined []byte
+	if compareHashes(a, b) < 0 {
+		joined = append(append(joined, a...), b...)
+	} else {
+		joined = append(append(joined, b...), a...)
+	}
+	sum := sha256.Sum256(joined)
+	return sum[:]
+}
+
+// compareHashes compares hashes as signed bytes, starting from the last byte.
+func compareHashes(a, b []byte) int {
+	for i := min(len(a), len(b)) - 1; i >= 0; i-- {
+		if diff := int(int8(a[i])) - int(int8(b[i])); diff != 0 {
+			return diff
+		}
+	}
+	return len(a) - len(b)
+}
@@ -0,0 +1,143 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package qldb
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/amzn/ion-go/ion"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/qldb"
+	"github.com/aws/aws-sdk-go-v2/service/qldb/types"
+)
+
+// qldbAPI is the subset of the QLDB API used by VerifyDocument and the ledger
+// helpers.
+type qldbAPI interface {
+	ListLedgers(ctx context.Context, params *qldb.ListLedgersInput, optFns ...func(*qldb.Options)) (*qldb.ListLedgersOutput, error)
+	DescribeLedger(ctx context.Context, params *qldb.DescribeLedgerInput, optFns ...func(*qldb.Options)) (*qldb.DescribeLedgerOutput, error)
+	GetDigest(ctx context.Context, params *qldb.GetDigestInput, optFns ...func(*qldb.Options)) (*qldb.GetDigestOutput, error)
+	GetRevision(ctx context.Context, params *qldb.GetRevisionInput, optFns ...func(*qldb.Options)) (*qldb.GetRevisionOutput, error)
+}
+
+// tableNamePattern matches valid QLDB table names, which can't be passed as
+// statement parameters.
+var tableNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]{0,127}$`)
+
+// BlockAddress is the location of a revision in the ledger journal.
+type BlockAddress struct {
+	StrandID   string `ion:"strandId"`
+	SequenceNo int64  `ion:"sequenceNo"`
+}
+
+// IonText returns the address in the Ion text form VerifyDocument accepts.
+func (a BlockAddress) IonText() string {
+	return fmt.Sprintf("{strandId:%q,sequenceNo:%d}", a.StrandID, a.SequenceNo)
+}
+
+// RevisionMetadata is the system metadata of a revision.
+type RevisionMetadata struct {
+	ID      string    `ion:"id"`
+	Version int64     `ion:"version"`
+	TxTime  time.Time `ion:"txTime"`
+	TxID    string    `ion:"txId"`
+}
+
+// Revision is one version of a document. Data is nil for the revision that
+// deleted the document.
+type Revision struct {
+	BlockAddress BlockAddress     `ion:"blockAddress"`
+	Hash         []byte           `ion:"hash"`
+	Data         map[string]any   `ion:"data"`
+	Metadata     RevisionMetadata `ion:"metadata"`
+}
+
+// GetDocumentHistory returns every revision of the document with docID in
+// table, oldest first.
+func (s *Source) GetDocumentHistory(ctx context.Context, table, docID string) ([]Revision, error) {
+	if !tableNamePattern.MatchString(table) {
+		return nil, fmt.Errorf("invalid table name %q", table)
+	}
+	statement := fmt.Sprintf("SELECT * FROM history(%s) AS h WHERE h.metadata.id = ?", table)
+	values, err := s.executeIon(ctx, statement, []any{docID})
+	if err != nil {
+		return nil, err
+	}
+
+	revisions := make([]Revision, len(values))
+	for i, value := range values {
+		if err := ion.Unmarshal(value, &revisions[i]); err != nil {
+			return nil, fmt.Errorf("unable to unmarshal revision: %w", err)
+		}
+	}
+	return revisions, nil
+}
+
+// VerifyDocument verifies the revision of docID at blockAddress against the
+// ledger's current digest. It fetches the digest and a proof for the revision,
+// then recomputes the digest by dotting the revision hash with each proof hash
+// in turn. It reports false if the recomputed digest doesn't match, meaning the
+// revision isn't part of the journal. blockAddress is in Ion text form, as
+// returned by BlockAddress.IonText or the QLDB console, e.g.
+// {strandId:"BlFTjlSXze9BIh1KOszcE3",sequenceNo:14}.
+func (s *Source) VerifyDocument(ctx context.Context, docID, blockAddress string) (bool, error) {
+	digest, err := s.qldbAPI.GetDigest(ctx, &qldb.GetDigestInput{Name: aws.String(s.LedgerName)})
+	if err != nil {
+		return false, fmt.Errorf("unable to get digest of ledger %s: %w", s.LedgerName, err)
+	}
+
+	out, err := s.qldbAPI.GetRevision(ctx, &qldb.GetRevisionInput{
+		Name:             aws.String(s.LedgerName),
+		DocumentId:       aws.String(docID),
+		BlockAddress:     &types.ValueHolder{IonText: aws.String(blockAddress)},
+		DigestTipAddress: digest.DigestTipAddress,
+	})
+	if err != nil {
+		return false, fmt.Errorf("unable to get revision of document %s: %w", docID, err)
+	}
+	if out.Revision == nil || out.Proof == nil {
+		return false, fmt.Errorf("unable to get revision of document %s: empty response", docID)
+	}
+
+	var revision Revision
+	if err := ion.UnmarshalString(aws.ToString(out.Revision.IonText), &revision); err != nil {
+		return false, fmt.Errorf("unable to unmarshal revision: %w", err)
+	}
+	var proof [][]byte
+	if err := ion.UnmarshalString(aws.ToString(out.Proof.IonText), &proof); err != nil {
+		return false, fmt.Errorf("unable to unmarshal proof: %w", err)
+	}
+	if revision.Metadata.ID != docID {
+		return false, nil
+	}
+	return verifyProof(revision.Hash, digest.Digest, proof), nil
+}
+
+// verifyProof reports whether dotting hash with each proof hash in turn
+// produces digest.
+func verifyProof(hash, digest []byte, proof [][]byte) bool {
+	if len(hash) == 0 || len(digest) == 0 {
+		return false
+	}
+	candidate := hash
+	for _, p := range proof {
+		candidate = dotHash(candidate, p)
+	}
+	return bytes.Equal(candidate, digest)
+}
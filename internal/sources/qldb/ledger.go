@@ -0,0 +1,98 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package qldb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/qldb"
+)
+
+// LedgerSummary is a ledger returned by ListLedgers.
+type LedgerSummary struct {
+	Name      string
+	State     string // CREATING, ACTIVE, DELETING or DELETED
+	CreatedAt time.Time
+}
+
+// LedgerInfo describes the configured ledger.
+type LedgerInfo struct {
+	Name               string
+	ARN                string
+	State              string // CREATING, ACTIVE, DELETING or DELETED
+	PermissionsMode    string // ALLOW_ALL or STANDARD
+	DeletionProtection bool
+	CreatedAt          time.Time
+	Encryption         *LedgerEncryption // Nil if QLDB didn't report encryption settings
+}
+
+// LedgerEncryption describes how a ledger is encrypted at rest.
+type LedgerEncryption struct {
+	Status               string    // ENABLED, UPDATING or KMS_KEY_INACCESSIBLE
+	KMSKeyARN            string    // "AWS_OWNED_KMS_KEY" for the AWS owned key
+	KeyInaccessibleSince time.Time // Zero unless Status is KMS_KEY_INACCESSIBLE
+}
+
+// ListLedgers returns every ledger in the account and region, following
+// NextToken across pages.
+func (s *Source) ListLedgers(ctx context.Context) ([]LedgerSummary, error) {
+	ledgers := []LedgerSummary{}
+	input := &qldb.ListLedgersInput{}
+	for {
+		out, err := s.qldbAPI.ListLedgers(ctx, input)
+		if err != nil {
+			return nil, fmt.Errorf("unable to list ledgers: %w", err)
+		}
+		for _, l := range out.Ledgers {
+			ledgers = append(ledgers, LedgerSummary{
+				Name:      aws.ToString(l.Name),
+				State:     string(l.State),
+				CreatedAt: aws.ToTime(l.CreationDateTime),
+			})
+		}
+		if aws.ToString(out.NextToken) == "" {
+			return ledgers, nil
+		}
+		input.NextToken = out.NextToken
+	}
+}
+
+// DescribeLedger returns the state, permissions mode, deletion protection and
+// encryption settings of the configured ledger.
+func (s *Source) DescribeLedger(ctx context.Context) (*LedgerInfo, error) {
+	out, err := s.qldbAPI.DescribeLedger(ctx, &qldb.DescribeLedgerInput{Name: aws.String(s.LedgerName)})
+	if err != nil {
+		return nil, fmt.Errorf("unable to describe ledger %s: %w", s.LedgerName, err)
+	}
+	info := &LedgerInfo{
+		Name:               aws.ToString(out.Name),
+		ARN:                aws.ToString(out.Arn),
+		State:              string(out.State),
+		PermissionsMode:    string(out.PermissionsMode),
+		DeletionProtection: aws.ToBool(out.DeletionProtection),
+		CreatedAt:          aws.ToTime(out.CreationDateTime),
+	}
+	if enc := out.EncryptionDescription; enc != nil {
+		info.Encryption = &LedgerEncryption{
+			Status:               string(enc.EncryptionStatus),
+			KMSKeyARN:            aws.ToString(enc.KmsKeyArn),
+			KeyInaccessibleSince: aws.ToTime(enc.InaccessibleKmsKeyDateTime),
+		}
+	}
+	return info, nil
+}
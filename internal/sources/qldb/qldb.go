@@ -22,12 +22,11 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/aws/aws-sdk-go-v2/config"
-	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/service/qldb"
 	"github.com/aws/aws-sdk-go-v2/service/qldbsession"
 	"github.com/goccy/go-yaml"
 	"github.com/googleapis/genai-toolbox/internal/sources"
+	sourceutil "github.com/googleapis/genai-toolbox/internal/sources/util"
 	"go.opentelemetry.io/otel/trace"
 )
 
@@ -37,9 +36,7 @@ const SourceKind string = "qldb"
 var _ sources.SourceConfig = Config{}
 
 func init() {
-	if !sources.Register(SourceKind, newConfig) {
-		panic(fmt.Sprintf("source kind %q already registered", SourceKind))
-	}
+	sources.MustRegister(SourceKind, newConfig)
 }
 
 func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources.SourceConfig, error) {
@@ -47,17 +44,41 @@ func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources
 	if err := decoder.DecodeContext(ctx, &actual); err != nil {
 		return nil, err
 	}
+
+	for _, secret := range []struct {
+		field string
+		value *string
+		file  string
+	}{
+		{"secretAccessKey", &actual.SecretAccessKey, actual.SecretAccessKeyFile},
+		{"sessionToken", &actual.SessionToken, actual.SessionTokenFile},
+	} {
+		resolved, err := sourceutil.ResolveSecret(secret.field, *secret.value, secret.file)
+		if err != nil {
+			return nil, fmt.Errorf("source %q (%s): unable to resolve secret: %w", name, SourceKind, err)
+		}
+		*secret.value = resolved
+	}
+	if actual.MaxRetries < 0 {
+		return nil, fmt.Errorf("source %q (%s): maxRetries must not be negative", name, SourceKind)
+	}
 	return actual, nil
 }
 
 type Config struct {
-	Name            string `yaml:"name" validate:"required"`
-	Kind            string `yaml:"kind" validate:"required"`
-	Region          string `yaml:"region" validate:"required"`
-	LedgerName      string `yaml:"ledgerName" validate:"required"`
-	AccessKeyID     string `yaml:"accessKeyId"`     // Optional: explicit credentials
-	SecretAccessKey string `yaml:"secretAccessKey"` // Optional: explicit credentials
-	SessionToken    string `yaml:"sessionToken"`    // Optional: session token
+	Name                string `yaml:"name" validate:"required"`
+	Kind                string `yaml:"kind" validate:"required"`
+	Region              string `yaml:"region"` // Optional: resolved from AWS_REGION, the shared config, or EC2 instance metadata if unset
+	LedgerName          string `yaml:"ledgerName" validate:"required"`
+	AccessKeyID         string `yaml:"accessKeyId"`         // Optional: explicit credentials
+	SecretAccessKey     string `yaml:"secretAccessKey"`     // Optional: explicit credentials
+	SecretAccessKeyFile string `yaml:"secretAccessKeyFile"` // Optional: file to read secretAccessKey from
+	SessionToken        string `yaml:"sessionToken"`        // Optional: session token
+	SessionTokenFile    string `yaml:"sessionTokenFile"`    // Optional: file to read sessionToken from
+	RoleARN             string `yaml:"roleArn"`             // Optional: role to assume with the resolved credentials
+	RoleSessionName     string `yaml:"roleSessionName"`     // Optional: session name for the assumed role
+	ExternalID          string `yaml:"externalId"`          // Optional: external ID required by the role trust policy
+	MaxRetries          int    `yaml:"maxRetries"`          // Optional: retries after the first attempt, e.g. on throttling (default: 2)
 }
 
 func (r Config) SourceConfigKind() string {
@@ -65,7 +86,7 @@ func (r Config) SourceConfigKind() string {
 }
 
 func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.Source, error) {
-	qldbClient, sessionClient, err := initQLDBClients(ctx, tracer, r.Name, r.Region, r.AccessKeyID, r.SecretAccessKey, r.SessionToken)
+	qldbClient, sessionClient, err := initQLDBClients(ctx, tracer, r)
 	if err != nil {
 		return nil, fmt.Errorf("source %q (%s): unable to create QLDB clients: %w", r.Name, SourceKind, err)
 	}
@@ -82,6 +103,8 @@ func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.So
 		Config:        r,
 		QLDBClient:    qldbClient,
 		SessionClient: sessionClient,
+		qldbAPI:       qldbClient,
+		sessionAPI:    sessionClient,
 	}
 	return s, nil
 }
@@ -92,6 +115,8 @@ type Source struct {
 	Config
 	QLDBClient    *qldb.Client
 	SessionClient *qldbsession.Client
+	qldbAPI       qldbAPI    // QLDBClient, replaced in tests
+	sessionAPI    sessionAPI // SessionClient, replaced in tests
 }
 
 func (s *Source) SourceKind() string {
@@ -102,6 +127,14 @@ func (s *Source) ToConfig() sources.SourceConfig {
 	return s.Config
 }
 
+// HealthCheck describes the ledger, as Initialize does.
+func (s *Source) HealthCheck(ctx context.Context) error {
+	_, err := s.QLDBClient.DescribeLedger(ctx, &qldb.DescribeLedgerInput{
+		Name: &s.LedgerName,
+	})
+	return err
+}
+
 // QLDBServiceClient returns the underlying AWS QLDB service client for direct API access.
 func (s *Source) QLDBServiceClient() *qldb.Client {
 	return s.QLDBClient
@@ -115,26 +148,25 @@ func (s *Source) QLDBSessionClient() *qldbsession.Client {
 // Close is not needed for this source because AWS SDK v2 clients manage
 // their own connection pooling and cleanup automatically.
 
-func initQLDBClients(ctx context.Context, tracer trace.Tracer, name, region, accessKeyID, secretAccessKey, sessionToken string) (*qldb.Client, *qldbsession.Client, error) {
-	ctx, span := sources.InitConnectionSpan(ctx, tracer, SourceKind, name)
+func initQLDBClients(ctx context.Context, tracer trace.Tracer, r Config) (*qldb.Client, *qldbsession.Client, error) {
+	ctx, span := sources.InitConnectionSpan(ctx, tracer, SourceKind, r.Name)
 	defer span.End()
 
-	// Build AWS config load options
-	configOpts := []func(*config.LoadOptions) error{
-		config.WithRegion(region),
-	}
-
-	// Use explicit credentials if provided
-	if accessKeyID != "" && secretAccessKey != "" {
-		configOpts = append(configOpts, config.WithCredentialsProvider(
-			credentials.NewStaticCredentialsProvider(accessKeyID, secretAccessKey, sessionToken),
-		))
-	}
-
-	// Load AWS configuration
-	cfg, err := config.LoadDefaultConfig(ctx, configOpts...)
+	cfg, err := sourceutil.LoadAWSConfig(ctx, sourceutil.AWSOptions{
+		Region:          r.Region,
+		AccessKeyID:     r.AccessKeyID,
+		SecretAccessKey: r.SecretAccessKey,
+		SessionToken:    r.SessionToken,
+		RoleARN:         r.RoleARN,
+		RoleSessionName: r.RoleSessionName,
+		ExternalID:      r.ExternalID,
+		MaxRetries:      r.MaxRetries,
+	})
 	if err != nil {
-		return nil, nil, fmt.Errorf("unable to load AWS config: %w", err)
+		return nil, nil, err
+	}
+	if cfg.Region == "" {
+		return nil, nil, fmt.Errorf("unable to determine AWS region, set region in the source config or AWS_REGION")
 	}
 
 	// Create QLDB clients
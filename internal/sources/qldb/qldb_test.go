@@ -17,10 +17,19 @@ package qldb
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
 	"testing"
+	"time"
 
+	"github.com/amzn/ion-go/ion"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/qldb"
+	qldbtypes "github.com/aws/aws-sdk-go-v2/service/qldb/types"
+	"github.com/aws/aws-sdk-go-v2/service/qldbsession"
+	"github.com/aws/aws-sdk-go-v2/service/qldbsession/types"
 	"github.com/goccy/go-yaml"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestParseFromYamlQLDB(t *testing.T) {
@@ -72,6 +81,26 @@ ledgerName: vehicle-registration`,
 				LedgerName: "vehicle-registration",
 			},
 		},
+		{
+			name: "valid configuration with assumed role",
+			yamlContent: `name: test-qldb
+kind: qldb
+region: us-east-1
+ledgerName: myLedger
+roleArn: arn:aws:iam::123456789012:role/toolbox
+roleSessionName: toolbox-session
+externalId: my-external-id`,
+			wantErr: false,
+			expected: Config{
+				Name:            "test-qldb",
+				Kind:            "qldb",
+				Region:          "us-east-1",
+				LedgerName:      "myLedger",
+				RoleARN:         "arn:aws:iam::123456789012:role/toolbox",
+				RoleSessionName: "toolbox-session",
+				ExternalID:      "my-external-id",
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -87,6 +116,9 @@ ledgerName: vehicle-registration`,
 				assert.Equal(t, tt.expected.Kind, config.(Config).Kind)
 				assert.Equal(t, tt.expected.Region, config.(Config).Region)
 				assert.Equal(t, tt.expected.LedgerName, config.(Config).LedgerName)
+				assert.Equal(t, tt.expected.RoleARN, config.(Config).RoleARN)
+				assert.Equal(t, tt.expected.RoleSessionName, config.(Config).RoleSessionName)
+				assert.Equal(t, tt.expected.ExternalID, config.(Config).ExternalID)
 			}
 		})
 	}
@@ -134,3 +166,311 @@ func TestSourceKindQLDB(t *testing.T) {
 	source := Source{Config: config}
 	assert.Equal(t, SourceKind, source.SourceKind())
 }
+
+// fakeSessionClient records session commands and serves two pages of results,
+// or a single page of documents if set. The first occConflicts commits fail
+// with an OCC conflict.
+type fakeSessionClient struct {
+	t            *testing.T
+	commands     []string
+	statements   []string
+	params       [][]types.ValueHolder
+	digests      [][]byte
+	documents    []map[string]any
+	occConflicts int
+}
+
+func (f *fakeSessionClient) SendCommand(ctx context.Context, params *qldbsession.SendCommandInput, optFns ...func(*qldbsession.Options)) (*qldbsession.SendCommandOutput, error) {
+	page := func(token *string, docs ...map[string]any) *types.Page {
+		p := &types.Page{NextPageToken: token}
+		for _, doc := range docs {
+			binary, err := ion.MarshalBinary(doc)
+			require.NoError(f.t, err)
+			p.Values = append(p.Values, types.ValueHolder{IonBinary: binary})
+		}
+		return p
+	}
+	switch {
+	case params.StartSession != nil:
+		f.commands = append(f.commands, "StartSession")
+		return &qldbsession.SendCommandOutput{StartSession: &types.StartSessionResult{SessionToken: aws.String("session-1")}}, nil
+	case params.StartTransaction != nil:
+		f.commands = append(f.commands, "StartTransaction")
+		return &qldbsession.SendCommandOutput{StartTransaction: &types.StartTransactionResult{TransactionId: aws.String("tx-1")}}, nil
+	case params.ExecuteStatement != nil:
+		f.commands = append(f.commands, "ExecuteStatement")
+		f.statements = append(f.statements, aws.ToString(params.ExecuteStatement.Statement))
+		f.params = append(f.params, params.ExecuteStatement.Parameters)
+		if f.documents != nil {
+			return &qldbsession.SendCommandOutput{ExecuteStatement: &types.ExecuteStatementResult{
+				FirstPage: page(nil, f.documents...),
+			}}, nil
+		}
+		return &qldbsession.SendCommandOutput{ExecuteStatement: &types.ExecuteStatementResult{
+			FirstPage: page(aws.String("page-2"), map[string]any{"VIN": "1N4AL11D75C109151", "Year": 2011}),
+		}}, nil
+	case params.FetchPage != nil:
+		f.commands = append(f.commands, "FetchPage")
+		return &qldbsession.SendCommandOutput{FetchPage: &types.FetchPageResult{
+			Page: page(nil, map[string]any{"VIN": "KM8SRDHF6EU074761", "Year": 2015}),
+		}}, nil
+	case params.CommitTransaction != nil:
+		f.commands = append(f.commands, "CommitTransaction")
+		f.digests = append(f.digests, params.CommitTransaction.CommitDigest)
+		if f.occConflicts > 0 {
+			f.occConflicts--
+			return nil, &types.OccConflictException{Message: aws.String("conflict")}
+		}
+		return &qldbsession.SendCommandOutput{CommitTransaction: &types.CommitTransactionResult{}}, nil
+	case params.AbortTransaction != nil:
+		f.commands = append(f.commands, "AbortTransaction")
+		return &qldbsession.SendCommandOutput{}, nil
+	case params.EndSession != nil:
+		f.commands = append(f.commands, "EndSession")
+		return &qldbsession.SendCommandOutput{}, nil
+	}
+	f.t.Fatalf("unexpected command %+v", params)
+	return nil, nil
+}
+
+func TestExecute(t *testing.T) {
+	client := &fakeSessionClient{t: t}
+	source := &Source{Config: Config{Name: "test", LedgerName: "vehicles"}, sessionAPI: client}
+
+	docs, err := source.Execute(context.Background(), "SELECT * FROM VehicleRegistration WHERE Year > ?", 2010)
+	require.NoError(t, err)
+	require.Len(t, docs, 2)
+	assert.Equal(t, "1N4AL11D75C109151", docs[0]["VIN"])
+	assert.Equal(t, "KM8SRDHF6EU074761", docs[1]["VIN"])
+	assert.Equal(t, []string{"StartSession", "StartTransaction", "ExecuteStatement", "FetchPage", "CommitTransaction", "EndSession"}, client.commands)
+
+	var param int
+	require.Len(t, client.params[0], 1)
+	require.NoError(t, ion.Unmarshal(client.params[0][0].IonBinary, &param))
+	assert.Equal(t, 2010, param)
+	assert.Len(t, client.digests[0], sha256.Size)
+}
+
+func TestExecuteRetriesOCCConflict(t *testing.T) {
+	client := &fakeSessionClient{t: t, occConflicts: 1}
+	source := &Source{Config: Config{Name: "test", LedgerName: "vehicles"}, sessionAPI: client}
+
+	docs, err := source.Execute(context.Background(), "UPDATE VehicleRegistration SET Year = ? WHERE VIN = ?", 2012, "1N4AL11D75C109151")
+	require.NoError(t, err)
+	assert.Len(t, docs, 2)
+	assert.Equal(t, []string{
+		"StartSession",
+		"StartTransaction", "ExecuteStatement", "FetchPage", "CommitTransaction",
+		"StartTransaction", "ExecuteStatement", "FetchPage", "CommitTransaction",
+		"EndSession",
+	}, client.commands)
+	// The digest only depends on the transaction ID, statement, and parameters
+	assert.Equal(t, client.digests[0], client.digests[1])
+}
+
+func TestExecuteGivesUpAfterOCCRetries(t *testing.T) {
+	client := &fakeSessionClient{t: t, occConflicts: MaxOCCRetries + 1}
+	source := &Source{Config: Config{Name: "test", LedgerName: "vehicles"}, sessionAPI: client}
+
+	_, err := source.Execute(context.Background(), "DELETE FROM VehicleRegistration")
+	var occ *types.OccConflictException
+	require.ErrorAs(t, err, &occ)
+	assert.Len(t, client.digests, MaxOCCRetries+1)
+}
+
+func TestDotHash(t *testing.T) {
+	a := sha256.Sum256([]byte("a"))
+	b := sha256.Sum256([]byte("b"))
+
+	// Dotting is order-independent, and an empty hash is the identity
+	assert.Equal(t, dotHash(a[:], b[:]), dotHash(b[:], a[:]))
+	assert.Equal(t, a[:], dotHash(a[:], nil))
+	assert.Equal(t, b[:], dotHash(nil, b[:]))
+
+	// Hashes are compared as signed bytes from the last byte
+	x := make([]byte, sha256.Size)
+	y := make([]byte, sha256.Size)
+	x[0], y[0] = 0x01, 0x02
+	x[sha256.Size-1], y[sha256.Size-1] = 0x7f, 0x80
+	assert.Positive(t, compareHashes(x, y))
+	joined := append(append([]byte{}, y...), x...)
+	want := sha256.Sum256(joined)
+	assert.Equal(t, want[:], dotHash(x, y))
+}
+
+func TestGetDocumentHistory(t *testing.T) {
+	txTime := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	revision := func(version int, data map[string]any) map[string]any {
+		doc := map[string]any{
+			"blockAddress": map[string]any{"strandId": "strand-1", "sequenceNo": 10 + version},
+			"hash":         []byte{byte(version)},
+			"metadata":     map[string]any{"id": "doc-1", "version": version, "txTime": txTime, "txId": "tx-1"},
+		}
+		if data != nil {
+			doc["data"] = data
+		}
+		return doc
+	}
+	client := &fakeSessionClient{t: t, documents: []map[string]any{
+		revision(0, map[string]any{"VIN": "1N4AL11D75C109151", "Year": 2011}),
+		revision(1, nil),
+	}}
+	source := &Source{Config: Config{Name: "test", LedgerName: "vehicles"}, sessionAPI: client}
+
+	revisions, err := source.GetDocumentHistory(context.Background(), "VehicleRegistration", "doc-1")
+	require.NoError(t, err)
+	require.Len(t, revisions, 2)
+	assert.Equal(t, "SELECT * FROM history(VehicleRegistration) AS h WHERE h.metadata.id = ?", client.statements[0])
+	assert.Equal(t, BlockAddress{StrandID: "strand-1", SequenceNo: 10}, revisions[0].BlockAddress)
+	assert.Equal(t, "1N4AL11D75C109151", revisions[0].Data["VIN"])
+	assert.Equal(t, "doc-1", revisions[0].Metadata.ID)
+	assert.True(t, txTime.Equal(revisions[0].Metadata.TxTime))
+	assert.Equal(t, int64(1), revisions[1].Metadata.Version)
+	assert.Nil(t, revisions[1].Data)
+
+	var param string
+	require.NoError(t, ion.Unmarshal(client.params[0][0].IonBinary, &param))
+	assert.Equal(t, "doc-1", param)
+
+	_, err = source.GetDocumentHistory(context.Background(), "Vehicle; DROP TABLE x", "doc-1")
+	assert.Error(t, err)
+}
+
+// fakeQLDBClient serves a digest and a revision whose proof dots its hash up
+// to the digest.
+type fakeQLDBClient struct {
+	t        *testing.T
+	revision Revision
+	proof    [][]byte
+	digest   []byte
+	input    *qldb.GetRevisionInput
+
+	ledgerPages []*qldb.ListLedgersOutput
+	listReqs    []qldb.ListLedgersInput
+	ledger      *qldb.DescribeLedgerOutput
+}
+
+func (f *fakeQLDBClient) ListLedgers(ctx context.Context, params *qldb.ListLedgersInput, optFns ...func(*qldb.Options)) (*qldb.ListLedgersOutput, error) {
+	f.listReqs = append(f.listReqs, *params)
+	return f.ledgerPages[len(f.listReqs)-1], nil
+}
+
+func (f *fakeQLDBClient) DescribeLedger(ctx context.Context, params *qldb.DescribeLedgerInput, optFns ...func(*qldb.Options)) (*qldb.DescribeLedgerOutput, error) {
+	if aws.ToString(params.Name) != aws.ToString(f.ledger.Name) {
+		return nil, &qldbtypes.ResourceNotFoundException{Message: aws.String("ledger not found")}
+	}
+	return f.ledger, nil
+}
+
+func (f *fakeQLDBClient) GetDigest(ctx context.Context, params *qldb.GetDigestInput, optFns ...func(*qldb.Options)) (*qldb.GetDigestOutput, error) {
+	return &qldb.GetDigestOutput{
+		Digest:           f.digest,
+		DigestTipAddress: &qldbtypes.ValueHolder{IonText: aws.String(`{strandId:"strand-1",sequenceNo:20}`)},
+	}, nil
+}
+
+func (f *fakeQLDBClient) GetRevision(ctx context.Context, params *qldb.GetRevisionInput, optFns ...func(*qldb.Options)) (*qldb.GetRevisionOutput, error) {
+	f.input = params
+	revision, err := ion.MarshalText(f.revision)
+	require.NoError(f.t, err)
+	proof, err := ion.MarshalText(f.proof)
+	require.NoError(f.t, err)
+	return &qldb.GetRevisionOutput{
+		Revision: &qldbtypes.ValueHolder{IonText: aws.String(string(revision))},
+		Proof:    &qldbtypes.ValueHolder{IonText: aws.String(string(proof))},
+	}, nil
+}
+
+func newFakeQLDBClient(t *testing.T) *fakeQLDBClient {
+	hash := sha256.Sum256([]byte("revision"))
+	p1 := sha256.Sum256([]byte("sibling"))
+	p2 := sha256.Sum256([]byte("uncle"))
+	proof := [][]byte{p1[:], p2[:]}
+	return &fakeQLDBClient{
+		t: t,
+		revision: Revision{
+			BlockAddress: BlockAddress{StrandID: "strand-1", SequenceNo: 14},
+			Hash:         hash[:],
+			Metadata:     RevisionMetadata{ID: "doc-1", Version: 2, TxTime: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC), TxID: "tx-1"},
+		},
+		proof:  proof,
+		digest: dotHash(dotHash(hash[:], p1[:]), p2[:]),
+	}
+}
+
+func TestVerifyDocument(t *testing.T) {
+	client := newFakeQLDBClient(t)
+	source := &Source{Config: Config{Name: "test", LedgerName: "vehicles"}, qldbAPI: client}
+	address := client.revision.BlockAddress.IonText()
+
+	ok, err := source.VerifyDocument(context.Background(), "doc-1", address)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "doc-1", aws.ToString(client.input.DocumentId))
+	assert.Equal(t, `{strandId:"strand-1",sequenceNo:14}`, aws.ToString(client.input.BlockAddress.IonText))
+	assert.Equal(t, `{strandId:"strand-1",sequenceNo:20}`, aws.ToString(client.input.DigestTipAddress.IonText))
+
+	// A tampered revision hash no longer dots up to the digest
+	client.revision.Hash[0] ^= 0xff
+	ok, err = source.VerifyDocument(context.Background(), "doc-1", address)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	// A revision of a different document doesn't verify docID
+	client = newFakeQLDBClient(t)
+	source.qldbAPI = client
+	ok, err = source.VerifyDocument(context.Background(), "doc-2", address)
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestListLedgers(t *testing.T) {
+	created := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	client := &fakeQLDBClient{ledgerPages: []*qldb.ListLedgersOutput{
+		{
+			Ledgers:   []qldbtypes.LedgerSummary{{Name: aws.String("vehicles"), State: qldbtypes.LedgerStateActive, CreationDateTime: &created}},
+			NextToken: aws.String("page-2"),
+		},
+		{Ledgers: []qldbtypes.LedgerSummary{{Name: aws.String("audit"), State: qldbtypes.LedgerStateCreating}}},
+	}}
+	source := &Source{Config: Config{Name: "test", LedgerName: "vehicles"}, qldbAPI: client}
+
+	ledgers, err := source.ListLedgers(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []LedgerSummary{
+		{Name: "vehicles", State: "ACTIVE", CreatedAt: created},
+		{Name: "audit", State: "CREATING"},
+	}, ledgers)
+	require.Len(t, client.listReqs, 2)
+	assert.Equal(t, "page-2", aws.ToString(client.listReqs[1].NextToken))
+}
+
+func TestDescribeLedger(t *testing.T) {
+	client := &fakeQLDBClient{ledger: &qldb.DescribeLedgerOutput{
+		Name:               aws.String("vehicles"),
+		Arn:                aws.String("arn:aws:qldb:us-east-1:123456789012:ledger/vehicles"),
+		State:              qldbtypes.LedgerStateActive,
+		PermissionsMode:    qldbtypes.PermissionsModeStandard,
+		DeletionProtection: aws.Bool(true),
+		EncryptionDescription: &qldbtypes.LedgerEncryptionDescription{
+			EncryptionStatus: qldbtypes.EncryptionStatusEnabled,
+			KmsKeyArn:        aws.String("AWS_OWNED_KMS_KEY"),
+		},
+	}}
+	source := &Source{Config: Config{Name: "test", LedgerName: "vehicles"}, qldbAPI: client}
+
+	info, err := source.DescribeLedger(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, &LedgerInfo{
+		Name:               "vehicles",
+		ARN:                "arn:aws:qldb:us-east-1:123456789012:ledger/vehicles",
+		State:              "ACTIVE",
+		PermissionsMode:    "STANDARD",
+		DeletionProtection: true,
+		Encryption:         &LedgerEncryption{Status: "ENABLED", KMSKeyARN: "AWS_OWNED_KMS_KEY"},
+	}, info)
+
+	source.LedgerName = "missing"
+	_, err = source.DescribeLedger(context.Background())
+	assert.ErrorContains(t, err, "unable to describe ledger missing")
+}
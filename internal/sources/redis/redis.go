@@ -15,24 +15,29 @@ package redis
 
 import (
 	"context"
+	"crypto/tls"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/goccy/go-yaml"
 	"github.com/googleapis/genai-toolbox/internal/sources"
+	sourceutil "github.com/googleapis/genai-toolbox/internal/sources/util"
 	"github.com/redis/go-redis/v9"
 	"go.opentelemetry.io/otel/trace"
 )
 
 const SourceKind string = "redis"
 
+// DefaultPoolSize is the number of connections kept per server, or per node
+// of a cluster, when poolSize is unset.
+const DefaultPoolSize = 10
+
 // validate interface
 var _ sources.SourceConfig = Config{}
 
 func init() {
-	if !sources.Register(SourceKind, newConfig) {
-		panic(fmt.Sprintf("source kind %q already registered", SourceKind))
-	}
+	sources.MustRegister(SourceKind, newConfig)
 }
 
 func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources.SourceConfig, error) {
@@ -40,6 +45,9 @@ func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources
 	if err := decoder.DecodeContext(ctx, &actual); err != nil {
 		return nil, err
 	}
+	if actual.PoolSize < 0 {
+		return nil, fmt.Errorf("source %q (%s): poolSize must not be negative", name, SourceKind)
+	}
 	return actual, nil
 }
 
@@ -52,6 +60,9 @@ type Config struct {
 	Database       int      `yaml:"database"`
 	UseGCPIAM      bool     `yaml:"useGCPIAM"`
 	ClusterEnabled bool     `yaml:"clusterEnabled"`
+	UseTLS         bool     `yaml:"useTLS"`    // Optional: connect over TLS, e.g. to ElastiCache with in-transit encryption
+	TLSCAFile      string   `yaml:"tlsCAFile"` // Optional: path to CA certificates used to verify the server; implies useTLS
+	PoolSize       int      `yaml:"poolSize"`  // Optional: connections per server or cluster node (default: 10)
 }
 
 func (r Config) SourceConfigKind() string {
@@ -91,19 +102,31 @@ func initRedisClient(ctx context.Context, r Config) (RedisClient, error) {
 		}
 	}
 
+	tlsConfig, err := sourceutil.LoadTLSConfig(r.TLSCAFile)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load TLS config: %w", err)
+	}
+	if tlsConfig == nil && r.UseTLS {
+		tlsConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+	poolSize := r.PoolSize
+	if poolSize == 0 {
+		poolSize = DefaultPoolSize
+	}
+
 	var client RedisClient
-	var err error
 	if r.ClusterEnabled {
 		// Create a new Redis Cluster client
 		clusterClient := redis.NewClusterClient(&redis.ClusterOptions{
 			Addrs: r.Address,
 			// PoolSize applies per cluster node and not for the whole cluster.
-			PoolSize:                   10,
+			PoolSize:                   poolSize,
 			ConnMaxIdleTime:            60 * time.Second,
 			MinIdleConns:               1,
 			CredentialsProviderContext: authFn,
 			Username:                   r.Username,
 			Password:                   r.Password,
+			TLSConfig:                  tlsConfig,
 		})
 		err = clusterClient.ForEachShard(ctx, func(ctx context.Context, shard *redis.Client) error {
 			return shard.Ping(ctx).Err()
@@ -118,13 +141,14 @@ func initRedisClient(ctx context.Context, r Config) (RedisClient, error) {
 	// Create a new Redis client
 	standaloneClient := redis.NewClient(&redis.Options{
 		Addr:                       r.Address[0],
-		PoolSize:                   10,
+		PoolSize:                   poolSize,
 		ConnMaxIdleTime:            60 * time.Second,
 		MinIdleConns:               1,
 		DB:                         r.Database,
 		CredentialsProviderContext: authFn,
 		Username:                   r.Username,
 		Password:                   r.Password,
+		TLSConfig:                  tlsConfig,
 	})
 	_, err = standaloneClient.Ping(ctx).Result()
 	if err != nil {
@@ -149,6 +173,58 @@ func (s *Source) ToConfig() sources.SourceConfig {
 	return s.Config
 }
 
+// HealthCheck pings the Redis server.
+func (s *Source) HealthCheck(ctx context.Context) error {
+	return s.Client.Do(ctx, "PING").Err()
+}
+
 func (s *Source) RedisClient() RedisClient {
 	return s.Client
 }
+
+// Get returns the string value of key. found is false if the key does not
+// exist.
+func (s *Source) Get(ctx context.Context, key string) (value string, found bool, err error) {
+	value, err = s.Client.Do(ctx, "GET", key).Text()
+	if errors.Is(err, redis.Nil) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, fmt.Errorf("unable to get key %q: %w", key, err)
+	}
+	return value, true, nil
+}
+
+// Set sets key to value. A positive ttl expires the key after ttl, rounded down
+// to the millisecond; zero keeps it until it is deleted.
+func (s *Source) Set(ctx context.Context, key, value string, ttl time.Duration) error {
+	if ttl != 0 && ttl < time.Millisecond {
+		return fmt.Errorf("ttl must be zero or at least 1ms, got %s", ttl)
+	}
+	args := []any{"SET", key, value}
+	if ttl > 0 {
+		args = append(args, "PX", ttl.Milliseconds())
+	}
+	if err := s.Client.Do(ctx, args...).Err(); err != nil {
+		return fmt.Errorf("unable to set key %q: %w", key, err)
+	}
+	return nil
+}
+
+// Del deletes keys and returns how many of them existed. In cluster mode all
+// keys must hash to the same slot.
+func (s *Source) Del(ctx context.Context, keys ...string) (int64, error) {
+	if len(keys) == 0 {
+		return 0, nil
+	}
+	args := make([]any, 0, len(keys)+1)
+	args = append(args, "DEL")
+	for _, key := range keys {
+		args = append(args, key)
+	}
+	n, err := s.Client.Do(ctx, args...).Int64()
+	if err != nil {
+		return 0, fmt.Errorf("unable to delete keys: %w", err)
+	}
+	return n, nil
+}
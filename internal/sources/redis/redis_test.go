@@ -15,14 +15,18 @@
 package redis_test
 
 import (
+	"context"
+	"errors"
 	"strings"
 	"testing"
+	"time"
 
 	yaml "github.com/goccy/go-yaml"
 	"github.com/google/go-cmp/cmp"
 	"github.com/googleapis/genai-toolbox/internal/server"
 	"github.com/googleapis/genai-toolbox/internal/sources/redis"
 	"github.com/googleapis/genai-toolbox/internal/testutils"
+	goredis "github.com/redis/go-redis/v9"
 )
 
 func TestParseFromYamlRedis(t *testing.T) {
@@ -62,6 +66,9 @@ func TestParseFromYamlRedis(t *testing.T) {
 					database: 1
 					useGCPIAM: true
 					clusterEnabled: true
+					useTLS: true
+					tlsCAFile: /etc/ssl/elasticache-ca.pem
+					poolSize: 25
 			`,
 			want: server.SourceConfigs{
 				"my-redis-instance": redis.Config{
@@ -72,6 +79,9 @@ func TestParseFromYamlRedis(t *testing.T) {
 					Database:       1,
 					ClusterEnabled: true,
 					UseGCPIAM:      true,
+					UseTLS:         true,
+					TLSCAFile:      "/etc/ssl/elasticache-ca.pem",
+					PoolSize:       25,
 				},
 			},
 		},
@@ -128,6 +138,18 @@ func TestFailParseFromYaml(t *testing.T) {
 			`,
 			err: "unable to parse source \"my-redis-instance\" as \"redis\": [6:1] unknown field \"project\"",
 		},
+		{
+			desc: "negative pool size",
+			in: `
+			sources:
+				my-redis-instance:
+					kind: redis
+					address:
+					  - 127.0.0.1
+					poolSize: -1
+			`,
+			err: "poolSize must not be negative",
+		},
 		{
 			desc: "missing required field",
 			in: `
@@ -155,3 +177,78 @@ func TestFailParseFromYaml(t *testing.T) {
 		})
 	}
 }
+
+// fakeClient is a redis.RedisClient backed by a map, recording each command.
+type fakeClient struct {
+	data     map[string]string
+	commands [][]any
+}
+
+func (f *fakeClient) Do(ctx context.Context, args ...any) *goredis.Cmd {
+	f.commands = append(f.commands, args)
+	cmd := goredis.NewCmd(ctx, args...)
+	switch args[0] {
+	case "GET":
+		value, ok := f.data[args[1].(string)]
+		if !ok {
+			cmd.SetErr(goredis.Nil)
+			return cmd
+		}
+		cmd.SetVal(value)
+	case "SET":
+		f.data[args[1].(string)] = args[2].(string)
+		cmd.SetVal("OK")
+	case "DEL":
+		var n int64
+		for _, key := range args[1:] {
+			if _, ok := f.data[key.(string)]; ok {
+				delete(f.data, key.(string))
+				n++
+			}
+		}
+		cmd.SetVal(n)
+	default:
+		cmd.SetErr(errors.New("ERR unknown command"))
+	}
+	return cmd
+}
+
+func TestKeyHelpers(t *testing.T) {
+	ctx := context.Background()
+	client := &fakeClient{data: map[string]string{}}
+	source := &redis.Source{Config: redis.Config{Name: "my-redis-instance"}, Client: client}
+
+	if err := source.Set(ctx, "session:1", "alice", 90*time.Second); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := source.Set(ctx, "session:2", "bob", 0); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := [][]any{{"SET", "session:1", "alice", "PX", int64(90000)}, {"SET", "session:2", "bob"}}
+	if diff := cmp.Diff(want, client.commands); diff != "" {
+		t.Fatalf("incorrect commands: diff %v", diff)
+	}
+	if err := source.Set(ctx, "session:3", "eve", time.Microsecond); err == nil {
+		t.Fatalf("expected an error for a sub-millisecond ttl")
+	}
+
+	value, found, err := source.Get(ctx, "session:1")
+	if err != nil || !found || value != "alice" {
+		t.Fatalf("got (%q, %v, %v), want (\"alice\", true, nil)", value, found, err)
+	}
+	value, found, err = source.Get(ctx, "missing")
+	if err != nil || found || value != "" {
+		t.Fatalf("got (%q, %v, %v), want (\"\", false, nil)", value, found, err)
+	}
+
+	n, err := source.Del(ctx, "session:1", "session:2", "missing")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if n != 2 {
+		t.Fatalf("got %d deleted keys, want 2", n)
+	}
+	if n, err := source.Del(ctx); err != nil || n != 0 {
+		t.Fatalf("got (%d, %v) for no keys, want (0, nil)", n, err)
+	}
+}
@@ -0,0 +1,183 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redshift
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Supported file formats for UNLOAD and COPY.
+const (
+	FormatCSV     = "CSV"
+	FormatParquet = "PARQUET"
+)
+
+// UnloadOptions controls the files written by UnloadToS3.
+type UnloadOptions struct {
+	Format         string   // CSV or PARQUET (default: Redshift's pipe-delimited text)
+	PartitionBy    []string // Optional: columns used to partition output into S3 prefixes
+	Header         bool     // Write a header row; not supported with PARQUET
+	AllowOverwrite bool     // Overwrite existing files at s3Path
+}
+
+// CopyOptions controls how CopyFromS3 reads its input files.
+type CopyOptions struct {
+	Format string // CSV or PARQUET (default: Redshift's pipe-delimited text)
+	Header bool   // Skip the first line of each file; not supported with PARQUET
+	Region string // Optional: region of the S3 bucket, if different from the cluster
+}
+
+// UnloadToS3 runs UNLOAD to write the results of query to files under s3Path,
+// using iamRole to access the bucket.
+func (s *Source) UnloadToS3(ctx context.Context, query, s3Path, iamRole string, opts UnloadOptions) error {
+	stmt, err := buildUnloadSQL(query, s3Path, iamRole, opts)
+	if err != nil {
+		return err
+	}
+	if err := s.exec(ctx, stmt); err != nil {
+		return fmt.Errorf("unable to unload to %s: %w", s3Path, err)
+	}
+	return nil
+}
+
+// CopyFromS3 runs COPY to load the files under s3Path into table, using iamRole
+// to access the bucket. table may be schema-qualified.
+func (s *Source) CopyFromS3(ctx context.Context, table, s3Path, iamRole string, opts CopyOptions) error {
+	stmt, err := buildCopySQL(table, s3Path, iamRole, opts)
+	if err != nil {
+		return err
+	}
+	if err := s.exec(ctx, stmt); err != nil {
+		return fmt.Errorf("unable to copy from %s: %w", s3Path, err)
+	}
+	return nil
+}
+
+// exec runs a statement that returns no rows over whichever connection the
+// source is configured with.
+func (s *Source) exec(ctx context.Context, stmt string) error {
+	if s.DB != nil {
+		_, err := s.DB.ExecContext(ctx, stmt)
+		return err
+	}
+	if s.dataAPI != nil {
+		_, err := s.ExecuteDataAPI(ctx, stmt)
+		return err
+	}
+	return fmt.Errorf("source %q (%s): not connected", s.Name, SourceKind)
+}
+
+func buildUnloadSQL(query, s3Path, iamRole string, opts UnloadOptions) (string, error) {
+	if strings.TrimSpace(query) == "" {
+		return "", fmt.Errorf("query is required")
+	}
+	if err := validateS3Args(s3Path, iamRole); err != nil {
+		return "", err
+	}
+	format, err := formatClause(opts.Format, opts.Header)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "UNLOAD (%s) TO %s IAM_ROLE %s", quoteLiteral(query), quoteLiteral(s3Path), quoteLiteral(iamRole))
+	b.WriteString(format)
+	if len(opts.PartitionBy) > 0 {
+		columns := make([]string, len(opts.PartitionBy))
+		for i, column := range opts.PartitionBy {
+			columns[i] = quoteIdentifier(column)
+		}
+		fmt.Fprintf(&b, " PARTITION BY (%s)", strings.Join(columns, ", "))
+	}
+	if opts.Header {
+		b.WriteString(" HEADER")
+	}
+	if opts.AllowOverwrite {
+		b.WriteString(" ALLOWOVERWRITE")
+	}
+	return b.String(), nil
+}
+
+func buildCopySQL(table, s3Path, iamRole string, opts CopyOptions) (string, error) {
+	if strings.TrimSpace(table) == "" {
+		return "", fmt.Errorf("table is required")
+	}
+	if err := validateS3Args(s3Path, iamRole); err != nil {
+		return "", err
+	}
+	format, err := formatClause(opts.Format, opts.Header)
+	if err != nil {
+		return "", err
+	}
+
+	parts := strings.Split(table, ".")
+	for i, part := range parts {
+		parts[i] = quoteIdentifier(part)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "COPY %s FROM %s IAM_ROLE %s", strings.Join(parts, "."), quoteLiteral(s3Path), quoteLiteral(iamRole))
+	b.WriteString(format)
+	if opts.Header {
+		b.WriteString(" IGNOREHEADER 1")
+	}
+	if opts.Region != "" {
+		fmt.Fprintf(&b, " REGION %s", quoteLiteral(opts.Region))
+	}
+	return b.String(), nil
+}
+
+func validateS3Args(s3Path, iamRole string) error {
+	if !strings.HasPrefix(s3Path, "s3://") {
+		return fmt.Errorf("s3Path must start with s3://, got %q", s3Path)
+	}
+	if iamRole == "" {
+		return fmt.Errorf("iamRole is required")
+	}
+	return nil
+}
+
+// formatClause returns the FORMAT AS clause for format, which is empty for
+// Redshift's default text format.
+func formatClause(format string, header bool) (string, error) {
+	switch strings.ToUpper(format) {
+	case "":
+		return "", nil
+	case FormatCSV:
+		return " FORMAT AS CSV", nil
+	case FormatParquet:
+		if header {
+			return "", fmt.Errorf("header is not supported with PARQUET")
+		}
+		return " FORMAT AS PARQUET", nil
+	default:
+		return "", fmt.Errorf("unsupported format %q, must be CSV or PARQUET", format)
+	}
+}
+
+// quoteLiteral quotes s as a Redshift string literal. Redshift treats
+// backslashes in literals as escapes, so they are doubled along with quotes.
+func quoteLiteral(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `'`, `''`)
+	return "'" + s + "'"
+}
+
+// quoteIdentifier quotes s as a single Redshift identifier.
+func quoteIdentifier(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, `""`) + `"`
+}
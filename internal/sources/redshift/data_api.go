@@ -0,0 +1,208 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redshift
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/redshiftdata"
+	"github.com/aws/aws-sdk-go-v2/service/redshiftdata/types"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	sourceutil "github.com/googleapis/genai-toolbox/internal/sources/util"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Data API polling constants
+const (
+	DataAPIPollInterval    = 100 * time.Millisecond // Initial delay between DescribeStatement calls
+	DataAPIMaxPollInterval = 2 * time.Second        // Upper bound for the polling delay
+)
+
+// dataAPIClient is the subset of the Redshift Data API used by the source.
+type dataAPIClient interface {
+	ExecuteStatement(ctx context.Context, params *redshiftdata.ExecuteStatementInput, optFns ...func(*redshiftdata.Options)) (*redshiftdata.ExecuteStatementOutput, error)
+	DescribeStatement(ctx context.Context, params *redshiftdata.DescribeStatementInput, optFns ...func(*redshiftdata.Options)) (*redshiftdata.DescribeStatementOutput, error)
+	GetStatementResult(ctx context.Context, params *redshiftdata.GetStatementResultInput, optFns ...func(*redshiftdata.Options)) (*redshiftdata.GetStatementResultOutput, error)
+	CancelStatement(ctx context.Context, params *redshiftdata.CancelStatementInput, optFns ...func(*redshiftdata.Options)) (*redshiftdata.CancelStatementOutput, error)
+	ListDatabases(ctx context.Context, params *redshiftdata.ListDatabasesInput, optFns ...func(*redshiftdata.Options)) (*redshiftdata.ListDatabasesOutput, error)
+}
+
+func initDataAPIClient(ctx context.Context, tracer trace.Tracer, r Config) (*redshiftdata.Client, error) {
+	ctx, span := sources.InitConnectionSpan(ctx, tracer, SourceKind, r.Name)
+	defer span.End()
+
+	region := r.Region
+	if region == "" {
+		region = extractRegionFromHost(r.Host)
+	}
+
+	cfg, err := sourceutil.LoadAWSConfig(ctx, sourceutil.AWSOptions{Region: region})
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Region == "" {
+		return nil, fmt.Errorf("unable to determine AWS region, set region in the source config")
+	}
+
+	return redshiftdata.NewFromConfig(cfg), nil
+}
+
+// pingDataAPI verifies that the configured cluster or workgroup is reachable
+// with the current credentials.
+func (s *Source) pingDataAPI(ctx context.Context) error {
+	_, err := s.dataAPI.ListDatabases(ctx, &redshiftdata.ListDatabasesInput{
+		Database:          aws.String(s.Database),
+		ClusterIdentifier: optionalString(s.ClusterIdentifier),
+		WorkgroupName:     optionalString(s.WorkgroupName),
+		DbUser:            optionalString(s.DBUser),
+		SecretArn:         optionalString(s.SecretArn),
+		MaxResults:        aws.Int32(1),
+	})
+	return err
+}
+
+// ExecuteDataAPI runs a statement through the Redshift Data API, waits for it to
+// finish, and returns the result rows keyed by column name. Statements without
+// a result set return no rows.
+func (s *Source) ExecuteDataAPI(ctx context.Context, sql string) ([]map[string]any, error) {
+	if s.dataAPI == nil {
+		return nil, fmt.Errorf("source %q (%s): useDataApi is not enabled", s.Name, SourceKind)
+	}
+
+	out, err := s.dataAPI.ExecuteStatement(ctx, &redshiftdata.ExecuteStatementInput{
+		Sql:               aws.String(sql),
+		Database:          aws.String(s.Database),
+		ClusterIdentifier: optionalString(s.ClusterIdentifier),
+		WorkgroupName:     optionalString(s.WorkgroupName),
+		DbUser:            optionalString(s.DBUser),
+		SecretArn:         optionalString(s.SecretArn),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to execute statement: %w", err)
+	}
+	id := out.Id
+
+	desc, err := s.waitForStatement(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !aws.ToBool(desc.HasResultSet) {
+		return []map[string]any{}, nil
+	}
+
+	var (
+		rows      []map[string]any
+		columns   []string
+		nextToken *string
+	)
+	for {
+		res, err := s.dataAPI.GetStatementResult(ctx, &redshiftdata.GetStatementResultInput{
+			Id:        id,
+			NextToken: nextToken,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("unable to get statement result: %w", err)
+		}
+		if columns == nil {
+			columns = make([]string, len(res.ColumnMetadata))
+			for i, col := range res.ColumnMetadata {
+				columns[i] = aws.ToString(col.Name)
+			}
+		}
+		for _, record := range res.Records {
+			row := make(map[string]any, len(record))
+			for i, field := range record {
+				if i < len(columns) {
+					row[columns[i]] = fieldValue(field)
+				}
+			}
+			rows = append(rows, row)
+		}
+		if aws.ToString(res.NextToken) == "" {
+			break
+		}
+		nextToken = res.NextToken
+	}
+	if rows == nil {
+		rows = []map[string]any{}
+	}
+	return rows, nil
+}
+
+// waitForStatement polls DescribeStatement with exponential backoff until the
+// statement reaches a terminal state. If ctx is cancelled first the statement
+// is cancelled on a best-effort basis.
+func (s *Source) waitForStatement(ctx context.Context, id *string) (*redshiftdata.DescribeStatementOutput, error) {
+	delay := DataAPIPollInterval
+	for {
+		desc, err := s.dataAPI.DescribeStatement(ctx, &redshiftdata.DescribeStatementInput{Id: id})
+		if err != nil {
+			return nil, fmt.Errorf("unable to describe statement: %w", err)
+		}
+		switch desc.Status {
+		case types.StatusStringFinished:
+			return desc, nil
+		case types.StatusStringFailed:
+			return nil, fmt.Errorf("statement failed: %s", aws.ToString(desc.Error))
+		case types.StatusStringAborted:
+			return nil, errors.New("statement was aborted")
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			cancelCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+			_, _ = s.dataAPI.CancelStatement(cancelCtx, &redshiftdata.CancelStatementInput{Id: id})
+			cancel()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+		delay = min(delay*2, DataAPIMaxPollInterval)
+	}
+}
+
+// fieldValue converts a Data API field union into a Go value.
+func fieldValue(field types.Field) any {
+	switch v := field.(type) {
+	case *types.FieldMemberIsNull:
+		return nil
+	case *types.FieldMemberBooleanValue:
+		return v.Value
+	case *types.FieldMemberLongValue:
+		return v.Value
+	case *types.FieldMemberDoubleValue:
+		return v.Value
+	case *types.FieldMemberStringValue:
+		return v.Value
+	case *types.FieldMemberBlobValue:
+		return v.Value
+	default:
+		return nil
+	}
+}
+
+// optionalString returns nil for empty strings so unset options are omitted
+// from Data API requests.
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return aws.String(s)
+}
@@ -0,0 +1,123 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redshift
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+)
+
+// QueryMaps runs query and returns each row as a map of column name to value.
+// Values keep the types the driver scans them into, except that:
+//
+//   - NULL is nil
+//   - NUMERIC and DECIMAL are strings, so no precision is lost
+//   - JSON and JSONB are decoded
+//   - other text returned as bytes is a string, while BYTEA stays []byte
+//
+// TIMESTAMP, TIMESTAMPTZ and DATE are time.Time, integers int64, floating point
+// numbers float64, and BOOLEAN bool.
+func (s *Source) QueryMaps(ctx context.Context, query string, args ...any) ([]map[string]any, error) {
+	if s.DB == nil {
+		return nil, fmt.Errorf("source %q (%s): QueryMaps requires a database connection", s.Name, SourceKind)
+	}
+	rows, err := s.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to execute query: %w", err)
+	}
+	return scanMaps(rows)
+}
+
+// WithReadOnlyTx runs fn in a transaction made read-only with SET TRANSACTION
+// READ ONLY, so any statement fn runs that would modify the warehouse fails.
+// The transaction is rolled back once fn returns, or panics, which for a
+// read-only transaction is equivalent to committing it. fn's error is returned
+// as is.
+func (s *Source) WithReadOnlyTx(ctx context.Context, fn func(*sql.Tx) error) error {
+	if s.DB == nil {
+		return fmt.Errorf("source %q (%s): WithReadOnlyTx requires a database connection", s.Name, SourceKind)
+	}
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("unable to begin transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	if _, err := tx.ExecContext(ctx, "SET TRANSACTION READ ONLY"); err != nil {
+		return fmt.Errorf("unable to make transaction read-only: %w", err)
+	}
+	return fn(tx)
+}
+
+// scanMaps reads every row of rows into a map and closes rows.
+func scanMaps(rows *sql.Rows) ([]map[string]any, error) {
+	defer rows.Close()
+
+	colTypes, err := rows.ColumnTypes()
+	if err != nil {
+		return nil, fmt.Errorf("unable to get column types: %w", err)
+	}
+	rawValues := make([]any, len(colTypes))
+	dest := make([]any, len(colTypes))
+	for i := range rawValues {
+		dest[i] = &rawValues[i]
+	}
+
+	out := []map[string]any{}
+	for rows.Next() {
+		if err := rows.Scan(dest...); err != nil {
+			return nil, fmt.Errorf("unable to parse row: %w", err)
+		}
+		row := make(map[string]any, len(colTypes))
+		for i, col := range colTypes {
+			val, err := convertValue(col.DatabaseTypeName(), rawValues[i])
+			if err != nil {
+				return nil, fmt.Errorf("column %q: %w", col.Name(), err)
+			}
+			row[col.Name()] = val
+		}
+		out = append(out, row)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("errors encountered during row iteration: %w", err)
+	}
+	return out, nil
+}
+
+// convertValue converts a value scanned from a column of the given database
+// type to its Go-native form.
+func convertValue(typeName string, val any) (any, error) {
+	b, ok := val.([]byte)
+	if !ok {
+		return val, nil
+	}
+	switch typeName {
+	case "BYTEA":
+		// Copy, as the driver may reuse the buffer for the next row
+		return append([]byte(nil), b...), nil
+	case "JSON", "JSONB":
+		var decoded any
+		if err := json.Unmarshal(b, &decoded); err != nil {
+			return nil, fmt.Errorf("unable to unmarshal json data: %w", err)
+		}
+		return decoded, nil
+	default:
+		return string(b), nil
+	}
+}
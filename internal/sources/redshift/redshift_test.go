@@ -17,10 +17,22 @@ package redshift
 import (
 	"bytes"
 	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/redshift"
+	"github.com/aws/aws-sdk-go-v2/service/redshiftdata"
+	"github.com/aws/aws-sdk-go-v2/service/redshiftdata/types"
 	"github.com/goccy/go-yaml"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestRedshiftConfig(t *testing.T) {
@@ -50,6 +62,164 @@ database: mydb`,
 				Database: "mydb",
 			},
 		},
+		{
+			name: "valid IAM configuration",
+			yamlContent: `name: test-redshift
+kind: redshift
+host: mycluster.abc123.us-west-2.redshift.amazonaws.com
+port: "5439"
+database: mydb
+iamAuth: true
+clusterIdentifier: mycluster
+dbUser: analyst`,
+			wantErr: false,
+			expected: Config{
+				Name:              "test-redshift",
+				Kind:              "redshift",
+				Host:              "mycluster.abc123.us-west-2.redshift.amazonaws.com",
+				Port:              "5439",
+				Database:          "mydb",
+				IAMAuth:           true,
+				ClusterIdentifier: "mycluster",
+				DBUser:            "analyst",
+			},
+		},
+		{
+			name: "IAM configuration missing cluster identifier",
+			yamlContent: `name: test-redshift
+kind: redshift
+host: mycluster.abc123.us-west-2.redshift.amazonaws.com
+port: "5439"
+database: mydb
+iamAuth: true
+dbUser: analyst`,
+			wantErr:  true,
+			expected: Config{Name: "test-redshift"},
+		},
+		{
+			name: "missing password without IAM",
+			yamlContent: `name: test-redshift
+kind: redshift
+host: mycluster.abc123.us-west-2.redshift.amazonaws.com
+port: "5439"
+user: admin
+database: mydb`,
+			wantErr:  true,
+			expected: Config{Name: "test-redshift"},
+		},
+		{
+			name: "valid SSL configuration",
+			yamlContent: `name: test-redshift
+kind: redshift
+host: mycluster.abc123.us-west-2.redshift.amazonaws.com
+port: "5439"
+user: admin
+password: mypassword
+database: mydb
+sslMode: verify-full
+sslRootCert: /etc/ssl/redshift-ca-bundle.crt`,
+			wantErr: false,
+			expected: Config{
+				Name:        "test-redshift",
+				Kind:        "redshift",
+				Host:        "mycluster.abc123.us-west-2.redshift.amazonaws.com",
+				Port:        "5439",
+				User:        "admin",
+				Password:    "mypassword",
+				Database:    "mydb",
+				SSLMode:     "verify-full",
+				SSLRootCert: "/etc/ssl/redshift-ca-bundle.crt",
+			},
+		},
+		{
+			name: "invalid SSL mode",
+			yamlContent: `name: test-redshift
+kind: redshift
+host: mycluster.abc123.us-west-2.redshift.amazonaws.com
+port: "5439"
+user: admin
+password: mypassword
+database: mydb
+sslMode: verify`,
+			wantErr:  true,
+			expected: Config{Name: "test-redshift"},
+		},
+		{
+			name: "valid connection lifetime configuration",
+			yamlContent: `name: test-redshift
+kind: redshift
+host: mycluster.abc123.us-west-2.redshift.amazonaws.com
+port: "5439"
+user: admin
+password: mypassword
+database: mydb
+connMaxLifetime: 30m
+connMaxIdleTime: 2m`,
+			wantErr: false,
+			expected: Config{
+				Name:            "test-redshift",
+				Kind:            "redshift",
+				Host:            "mycluster.abc123.us-west-2.redshift.amazonaws.com",
+				Port:            "5439",
+				User:            "admin",
+				Password:        "mypassword",
+				Database:        "mydb",
+				ConnMaxLifetime: "30m",
+				ConnMaxIdleTime: "2m",
+			},
+		},
+		{
+			name: "invalid connection idle time",
+			yamlContent: `name: test-redshift
+kind: redshift
+host: mycluster.abc123.us-west-2.redshift.amazonaws.com
+port: "5439"
+user: admin
+password: mypassword
+database: mydb
+connMaxIdleTime: five minutes`,
+			wantErr:  true,
+			expected: Config{Name: "test-redshift"},
+		},
+		{
+			name: "valid Data API serverless configuration",
+			yamlContent: `name: test-redshift
+kind: redshift
+database: dev
+useDataApi: true
+workgroupName: analytics
+region: us-east-1`,
+			wantErr: false,
+			expected: Config{
+				Name:          "test-redshift",
+				Kind:          "redshift",
+				Database:      "dev",
+				UseDataAPI:    true,
+				WorkgroupName: "analytics",
+				Region:        "us-east-1",
+			},
+		},
+		{
+			name: "Data API with both cluster and workgroup",
+			yamlContent: `name: test-redshift
+kind: redshift
+database: dev
+useDataApi: true
+clusterIdentifier: mycluster
+workgroupName: analytics`,
+			wantErr:  true,
+			expected: Config{Name: "test-redshift"},
+		},
+		{
+			name: "missing host without Data API",
+			yamlContent: `name: test-redshift
+kind: redshift
+user: admin
+password: mypassword
+database: mydb`,
+			wantErr:  true,
+			expected: Config{Name: "test-redshift"},
+		},
 	}
 
 	for _, tt := range tests {
@@ -63,11 +233,39 @@ database: mydb`,
 				assert.NoError(t, err)
 				assert.Equal(t, tt.expected.Name, config.(Config).Name)
 				assert.Equal(t, tt.expected.Host, config.(Config).Host)
+				assert.Equal(t, tt.expected.IAMAuth, config.(Config).IAMAuth)
+				assert.Equal(t, tt.expected.ClusterIdentifier, config.(Config).ClusterIdentifier)
+				assert.Equal(t, tt.expected.DBUser, config.(Config).DBUser)
+				assert.Equal(t, tt.expected.UseDataAPI, config.(Config).UseDataAPI)
+				assert.Equal(t, tt.expected.SSLMode, config.(Config).SSLMode)
+				assert.Equal(t, tt.expected.ConnMaxLifetime, config.(Config).ConnMaxLifetime)
+				assert.Equal(t, tt.expected.ConnMaxIdleTime, config.(Config).ConnMaxIdleTime)
+				assert.Equal(t, tt.expected.SSLRootCert, config.(Config).SSLRootCert)
+				assert.Equal(t, tt.expected.WorkgroupName, config.(Config).WorkgroupName)
 			}
 		})
 	}
 }
 
+func TestBuildQueryParams(t *testing.T) {
+	params := buildQueryParams(Config{
+		QueryParams: map[string]string{"sslmode": "disable", "connect_timeout": "10"},
+		SSLMode:     "verify-full",
+		SSLRootCert: "/etc/ssl/redshift-ca-bundle.crt",
+	}, "genai-toolbox")
+
+	assert.Equal(t, map[string]string{
+		"application_name": "genai-toolbox",
+		"connect_timeout":  "10",
+		"sslmode":          "verify-full",
+		"sslrootcert":      "/etc/ssl/redshift-ca-bundle.crt",
+	}, params)
+
+	// Without sslMode, libpq's default applies
+	params = buildQueryParams(Config{}, "genai-toolbox")
+	assert.NotContains(t, params, "sslmode")
+}
+
 func TestSourceKind(t *testing.T) {
 	config := Config{
 		Name:     "test",
@@ -83,3 +281,424 @@ func TestSourceKind(t *testing.T) {
 	source := Source{Config: config}
 	assert.Equal(t, SourceKind, source.SourceKind())
 }
+
+type fakeCredentialsClient struct {
+	calls      int
+	expiration time.Time
+}
+
+func (f *fakeCredentialsClient) GetClusterCredentials(ctx context.Context, params *redshift.GetClusterCredentialsInput, optFns ...func(*redshift.Options)) (*redshift.GetClusterCredentialsOutput, error) {
+	f.calls++
+	return &redshift.GetClusterCredentialsOutput{
+		DbUser:     aws.String("IAM:" + aws.ToString(params.DbUser)),
+		DbPassword: aws.String("temporary-password"),
+		Expiration: aws.Time(f.expiration),
+	}, nil
+}
+
+func TestIAMConnectorCredentials(t *testing.T) {
+	client := &fakeCredentialsClient{expiration: time.Now().Add(15 * time.Minute)}
+	connector := &iamConnector{
+		client:            client,
+		clusterIdentifier: "mycluster",
+		dbUser:            "analyst",
+		dbName:            "mydb",
+	}
+
+	user, password, err := connector.credentials(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "IAM:analyst", user)
+	assert.Equal(t, "temporary-password", password)
+
+	// Valid credentials are reused
+	_, _, err = connector.credentials(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, client.calls)
+
+	// Credentials about to expire are refreshed
+	connector.expiration = time.Now().Add(CredentialRefreshWindow / 2)
+	_, _, err = connector.credentials(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, client.calls)
+}
+
+func TestIAMConnectorReconnectsOnAuthFailure(t *testing.T) {
+	client := &fakeCredentialsClient{expiration: time.Now().Add(15 * time.Minute)}
+	var dials int
+	connector := &iamConnector{
+		client:            client,
+		clusterIdentifier: "mycluster",
+		dbUser:            "analyst",
+		dbName:            "mydb",
+		buildURL:          func(user, pass string) string { return user + ":" + pass },
+		dial: func(ctx context.Context, dsn string) (driver.Conn, error) {
+			dials++
+			if dials == 1 {
+				return nil, &pq.Error{Code: "28P01", Message: "password authentication failed"}
+			}
+			return nil, nil
+		},
+	}
+
+	// Rejected credentials are refreshed and the connection retried once
+	_, err := connector.Connect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, dials)
+	assert.Equal(t, 2, client.calls)
+
+	// Other errors are returned without refreshing
+	connector.dial = func(ctx context.Context, dsn string) (driver.Conn, error) {
+		dials++
+		return nil, errors.New("connection refused")
+	}
+	_, err = connector.Connect(context.Background())
+	require.ErrorContains(t, err, "connection refused")
+	assert.Equal(t, 3, dials)
+	assert.Equal(t, 2, client.calls)
+}
+
+func TestExtractRegionFromHost(t *testing.T) {
+	assert.Equal(t, "us-west-2", extractRegionFromHost("mycluster.abc123.us-west-2.redshift.amazonaws.com"))
+	assert.Equal(t, "eu-west-1", extractRegionFromHost("default.123456789012.eu-west-1.redshift-serverless.amazonaws.com"))
+	assert.Equal(t, "", extractRegionFromHost("localhost"))
+}
+
+type fakeDataAPIClient struct {
+	statuses  []types.StatusString
+	describes int
+	cancelled bool
+	executed  *redshiftdata.ExecuteStatementInput
+	pages     []*redshiftdata.GetStatementResultOutput
+	page      int
+}
+
+func (f *fakeDataAPIClient) ExecuteStatement(ctx context.Context, params *redshiftdata.ExecuteStatementInput, optFns ...func(*redshiftdata.Options)) (*redshiftdata.ExecuteStatementOutput, error) {
+	f.executed = params
+	return &redshiftdata.ExecuteStatementOutput{Id: aws.String("stmt-1")}, nil
+}
+
+func (f *fakeDataAPIClient) DescribeStatement(ctx context.Context, params *redshiftdata.DescribeStatementInput, optFns ...func(*redshiftdata.Options)) (*redshiftdata.DescribeStatementOutput, error) {
+	status := f.statuses[min(f.describes, len(f.statuses)-1)]
+	f.describes++
+	return &redshiftdata.DescribeStatementOutput{
+		Id:           params.Id,
+		Status:       status,
+		Error:        aws.String("syntax error at or near \"SELEC\""),
+		HasResultSet: aws.Bool(len(f.pages) > 0),
+	}, nil
+}
+
+func (f *fakeDataAPIClient) GetStatementResult(ctx context.Context, params *redshiftdata.GetStatementResultInput, optFns ...func(*redshiftdata.Options)) (*redshiftdata.GetStatementResultOutput, error) {
+	out := f.pages[f.page]
+	f.page++
+	return out, nil
+}
+
+func (f *fakeDataAPIClient) CancelStatement(ctx context.Context, params *redshiftdata.CancelStatementInput, optFns ...func(*redshiftdata.Options)) (*redshiftdata.CancelStatementOutput, error) {
+	f.cancelled = true
+	return &redshiftdata.CancelStatementOutput{}, nil
+}
+
+func (f *fakeDataAPIClient) ListDatabases(ctx context.Context, params *redshiftdata.ListDatabasesInput, optFns ...func(*redshiftdata.Options)) (*redshiftdata.ListDatabasesOutput, error) {
+	return &redshiftdata.ListDatabasesOutput{Databases: []string{"dev"}}, nil
+}
+
+func TestExecuteDataAPI(t *testing.T) {
+	columns := []types.ColumnMetadata{{Name: aws.String("id")}, {Name: aws.String("name")}}
+	client := &fakeDataAPIClient{
+		statuses: []types.StatusString{types.StatusStringSubmitted, types.StatusStringStarted, types.StatusStringFinished},
+		pages: []*redshiftdata.GetStatementResultOutput{
+			{
+				ColumnMetadata: columns,
+				Records: [][]types.Field{
+					{&types.FieldMemberLongValue{Value: 1}, &types.FieldMemberStringValue{Value: "alice"}},
+				},
+				NextToken: aws.String("page-2"),
+			},
+			{
+				ColumnMetadata: columns,
+				Records: [][]types.Field{
+					{&types.FieldMemberLongValue{Value: 2}, &types.FieldMemberIsNull{Value: true}},
+				},
+			},
+		},
+	}
+	source := &Source{
+		Config:  Config{Name: "test", Database: "dev", WorkgroupName: "analytics", UseDataAPI: true},
+		dataAPI: client,
+	}
+
+	rows, err := source.ExecuteDataAPI(context.Background(), "SELECT id, name FROM users")
+	require.NoError(t, err)
+	assert.Equal(t, []map[string]any{
+		{"id": int64(1), "name": "alice"},
+		{"id": int64(2), "name": nil},
+	}, rows)
+	assert.Equal(t, 3, client.describes)
+	assert.Equal(t, "analytics", aws.ToString(client.executed.WorkgroupName))
+	assert.Nil(t, client.executed.ClusterIdentifier)
+}
+
+func TestExecuteDataAPIFailed(t *testing.T) {
+	source := &Source{
+		Config:  Config{Name: "test", Database: "dev", ClusterIdentifier: "mycluster"},
+		dataAPI: &fakeDataAPIClient{statuses: []types.StatusString{types.StatusStringFailed}},
+	}
+
+	_, err := source.ExecuteDataAPI(context.Background(), "SELEC 1")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "syntax error")
+}
+
+func TestExecuteDataAPICancelled(t *testing.T) {
+	client := &fakeDataAPIClient{statuses: []types.StatusString{types.StatusStringStarted}}
+	source := &Source{
+		Config:  Config{Name: "test", Database: "dev", ClusterIdentifier: "mycluster"},
+		dataAPI: client,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := source.ExecuteDataAPI(ctx, "SELECT pg_sleep(60)")
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.True(t, client.cancelled)
+}
+
+func TestExecuteDataAPIDisabled(t *testing.T) {
+	source := &Source{Config: Config{Name: "test"}}
+	_, err := source.ExecuteDataAPI(context.Background(), "SELECT 1")
+	assert.Error(t, err)
+}
+
+func TestBuildUnloadSQL(t *testing.T) {
+	stmt, err := buildUnloadSQL(
+		"SELECT * FROM sales WHERE region = 'us\\west'",
+		"s3://bucket/exports/sales_",
+		"arn:aws:iam::123456789012:role/RedshiftUnload",
+		UnloadOptions{Format: "csv", PartitionBy: []string{"year", "month"}, Header: true, AllowOverwrite: true},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, `UNLOAD ('SELECT * FROM sales WHERE region = ''us\\west''') TO 's3://bucket/exports/sales_' `+
+		`IAM_ROLE 'arn:aws:iam::123456789012:role/RedshiftUnload' FORMAT AS CSV PARTITION BY ("year", "month") HEADER ALLOWOVERWRITE`, stmt)
+
+	_, err = buildUnloadSQL("SELECT 1", "s3://bucket/out", "role", UnloadOptions{Format: FormatParquet, Header: true})
+	assert.ErrorContains(t, err, "header is not supported")
+
+	_, err = buildUnloadSQL("SELECT 1", "bucket/out", "role", UnloadOptions{})
+	assert.ErrorContains(t, err, "s3://")
+}
+
+func TestBuildCopySQL(t *testing.T) {
+	stmt, err := buildCopySQL(
+		"analytics.daily_sales",
+		"s3://bucket/imports/",
+		"arn:aws:iam::123456789012:role/RedshiftCopy",
+		CopyOptions{Format: FormatCSV, Header: true, Region: "us-east-1"},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, `COPY "analytics"."daily_sales" FROM 's3://bucket/imports/' `+
+		`IAM_ROLE 'arn:aws:iam::123456789012:role/RedshiftCopy' FORMAT AS CSV IGNOREHEADER 1 REGION 'us-east-1'`, stmt)
+
+	stmt, err = buildCopySQL(`weird"table`, "s3://bucket/data.parquet", "role", CopyOptions{Format: FormatParquet})
+	require.NoError(t, err)
+	assert.Equal(t, `COPY "weird""table" FROM 's3://bucket/data.parquet' IAM_ROLE 'role' FORMAT AS PARQUET`, stmt)
+
+	_, err = buildCopySQL("t", "s3://bucket/data", "role", CopyOptions{Format: "JSON"})
+	assert.ErrorContains(t, err, "unsupported format")
+}
+
+// fakeDriver is a driver.Connector that records the statements run on each connection it opens.
+type fakeDriver struct {
+	mu    sync.Mutex
+	conns []*fakeConn
+	rows  func() driver.Rows // Optional: rows returned by queries
+}
+
+func (d *fakeDriver) Connect(ctx context.Context) (driver.Conn, error) {
+	return d.Open("")
+}
+
+func (d *fakeDriver) Driver() driver.Driver { return d }
+
+func (d *fakeDriver) Open(name string) (driver.Conn, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	c := &fakeConn{rows: d.rows}
+	d.conns = append(d.conns, c)
+	return c, nil
+}
+
+type fakeConn struct {
+	mu         sync.Mutex
+	statements []string
+	closed     bool
+	rows       func() driver.Rows
+}
+
+func (c *fakeConn) record(query string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.statements = append(c.statements, query)
+}
+
+func (c *fakeConn) isClosed() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.closed
+}
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) { return nil, driver.ErrSkip }
+func (c *fakeConn) Begin() (driver.Tx, error)                 { return nil, driver.ErrSkip }
+
+func (c *fakeConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	c.record("BEGIN")
+	return &fakeTx{conn: c}, nil
+}
+
+func (c *fakeConn) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.closed = true
+	return nil
+}
+
+func (c *fakeConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	c.record(query)
+	return driver.RowsAffected(0), nil
+}
+
+func (c *fakeConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	c.record(query)
+	if c.rows != nil {
+		return c.rows(), nil
+	}
+	return &fakeRows{remaining: 1}, nil
+}
+
+type fakeTx struct{ conn *fakeConn }
+
+func (tx *fakeTx) Commit() error   { tx.conn.record("COMMIT"); return nil }
+func (tx *fakeTx) Rollback() error { tx.conn.record("ROLLBACK"); return nil }
+
+type fakeRows struct{ remaining int }
+
+func (r *fakeRows) Columns() []string { return []string{"n"} }
+func (r *fakeRows) Close() error      { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.remaining == 0 {
+		return io.EOF
+	}
+	r.remaining--
+	dest[0] = int64(1)
+	return nil
+}
+
+func TestQueryWithTimeout(t *testing.T) {
+	fake := &fakeDriver{}
+	db := sql.OpenDB(fake)
+	defer db.Close()
+
+	source := &Source{Config: Config{Name: "test"}, DB: db}
+	rows, err := source.QueryWithTimeout(context.Background(), 1500*time.Millisecond, "SELECT 1")
+	require.NoError(t, err)
+
+	require.Len(t, fake.conns, 1)
+	conn := fake.conns[0]
+	assert.Equal(t, []string{"SET statement_timeout TO 1500", "SELECT 1"}, conn.statements)
+
+	// The connection stays open while rows are being read
+	time.Sleep(10 * time.Millisecond)
+	assert.False(t, conn.isClosed())
+	for rows.Next() {
+	}
+	require.NoError(t, rows.Close())
+
+	// and is then discarded rather than reused with the timeout still set
+	assert.Eventually(t, conn.isClosed, time.Second, 5*time.Millisecond)
+	_, err = db.ExecContext(context.Background(), "SELECT 2")
+	require.NoError(t, err)
+	assert.Len(t, fake.conns, 2)
+
+	_, err = source.QueryWithTimeout(context.Background(), 0, "SELECT 1")
+	assert.Error(t, err)
+}
+
+// typedRows serves canned rows along with the database type of each column.
+type typedRows struct {
+	columns []string
+	types   []string
+	values  [][]driver.Value
+}
+
+func (r *typedRows) Columns() []string                           { return r.columns }
+func (r *typedRows) ColumnTypeDatabaseTypeName(index int) string { return r.types[index] }
+func (r *typedRows) Close() error                                { return nil }
+
+func (r *typedRows) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.values[0])
+	r.values = r.values[1:]
+	return nil
+}
+
+func TestQueryMaps(t *testing.T) {
+	created := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	fake := &fakeDriver{rows: func() driver.Rows {
+		return &typedRows{
+			columns: []string{"id", "name", "price", "created", "tags", "raw", "active"},
+			types:   []string{"INT8", "VARCHAR", "NUMERIC", "TIMESTAMP", "JSON", "BYTEA", "BOOL"},
+			values: [][]driver.Value{
+				{int64(1), []byte("widget"), []byte("12345678901234567890.12"), created, []byte(`["a","b"]`), []byte{0x01, 0x02}, true},
+				{int64(2), nil, nil, nil, nil, nil, nil},
+			},
+		}
+	}}
+	db := sql.OpenDB(fake)
+	defer db.Close()
+
+	source := &Source{Config: Config{Name: "test"}, DB: db}
+	rows, err := source.QueryMaps(context.Background(), "SELECT * FROM products WHERE id > $1", 0)
+	require.NoError(t, err)
+	assert.Equal(t, []map[string]any{
+		{
+			"id":      int64(1),
+			"name":    "widget",
+			"price":   "12345678901234567890.12",
+			"created": created,
+			"tags":    []any{"a", "b"},
+			"raw":     []byte{0x01, 0x02},
+			"active":  true,
+		},
+		{"id": int64(2), "name": nil, "price": nil, "created": nil, "tags": nil, "raw": nil, "active": nil},
+	}, rows)
+
+	_, err = (&Source{Config: Config{Name: "test"}}).QueryMaps(context.Background(), "SELECT 1")
+	assert.ErrorContains(t, err, "requires a database connection")
+}
+
+func TestWithReadOnlyTx(t *testing.T) {
+	fake := &fakeDriver{}
+	db := sql.OpenDB(fake)
+	defer db.Close()
+
+	source := &Source{Config: Config{Name: "test"}, DB: db}
+	err := source.WithReadOnlyTx(context.Background(), func(tx *sql.Tx) error {
+		var n int
+		return tx.QueryRowContext(context.Background(), "SELECT 1").Scan(&n)
+	})
+	require.NoError(t, err)
+	require.Len(t, fake.conns, 1)
+	assert.Equal(t, []string{"BEGIN", "SET TRANSACTION READ ONLY", "SELECT 1", "ROLLBACK"}, fake.conns[0].statements)
+
+	// The transaction is rolled back when fn fails, and fn's error is returned
+	fnErr := errors.New("boom")
+	err = source.WithReadOnlyTx(context.Background(), func(tx *sql.Tx) error { return fnErr })
+	assert.ErrorIs(t, err, fnErr)
+	assert.Equal(t, "ROLLBACK", fake.conns[0].statements[len(fake.conns[0].statements)-1])
+
+	err = (&Source{Config: Config{Name: "test"}}).WithReadOnlyTx(context.Background(), func(*sql.Tx) error { return nil })
+	assert.ErrorContains(t, err, "requires a database connection")
+}
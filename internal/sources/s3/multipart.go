@@ -0,0 +1,175 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s3
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+// Multipart upload constants
+const (
+	MinPartSize        int64 = 5 * 1024 * 1024  // Smallest part S3 accepts, except for the last part
+	DefaultPartSize    int64 = 8 * 1024 * 1024  // Part size used when none is given
+	MaxUploadParts           = 10000            // Most parts S3 allows in one upload
+	UploadConcurrency        = 4                // Parts uploaded at the same time
+	AbortUploadTimeout       = 30 * time.Second // Time allowed to abort a failed upload
+)
+
+// UploadLargeObject uploads r to key in parts of partSize bytes, uploading up to
+// UploadConcurrency parts at a time. A partSize of 0 means DefaultPartSize. If
+// any part fails the upload is aborted so no incomplete parts are left billed
+// in the bucket. An empty bucket means the configured default bucket.
+func (s *Source) UploadLargeObject(ctx context.Context, bucket, key string, r io.Reader, partSize int64) error {
+	bucket, err := s.bucketOrDefault(bucket)
+	if err != nil {
+		return err
+	}
+	if partSize == 0 {
+		partSize = DefaultPartSize
+	}
+	if partSize < MinPartSize {
+		return fmt.Errorf("partSize must be at least %d bytes, got %d", MinPartSize, partSize)
+	}
+
+	created, err := s.api.CreateMultipartUpload(ctx, &s3.CreateMultipartUploadInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return fmt.Errorf("unable to start upload of s3://%s/%s: %w", bucket, key, err)
+	}
+	uploadID := created.UploadId
+
+	parts, err := s.uploadParts(ctx, bucket, key, uploadID, r, partSize)
+	if err == nil {
+		_, err = s.api.CompleteMultipartUpload(ctx, &s3.CompleteMultipartUploadInput{
+			Bucket:          aws.String(bucket),
+			Key:             aws.String(key),
+			UploadId:        uploadID,
+			MultipartUpload: &types.CompletedMultipartUpload{Parts: parts},
+		})
+		if err == nil {
+			return nil
+		}
+		err = fmt.Errorf("unable to complete upload: %w", err)
+	}
+
+	// Abort with a fresh deadline since ctx may be why the upload failed
+	abortCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), AbortUploadTimeout)
+	defer cancel()
+	if _, abortErr := s.api.AbortMultipartUpload(abortCtx, &s3.AbortMultipartUploadInput{
+		Bucket:   aws.String(bucket),
+		Key:      aws.String(key),
+		UploadId: uploadID,
+	}); abortErr != nil {
+		err = errors.Join(err, fmt.Errorf("unable to abort upload %s: %w", aws.ToString(uploadID), abortErr))
+	}
+	return fmt.Errorf("unable to upload s3://%s/%s: %w", bucket, key, err)
+}
+
+// uploadParts reads r in partSize chunks and uploads them concurrently,
+// returning the completed parts in order.
+func (s *Source) uploadParts(ctx context.Context, bucket, key string, uploadID *string, r io.Reader, partSize int64) ([]types.CompletedPart, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		parts    []types.CompletedPart
+		firstErr error
+	)
+	fail := func(err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if firstErr == nil {
+			firstErr = err
+			cancel()
+		}
+	}
+	sem := make(chan struct{}, UploadConcurrency)
+
+	for partNumber := int32(1); ; partNumber++ {
+		if partNumber > MaxUploadParts {
+			fail(fmt.Errorf("object needs more than %d parts, use a larger partSize", MaxUploadParts))
+			break
+		}
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			fail(ctx.Err())
+			break
+		}
+
+		buf := make([]byte, partSize)
+		n, readErr := io.ReadFull(r, buf)
+		if readErr == io.EOF && partNumber > 1 {
+			<-sem
+			break
+		}
+		if readErr != nil && readErr != io.EOF && readErr != io.ErrUnexpectedEOF {
+			<-sem
+			fail(fmt.Errorf("unable to read part %d: %w", partNumber, readErr))
+			break
+		}
+
+		wg.Add(1)
+		go func(partNumber int32, data []byte) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			out, err := s.api.UploadPart(ctx, &s3.UploadPartInput{
+				Bucket:     aws.String(bucket),
+				Key:        aws.String(key),
+				UploadId:   uploadID,
+				PartNumber: aws.Int32(partNumber),
+				Body:       bytes.NewReader(data),
+			})
+			if err != nil {
+				fail(fmt.Errorf("unable to upload part %d: %w", partNumber, err))
+				return
+			}
+			mu.Lock()
+			parts = append(parts, types.CompletedPart{ETag: out.ETag, PartNumber: aws.Int32(partNumber)})
+			mu.Unlock()
+		}(partNumber, buf[:n])
+
+		if readErr != nil {
+			// Short or empty read: this was the last part
+			break
+		}
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	slices.SortFunc(parts, func(a, b types.CompletedPart) int {
+		return int(aws.ToInt32(a.PartNumber) - aws.ToInt32(b.PartNumber))
+	})
+	return parts, nil
+}
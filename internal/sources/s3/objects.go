@@ -0,0 +1,316 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s3
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+// MaxDeleteObjects is the most keys DeleteObjects accepts in one request.
+const MaxDeleteObjects = 1000
+
+// Server-side encryption modes for PutOptions.
+const (
+	EncryptionSSES3  = "AES256"  // SSE-S3: keys managed by S3
+	EncryptionSSEKMS = "aws:kms" // SSE-KMS: keys managed by AWS KMS
+)
+
+// s3API is the subset of the S3 API used by the object helpers.
+type s3API interface {
+	ListObjectsV2(ctx context.Context, params *s3.ListObjectsV2Input, optFns ...func(*s3.Options)) (*s3.ListObjectsV2Output, error)
+	GetObject(ctx context.Context, params *s3.GetObjectInput, optFns ...func(*s3.Options)) (*s3.GetObjectOutput, error)
+	PutObject(ctx context.Context, params *s3.PutObjectInput, optFns ...func(*s3.Options)) (*s3.PutObjectOutput, error)
+	SelectObjectContentStream(ctx context.Context, params *s3.SelectObjectContentInput, optFns ...func(*s3.Options)) (*s3.SelectObjectContentEventStream, error)
+	CreateMultipartUpload(ctx context.Context, params *s3.CreateMultipartUploadInput, optFns ...func(*s3.Options)) (*s3.CreateMultipartUploadOutput, error)
+	UploadPart(ctx context.Context, params *s3.UploadPartInput, optFns ...func(*s3.Options)) (*s3.UploadPartOutput, error)
+	CompleteMultipartUpload(ctx context.Context, params *s3.CompleteMultipartUploadInput, optFns ...func(*s3.Options)) (*s3.CompleteMultipartUploadOutput, error)
+	AbortMultipartUpload(ctx context.Context, params *s3.AbortMultipartUploadInput, optFns ...func(*s3.Options)) (*s3.AbortMultipartUploadOutput, error)
+	HeadObject(ctx context.Context, params *s3.HeadObjectInput, optFns ...func(*s3.Options)) (*s3.HeadObjectOutput, error)
+	CopyObject(ctx context.Context, params *s3.CopyObjectInput, optFns ...func(*s3.Options)) (*s3.CopyObjectOutput, error)
+	DeleteObjects(ctx context.Context, params *s3.DeleteObjectsInput, optFns ...func(*s3.Options)) (*s3.DeleteObjectsOutput, error)
+}
+
+// s3Client adapts *s3.Client to s3API.
+type s3Client struct {
+	*s3.Client
+}
+
+// SelectObjectContentStream runs SelectObjectContent and returns its event
+// stream. The SDK doesn't allow setting the stream on SelectObjectContentOutput,
+// so tests fake this method instead.
+func (c s3Client) SelectObjectContentStream(ctx context.Context, params *s3.SelectObjectContentInput, optFns ...func(*s3.Options)) (*s3.SelectObjectContentEventStream, error) {
+	out, err := c.SelectObjectContent(ctx, params, optFns...)
+	if err != nil {
+		return nil, err
+	}
+	return out.GetStream(), nil
+}
+
+// ObjectInfo describes an object in a bucket.
+type ObjectInfo struct {
+	Key          string
+	Size         int64
+	LastModified time.Time
+	ETag         string
+}
+
+// ListObjects returns every object in bucket whose key starts with prefix,
+// following continuation tokens across pages. An empty bucket means the
+// configured default bucket.
+func (s *Source) ListObjects(ctx context.Context, bucket, prefix string) ([]ObjectInfo, error) {
+	bucket, err := s.bucketOrDefault(bucket)
+	if err != nil {
+		return nil, err
+	}
+
+	objects := []ObjectInfo{}
+	input := &s3.ListObjectsV2Input{Bucket: aws.String(bucket)}
+	if prefix != "" {
+		input.Prefix = aws.String(prefix)
+	}
+	for {
+		out, err := s.api.ListObjectsV2(ctx, input)
+		if err != nil {
+			return nil, fmt.Errorf("unable to list objects in %s: %w", bucket, err)
+		}
+		for _, obj := range out.Contents {
+			objects = append(objects, ObjectInfo{
+				Key:          aws.ToString(obj.Key),
+				Size:         aws.ToInt64(obj.Size),
+				LastModified: aws.ToTime(obj.LastModified),
+				ETag:         aws.ToString(obj.ETag),
+			})
+		}
+		if !aws.ToBool(out.IsTruncated) || aws.ToString(out.NextContinuationToken) == "" {
+			return objects, nil
+		}
+		input.ContinuationToken = out.NextContinuationToken
+	}
+}
+
+// HeadObject returns the metadata of key. It reports false, with no error, if
+// the object doesn't exist. An empty bucket means the configured default
+// bucket.
+func (s *Source) HeadObject(ctx context.Context, bucket, key string) (*ObjectInfo, bool, error) {
+	bucket, err := s.bucketOrDefault(bucket)
+	if err != nil {
+		return nil, false, err
+	}
+	out, err := s.api.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		if isNotFound(err) {
+			return nil, false, nil
+		}
+		return nil, false, fmt.Errorf("unable to head s3://%s/%s: %w", bucket, key, err)
+	}
+	return &ObjectInfo{
+		Key:          key,
+		Size:         aws.ToInt64(out.ContentLength),
+		LastModified: aws.ToTime(out.LastModified),
+		ETag:         aws.ToString(out.ETag),
+	}, true, nil
+}
+
+// isNotFound reports whether err means the object doesn't exist. HEAD responses
+// have no body, so S3 reports a missing key as a bare 404.
+func isNotFound(err error) bool {
+	var notFound *types.NotFound
+	var noSuchKey *types.NoSuchKey
+	if errors.As(err, &notFound) || errors.As(err, &noSuchKey) {
+		return true
+	}
+	var respErr *awshttp.ResponseError
+	return errors.As(err, &respErr) && respErr.HTTPStatusCode() == http.StatusNotFound
+}
+
+// PutOptions controls how PutObject stores an object.
+type PutOptions struct {
+	ContentType          string // Optional: MIME type of the object
+	ServerSideEncryption string // Optional: EncryptionSSES3 or EncryptionSSEKMS
+	KMSKeyID             string // Optional: KMS key ID or ARN for EncryptionSSEKMS (default: the AWS managed key)
+}
+
+// GetObject returns the contents of key. The caller must close the returned
+// reader. An empty bucket means the configured default bucket.
+func (s *Source) GetObject(ctx context.Context, bucket, key string) (io.ReadCloser, error) {
+	bucket, err := s.bucketOrDefault(bucket)
+	if err != nil {
+		return nil, err
+	}
+	out, err := s.api.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to get s3://%s/%s: %w", bucket, key, err)
+	}
+	return out.Body, nil
+}
+
+// PutObject writes body to key. An empty bucket means the configured default
+// bucket.
+func (s *Source) PutObject(ctx context.Context, bucket, key string, body io.Reader, opts PutOptions) error {
+	bucket, err := s.bucketOrDefault(bucket)
+	if err != nil {
+		return err
+	}
+	input := &s3.PutObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+		Body:   body,
+	}
+	if opts.ContentType != "" {
+		input.ContentType = aws.String(opts.ContentType)
+	}
+	if opts.KMSKeyID != "" && opts.ServerSideEncryption != EncryptionSSEKMS {
+		return fmt.Errorf("KMSKeyID requires ServerSideEncryption %q", EncryptionSSEKMS)
+	}
+	switch opts.ServerSideEncryption {
+	case "":
+	case EncryptionSSES3:
+		input.ServerSideEncryption = types.ServerSideEncryptionAes256
+	case EncryptionSSEKMS:
+		input.ServerSideEncryption = types.ServerSideEncryptionAwsKms
+		if opts.KMSKeyID != "" {
+			input.SSEKMSKeyId = aws.String(opts.KMSKeyID)
+		}
+	default:
+		return fmt.Errorf("invalid ServerSideEncryption %q, must be %q or %q", opts.ServerSideEncryption, EncryptionSSES3, EncryptionSSEKMS)
+	}
+
+	if _, err := s.api.PutObject(ctx, input); err != nil {
+		return fmt.Errorf("unable to put s3://%s/%s: %w", bucket, key, err)
+	}
+	return nil
+}
+
+// CopyObject copies srcKey to dstKey within S3, without downloading it. An
+// empty bucket means the configured default bucket.
+func (s *Source) CopyObject(ctx context.Context, srcBucket, srcKey, dstBucket, dstKey string) error {
+	srcBucket, err := s.bucketOrDefault(srcBucket)
+	if err != nil {
+		return err
+	}
+	dstBucket, err = s.bucketOrDefault(dstBucket)
+	if err != nil {
+		return err
+	}
+	if _, err := s.api.CopyObject(ctx, &s3.CopyObjectInput{
+		Bucket:     aws.String(dstBucket),
+		Key:        aws.String(dstKey),
+		CopySource: aws.String(copySource(srcBucket, srcKey)),
+	}); err != nil {
+		return fmt.Errorf("unable to copy s3://%s/%s to s3://%s/%s: %w", srcBucket, srcKey, dstBucket, dstKey, err)
+	}
+	return nil
+}
+
+// copySource returns the URL-encoded bucket/key that CopyObject expects.
+func copySource(bucket, key string) string {
+	segments := strings.Split(key, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return bucket + "/" + strings.Join(segments, "/")
+}
+
+// DeleteFailure is a key DeleteObjects was unable to delete.
+type DeleteFailure struct {
+	Key     string
+	Code    string // e.g. AccessDenied
+	Message string
+}
+
+// DeleteObjectsError is returned by DeleteObjects when some keys were not
+// deleted. The other keys were deleted.
+type DeleteObjectsError struct {
+	Bucket string
+	Failed []DeleteFailure
+}
+
+func (e *DeleteObjectsError) Error() string {
+	first := e.Failed[0]
+	msg := fmt.Sprintf("unable to delete %d objects from %s: %s: %s %s", len(e.Failed), e.Bucket, first.Key, first.Code, first.Message)
+	if len(e.Failed) > 1 {
+		msg += fmt.Sprintf(" (and %d more)", len(e.Failed)-1)
+	}
+	return msg
+}
+
+// DeleteObjects deletes keys from bucket, in batches of MaxDeleteObjects.
+// Deleting a key that doesn't exist succeeds. If S3 fails to delete some keys,
+// the rest are still deleted and a *DeleteObjectsError lists the failures. An
+// empty bucket means the configured default bucket.
+func (s *Source) DeleteObjects(ctx context.Context, bucket string, keys []string) error {
+	bucket, err := s.bucketOrDefault(bucket)
+	if err != nil {
+		return err
+	}
+
+	var failed []DeleteFailure
+	for start := 0; start < len(keys); start += MaxDeleteObjects {
+		batch := keys[start:min(start+MaxDeleteObjects, len(keys))]
+		objects := make([]types.ObjectIdentifier, len(batch))
+		for i, key := range batch {
+			objects[i] = types.ObjectIdentifier{Key: aws.String(key)}
+		}
+		// Quiet mode only reports the keys that failed
+		out, err := s.api.DeleteObjects(ctx, &s3.DeleteObjectsInput{
+			Bucket: aws.String(bucket),
+			Delete: &types.Delete{Objects: objects, Quiet: aws.Bool(true)},
+		})
+		if err != nil {
+			return fmt.Errorf("unable to delete objects from %s: %w", bucket, err)
+		}
+		for _, e := range out.Errors {
+			failed = append(failed, DeleteFailure{
+				Key:     aws.ToString(e.Key),
+				Code:    aws.ToString(e.Code),
+				Message: aws.ToString(e.Message),
+			})
+		}
+	}
+	if len(failed) > 0 {
+		return &DeleteObjectsError{Bucket: bucket, Failed: failed}
+	}
+	return nil
+}
+
+// bucketOrDefault returns bucket, or the configured default bucket if bucket
+// is empty.
+func (s *Source) bucketOrDefault(bucket string) (string, error) {
+	if bucket != "" {
+		return bucket, nil
+	}
+	if s.Bucket == "" {
+		return "", fmt.Errorf("source %q (%s): no bucket given and no default bucket configured", s.Name, SourceKind)
+	}
+	return s.Bucket, nil
+}
@@ -0,0 +1,82 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s3
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// MaxPresignExpiry is the longest expiry SigV4 allows for a presigned URL.
+const MaxPresignExpiry = 7 * 24 * time.Hour
+
+// presignAPI is the subset of s3.PresignClient used by the source.
+type presignAPI interface {
+	PresignGetObject(ctx context.Context, params *s3.GetObjectInput, optFns ...func(*s3.PresignOptions)) (*v4.PresignedHTTPRequest, error)
+	PresignPutObject(ctx context.Context, params *s3.PutObjectInput, optFns ...func(*s3.PresignOptions)) (*v4.PresignedHTTPRequest, error)
+}
+
+// PresignGetObject returns a URL that downloads key without credentials until
+// expiry passes. An empty bucket means the configured default bucket.
+func (s *Source) PresignGetObject(ctx context.Context, bucket, key string, expiry time.Duration) (string, error) {
+	bucket, err := s.bucketOrDefault(bucket)
+	if err != nil {
+		return "", err
+	}
+	if err := validateExpiry(expiry); err != nil {
+		return "", err
+	}
+	req, err := s.presigner.PresignGetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	}, s3.WithPresignExpires(expiry))
+	if err != nil {
+		return "", fmt.Errorf("unable to presign download of s3://%s/%s: %w", bucket, key, err)
+	}
+	return req.URL, nil
+}
+
+// PresignPutObject returns a URL that uploads key with an HTTP PUT without
+// credentials until expiry passes. An empty bucket means the configured default
+// bucket.
+func (s *Source) PresignPutObject(ctx context.Context, bucket, key string, expiry time.Duration) (string, error) {
+	bucket, err := s.bucketOrDefault(bucket)
+	if err != nil {
+		return "", err
+	}
+	if err := validateExpiry(expiry); err != nil {
+		return "", err
+	}
+	req, err := s.presigner.PresignPutObject(ctx, &s3.PutObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	}, s3.WithPresignExpires(expiry))
+	if err != nil {
+		return "", fmt.Errorf("unable to presign upload of s3://%s/%s: %w", bucket, key, err)
+	}
+	return req.URL, nil
+}
+
+func validateExpiry(expiry time.Duration) error {
+	if expiry <= 0 || expiry > MaxPresignExpiry {
+		return fmt.Errorf("expiry must be greater than 0 and at most %s, got %s", MaxPresignExpiry, expiry)
+	}
+	return nil
+}
@@ -22,11 +22,10 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/aws/aws-sdk-go-v2/config"
-	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/goccy/go-yaml"
 	"github.com/googleapis/genai-toolbox/internal/sources"
+	sourceutil "github.com/googleapis/genai-toolbox/internal/sources/util"
 	"go.opentelemetry.io/otel/trace"
 )
 
@@ -36,9 +35,7 @@ const SourceKind string = "s3"
 var _ sources.SourceConfig = Config{}
 
 func init() {
-	if !sources.Register(SourceKind, newConfig) {
-		panic(fmt.Sprintf("source kind %q already registered", SourceKind))
-	}
+	sources.MustRegister(SourceKind, newConfig)
 }
 
 func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources.SourceConfig, error) {
@@ -46,18 +43,38 @@ func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources
 	if err := decoder.DecodeContext(ctx, &actual); err != nil {
 		return nil, err
 	}
+
+	secretAccessKey, err := sourceutil.ResolveSecret("secretAccessKey", actual.SecretAccessKey, actual.SecretAccessKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("source %q (%s): unable to resolve secret: %w", name, SourceKind, err)
+	}
+	actual.SecretAccessKey = secretAccessKey
+
+	if err := sourceutil.ValidateAWSEndpoint(actual.Endpoint, actual.UseFIPS, actual.UseDualStack); err != nil {
+		return nil, fmt.Errorf("source %q (%s): %w", name, SourceKind, err)
+	}
+	if actual.MaxRetries < 0 {
+		return nil, fmt.Errorf("source %q (%s): maxRetries must not be negative", name, SourceKind)
+	}
 	return actual, nil
 }
 
 type Config struct {
-	Name            string `yaml:"name" validate:"required"`
-	Kind            string `yaml:"kind" validate:"required"`
-	Region          string `yaml:"region" validate:"required"`
-	Bucket          string `yaml:"bucket"`          // Optional: default bucket
-	Endpoint        string `yaml:"endpoint"`        // Optional: for S3-compatible services
-	ForcePathStyle  bool   `yaml:"forcePathStyle"`  // Optional: use path-style addressing
-	AccessKeyID     string `yaml:"accessKeyId"`     // Optional: for explicit credentials
-	SecretAccessKey string `yaml:"secretAccessKey"` // Optional: for explicit credentials
+	Name                string `yaml:"name" validate:"required"`
+	Kind                string `yaml:"kind" validate:"required"`
+	Region              string `yaml:"region"`              // Optional: resolved from AWS_REGION, the shared config, or EC2 instance metadata if unset
+	Bucket              string `yaml:"bucket"`              // Optional: default bucket
+	Endpoint            string `yaml:"endpoint"`            // Optional: for S3-compatible services
+	ForcePathStyle      bool   `yaml:"forcePathStyle"`      // Optional: use path-style addressing
+	AccessKeyID         string `yaml:"accessKeyId"`         // Optional: for explicit credentials
+	SecretAccessKey     string `yaml:"secretAccessKey"`     // Optional: for explicit credentials
+	SecretAccessKeyFile string `yaml:"secretAccessKeyFile"` // Optional: file to read secretAccessKey from
+	RoleARN             string `yaml:"roleArn"`             // Optional: role to assume with the resolved credentials
+	RoleSessionName     string `yaml:"roleSessionName"`     // Optional: session name for the assumed role
+	ExternalID          string `yaml:"externalId"`          // Optional: external ID required by the role trust policy
+	UseFIPS             bool   `yaml:"useFIPS"`             // Optional: use FIPS endpoints
+	UseDualStack        bool   `yaml:"useDualStack"`        // Optional: use dual-stack (IPv4 and IPv6) endpoints
+	MaxRetries          int    `yaml:"maxRetries"`          // Optional: retries after the first attempt, e.g. on throttling (default: 2)
 }
 
 func (r Config) SourceConfigKind() string {
@@ -65,7 +82,7 @@ func (r Config) SourceConfigKind() string {
 }
 
 func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.Source, error) {
-	client, err := initS3Client(ctx, tracer, r.Name, r.Region, r.Endpoint, r.ForcePathStyle, r.AccessKeyID, r.SecretAccessKey)
+	client, err := initS3Client(ctx, tracer, r)
 	if err != nil {
 		return nil, fmt.Errorf("source %q (%s): unable to create S3 client: %w", r.Name, SourceKind, err)
 	}
@@ -77,8 +94,10 @@ func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.So
 	}
 
 	s := &Source{
-		Config: r,
-		Client: client,
+		Config:    r,
+		Client:    client,
+		api:       s3Client{client},
+		presigner: s3.NewPresignClient(client),
 	}
 	return s, nil
 }
@@ -87,7 +106,9 @@ var _ sources.Source = &Source{}
 
 type Source struct {
 	Config
-	Client *s3.Client
+	Client    *s3.Client
+	api       s3API      // Client, replaced in tests
+	presigner presignAPI // Presign client for Client, replaced in tests
 }
 
 func (s *Source) SourceKind() string {
@@ -98,6 +119,12 @@ func (s *Source) ToConfig() sources.SourceConfig {
 	return s.Config
 }
 
+// HealthCheck lists buckets, as Initialize does.
+func (s *Source) HealthCheck(ctx context.Context) error {
+	_, err := s.Client.ListBuckets(ctx, &s3.ListBucketsInput{})
+	return err
+}
+
 // S3Client returns the underlying AWS S3 client for direct API access.
 func (s *Source) S3Client() *s3.Client {
 	return s.Client
@@ -106,48 +133,35 @@ func (s *Source) S3Client() *s3.Client {
 // Close is not needed for this source because AWS SDK v2 clients manage
 // their own connection pooling and cleanup automatically.
 
-func initS3Client(ctx context.Context, tracer trace.Tracer, name, region, endpoint string, forcePathStyle bool, accessKeyID, secretAccessKey string) (*s3.Client, error) {
+func initS3Client(ctx context.Context, tracer trace.Tracer, r Config) (*s3.Client, error) {
 	//nolint:all // Reassigned ctx
-	ctx, span := sources.InitConnectionSpan(ctx, tracer, SourceKind, name)
+	ctx, span := sources.InitConnectionSpan(ctx, tracer, SourceKind, r.Name)
 	defer span.End()
 
-	// Build AWS config load options
-	configOpts := []func(*config.LoadOptions) error{
-		config.WithRegion(region),
-	}
-
-	// Use explicit credentials if provided (same pattern as DynamoDB)
-	if accessKeyID != "" && secretAccessKey != "" {
-		configOpts = append(configOpts, config.WithCredentialsProvider(
-			credentials.NewStaticCredentialsProvider(accessKeyID, secretAccessKey, ""),
-		))
-	}
-
-	// Load AWS configuration
-	cfg, err := config.LoadDefaultConfig(ctx, configOpts...)
+	// The endpoint is set separately if specified (for S3-compatible services like MinIO)
+	cfg, err := sourceutil.LoadAWSConfig(ctx, sourceutil.AWSOptions{
+		Region:          r.Region,
+		AccessKeyID:     r.AccessKeyID,
+		SecretAccessKey: r.SecretAccessKey,
+		Endpoint:        r.Endpoint,
+		RoleARN:         r.RoleARN,
+		RoleSessionName: r.RoleSessionName,
+		ExternalID:      r.ExternalID,
+		UseFIPS:         r.UseFIPS,
+		UseDualStack:    r.UseDualStack,
+		MaxRetries:      r.MaxRetries,
+	})
 	if err != nil {
-		return nil, fmt.Errorf("unable to load AWS config: %w", err)
-	}
-
-	// Create S3 client options
-	opts := []func(*s3.Options){}
-
-	// Apply path style regardless of endpoint
-	if forcePathStyle {
-		opts = append(opts, func(o *s3.Options) {
-			o.UsePathStyle = true
-		})
+		return nil, err
 	}
-
-	// Add custom endpoint separately if specified (for S3-compatible services like MinIO)
-	if endpoint != "" {
-		opts = append(opts, func(o *s3.Options) {
-			o.BaseEndpoint = &endpoint
-		})
+	if cfg.Region == "" {
+		return nil, fmt.Errorf("unable to determine AWS region, set region in the source config or AWS_REGION")
 	}
 
-	// Create the S3 client
-	client := s3.NewFromConfig(cfg, opts...)
+	// Create the S3 client, applying path style regardless of endpoint
+	client := s3.NewFromConfig(cfg, func(o *s3.Options) {
+		o.UsePathStyle = r.ForcePathStyle
+	})
 
 	return client, nil
 }
@@ -17,10 +17,21 @@ package s3
 import (
 	"bytes"
 	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 	"github.com/goccy/go-yaml"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestParseFromYamlS3(t *testing.T) {
@@ -134,6 +145,52 @@ secretAccessKey: secretexample`,
 				SecretAccessKey: "secretexample",
 			},
 		},
+		{
+			name: "valid configuration with assumed role",
+			yamlContent: `name: test-s3
+kind: s3
+region: us-east-1
+roleArn: arn:aws:iam::123456789012:role/toolbox
+roleSessionName: toolbox-session
+externalId: my-external-id`,
+			wantErr: false,
+			expected: Config{
+				Name:            "test-s3",
+				Kind:            "s3",
+				Region:          "us-east-1",
+				RoleARN:         "arn:aws:iam::123456789012:role/toolbox",
+				RoleSessionName: "toolbox-session",
+				ExternalID:      "my-external-id",
+			},
+		},
+		{
+			name: "valid configuration with FIPS and dual-stack endpoints",
+			yamlContent: `name: test-s3
+kind: s3
+region: us-east-1
+useFIPS: true
+useDualStack: true`,
+			wantErr: false,
+			expected: Config{
+				Name:         "test-s3",
+				Kind:         "s3",
+				Region:       "us-east-1",
+				UseFIPS:      true,
+				UseDualStack: true,
+			},
+		},
+		{
+			name: "custom endpoint with FIPS",
+			yamlContent: `name: test-s3
+kind: s3
+region: us-east-1
+endpoint: http://localhost:4566
+useFIPS: true`,
+			wantErr: true,
+			expected: Config{
+				Name: "test-s3",
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -161,6 +218,11 @@ secretAccessKey: secretexample`,
 				if tt.expected.SecretAccessKey != "" {
 					assert.Equal(t, tt.expected.SecretAccessKey, config.(Config).SecretAccessKey)
 				}
+				assert.Equal(t, tt.expected.RoleARN, config.(Config).RoleARN)
+				assert.Equal(t, tt.expected.RoleSessionName, config.(Config).RoleSessionName)
+				assert.Equal(t, tt.expected.ExternalID, config.(Config).ExternalID)
+				assert.Equal(t, tt.expected.UseFIPS, config.(Config).UseFIPS)
+				assert.Equal(t, tt.expected.UseDualStack, config.(Config).UseDualStack)
 			}
 		})
 	}
@@ -205,3 +267,407 @@ func TestSourceKindS3(t *testing.T) {
 	source := Source{Config: config}
 	assert.Equal(t, SourceKind, source.SourceKind())
 }
+
+// fakeS3Client serves canned responses for the object helpers.
+type fakeS3Client struct {
+	listPages []*s3.ListObjectsV2Output
+	listReqs  []s3.ListObjectsV2Input
+	objects   map[string]string
+	putReqs   []*s3.PutObjectInput
+	putBodies []string
+	selectReq *s3.SelectObjectContentInput
+	events    []types.SelectObjectContentEventStream
+
+	mu        sync.Mutex
+	partSizes map[int32]int
+	failPart  int32
+	completed *s3.CompleteMultipartUploadInput
+	aborted   bool
+	headErr   error
+
+	copyReq     *s3.CopyObjectInput
+	deleteSizes []int
+	denied      map[string]bool // Keys DeleteObjects fails to delete
+}
+
+func (f *fakeS3Client) ListObjectsV2(ctx context.Context, params *s3.ListObjectsV2Input, optFns ...func(*s3.Options)) (*s3.ListObjectsV2Output, error) {
+	f.listReqs = append(f.listReqs, *params)
+	return f.listPages[len(f.listReqs)-1], nil
+}
+
+func (f *fakeS3Client) GetObject(ctx context.Context, params *s3.GetObjectInput, optFns ...func(*s3.Options)) (*s3.GetObjectOutput, error) {
+	body, ok := f.objects[aws.ToString(params.Bucket)+"/"+aws.ToString(params.Key)]
+	if !ok {
+		return nil, &types.NoSuchKey{}
+	}
+	return &s3.GetObjectOutput{Body: io.NopCloser(strings.NewReader(body))}, nil
+}
+
+func (f *fakeS3Client) PutObject(ctx context.Context, params *s3.PutObjectInput, optFns ...func(*s3.Options)) (*s3.PutObjectOutput, error) {
+	body, err := io.ReadAll(params.Body)
+	if err != nil {
+		return nil, err
+	}
+	f.putReqs = append(f.putReqs, params)
+	f.putBodies = append(f.putBodies, string(body))
+	return &s3.PutObjectOutput{}, nil
+}
+
+func (f *fakeS3Client) SelectObjectContentStream(ctx context.Context, params *s3.SelectObjectContentInput, optFns ...func(*s3.Options)) (*s3.SelectObjectContentEventStream, error) {
+	f.selectReq = params
+	events := make(chan types.SelectObjectContentEventStream, len(f.events))
+	for _, e := range f.events {
+		events <- e
+	}
+	close(events)
+	return s3.NewSelectObjectContentEventStream(func(s *s3.SelectObjectContentEventStream) {
+		s.Reader = &fakeSelectStream{events: events}
+	}), nil
+}
+
+func (f *fakeS3Client) CreateMultipartUpload(ctx context.Context, params *s3.CreateMultipartUploadInput, optFns ...func(*s3.Options)) (*s3.CreateMultipartUploadOutput, error) {
+	f.partSizes = map[int32]int{}
+	return &s3.CreateMultipartUploadOutput{UploadId: aws.String("upload-1")}, nil
+}
+
+func (f *fakeS3Client) UploadPart(ctx context.Context, params *s3.UploadPartInput, optFns ...func(*s3.Options)) (*s3.UploadPartOutput, error) {
+	partNumber := aws.ToInt32(params.PartNumber)
+	if partNumber == f.failPart {
+		return nil, errors.New("connection reset")
+	}
+	data, err := io.ReadAll(params.Body)
+	if err != nil {
+		return nil, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.partSizes[partNumber] = len(data)
+	return &s3.UploadPartOutput{ETag: aws.String(fmt.Sprintf("etag-%d", partNumber))}, nil
+}
+
+func (f *fakeS3Client) CompleteMultipartUpload(ctx context.Context, params *s3.CompleteMultipartUploadInput, optFns ...func(*s3.Options)) (*s3.CompleteMultipartUploadOutput, error) {
+	f.completed = params
+	return &s3.CompleteMultipartUploadOutput{}, nil
+}
+
+func (f *fakeS3Client) AbortMultipartUpload(ctx context.Context, params *s3.AbortMultipartUploadInput, optFns ...func(*s3.Options)) (*s3.AbortMultipartUploadOutput, error) {
+	f.aborted = true
+	return &s3.AbortMultipartUploadOutput{}, nil
+}
+
+func (f *fakeS3Client) HeadObject(ctx context.Context, params *s3.HeadObjectInput, optFns ...func(*s3.Options)) (*s3.HeadObjectOutput, error) {
+	if f.headErr != nil {
+		return nil, f.headErr
+	}
+	body, ok := f.objects[aws.ToString(params.Bucket)+"/"+aws.ToString(params.Key)]
+	if !ok {
+		return nil, &types.NotFound{}
+	}
+	return &s3.HeadObjectOutput{ContentLength: aws.Int64(int64(len(body))), ETag: aws.String(`"etag"`)}, nil
+}
+
+func (f *fakeS3Client) CopyObject(ctx context.Context, params *s3.CopyObjectInput, optFns ...func(*s3.Options)) (*s3.CopyObjectOutput, error) {
+	f.copyReq = params
+	return &s3.CopyObjectOutput{}, nil
+}
+
+func (f *fakeS3Client) DeleteObjects(ctx context.Context, params *s3.DeleteObjectsInput, optFns ...func(*s3.Options)) (*s3.DeleteObjectsOutput, error) {
+	f.deleteSizes = append(f.deleteSizes, len(params.Delete.Objects))
+	out := &s3.DeleteObjectsOutput{}
+	for _, obj := range params.Delete.Objects {
+		if f.denied[aws.ToString(obj.Key)] {
+			out.Errors = append(out.Errors, types.Error{Key: obj.Key, Code: aws.String("AccessDenied"), Message: aws.String("Access Denied")})
+		}
+	}
+	return out, nil
+}
+
+// fakeSelectStream replays canned S3 Select events.
+type fakeSelectStream struct {
+	events chan types.SelectObjectContentEventStream
+}
+
+func (f *fakeSelectStream) Events() <-chan types.SelectObjectContentEventStream { return f.events }
+func (f *fakeSelectStream) Close() error                                        { return nil }
+func (f *fakeSelectStream) Err() error                                          { return nil }
+
+func TestListObjects(t *testing.T) {
+	modified := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	client := &fakeS3Client{listPages: []*s3.ListObjectsV2Output{
+		{
+			Contents:              []types.Object{{Key: aws.String("exports/a.csv"), Size: aws.Int64(10), LastModified: aws.Time(modified), ETag: aws.String(`"abc"`)}},
+			IsTruncated:           aws.Bool(true),
+			NextContinuationToken: aws.String("token-2"),
+		},
+		{
+			Contents:    []types.Object{{Key: aws.String("exports/b.csv"), Size: aws.Int64(20)}},
+			IsTruncated: aws.Bool(false),
+		},
+	}}
+	source := &Source{Config: Config{Name: "test", Bucket: "default-bucket"}, api: client}
+
+	objects, err := source.ListObjects(context.Background(), "", "exports/")
+	require.NoError(t, err)
+	assert.Equal(t, []ObjectInfo{
+		{Key: "exports/a.csv", Size: 10, LastModified: modified, ETag: `"abc"`},
+		{Key: "exports/b.csv", Size: 20},
+	}, objects)
+	require.Len(t, client.listReqs, 2)
+	assert.Equal(t, "default-bucket", aws.ToString(client.listReqs[0].Bucket))
+	assert.Equal(t, "exports/", aws.ToString(client.listReqs[0].Prefix))
+	assert.Nil(t, client.listReqs[0].ContinuationToken)
+	assert.Equal(t, "token-2", aws.ToString(client.listReqs[1].ContinuationToken))
+}
+
+func TestListObjectsNoBucket(t *testing.T) {
+	source := &Source{Config: Config{Name: "test"}, api: &fakeS3Client{}}
+	_, err := source.ListObjects(context.Background(), "", "")
+	require.Error(t, err)
+}
+
+func TestGetObject(t *testing.T) {
+	client := &fakeS3Client{objects: map[string]string{"default-bucket/results.json": `{"ok":true}`}}
+	source := &Source{Config: Config{Name: "test", Bucket: "default-bucket"}, api: client}
+
+	body, err := source.GetObject(context.Background(), "", "results.json")
+	require.NoError(t, err)
+	defer body.Close()
+	data, err := io.ReadAll(body)
+	require.NoError(t, err)
+	assert.Equal(t, `{"ok":true}`, string(data))
+
+	_, err = source.GetObject(context.Background(), "", "missing.json")
+	var noSuchKey *types.NoSuchKey
+	assert.True(t, errors.As(err, &noSuchKey))
+}
+
+func TestPutObject(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     PutOptions
+		wantErr  bool
+		wantSSE  types.ServerSideEncryption
+		wantKMS  string
+		wantType string
+	}{
+		{name: "plain"},
+		{name: "content type", opts: PutOptions{ContentType: "text/csv"}, wantType: "text/csv"},
+		{name: "sse-s3", opts: PutOptions{ServerSideEncryption: EncryptionSSES3}, wantSSE: types.ServerSideEncryptionAes256},
+		{name: "sse-kms", opts: PutOptions{ServerSideEncryption: EncryptionSSEKMS, KMSKeyID: "alias/results"}, wantSSE: types.ServerSideEncryptionAwsKms, wantKMS: "alias/results"},
+		{name: "invalid encryption", opts: PutOptions{ServerSideEncryption: "rot13"}, wantErr: true},
+		{name: "kms key without sse-kms", opts: PutOptions{ServerSideEncryption: EncryptionSSES3, KMSKeyID: "alias/results"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &fakeS3Client{}
+			source := &Source{Config: Config{Name: "test", Bucket: "default-bucket"}, api: client}
+
+			err := source.PutObject(context.Background(), "other-bucket", "out.csv", strings.NewReader("a,b"), tt.opts)
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Empty(t, client.putReqs)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, client.putReqs, 1)
+			req := client.putReqs[0]
+			assert.Equal(t, "other-bucket", aws.ToString(req.Bucket))
+			assert.Equal(t, "out.csv", aws.ToString(req.Key))
+			assert.Equal(t, "a,b", client.putBodies[0])
+			assert.Equal(t, tt.wantSSE, req.ServerSideEncryption)
+			assert.Equal(t, tt.wantKMS, aws.ToString(req.SSEKMSKeyId))
+			assert.Equal(t, tt.wantType, aws.ToString(req.ContentType))
+		})
+	}
+}
+
+// fakePresigner records the expiry of each presign request.
+type fakePresigner struct {
+	expires []time.Duration
+}
+
+func (f *fakePresigner) PresignGetObject(ctx context.Context, params *s3.GetObjectInput, optFns ...func(*s3.PresignOptions)) (*v4.PresignedHTTPRequest, error) {
+	return f.presign("GET", aws.ToString(params.Bucket), aws.ToString(params.Key), optFns)
+}
+
+func (f *fakePresigner) PresignPutObject(ctx context.Context, params *s3.PutObjectInput, optFns ...func(*s3.PresignOptions)) (*v4.PresignedHTTPRequest, error) {
+	return f.presign("PUT", aws.ToString(params.Bucket), aws.ToString(params.Key), optFns)
+}
+
+func (f *fakePresigner) presign(method, bucket, key string, optFns []func(*s3.PresignOptions)) (*v4.PresignedHTTPRequest, error) {
+	opts := s3.PresignOptions{}
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+	f.expires = append(f.expires, opts.Expires)
+	return &v4.PresignedHTTPRequest{Method: method, URL: "https://" + bucket + ".s3.amazonaws.com/" + key + "?X-Amz-Signature=sig"}, nil
+}
+
+func TestPresign(t *testing.T) {
+	presigner := &fakePresigner{}
+	source := &Source{Config: Config{Name: "test", Bucket: "default-bucket"}, presigner: presigner}
+	ctx := context.Background()
+
+	url, err := source.PresignGetObject(ctx, "", "results.csv", time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, "https://default-bucket.s3.amazonaws.com/results.csv?X-Amz-Signature=sig", url)
+
+	url, err = source.PresignPutObject(ctx, "uploads", "input.csv", MaxPresignExpiry)
+	require.NoError(t, err)
+	assert.Equal(t, "https://uploads.s3.amazonaws.com/input.csv?X-Amz-Signature=sig", url)
+	assert.Equal(t, []time.Duration{time.Hour, MaxPresignExpiry}, presigner.expires)
+
+	_, err = source.PresignGetObject(ctx, "", "results.csv", MaxPresignExpiry+time.Second)
+	require.Error(t, err)
+	_, err = source.PresignPutObject(ctx, "", "input.csv", 0)
+	require.Error(t, err)
+	assert.Len(t, presigner.expires, 2)
+}
+
+func TestSelectObjectContent(t *testing.T) {
+	records := func(payload string) types.SelectObjectContentEventStream {
+		return &types.SelectObjectContentEventStreamMemberRecords{Value: types.RecordsEvent{Payload: []byte(payload)}}
+	}
+	client := &fakeS3Client{events: []types.SelectObjectContentEventStream{
+		records("1\n"),
+		&types.SelectObjectContentEventStreamMemberProgress{},
+		records("7\n"),
+		&types.SelectObjectContentEventStreamMemberStats{},
+		&types.SelectObjectContentEventStreamMemberEnd{},
+	}}
+	source := &Source{Config: Config{Name: "test", Bucket: "default-bucket"}, api: client}
+
+	body, err := source.SelectObjectContent(context.Background(), "", "logs.csv.gz",
+		"SELECT s.id FROM S3Object s WHERE s.status = 'error'",
+		InputFormat{Format: "csv", CSVHeader: true, Compression: "gzip"}, OutputFormat{Format: "CSV"})
+	require.NoError(t, err)
+	data, err := io.ReadAll(body)
+	require.NoError(t, err)
+	require.NoError(t, body.Close())
+	assert.Equal(t, "1\n7\n", string(data))
+
+	req := client.selectReq
+	assert.Equal(t, "default-bucket", aws.ToString(req.Bucket))
+	assert.Equal(t, types.ExpressionTypeSql, req.ExpressionType)
+	assert.Equal(t, types.FileHeaderInfoUse, req.InputSerialization.CSV.FileHeaderInfo)
+	assert.Equal(t, types.CompressionTypeGzip, req.InputSerialization.CompressionType)
+	assert.NotNil(t, req.OutputSerialization.CSV)
+
+	// A stream without an End event was truncated
+	client.events = client.events[:2]
+	body, err = source.SelectObjectContent(context.Background(), "", "logs.csv.gz", "SELECT * FROM S3Object",
+		InputFormat{Format: "CSV"}, OutputFormat{Format: "CSV"})
+	require.NoError(t, err)
+	_, err = io.ReadAll(body)
+	require.Error(t, err)
+}
+
+func TestSelectSerialization(t *testing.T) {
+	in, err := buildInputSerialization(InputFormat{Format: "JSON", JSONLines: true})
+	require.NoError(t, err)
+	assert.Equal(t, types.JSONTypeLines, in.JSON.Type)
+
+	in, err = buildInputSerialization(InputFormat{Format: "PARQUET"})
+	require.NoError(t, err)
+	assert.NotNil(t, in.Parquet)
+
+	_, err = buildInputSerialization(InputFormat{Format: "PARQUET", Compression: "GZIP"})
+	require.Error(t, err)
+	_, err = buildInputSerialization(InputFormat{Format: "XML"})
+	require.Error(t, err)
+	_, err = buildInputSerialization(InputFormat{Format: "CSV", Compression: "zstd"})
+	require.Error(t, err)
+
+	out, err := buildOutputSerialization(OutputFormat{Format: "json"})
+	require.NoError(t, err)
+	assert.Equal(t, "\n", aws.ToString(out.JSON.RecordDelimiter))
+	_, err = buildOutputSerialization(OutputFormat{Format: "PARQUET"})
+	require.Error(t, err)
+}
+
+func TestUploadLargeObject(t *testing.T) {
+	data := strings.Repeat("x", int(2*MinPartSize+100))
+
+	client := &fakeS3Client{}
+	source := &Source{Config: Config{Name: "test", Bucket: "default-bucket"}, api: client}
+	err := source.UploadLargeObject(context.Background(), "", "export.csv", strings.NewReader(data), MinPartSize)
+	require.NoError(t, err)
+	assert.Equal(t, map[int32]int{1: int(MinPartSize), 2: int(MinPartSize), 3: 100}, client.partSizes)
+	require.NotNil(t, client.completed)
+	assert.Equal(t, "upload-1", aws.ToString(client.completed.UploadId))
+	var parts []string
+	for _, part := range client.completed.MultipartUpload.Parts {
+		parts = append(parts, fmt.Sprintf("%d:%s", aws.ToInt32(part.PartNumber), aws.ToString(part.ETag)))
+	}
+	assert.Equal(t, []string{"1:etag-1", "2:etag-2", "3:etag-3"}, parts)
+	assert.False(t, client.aborted)
+
+	// A failed part aborts the upload
+	client = &fakeS3Client{failPart: 2}
+	source.api = client
+	err = source.UploadLargeObject(context.Background(), "", "export.csv", strings.NewReader(data), MinPartSize)
+	require.ErrorContains(t, err, "connection reset")
+	assert.Nil(t, client.completed)
+	assert.True(t, client.aborted)
+
+	// Parts below the S3 minimum are rejected up front
+	err = source.UploadLargeObject(context.Background(), "", "export.csv", strings.NewReader(data), 1024)
+	require.Error(t, err)
+}
+
+func TestHeadObject(t *testing.T) {
+	client := &fakeS3Client{objects: map[string]string{"default-bucket/results.json": `{"ok":true}`}}
+	source := &Source{Config: Config{Name: "test", Bucket: "default-bucket"}, api: client}
+	ctx := context.Background()
+
+	info, found, err := source.HeadObject(ctx, "", "results.json")
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, &ObjectInfo{Key: "results.json", Size: 11, ETag: `"etag"`}, info)
+
+	info, found, err = source.HeadObject(ctx, "", "missing.json")
+	require.NoError(t, err)
+	assert.False(t, found)
+	assert.Nil(t, info)
+
+	// Other errors, such as access denied, are returned
+	client.headErr = errors.New("AccessDenied")
+	_, found, err = source.HeadObject(ctx, "", "results.json")
+	require.Error(t, err)
+	assert.False(t, found)
+}
+
+func TestCopyObject(t *testing.T) {
+	client := &fakeS3Client{}
+	source := &Source{Config: Config{Name: "test", Bucket: "default-bucket"}, api: client}
+
+	err := source.CopyObject(context.Background(), "", "results/q 1+2.csv", "archive", "2025/q1.csv")
+	require.NoError(t, err)
+	assert.Equal(t, "default-bucket/results/q%201+2.csv", aws.ToString(client.copyReq.CopySource))
+	assert.Equal(t, "archive", aws.ToString(client.copyReq.Bucket))
+	assert.Equal(t, "2025/q1.csv", aws.ToString(client.copyReq.Key))
+}
+
+func TestDeleteObjects(t *testing.T) {
+	client := &fakeS3Client{denied: map[string]bool{"key-5": true, "key-1500": true}}
+	source := &Source{Config: Config{Name: "test", Bucket: "default-bucket"}, api: client}
+
+	var keys []string
+	for i := range 2500 {
+		keys = append(keys, fmt.Sprintf("key-%d", i))
+	}
+	err := source.DeleteObjects(context.Background(), "", keys)
+	assert.Equal(t, []int{1000, 1000, 500}, client.deleteSizes)
+
+	var deleteErr *DeleteObjectsError
+	require.ErrorAs(t, err, &deleteErr)
+	assert.Equal(t, []DeleteFailure{
+		{Key: "key-5", Code: "AccessDenied", Message: "Access Denied"},
+		{Key: "key-1500", Code: "AccessDenied", Message: "Access Denied"},
+	}, deleteErr.Failed)
+	assert.Contains(t, err.Error(), "unable to delete 2 objects from default-bucket: key-5: AccessDenied")
+
+	client.denied = nil
+	require.NoError(t, source.DeleteObjects(context.Background(), "", keys[:10]))
+	require.NoError(t, source.DeleteObjects(context.Background(), "", nil))
+}
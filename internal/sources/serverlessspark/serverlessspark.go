@@ -19,11 +19,13 @@ import (
 	"fmt"
 
 	dataproc "cloud.google.com/go/dataproc/v2/apiv1"
+	"cloud.google.com/go/dataproc/v2/apiv1/dataprocpb"
 	longrunning "cloud.google.com/go/longrunning/autogen"
 	"github.com/goccy/go-yaml"
 	"github.com/googleapis/genai-toolbox/internal/sources"
 	"github.com/googleapis/genai-toolbox/internal/util"
 	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/api/iterator"
 	"google.golang.org/api/option"
 )
 
@@ -33,9 +35,7 @@ const SourceKind string = "serverless-spark"
 var _ sources.SourceConfig = Config{}
 
 func init() {
-	if !sources.Register(SourceKind, newConfig) {
-		panic(fmt.Sprintf("source kind %q already registered", SourceKind))
-	}
+	sources.MustRegister(SourceKind, newConfig)
 }
 
 func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources.SourceConfig, error) {
@@ -96,6 +96,18 @@ func (s *Source) ToConfig() sources.SourceConfig {
 	return s.Config
 }
 
+// HealthCheck lists a batch in the configured location.
+func (s *Source) HealthCheck(ctx context.Context) error {
+	_, err := s.Client.ListBatches(ctx, &dataprocpb.ListBatchesRequest{
+		Parent:   fmt.Sprintf("projects/%s/locations/%s", s.Project, s.Location),
+		PageSize: 1,
+	}).Next()
+	if err != nil && err != iterator.Done {
+		return err
+	}
+	return nil
+}
+
 func (s *Source) GetBatchControllerClient() *dataproc.BatchControllerClient {
 	return s.Client
 }
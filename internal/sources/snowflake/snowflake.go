@@ -0,0 +1,245 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package snowflake provides a source implementation for the Snowflake data warehouse.
+//
+// The source authenticates with either a password or key-pair (JWT)
+// authentication. Connection pooling is configurable for different workload
+// requirements.
+package snowflake
+
+import (
+	"context"
+	"crypto/rsa"
+	"crypto/x509"
+	"database/sql"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	sourceutil "github.com/googleapis/genai-toolbox/internal/sources/util"
+	"github.com/snowflakedb/gosnowflake"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const SourceKind string = "snowflake"
+
+// Default configuration constants
+const (
+	DefaultMaxOpenConns    = 25        // Default maximum open connections
+	DefaultMaxIdleConns    = 5         // Default maximum idle connections
+	DefaultConnMaxLifetime = time.Hour // Default connection maximum lifetime
+)
+
+// validate interface
+var _ sources.SourceConfig = Config{}
+
+func init() {
+	sources.MustRegister(SourceKind, newConfig)
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources.SourceConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+
+	password, err := sourceutil.ResolveSecret("password", actual.Password, actual.PasswordFile)
+	if err != nil {
+		return nil, fmt.Errorf("source %q (%s): unable to resolve secret: %w", name, SourceKind, err)
+	}
+	actual.Password = password
+
+	if (actual.Password == "") == (actual.PrivateKeyPath == "") {
+		return nil, fmt.Errorf("source %q (%s): exactly one of password or privateKeyPath is required", name, SourceKind)
+	}
+	if actual.MaxOpenConns < 0 || actual.MaxIdleConns < 0 {
+		return nil, fmt.Errorf("source %q (%s): maxOpenConns and maxIdleConns must not be negative", name, SourceKind)
+	}
+	if _, err := sourceutil.ParseDurationOption("connMaxLifetime", actual.ConnMaxLifetime, DefaultConnMaxLifetime); err != nil {
+		return nil, fmt.Errorf("source %q (%s): %w", name, SourceKind, err)
+	}
+	if _, err := sourceutil.ParseDurationOption("connMaxIdleTime", actual.ConnMaxIdleTime, 0); err != nil {
+		return nil, fmt.Errorf("source %q (%s): %w", name, SourceKind, err)
+	}
+	return actual, nil
+}
+
+type Config struct {
+	Name            string `yaml:"name" validate:"required"`
+	Kind            string `yaml:"kind" validate:"required"`
+	Account         string `yaml:"account" validate:"required"` // Account identifier, e.g., myorg-myaccount
+	User            string `yaml:"user" validate:"required"`
+	Password        string `yaml:"password"`        // Required unless privateKeyPath is set
+	PasswordFile    string `yaml:"passwordFile"`    // Optional: file to read password from
+	PrivateKeyPath  string `yaml:"privateKeyPath"`  // Required unless password is set: unencrypted PEM RSA key for key-pair auth
+	Warehouse       string `yaml:"warehouse"`       // Optional: default warehouse (default: the user's default)
+	Database        string `yaml:"database"`        // Optional: default database
+	Schema          string `yaml:"schema"`          // Optional: default schema
+	Role            string `yaml:"role"`            // Optional: role to use (default: the user's default role)
+	MaxOpenConns    int    `yaml:"maxOpenConns"`    // Optional: max open connections (default 25)
+	MaxIdleConns    int    `yaml:"maxIdleConns"`    // Optional: max idle connections (default 5)
+	ConnMaxLifetime string `yaml:"connMaxLifetime"` // Optional: max time a connection is reused, e.g. "30m" (default 1h)
+	ConnMaxIdleTime string `yaml:"connMaxIdleTime"` // Optional: max time a connection stays idle, e.g. "5m" (default: no limit)
+}
+
+func (r Config) SourceConfigKind() string {
+	return SourceKind
+}
+
+func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.Source, error) {
+	db, err := initSnowflakeConnection(ctx, tracer, r)
+	if err != nil {
+		return nil, fmt.Errorf("source %q (%s): unable to create connection: %w", r.Name, SourceKind, err)
+	}
+
+	err = db.PingContext(ctx)
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("source %q (%s): unable to connect successfully: %w", r.Name, SourceKind, err)
+	}
+
+	s := &Source{
+		Config: r,
+		DB:     db,
+	}
+	return s, nil
+}
+
+var _ sources.Source = &Source{}
+
+type Source struct {
+	Config
+	DB *sql.DB
+}
+
+func (s *Source) SourceKind() string {
+	return SourceKind
+}
+
+func (s *Source) ToConfig() sources.SourceConfig {
+	return s.Config
+}
+
+// HealthCheck pings the database.
+func (s *Source) HealthCheck(ctx context.Context) error {
+	return s.DB.PingContext(ctx)
+}
+
+// SnowflakeDB returns the underlying database connection for direct SQL operations.
+func (s *Source) SnowflakeDB() *sql.DB {
+	return s.DB
+}
+
+// Close closes the database connection and releases resources.
+func (s *Source) Close() error {
+	if s == nil || s.DB == nil {
+		return nil
+	}
+	return s.DB.Close()
+}
+
+func initSnowflakeConnection(ctx context.Context, tracer trace.Tracer, r Config) (*sql.DB, error) {
+	//nolint:all // Reassigned ctx
+	ctx, span := sources.InitConnectionSpan(ctx, tracer, SourceKind, r.Name)
+	defer span.End()
+
+	cfg, err := buildDriverConfig(r)
+	if err != nil {
+		return nil, err
+	}
+	db := sql.OpenDB(gosnowflake.NewConnector(gosnowflake.SnowflakeDriver{}, *cfg))
+
+	// Configure connection pool with defaults
+	maxOpenConns, maxIdleConns := r.MaxOpenConns, r.MaxIdleConns
+	if maxOpenConns == 0 {
+		maxOpenConns = DefaultMaxOpenConns
+	}
+	if maxIdleConns == 0 {
+		maxIdleConns = DefaultMaxIdleConns
+	}
+	db.SetMaxOpenConns(maxOpenConns)
+	db.SetMaxIdleConns(maxIdleConns)
+
+	connMaxLifetime, _ := sourceutil.ParseDurationOption("connMaxLifetime", r.ConnMaxLifetime, DefaultConnMaxLifetime)
+	connMaxIdleTime, _ := sourceutil.ParseDurationOption("connMaxIdleTime", r.ConnMaxIdleTime, 0)
+	db.SetConnMaxLifetime(connMaxLifetime)
+	db.SetConnMaxIdleTime(connMaxIdleTime)
+
+	return db, nil
+}
+
+// buildDriverConfig returns the driver configuration for r, loading the
+// private key when key-pair authentication is used.
+func buildDriverConfig(r Config) (*gosnowflake.Config, error) {
+	cfg := &gosnowflake.Config{
+		Account:   r.Account,
+		User:      r.User,
+		Warehouse: r.Warehouse,
+		Database:  r.Database,
+		Schema:    r.Schema,
+		Role:      r.Role,
+	}
+	if r.PrivateKeyPath == "" {
+		cfg.Password = r.Password
+		return cfg, nil
+	}
+
+	key, err := loadPrivateKey(r.PrivateKeyPath)
+	if err != nil {
+		return nil, err
+	}
+	cfg.Authenticator = gosnowflake.AuthTypeJwt
+	cfg.PrivateKey = key
+	return cfg, nil
+}
+
+// loadPrivateKey reads an unencrypted RSA private key in PKCS #8 ("PRIVATE
+// KEY") or PKCS #1 ("RSA PRIVATE KEY") PEM format.
+func loadPrivateKey(path string) (*rsa.PrivateKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read private key: %w", err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM data found in private key file %q", path)
+	}
+
+	switch block.Type {
+	case "PRIVATE KEY":
+		key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse private key: %w", err)
+		}
+		rsaKey, ok := key.(*rsa.PrivateKey)
+		if !ok {
+			return nil, fmt.Errorf("private key must be an RSA key, got %T", key)
+		}
+		return rsaKey, nil
+	case "RSA PRIVATE KEY":
+		key, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse private key: %w", err)
+		}
+		return key, nil
+	case "ENCRYPTED PRIVATE KEY":
+		return nil, fmt.Errorf("encrypted private keys are not supported, decrypt %q first", path)
+	default:
+		return nil, fmt.Errorf("unsupported PEM block type %q in private key file", block.Type)
+	}
+}
@@ -0,0 +1,199 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snowflake
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/goccy/go-yaml"
+	"github.com/snowflakedb/gosnowflake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFromYamlSnowflake(t *testing.T) {
+	tests := []struct {
+		name        string
+		yamlContent string
+		wantErr     string
+		expected    Config
+	}{
+		{
+			name: "password auth",
+			yamlContent: `name: test-snowflake
+kind: snowflake
+account: myorg-myaccount
+user: analyst
+password: secret
+warehouse: COMPUTE_WH
+database: ANALYTICS
+schema: PUBLIC
+role: ANALYST`,
+			expected: Config{
+				Name:      "test-snowflake",
+				Kind:      "snowflake",
+				Account:   "myorg-myaccount",
+				User:      "analyst",
+				Password:  "secret",
+				Warehouse: "COMPUTE_WH",
+				Database:  "ANALYTICS",
+				Schema:    "PUBLIC",
+				Role:      "ANALYST",
+			},
+		},
+		{
+			name: "key-pair auth with pool settings",
+			yamlContent: `name: test-snowflake
+kind: snowflake
+account: myorg-myaccount
+user: svc_toolbox
+privateKeyPath: /secrets/rsa_key.p8
+maxOpenConns: 10
+maxIdleConns: 2
+connMaxLifetime: 30m
+connMaxIdleTime: 5m`,
+			expected: Config{
+				Name:            "test-snowflake",
+				Kind:            "snowflake",
+				Account:         "myorg-myaccount",
+				User:            "svc_toolbox",
+				PrivateKeyPath:  "/secrets/rsa_key.p8",
+				MaxOpenConns:    10,
+				MaxIdleConns:    2,
+				ConnMaxLifetime: "30m",
+				ConnMaxIdleTime: "5m",
+			},
+		},
+		{
+			name: "password and private key",
+			yamlContent: `name: test-snowflake
+kind: snowflake
+account: myorg-myaccount
+user: analyst
+password: secret
+privateKeyPath: /secrets/rsa_key.p8`,
+			wantErr: "exactly one of password or privateKeyPath is required",
+		},
+		{
+			name: "no credentials",
+			yamlContent: `name: test-snowflake
+kind: snowflake
+account: myorg-myaccount
+user: analyst`,
+			wantErr: "exactly one of password or privateKeyPath is required",
+		},
+		{
+			name: "invalid connMaxLifetime",
+			yamlContent: `name: test-snowflake
+kind: snowflake
+account: myorg-myaccount
+user: analyst
+password: secret
+connMaxLifetime: forever`,
+			wantErr: "invalid connMaxLifetime",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decoder := yaml.NewDecoder(bytes.NewReader([]byte(tt.yamlContent)))
+			config, err := newConfig(context.Background(), "test-snowflake", decoder)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, config)
+		})
+	}
+}
+
+func TestSourceKindSnowflake(t *testing.T) {
+	s := &Source{}
+	assert.Equal(t, SourceKind, s.SourceKind())
+	assert.Nil(t, s.SnowflakeDB())
+	assert.NoError(t, s.Close())
+}
+
+// writeKey writes key to a PEM file with the given block type and returns its path.
+func writeKey(t *testing.T, blockType string, der []byte) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "rsa_key.pem")
+	data := pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der})
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+	return path
+}
+
+func TestBuildDriverConfig(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		path    func(t *testing.T) string
+		wantErr string
+	}{
+		{
+			name: "PKCS #8 key",
+			path: func(t *testing.T) string { return writeKey(t, "PRIVATE KEY", pkcs8) },
+		},
+		{
+			name: "PKCS #1 key",
+			path: func(t *testing.T) string { return writeKey(t, "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(key)) },
+		},
+		{
+			name:    "encrypted key",
+			path:    func(t *testing.T) string { return writeKey(t, "ENCRYPTED PRIVATE KEY", pkcs8) },
+			wantErr: "encrypted private keys are not supported",
+		},
+		{
+			name:    "missing file",
+			path:    func(t *testing.T) string { return filepath.Join(t.TempDir(), "missing.pem") },
+			wantErr: "unable to read private key",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := buildDriverConfig(Config{Account: "myorg-myaccount", User: "svc", PrivateKeyPath: tt.path(t)})
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, gosnowflake.AuthTypeJwt, cfg.Authenticator)
+			assert.True(t, key.Equal(cfg.PrivateKey))
+			assert.Empty(t, cfg.Password)
+		})
+	}
+
+	t.Run("password", func(t *testing.T) {
+		cfg, err := buildDriverConfig(Config{Account: "myorg-myaccount", User: "analyst", Password: "secret", Warehouse: "WH"})
+		require.NoError(t, err)
+		assert.Equal(t, "secret", cfg.Password)
+		assert.Equal(t, "WH", cfg.Warehouse)
+		assert.Nil(t, cfg.PrivateKey)
+	})
+}
@@ -16,8 +16,10 @@ package sources
 
 import (
 	"context"
-
 	"fmt"
+	"maps"
+	"slices"
+	"time"
 
 	"github.com/goccy/go-yaml"
 	"go.opentelemetry.io/otel/attribute"
@@ -30,14 +32,27 @@ type SourceConfigFactory func(ctx context.Context, name string, decoder *yaml.De
 var sourceRegistry = make(map[string]SourceConfigFactory)
 
 // Register registers a new source kind with its factory.
-// It returns false if the kind is already registered.
-func Register(kind string, factory SourceConfigFactory) bool {
+// It returns an error if the kind is already registered.
+func Register(kind string, factory SourceConfigFactory) error {
 	if _, exists := sourceRegistry[kind]; exists {
 		// Source with this kind already exists, do not overwrite.
-		return false
+		return fmt.Errorf("source kind %q already registered", kind)
 	}
 	sourceRegistry[kind] = factory
-	return true
+	return nil
+}
+
+// MustRegister is like Register but panics if the kind is already registered.
+// It is intended for use in init functions.
+func MustRegister(kind string, factory SourceConfigFactory) {
+	if err := Register(kind, factory); err != nil {
+		panic(err)
+	}
+}
+
+// RegisteredKinds returns the registered source kinds in sorted order.
+func RegisteredKinds() []string {
+	return slices.Sorted(maps.Keys(sourceRegistry))
 }
 
 // DecodeConfig decodes a source configuration using the registered factory for the given kind.
@@ -65,6 +80,22 @@ type Source interface {
 	ToConfig() SourceConfig
 }
 
+// Pingable is implemented by sources that can re-check their connection after
+// Initialize, e.g. for readiness and liveness probes.
+type Pingable interface {
+	HealthCheck(ctx context.Context) error
+}
+
+// DefaultCloseTimeout bounds Close on sources that implement ContextCloser.
+const DefaultCloseTimeout = 5 * time.Second
+
+// ContextCloser is implemented by sources that do network I/O when closing,
+// e.g. signing out or cancelling server-side jobs. CloseWithContext returns
+// once ctx is done, so callers can bound shutdown.
+type ContextCloser interface {
+	CloseWithContext(ctx context.Context) error
+}
+
 // InitConnectionSpan adds a span for database pool connection initialization
 func InitConnectionSpan(ctx context.Context, tracer trace.Tracer, sourceKind, sourceName string) (context.Context, trace.Span) {
 	ctx, span := tracer.Start(
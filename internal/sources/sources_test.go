@@ -0,0 +1,64 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sources_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+)
+
+func TestRegisteredKinds(t *testing.T) {
+	factory := func(context.Context, string, *yaml.Decoder) (sources.SourceConfig, error) {
+		return nil, nil
+	}
+	for _, kind := range []string{"zz-test-kind", "aa-test-kind"} {
+		if err := sources.Register(kind, factory); err != nil {
+			t.Fatalf("unable to register %q: %s", kind, err)
+		}
+	}
+
+	got := sources.RegisteredKinds()
+	if !slices.IsSorted(got) {
+		t.Errorf("RegisteredKinds() = %v, want sorted kinds", got)
+	}
+	for _, kind := range []string{"aa-test-kind", "zz-test-kind"} {
+		if !slices.Contains(got, kind) {
+			t.Errorf("RegisteredKinds() = %v, missing %q", got, kind)
+		}
+	}
+}
+
+func TestRegisterDuplicateKind(t *testing.T) {
+	factory := func(context.Context, string, *yaml.Decoder) (sources.SourceConfig, error) {
+		return nil, nil
+	}
+	if err := sources.Register("duplicate-test-kind", factory); err != nil {
+		t.Fatalf("unable to register kind: %s", err)
+	}
+	if err := sources.Register("duplicate-test-kind", factory); err == nil {
+		t.Fatal("expected error registering a duplicate kind")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected MustRegister to panic on a duplicate kind")
+		}
+	}()
+	sources.MustRegister("duplicate-test-kind", factory)
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/googleapis/genai-toolbox/internal/sources"
 	"github.com/googleapis/genai-toolbox/internal/util"
 	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/api/iterator"
 )
 
 const SourceKind string = "spanner"
@@ -31,9 +32,7 @@ const SourceKind string = "spanner"
 var _ sources.SourceConfig = Config{}
 
 func init() {
-	if !sources.Register(SourceKind, newConfig) {
-		panic(fmt.Sprintf("source kind %q already registered", SourceKind))
-	}
+	sources.MustRegister(SourceKind, newConfig)
 }
 
 func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources.SourceConfig, error) {
@@ -85,6 +84,17 @@ func (s *Source) ToConfig() sources.SourceConfig {
 	return s.Config
 }
 
+// HealthCheck runs SELECT 1 in a single-use read-only transaction.
+func (s *Source) HealthCheck(ctx context.Context) error {
+	iter := s.Client.Single().Query(ctx, spanner.Statement{SQL: "SELECT 1"})
+	defer iter.Stop()
+	_, err := iter.Next()
+	if err != nil && err != iterator.Done {
+		return err
+	}
+	return nil
+}
+
 func (s *Source) SpannerClient() *spanner.Client {
 	return s.Client
 }
@@ -27,12 +27,14 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/goccy/go-yaml"
 	"github.com/googleapis/genai-toolbox/internal/sources"
+	sourceutil "github.com/googleapis/genai-toolbox/internal/sources/util"
 	"github.com/googleapis/genai-toolbox/internal/util"
 	"go.opentelemetry.io/otel/trace"
 )
@@ -41,19 +43,19 @@ const SourceKind string = "splunk"
 
 // Default configuration constants
 const (
-	DefaultPort         = 8089   // Default Splunk management port
-	DefaultHECPort      = 8088   // Default HTTP Event Collector port
-	DefaultScheme       = "https" // Default connection scheme
-	DefaultTimeout      = "120s"  // Default client timeout
+	DefaultPort    = 8089    // Default Splunk management port
+	DefaultHECPort = 8088    // Default HTTP Event Collector port
+	DefaultScheme  = "https" // Default connection scheme
+	DefaultTimeout = "120s"  // Default client timeout
+
+	MaxConcurrentJobDeletes = 8 // Search jobs cancelled in parallel on close
 )
 
 // validate interface
 var _ sources.SourceConfig = Config{}
 
 func init() {
-	if !sources.Register(SourceKind, newConfig) {
-		panic(fmt.Sprintf("source kind %q already registered", SourceKind))
-	}
+	sources.MustRegister(SourceKind, newConfig)
 }
 
 func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources.SourceConfig, error) {
@@ -67,24 +69,73 @@ func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources
 	if err := decoder.DecodeContext(ctx, &actual); err != nil {
 		return nil, err
 	}
+
+	for _, secret := range []struct {
+		field string
+		value *string
+		file  string
+	}{
+		{"token", &actual.Token, actual.TokenFile},
+		{"password", &actual.Password, actual.PasswordFile},
+		{"hecToken", &actual.HECToken, actual.HECTokenFile},
+	} {
+		resolved, err := sourceutil.ResolveSecret(secret.field, *secret.value, secret.file)
+		if err != nil {
+			return nil, fmt.Errorf("source %q (%s): unable to resolve secret: %w", name, SourceKind, err)
+		}
+		*secret.value = resolved
+	}
+
+	if err := actual.validateAuth(); err != nil {
+		return nil, fmt.Errorf("source %q (%s): %w", name, SourceKind, err)
+	}
+	if actual.TLSCAFile != "" && actual.DisableSslVerification {
+		return nil, fmt.Errorf("source %q (%s): tlsCAFile and disableSslVerification cannot both be set", name, SourceKind)
+	}
+	if err := sourceutil.ValidateRateLimit(actual.RequestsPerSecond, actual.Burst); err != nil {
+		return nil, fmt.Errorf("source %q (%s): %w", name, SourceKind, err)
+	}
 	return actual, nil
 }
 
 // Config represents the configuration for a Splunk source.
 // It supports both token-based and username/password authentication.
 type Config struct {
-	Name                   string `yaml:"name" validate:"required"`
-	Kind                   string `yaml:"kind" validate:"required"`
-	Host                   string `yaml:"host" validate:"required"`
-	Port                   int    `yaml:"port"`
-	HECPort                int    `yaml:"hecPort"`
-	Scheme                 string `yaml:"scheme"`
-	Token                  string `yaml:"token"`
-	Username               string `yaml:"username"`
-	Password               string `yaml:"password"`
-	HECToken               string `yaml:"hecToken"`
-	Timeout                string `yaml:"timeout"`
-	DisableSslVerification bool   `yaml:"disableSslVerification"`
+	Name                   string  `yaml:"name" validate:"required"`
+	Kind                   string  `yaml:"kind" validate:"required"`
+	Host                   string  `yaml:"host" validate:"required"`
+	Port                   int     `yaml:"port"`
+	HECPort                int     `yaml:"hecPort"`
+	Scheme                 string  `yaml:"scheme"`
+	Token                  string  `yaml:"token"`
+	TokenFile              string  `yaml:"tokenFile"`
+	Username               string  `yaml:"username"`
+	Password               string  `yaml:"password"`
+	PasswordFile           string  `yaml:"passwordFile"`
+	HECToken               string  `yaml:"hecToken"`
+	HECTokenFile           string  `yaml:"hecTokenFile"`
+	Timeout                string  `yaml:"timeout"`
+	DisableSslVerification bool    `yaml:"disableSslVerification"`
+	TLSCAFile              string  `yaml:"tlsCAFile"`
+	RequestsPerSecond      float64 `yaml:"requestsPerSecond"` // Optional: client-side rate limit, unlimited if unset
+	Burst                  int     `yaml:"burst"`             // Optional: requests sent at once before the rate applies, default 1
+}
+
+// validateAuth checks that exactly one of token or username/password
+// authentication is configured.
+func (c Config) validateAuth() error {
+	hasBasic := c.Username != "" || c.Password != ""
+	switch {
+	case c.Token != "" && hasBasic:
+		return fmt.Errorf("token and username/password cannot both be set")
+	case c.Token != "":
+		return nil
+	case !hasBasic:
+		return fmt.Errorf("requires either token or username/password authentication")
+	case c.Username == "" || c.Password == "":
+		return fmt.Errorf("username and password must both be set")
+	}
+	return nil
 }
 
 func (c Config) SourceConfigKind() string {
@@ -118,6 +169,13 @@ func (c Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.So
 
 	// Configure HTTP transport
 	tr := &http.Transport{}
+	if c.TLSCAFile != "" {
+		tlsConfig, err := sourceutil.LoadTLSConfig(c.TLSCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("source %q (%s): unable to load TLS config: %w", c.Name, SourceKind, err)
+		}
+		tr.TLSClientConfig = tlsConfig
+	}
 	if c.DisableSslVerification {
 		tr.TLSClientConfig = &tls.Config{
 			InsecureSkipVerify: true,
@@ -127,7 +185,7 @@ func (c Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.So
 
 	client := &http.Client{
 		Timeout:   duration,
-		Transport: tr,
+		Transport: sourceutil.NewRateLimitedTransport(tr, c.RequestsPerSecond, c.Burst),
 	}
 
 	// Build base URLs
@@ -185,13 +243,13 @@ func (s *Source) authenticate(ctx context.Context) (string, error) {
 
 	resp, err := s.Client.Do(req)
 	if err != nil {
-		return "", fmt.Errorf("authentication request failed: %w", err)
+		return "", fmt.Errorf("authentication request failed: %w", sources.ClassifyError(err))
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return "", fmt.Errorf("authentication failed with status %d: %s", resp.StatusCode, string(body))
+		return "", sources.NewStatusError(resp.StatusCode, "authentication failed with status %d: %s", resp.StatusCode, string(body))
 	}
 
 	var authResp struct {
@@ -211,11 +269,31 @@ func (s *Source) authenticate(ctx context.Context) (string, error) {
 
 // testConnection verifies the connection to Splunk by making a simple API call.
 func (s *Source) testConnection(ctx context.Context) error {
-	testURL := fmt.Sprintf("%s/services/server/info?output_mode=json", s.baseURL)
+	if _, err := s.GetServerInfo(ctx); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ServerInfo describes the Splunk server the source is connected to.
+type ServerInfo struct {
+	Version      string   // e.g. 9.1.2
+	Build        string   // Build hash of the version
+	ServerName   string   // Name of the server instance
+	ProductType  string   // e.g. enterprise, cloud
+	LicenseState string   // e.g. OK, EXPIRED
+	ServerRoles  []string // e.g. indexer, search_head, license_master
+	GUID         string   // Unique ID of the server instance
+}
+
+// GetServerInfo returns the version, name, license state, and roles of the
+// Splunk server, from /services/server/info.
+func (s *Source) GetServerInfo(ctx context.Context) (*ServerInfo, error) {
+	infoURL := fmt.Sprintf("%s/services/server/info?output_mode=json", s.baseURL)
 
-	req, err := http.NewRequestWithContext(ctx, "GET", testURL, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", infoURL, nil)
 	if err != nil {
-		return fmt.Errorf("failed to create test request: %w", err)
+		return nil, fmt.Errorf("failed to create server info request: %w", err)
 	}
 
 	// Add authentication header
@@ -223,16 +301,45 @@ func (s *Source) testConnection(ctx context.Context) error {
 
 	resp, err := s.Client.Do(req)
 	if err != nil {
-		return fmt.Errorf("test request failed: %w", err)
+		return nil, fmt.Errorf("server info request failed: %w", sources.ClassifyError(err))
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("connection test failed with status %d: %s", resp.StatusCode, string(body))
-	}
-
-	return nil
+		return nil, sources.NewStatusError(resp.StatusCode, "failed to get server info with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var infoResp struct {
+		Entry []struct {
+			Content struct {
+				Version      string   `json:"version"`
+				Build        string   `json:"build"`
+				ServerName   string   `json:"serverName"`
+				ProductType  string   `json:"product_type"`
+				LicenseState string   `json:"licenseState"`
+				ServerRoles  []string `json:"server_roles"`
+				GUID         string   `json:"guid"`
+			} `json:"content"`
+		} `json:"entry"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&infoResp); err != nil {
+		return nil, fmt.Errorf("failed to decode server info: %w", err)
+	}
+	if len(infoResp.Entry) == 0 {
+		return nil, fmt.Errorf("no server info returned")
+	}
+
+	content := infoResp.Entry[0].Content
+	return &ServerInfo{
+		Version:      content.Version,
+		Build:        content.Build,
+		ServerName:   content.ServerName,
+		ProductType:  content.ProductType,
+		LicenseState: content.LicenseState,
+		ServerRoles:  content.ServerRoles,
+		GUID:         content.GUID,
+	}, nil
 }
 
 // SourceKind returns the kind string for this source.
@@ -245,6 +352,11 @@ func (s *Source) ToConfig() sources.SourceConfig {
 	return s.Config
 }
 
+// HealthCheck requests the Splunk server info.
+func (s *Source) HealthCheck(ctx context.Context) error {
+	return s.testConnection(ctx)
+}
+
 // SplunkClient returns the underlying HTTP client for direct API access.
 func (s *Source) SplunkClient() *http.Client {
 	return s.Client
@@ -265,25 +377,46 @@ func (s *Source) AuthToken() string {
 	return s.authToken
 }
 
-// Close releases resources and closes HTTP client connections.
+// Close calls CloseWithContext with a sources.DefaultCloseTimeout deadline.
 func (s *Source) Close() error {
+	ctx, cancel := context.WithTimeout(context.Background(), sources.DefaultCloseTimeout)
+	defer cancel()
+	return s.CloseWithContext(ctx)
+}
+
+// CloseWithContext cancels active search jobs and closes idle HTTP client
+// connections. Jobs still active when ctx is done are left for Splunk to
+// expire, and ctx's error is returned.
+func (s *Source) CloseWithContext(ctx context.Context) error {
 	if s == nil || s.Client == nil {
 		return nil
 	}
-	// Cancel all active search jobs
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, MaxConcurrentJobDeletes)
 	s.activeJobs.Range(func(key, value interface{}) bool {
-		if sid, ok := key.(string); ok {
-			_ = s.DeleteSearchJob(context.Background(), sid)
+		sid, ok := key.(string)
+		if !ok {
+			return true
+		}
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			return false
 		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			// Best effort: Splunk expires jobs that aren't deleted
+			_ = s.DeleteSearchJob(ctx, sid)
+		}()
 		return true
 	})
+	wg.Wait()
 
-	if s.Client != nil {
-		if transport, ok := s.Client.Transport.(*http.Transport); ok {
-			transport.CloseIdleConnections()
-		}
-	}
-	return nil
+	s.Client.CloseIdleConnections()
+	return ctx.Err()
 }
 
 // SearchJob represents a Splunk search job.
@@ -321,13 +454,13 @@ func (s *Source) CreateSearchJob(ctx context.Context, search string, params map[
 
 	resp, err := s.Client.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("search job request failed: %w", err)
+		return nil, fmt.Errorf("search job request failed: %w", sources.ClassifyError(err))
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusCreated {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("failed to create search job with status %d: %s", resp.StatusCode, string(body))
+		return nil, sources.NewStatusError(resp.StatusCode, "failed to create search job with status %d: %s", resp.StatusCode, string(body))
 	}
 
 	var jobResp SearchJobResponse
@@ -368,13 +501,13 @@ func (s *Source) GetSearchJobStatus(ctx context.Context, sid string) (*SearchJob
 
 	resp, err := s.Client.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("status request failed: %w", err)
+		return nil, fmt.Errorf("status request failed: %w", sources.ClassifyError(err))
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("failed to get job status with status %d: %s", resp.StatusCode, string(body))
+		return nil, sources.NewStatusError(resp.StatusCode, "failed to get job status with status %d: %s", resp.StatusCode, string(body))
 	}
 
 	var status SearchJobStatus
@@ -385,10 +518,17 @@ func (s *Source) GetSearchJobStatus(ctx context.Context, sid string) (*SearchJob
 	return &status, nil
 }
 
-// GetSearchResults retrieves the results of a completed search job.
-func (s *Source) GetSearchResults(ctx context.Context, sid string, offset int, count int) ([]byte, error) {
-	resultsURL := fmt.Sprintf("%s/services/search/jobs/%s/results?output_mode=json&offset=%d&count=%d",
-		s.baseURL, sid, offset, count)
+// GetSearchResults retrieves the results of a completed search job. If
+// fieldList is not empty, Splunk returns only the listed fields of each result.
+func (s *Source) GetSearchResults(ctx context.Context, sid string, offset int, count int, fieldList []string) ([]byte, error) {
+	params := url.Values{}
+	params.Set("output_mode", "json")
+	params.Set("offset", strconv.Itoa(offset))
+	params.Set("count", strconv.Itoa(count))
+	for _, field := range fieldList {
+		params.Add("f", field)
+	}
+	resultsURL := fmt.Sprintf("%s/services/search/jobs/%s/results?%s", s.baseURL, sid, params.Encode())
 
 	req, err := http.NewRequestWithContext(ctx, "GET", resultsURL, nil)
 	if err != nil {
@@ -399,13 +539,13 @@ func (s *Source) GetSearchResults(ctx context.Context, sid string, offset int, c
 
 	resp, err := s.Client.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("results request failed: %w", err)
+		return nil, fmt.Errorf("results request failed: %w", sources.ClassifyError(err))
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("failed to get results with status %d: %s", resp.StatusCode, string(body))
+		return nil, sources.NewStatusError(resp.StatusCode, "failed to get results with status %d: %s", resp.StatusCode, string(body))
 	}
 
 	results, err := io.ReadAll(resp.Body)
@@ -416,6 +556,98 @@ func (s *Source) GetSearchResults(ctx context.Context, sid string, offset int, c
 	return results, nil
 }
 
+// SearchResults is a decoded page of search job results.
+type SearchResults struct {
+	Fields           []string            // Field names, in the order Splunk lists them
+	Results          []map[string]string // One map of field name to value per result
+	Preview          bool                // Whether the results are a preview of an unfinished job
+	InitOffset       int                 // Offset of the first result in the page
+	PostProcessCount int                 // Number of results after any post-process search
+	Messages         []SearchMessage     // Informational or error messages from the search
+}
+
+// SearchMessage is a message returned with search results.
+type SearchMessage struct {
+	Type string `json:"type"` // e.g. INFO, WARN, ERROR
+	Text string `json:"text"`
+}
+
+// GetSearchResultsParsed retrieves the results of a completed search job and
+// decodes them with ParseSearchResults. If fieldList is not empty, only the
+// listed fields are returned.
+func (s *Source) GetSearchResultsParsed(ctx context.Context, sid string, offset int, count int, fieldList []string) (*SearchResults, error) {
+	data, err := s.GetSearchResults(ctx, sid, offset, count, fieldList)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSearchResults(data)
+}
+
+// ParseSearchResults decodes the JSON envelope returned by GetSearchResults.
+// Values of multivalue fields are joined with newlines, as Splunk displays them.
+func ParseSearchResults(data []byte) (*SearchResults, error) {
+	var envelope struct {
+		Preview          bool                         `json:"preview"`
+		InitOffset       int                          `json:"init_offset"`
+		PostProcessCount int                          `json:"post_process_count"`
+		Messages         []SearchMessage              `json:"messages"`
+		Fields           []json.RawMessage            `json:"fields"`
+		Results          []map[string]json.RawMessage `json:"results"`
+	}
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		return nil, fmt.Errorf("failed to decode search results: %w", err)
+	}
+
+	parsed := &SearchResults{
+		Fields:           make([]string, 0, len(envelope.Fields)),
+		Results:          make([]map[string]string, 0, len(envelope.Results)),
+		Preview:          envelope.Preview,
+		InitOffset:       envelope.InitOffset,
+		PostProcessCount: envelope.PostProcessCount,
+		Messages:         envelope.Messages,
+	}
+
+	// Fields are objects such as {"name": "host"}, or plain names in older versions
+	for _, raw := range envelope.Fields {
+		var field struct {
+			Name string `json:"name"`
+		}
+		if err := json.Unmarshal(raw, &field); err != nil {
+			if err := json.Unmarshal(raw, &field.Name); err != nil {
+				return nil, fmt.Errorf("failed to decode search result field %s: %w", raw, err)
+			}
+		}
+		parsed.Fields = append(parsed.Fields, field.Name)
+	}
+
+	for i, result := range envelope.Results {
+		row := make(map[string]string, len(result))
+		for name, raw := range result {
+			value, err := searchResultValue(raw)
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode field %q of result %d: %w", name, i, err)
+			}
+			row[name] = value
+		}
+		parsed.Results = append(parsed.Results, row)
+	}
+	return parsed, nil
+}
+
+// searchResultValue decodes a result value, which is a string or, for
+// multivalue fields, an array of strings.
+func searchResultValue(raw json.RawMessage) (string, error) {
+	var value string
+	if err := json.Unmarshal(raw, &value); err == nil {
+		return value, nil
+	}
+	var values []string
+	if err := json.Unmarshal(raw, &values); err != nil {
+		return "", err
+	}
+	return strings.Join(values, "\n"), nil
+}
+
 // DeleteSearchJob deletes a search job.
 func (s *Source) DeleteSearchJob(ctx context.Context, sid string) error {
 	deleteURL := fmt.Sprintf("%s/services/search/jobs/%s", s.baseURL, sid)
@@ -429,13 +661,13 @@ func (s *Source) DeleteSearchJob(ctx context.Context, sid string) error {
 
 	resp, err := s.Client.Do(req)
 	if err != nil {
-		return fmt.Errorf("delete request failed: %w", err)
+		return fmt.Errorf("delete request failed: %w", sources.ClassifyError(err))
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("failed to delete job with status %d: %s", resp.StatusCode, string(body))
+		return sources.NewStatusError(resp.StatusCode, "failed to delete job with status %d: %s", resp.StatusCode, string(body))
 	}
 
 	s.activeJobs.Delete(sid)
@@ -478,13 +710,13 @@ func (s *Source) SendHECEvent(ctx context.Context, event *HECEvent) error {
 
 	resp, err := s.Client.Do(req)
 	if err != nil {
-		return fmt.Errorf("HEC request failed: %w", err)
+		return fmt.Errorf("HEC request failed: %w", sources.ClassifyError(err))
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("HEC request failed with status %d: %s", resp.StatusCode, string(body))
+		return sources.NewStatusError(resp.StatusCode, "HEC request failed with status %d: %s", resp.StatusCode, string(body))
 	}
 
 	return nil
@@ -517,13 +749,13 @@ func (s *Source) SendHECRawEvent(ctx context.Context, event string, params map[s
 
 	resp, err := s.Client.Do(req)
 	if err != nil {
-		return fmt.Errorf("HEC raw request failed: %w", err)
+		return fmt.Errorf("HEC raw request failed: %w", sources.ClassifyError(err))
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("HEC raw request failed with status %d: %s", resp.StatusCode, string(body))
+		return sources.NewStatusError(resp.StatusCode, "HEC raw request failed with status %d: %s", resp.StatusCode, string(body))
 	}
 
 	return nil
@@ -15,6 +15,14 @@
 package splunk_test
 
 import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
 	"testing"
 
 	yaml "github.com/goccy/go-yaml"
@@ -23,6 +31,7 @@ import (
 	"github.com/googleapis/genai-toolbox/internal/sources"
 	"github.com/googleapis/genai-toolbox/internal/sources/splunk"
 	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"go.opentelemetry.io/otel/trace/noop"
 )
 
 func TestParseFromYamlSplunk(t *testing.T) {
@@ -309,6 +318,53 @@ func TestConfigValidation(t *testing.T) {
 			`,
 			wantErr: true,
 		},
+		{
+			desc: "token and username/password both set",
+			yamlStr: `
+			sources:
+				test:
+					kind: splunk
+					host: localhost
+					token: test-token
+					username: admin
+					password: password
+			`,
+			wantErr: true,
+		},
+		{
+			desc: "no authentication",
+			yamlStr: `
+			sources:
+				test:
+					kind: splunk
+					host: localhost
+			`,
+			wantErr: true,
+		},
+		{
+			desc: "username without password",
+			yamlStr: `
+			sources:
+				test:
+					kind: splunk
+					host: localhost
+					username: admin
+			`,
+			wantErr: true,
+		},
+		{
+			desc: "tlsCAFile with disabled SSL verification",
+			yamlStr: `
+			sources:
+				test:
+					kind: splunk
+					host: localhost
+					token: test-token
+					tlsCAFile: /etc/ssl/certs/internal-ca.pem
+					disableSslVerification: true
+			`,
+			wantErr: true,
+		},
 	}
 
 	for _, tc := range tcs {
@@ -485,6 +541,63 @@ func TestHECConfiguration(t *testing.T) {
 	}
 }
 
+// TestSecretFiles tests reading secrets from files
+func TestSecretFiles(t *testing.T) {
+	secretFile := filepath.Join(t.TempDir(), "hec-token")
+	if err := os.WriteFile(secretFile, []byte("hec-from-file\n"), 0o600); err != nil {
+		t.Fatalf("unable to write secret file: %v", err)
+	}
+	in := fmt.Sprintf(`
+	sources:
+		splunk-secrets:
+			kind: splunk
+			host: splunk.example.com
+			username: admin
+			password: file:/not/a/path
+			hecTokenFile: %s
+	`, secretFile)
+
+	got := struct {
+		Sources server.SourceConfigs `yaml:"sources"`
+	}{}
+
+	err := yaml.Unmarshal(testutils.FormatYaml(in), &got)
+	if err != nil {
+		t.Fatalf("failed to unmarshal: %v", err)
+	}
+
+	config, ok := got.Sources["splunk-secrets"].(splunk.Config)
+	if !ok {
+		t.Fatal("failed to cast to splunk.Config")
+	}
+
+	if config.Password != "file:/not/a/path" {
+		t.Errorf("Password = %q, want the literal value \"file:/not/a/path\"", config.Password)
+	}
+	if config.HECToken != "hec-from-file" {
+		t.Errorf("HECToken = %q, want \"hec-from-file\"", config.HECToken)
+	}
+}
+
+func TestSecretFileMissing(t *testing.T) {
+	in := `
+	sources:
+		splunk-secrets:
+			kind: splunk
+			host: splunk.example.com
+			tokenFile: /nonexistent/splunk-token
+	`
+
+	got := struct {
+		Sources server.SourceConfigs `yaml:"sources"`
+	}{}
+
+	err := yaml.Unmarshal(testutils.FormatYaml(in), &got)
+	if err == nil {
+		t.Fatal("expected error for missing secret file")
+	}
+}
+
 // TestSchemeVariations tests different scheme configurations
 func TestSchemeVariations(t *testing.T) {
 	tcs := []struct {
@@ -639,3 +752,119 @@ func TestPortConfiguration(t *testing.T) {
 		})
 	}
 }
+
+func TestParseSearchResults(t *testing.T) {
+	data := []byte(`{
+		"preview": false,
+		"init_offset": 10,
+		"post_process_count": 2,
+		"messages": [{"type": "INFO", "text": "Your timerange was substituted"}],
+		"fields": [{"name": "_time"}, {"name": "host"}, "status"],
+		"results": [
+			{"_time": "2025-01-02T03:04:05.000+00:00", "host": "web-1", "status": "500"},
+			{"_time": "2025-01-02T03:04:06.000+00:00", "host": ["web-1", "web-2"]}
+		]
+	}`)
+
+	got, err := splunk.ParseSearchResults(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := &splunk.SearchResults{
+		Fields: []string{"_time", "host", "status"},
+		Results: []map[string]string{
+			{"_time": "2025-01-02T03:04:05.000+00:00", "host": "web-1", "status": "500"},
+			{"_time": "2025-01-02T03:04:06.000+00:00", "host": "web-1\nweb-2"},
+		},
+		InitOffset:       10,
+		PostProcessCount: 2,
+		Messages:         []splunk.SearchMessage{{Type: "INFO", Text: "Your timerange was substituted"}},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("incorrect parse: diff %v", diff)
+	}
+
+	if _, err := splunk.ParseSearchResults([]byte(`{"results": [{"count": 3}]}`)); err == nil {
+		t.Fatalf("expected an error for a non-string value")
+	}
+}
+
+// newTestSource initializes a source connected to a test server running
+// handler.
+func newTestSource(t *testing.T, handler http.HandlerFunc) *splunk.Source {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	u, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatalf("unable to parse server URL: %s", err)
+	}
+	port, err := strconv.Atoi(u.Port())
+	if err != nil {
+		t.Fatalf("unable to parse server port: %s", err)
+	}
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unable to create context: %s", err)
+	}
+	cfg := splunk.Config{Name: "my-splunk", Kind: splunk.SourceKind, Host: u.Hostname(), Port: port, Scheme: "http", Token: "token", Timeout: "5s"}
+	src, err := cfg.Initialize(ctx, noop.NewTracerProvider().Tracer("test"))
+	if err != nil {
+		t.Fatalf("unable to initialize source: %s", err)
+	}
+	return src.(*splunk.Source)
+}
+
+func TestGetSearchResultsFieldList(t *testing.T) {
+	var gotQuery url.Values
+	src := newTestSource(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/services/search/jobs/1234.5/results" {
+			gotQuery = r.URL.Query()
+			fmt.Fprint(w, `{"messages": [{"type": "WARN", "text": "Results truncated"}], "fields": ["host"], "results": [{"host": "web-1"}]}`)
+			return
+		}
+		fmt.Fprint(w, `{"entry": [{"content": {}}]}`)
+	})
+
+	got, err := src.GetSearchResultsParsed(context.Background(), "1234.5", 0, 10, []string{"host", "_time"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if diff := cmp.Diff([]string{"host", "_time"}, gotQuery["f"]); diff != "" {
+		t.Fatalf("incorrect field list: diff %v", diff)
+	}
+	want := []splunk.SearchMessage{{Type: "WARN", Text: "Results truncated"}}
+	if diff := cmp.Diff(want, got.Messages); diff != "" {
+		t.Fatalf("incorrect messages: diff %v", diff)
+	}
+}
+
+func TestGetServerInfo(t *testing.T) {
+	src := newTestSource(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/services/server/info" || r.Header.Get("Authorization") != "Splunk token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprint(w, `{"entry": [{"name": "server-info", "content": {
+			"version": "9.1.2", "build": "b6b9c8185839", "serverName": "splunk-01", "product_type": "enterprise",
+			"licenseState": "OK", "server_roles": ["indexer", "search_head"], "guid": "A1B2"}}]}`)
+	})
+
+	got, err := src.GetServerInfo(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := &splunk.ServerInfo{
+		Version:      "9.1.2",
+		Build:        "b6b9c8185839",
+		ServerName:   "splunk-01",
+		ProductType:  "enterprise",
+		LicenseState: "OK",
+		ServerRoles:  []string{"indexer", "search_head"},
+		GUID:         "A1B2",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("incorrect server info: diff %v", diff)
+	}
+}
@@ -0,0 +1,261 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tableau
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/googleapis/genai-toolbox/internal/sources"
+)
+
+// MaxPageSize is the largest page the Tableau REST API returns.
+const MaxPageSize = 1000
+
+// Project is a project of the signed-in site.
+type Project struct {
+	ID                 string
+	Name               string
+	Description        string
+	ParentProjectID    string // Empty for top-level projects
+	ContentPermissions string // e.g. ManagedByOwner, LockedToProject
+	OwnerID            string
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+}
+
+// User is a user of the signed-in site.
+type User struct {
+	ID          string
+	Name        string
+	FullName    string
+	Email       string
+	SiteRole    string // e.g. Creator, Explorer, Viewer, Unlicensed
+	AuthSetting string
+	LastLogin   time.Time // Zero if the user has never signed in
+}
+
+// Group is a group of the signed-in site.
+type Group struct {
+	ID              string
+	Name            string
+	Domain          string // "local" for groups not imported from Active Directory
+	MinimumSiteRole string // Site role granted to members on sign-in, if any
+}
+
+// pagination is the page information of a list response.
+type pagination struct {
+	PageNumber     string `json:"pageNumber"`
+	PageSize       string `json:"pageSize"`
+	TotalAvailable string `json:"totalAvailable"`
+}
+
+// ListProjects returns every project of the signed-in site.
+func (c *TableauClient) ListProjects(ctx context.Context) ([]Project, error) {
+	projects := []Project{}
+	err := c.listPages(ctx, "projects", func(body []byte) (int, pagination, error) {
+		var resp struct {
+			Pagination pagination `json:"pagination"`
+			Projects   struct {
+				Project []struct {
+					ID                 string    `json:"id"`
+					Name               string    `json:"name"`
+					Description        string    `json:"description"`
+					ParentProjectID    string    `json:"parentProjectId"`
+					ContentPermissions string    `json:"contentPermissions"`
+					CreatedAt          time.Time `json:"createdAt"`
+					UpdatedAt          time.Time `json:"updatedAt"`
+					Owner              struct {
+						ID string `json:"id"`
+					} `json:"owner"`
+				} `json:"project"`
+			} `json:"projects"`
+		}
+		if err := json.Unmarshal(body, &resp); err != nil {
+			return 0, pagination{}, fmt.Errorf("failed to parse projects: %w", err)
+		}
+		for _, p := range resp.Projects.Project {
+			projects = append(projects, Project{
+				ID:                 p.ID,
+				Name:               p.Name,
+				Description:        p.Description,
+				ParentProjectID:    p.ParentProjectID,
+				ContentPermissions: p.ContentPermissions,
+				OwnerID:            p.Owner.ID,
+				CreatedAt:          p.CreatedAt,
+				UpdatedAt:          p.UpdatedAt,
+			})
+		}
+		return len(resp.Projects.Project), resp.Pagination, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return projects, nil
+}
+
+// ListUsers returns every user of the signed-in site.
+func (c *TableauClient) ListUsers(ctx context.Context) ([]User, error) {
+	users := []User{}
+	err := c.listPages(ctx, "users", func(body []byte) (int, pagination, error) {
+		var resp struct {
+			Pagination pagination `json:"pagination"`
+			Users      struct {
+				User []struct {
+					ID          string     `json:"id"`
+					Name        string     `json:"name"`
+					FullName    string     `json:"fullName"`
+					Email       string     `json:"email"`
+					SiteRole    string     `json:"siteRole"`
+					AuthSetting string     `json:"authSetting"`
+					LastLogin   *time.Time `json:"lastLogin"`
+				} `json:"user"`
+			} `json:"users"`
+		}
+		if err := json.Unmarshal(body, &resp); err != nil {
+			return 0, pagination{}, fmt.Errorf("failed to parse users: %w", err)
+		}
+		for _, u := range resp.Users.User {
+			user := User{
+				ID:          u.ID,
+				Name:        u.Name,
+				FullName:    u.FullName,
+				Email:       u.Email,
+				SiteRole:    u.SiteRole,
+				AuthSetting: u.AuthSetting,
+			}
+			if u.LastLogin != nil {
+				user.LastLogin = *u.LastLogin
+			}
+			users = append(users, user)
+		}
+		return len(resp.Users.User), resp.Pagination, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+// ListGroups returns every group of the signed-in site.
+func (c *TableauClient) ListGroups(ctx context.Context) ([]Group, error) {
+	groups := []Group{}
+	err := c.listPages(ctx, "groups", func(body []byte) (int, pagination, error) {
+		var resp struct {
+			Pagination pagination `json:"pagination"`
+			Groups     struct {
+				Group []struct {
+					ID     string `json:"id"`
+					Name   string `json:"name"`
+					Domain struct {
+						Name string `json:"name"`
+					} `json:"domain"`
+					Import struct {
+						SiteRole string `json:"siteRole"`
+					} `json:"import"`
+					MinimumSiteRole string `json:"minimumSiteRole"`
+				} `json:"group"`
+			} `json:"groups"`
+		}
+		if err := json.Unmarshal(body, &resp); err != nil {
+			return 0, pagination{}, fmt.Errorf("failed to parse groups: %w", err)
+		}
+		for _, g := range resp.Groups.Group {
+			// Imported groups report the role under import, local ones directly
+			role := g.MinimumSiteRole
+			if role == "" {
+				role = g.Import.SiteRole
+			}
+			groups = append(groups, Group{
+				ID:              g.ID,
+				Name:            g.Name,
+				Domain:          g.Domain.Name,
+				MinimumSiteRole: role,
+			})
+		}
+		return len(resp.Groups.Group), resp.Pagination, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return groups, nil
+}
+
+// listPages requests every page of a list endpoint of the signed-in site,
+// passing each response body to decode, which returns the number of items on
+// the page and the page information.
+func (c *TableauClient) listPages(ctx context.Context, resource string, decode func(body []byte) (int, pagination, error)) error {
+	for page := 1; ; page++ {
+		params := url.Values{
+			"pageSize":   {strconv.Itoa(MaxPageSize)},
+			"pageNumber": {strconv.Itoa(page)},
+		}
+		body, err := c.doSiteRequest(ctx, http.MethodGet, resource, params)
+		if err != nil {
+			return err
+		}
+		n, p, err := decode(body)
+		if err != nil {
+			return err
+		}
+		total, err := strconv.Atoi(p.TotalAvailable)
+		if err != nil {
+			return fmt.Errorf("failed to parse %s pagination: %w", resource, err)
+		}
+		if n == 0 || page*MaxPageSize >= total {
+			return nil
+		}
+	}
+}
+
+// doSiteRequest sends an authenticated request to an endpoint of the
+// signed-in site, such as "projects", and returns the response body.
+func (c *TableauClient) doSiteRequest(ctx context.Context, method, resource string, params url.Values) ([]byte, error) {
+	if err := c.EnsureValidToken(ctx); err != nil {
+		return nil, err
+	}
+
+	reqURL := fmt.Sprintf("%s/api/%s/sites/%s/%s", c.ServerURL, c.APIVersion, c.SiteID, resource)
+	if len(params) > 0 {
+		reqURL += "?" + params.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, method, reqURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("X-Tableau-Auth", c.AuthToken)
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", sources.ClassifyError(err))
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.parseErrorResponse(resp.StatusCode, body)
+	}
+	return body, nil
+}
@@ -22,6 +22,7 @@ package tableau
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
 	"encoding/json"
 	"encoding/xml"
 	"fmt"
@@ -31,6 +32,7 @@ import (
 
 	"github.com/goccy/go-yaml"
 	"github.com/googleapis/genai-toolbox/internal/sources"
+	sourceutil "github.com/googleapis/genai-toolbox/internal/sources/util"
 	"go.opentelemetry.io/otel/trace"
 )
 
@@ -38,23 +40,21 @@ const SourceKind string = "tableau"
 
 // Default configuration constants
 const (
-	DefaultAPIVersion     = "3.27"               // Latest stable Tableau REST API version
-	DefaultTimeout        = 30 * time.Second     // Default HTTP client timeout
-	DefaultTokenExpiry    = 240 * time.Minute    // Tableau tokens expire after 4 hours
-	TokenRefreshBuffer    = 5 * time.Minute      // Refresh token if it expires in less than 5 minutes
-	MaxIdleConns          = 100                  // Maximum idle connections in pool
-	MaxIdleConnsPerHost   = 10                   // Maximum idle connections per host
-	IdleConnTimeout       = 90 * time.Second     // Idle connection timeout
-	TLSHandshakeTimeout   = 10 * time.Second     // TLS handshake timeout
+	DefaultAPIVersion   = "3.27"            // Latest stable Tableau REST API version
+	DefaultTimeout      = 30 * time.Second  // Default HTTP client timeout
+	DefaultTokenExpiry  = 240 * time.Minute // Tableau tokens expire after 4 hours
+	TokenRefreshBuffer  = 5 * time.Minute   // Refresh token if it expires in less than 5 minutes
+	MaxIdleConns        = 100               // Maximum idle connections in pool
+	MaxIdleConnsPerHost = 10                // Maximum idle connections per host
+	IdleConnTimeout     = 90 * time.Second  // Idle connection timeout
+	TLSHandshakeTimeout = 10 * time.Second  // TLS handshake timeout
 )
 
 // validate interface
 var _ sources.SourceConfig = Config{}
 
 func init() {
-	if !sources.Register(SourceKind, newConfig) {
-		panic(fmt.Sprintf("source kind %q already registered", SourceKind))
-	}
+	sources.MustRegister(SourceKind, newConfig)
 }
 
 func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources.SourceConfig, error) {
@@ -62,19 +62,68 @@ func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources
 	if err := decoder.DecodeContext(ctx, &actual); err != nil {
 		return nil, err
 	}
+
+	for _, secret := range []struct {
+		field string
+		value *string
+		file  string
+	}{
+		{"password", &actual.Password, actual.PasswordFile},
+		{"personalAccessTokenSecret", &actual.PersonalAccessTokenSecret, actual.PersonalAccessTokenSecretFile},
+	} {
+		resolved, err := sourceutil.ResolveSecret(secret.field, *secret.value, secret.file)
+		if err != nil {
+			return nil, fmt.Errorf("source %q (%s): unable to resolve secret: %w", name, SourceKind, err)
+		}
+		*secret.value = resolved
+	}
+
+	if err := actual.validateAuth(); err != nil {
+		return nil, fmt.Errorf("source %q (%s): %w", name, SourceKind, err)
+	}
+	if err := sourceutil.ValidateRateLimit(actual.RequestsPerSecond, actual.Burst); err != nil {
+		return nil, fmt.Errorf("source %q (%s): %w", name, SourceKind, err)
+	}
 	return actual, nil
 }
 
 type Config struct {
-	Name                      string `yaml:"name" validate:"required"`
-	Kind                      string `yaml:"kind" validate:"required"`
-	ServerURL                 string `yaml:"serverUrl" validate:"required"`          // e.g., https://tableau.example.com
-	SiteName                  string `yaml:"siteName"`                               // Optional: for multi-site deployments
-	Username                  string `yaml:"username"`                               // For username/password auth
-	Password                  string `yaml:"password"`                               // For username/password auth
-	PersonalAccessTokenName   string `yaml:"personalAccessTokenName"`                // For PAT auth
-	PersonalAccessTokenSecret string `yaml:"personalAccessTokenSecret"`              // For PAT auth
-	APIVersion                string `yaml:"apiVersion"`                             // Optional: defaults to latest
+	Name                          string  `yaml:"name" validate:"required"`
+	Kind                          string  `yaml:"kind" validate:"required"`
+	ServerURL                     string  `yaml:"serverUrl" validate:"required"` // e.g., https://tableau.example.com
+	SiteName                      string  `yaml:"siteName"`                      // Optional: for multi-site deployments
+	Username                      string  `yaml:"username"`                      // For username/password auth
+	Password                      string  `yaml:"password"`                      // For username/password auth
+	PasswordFile                  string  `yaml:"passwordFile"`                  // Optional: file to read password from
+	PersonalAccessTokenName       string  `yaml:"personalAccessTokenName"`       // For PAT auth
+	PersonalAccessTokenSecret     string  `yaml:"personalAccessTokenSecret"`     // For PAT auth
+	PersonalAccessTokenSecretFile string  `yaml:"personalAccessTokenSecretFile"` // Optional: file to read personalAccessTokenSecret from
+	APIVersion                    string  `yaml:"apiVersion"`                    // Optional: defaults to latest
+	TLSCAFile                     string  `yaml:"tlsCAFile"`                     // Optional: path to CA certificates used to verify the server
+	RequestsPerSecond             float64 `yaml:"requestsPerSecond"`             // Optional: client-side rate limit, unlimited if unset
+	Burst                         int     `yaml:"burst"`                         // Optional: requests sent at once before the rate applies, default 1
+}
+
+// validateAuth checks that exactly one of personal access token or
+// username/password authentication is configured.
+func (r Config) validateAuth() error {
+	hasPAT := r.PersonalAccessTokenName != "" || r.PersonalAccessTokenSecret != ""
+	hasBasic := r.Username != "" || r.Password != ""
+	switch {
+	case hasPAT && hasBasic:
+		return fmt.Errorf("personal access token and username/password cannot both be set")
+	case hasPAT:
+		if r.PersonalAccessTokenName == "" || r.PersonalAccessTokenSecret == "" {
+			return fmt.Errorf("personalAccessTokenName and personalAccessTokenSecret must both be set")
+		}
+	case hasBasic:
+		if r.Username == "" || r.Password == "" {
+			return fmt.Errorf("username and password must both be set")
+		}
+	default:
+		return fmt.Errorf("requires either personal access token or username/password authentication")
+	}
+	return nil
 }
 
 func (r Config) SourceConfigKind() string {
@@ -82,10 +131,16 @@ func (r Config) SourceConfigKind() string {
 }
 
 func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.Source, error) {
-	client, err := initTableauClient(ctx, tracer, r.Name, r.ServerURL, r.SiteName, r.Username, r.Password, r.PersonalAccessTokenName, r.PersonalAccessTokenSecret, r.APIVersion)
+	tlsConfig, err := sourceutil.LoadTLSConfig(r.TLSCAFile)
+	if err != nil {
+		return nil, fmt.Errorf("source %q (%s): unable to load TLS config: %w", r.Name, SourceKind, err)
+	}
+
+	client, err := initTableauClient(ctx, tracer, r.Name, r.ServerURL, r.SiteName, r.Username, r.Password, r.PersonalAccessTokenName, r.PersonalAccessTokenSecret, r.APIVersion, tlsConfig)
 	if err != nil {
 		return nil, fmt.Errorf("source %q (%s): unable to create Tableau client: %w", r.Name, SourceKind, err)
 	}
+	client.HTTPClient.Transport = sourceutil.NewRateLimitedTransport(client.HTTPClient.Transport, r.RequestsPerSecond, r.Burst)
 
 	s := &Source{
 		Config: r,
@@ -109,37 +164,67 @@ func (s *Source) ToConfig() sources.SourceConfig {
 	return s.Config
 }
 
+// HealthCheck refreshes the authentication token if needed and fetches the
+// signed-in site.
+func (s *Source) HealthCheck(ctx context.Context) error {
+	if err := s.Client.EnsureValidToken(ctx); err != nil {
+		return err
+	}
+
+	siteURL := fmt.Sprintf("%s/api/%s/sites/%s", s.Client.ServerURL, s.Client.APIVersion, s.Client.SiteID)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, siteURL, nil)
+	if err != nil {
+		return fmt.Errorf("unable to create request: %w", err)
+	}
+	req.Header.Set("X-Tableau-Auth", s.Client.AuthToken)
+
+	resp, err := s.Client.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return s.Client.parseErrorResponse(resp.StatusCode, body)
+	}
+	return nil
+}
+
 // TableauClient returns the underlying Tableau REST API client for direct API access.
 func (s *Source) TableauClient() *TableauClient {
 	return s.Client
 }
 
-// Close signs out from Tableau and releases HTTP client resources.
+// Close calls CloseWithContext with a sources.DefaultCloseTimeout deadline.
 func (s *Source) Close() error {
+	ctx, cancel := context.WithTimeout(context.Background(), sources.DefaultCloseTimeout)
+	defer cancel()
+	return s.CloseWithContext(ctx)
+}
+
+// CloseWithContext signs out from Tableau and releases HTTP client resources.
+func (s *Source) CloseWithContext(ctx context.Context) error {
 	if s == nil || s.Client == nil {
 		return nil
 	}
-	if s.Client != nil {
-		// Sign out from Tableau if we have a valid token
-		if s.Client.AuthToken != "" && time.Now().Before(s.Client.TokenExpiry) {
-			// Best effort sign out - don't fail if it errors
-			signOutURL := fmt.Sprintf("%s/api/%s/auth/signout",
-				s.Client.ServerURL, s.Client.APIVersion)
-			req, err := http.NewRequest("POST", signOutURL, nil)
+	// Sign out from Tableau if we have a valid token
+	if s.Client.AuthToken != "" && time.Now().Before(s.Client.TokenExpiry) {
+		// Best effort sign out - don't fail if it errors
+		signOutURL := fmt.Sprintf("%s/api/%s/auth/signout",
+			s.Client.ServerURL, s.Client.APIVersion)
+		req, err := http.NewRequestWithContext(ctx, "POST", signOutURL, nil)
+		if err == nil {
+			req.Header.Set("X-Tableau-Auth", s.Client.AuthToken)
+			resp, err := s.Client.HTTPClient.Do(req)
 			if err == nil {
-				req.Header.Set("X-Tableau-Auth", s.Client.AuthToken)
-				resp, err := s.Client.HTTPClient.Do(req)
-				if err == nil {
-					resp.Body.Close()
-				}
+				resp.Body.Close()
 			}
 		}
-
-		// Close idle HTTP connections
-		if transport, ok := s.Client.HTTPClient.Transport.(*http.Transport); ok {
-			transport.CloseIdleConnections()
-		}
 	}
+
+	// Close idle HTTP connections
+	s.Client.HTTPClient.CloseIdleConnections()
 	return nil
 }
 
@@ -219,6 +304,13 @@ func (e *tableauError) Error() string {
 		e.StatusCode, e.ErrorCode, e.Summary, e.Detail)
 }
 
+// Is matches the sources error kind for the response status, e.g.
+// errors.Is(err, sources.ErrAuth) for a 401.
+func (e *tableauError) Is(target error) bool {
+	kind := sources.KindForStatus(e.StatusCode)
+	return kind != nil && kind == target
+}
+
 // errorResponse represents an error response from the API
 type errorResponse struct {
 	XMLName xml.Name `xml:"tsResponse"`
@@ -229,7 +321,7 @@ type errorResponse struct {
 	} `xml:"error"`
 }
 
-func initTableauClient(ctx context.Context, tracer trace.Tracer, name, serverURL, siteName, username, password, patName, patSecret, apiVersion string) (*TableauClient, error) {
+func initTableauClient(ctx context.Context, tracer trace.Tracer, name, serverURL, siteName, username, password, patName, patSecret, apiVersion string, tlsConfig *tls.Config) (*TableauClient, error) {
 	ctx, span := sources.InitConnectionSpan(ctx, tracer, SourceKind, name)
 	defer span.End()
 
@@ -246,6 +338,7 @@ func initTableauClient(ctx context.Context, tracer trace.Tracer, name, serverURL
 				MaxIdleConnsPerHost: MaxIdleConnsPerHost,
 				IdleConnTimeout:     IdleConnTimeout,
 				TLSHandshakeTimeout: TLSHandshakeTimeout,
+				TLSClientConfig:     tlsConfig,
 			},
 		},
 		ServerURL:  serverURL,
@@ -305,7 +398,7 @@ func (c *TableauClient) authenticateWithCredentials(ctx context.Context, usernam
 	// Execute request
 	resp, err := c.HTTPClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("failed to execute request: %w", err)
+		return fmt.Errorf("failed to execute request: %w", sources.ClassifyError(err))
 	}
 	defer resp.Body.Close()
 
@@ -361,7 +454,7 @@ func (c *TableauClient) authenticateWithPAT(ctx context.Context, tokenName, toke
 	// Execute request
 	resp, err := c.HTTPClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("failed to execute request: %w", err)
+		return fmt.Errorf("failed to execute request: %w", sources.ClassifyError(err))
 	}
 	defer resp.Body.Close()
 
@@ -446,6 +539,56 @@ func (c *TableauClient) EnsureValidToken(ctx context.Context) error {
 	return nil
 }
 
+// switchSiteRequest represents the switch site request body
+type switchSiteRequest struct {
+	Site siteInfo `json:"site" xml:"site"`
+}
+
+// SwitchSite moves the current session to the site with the given content
+// URL, which is "" for the default site, without signing in again. On success
+// the client's SiteName, SiteID, AuthToken and TokenExpiry refer to the new
+// site, and later token refreshes sign in to it.
+func (c *TableauClient) SwitchSite(ctx context.Context, contentUrl string) error {
+	if err := c.EnsureValidToken(ctx); err != nil {
+		return err
+	}
+
+	url := fmt.Sprintf("%s/api/%s/auth/switchSite", c.ServerURL, c.APIVersion)
+	jsonData, err := json.Marshal(switchSiteRequest{Site: siteInfo{ContentUrl: contentUrl}})
+	if err != nil {
+		return fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("X-Tableau-Auth", c.AuthToken)
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to execute request: %w", sources.ClassifyError(err))
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return c.parseErrorResponse(resp.StatusCode, body)
+	}
+
+	// The previous token is invalidated, so only keep the new one
+	if err := c.parseAuthResponse(body); err != nil {
+		return err
+	}
+	c.SiteName = contentUrl
+	return nil
+}
+
 // parseErrorResponse parses JSON or XML error response
 func (c *TableauClient) parseErrorResponse(statusCode int, body []byte) error {
 	// Try parsing as JSON first
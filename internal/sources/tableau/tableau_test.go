@@ -17,10 +17,16 @@ package tableau
 import (
 	"bytes"
 	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/goccy/go-yaml"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestParseFromYamlTableau(t *testing.T) {
@@ -175,7 +181,42 @@ func TestFailParseFromYamlTableau(t *testing.T) {
 	tests := []struct {
 		name        string
 		yamlContent string
+		err         string
 	}{
+		{
+			name: "PAT and username/password both set",
+			yamlContent: `name: test-tableau
+kind: tableau
+serverUrl: https://tableau.example.com
+username: admin
+password: secret123
+personalAccessTokenName: my-token
+personalAccessTokenSecret: token-secret-value`,
+			err: `source "test" (tableau): personal access token and username/password cannot both be set`,
+		},
+		{
+			name: "no authentication",
+			yamlContent: `name: test-tableau
+kind: tableau
+serverUrl: https://tableau.example.com`,
+			err: `source "test" (tableau): requires either personal access token or username/password authentication`,
+		},
+		{
+			name: "PAT name without secret",
+			yamlContent: `name: test-tableau
+kind: tableau
+serverUrl: https://tableau.example.com
+personalAccessTokenName: my-token`,
+			err: "personalAccessTokenName and personalAccessTokenSecret must both be set",
+		},
+		{
+			name: "password without username",
+			yamlContent: `name: test-tableau
+kind: tableau
+serverUrl: https://tableau.example.com
+password: secret123`,
+			err: "username and password must both be set",
+		},
 		{
 			name: "invalid yaml syntax",
 			yamlContent: `name: test-tableau
@@ -195,6 +236,9 @@ serverUrl: [invalid yaml syntax`,
 			decoder := yaml.NewDecoder(bytes.NewReader([]byte(tt.yamlContent)))
 			_, err := newConfig(context.Background(), "test", decoder)
 			assert.Error(t, err)
+			if tt.err != "" {
+				assert.ErrorContains(t, err, tt.err)
+			}
 		})
 	}
 }
@@ -212,3 +256,155 @@ func TestSourceKindTableau(t *testing.T) {
 	source := Source{Config: config}
 	assert.Equal(t, SourceKind, source.SourceKind())
 }
+
+func TestSwitchSite(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/3.21/auth/switchSite", r.URL.Path)
+		assert.Equal(t, "POST", r.Method)
+		if r.Header.Get("X-Tableau-Auth") != "old-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"error":{"code":"401002","summary":"Unauthorized Access","detail":"Invalid authentication credentials"}}`))
+			return
+		}
+
+		var req switchSiteRequest
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "marketing", req.Site.ContentUrl)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"credentials":{"token":"new-token","site":{"id":"site-2","contentUrl":"marketing"},"user":{"id":"user-1"}}}`))
+	}))
+	defer server.Close()
+
+	client := &TableauClient{
+		HTTPClient:  server.Client(),
+		ServerURL:   server.URL,
+		SiteName:    "sales",
+		APIVersion:  "3.21",
+		AuthToken:   "old-token",
+		SiteID:      "site-1",
+		TokenExpiry: time.Now().Add(time.Hour),
+	}
+	require.NoError(t, client.SwitchSite(context.Background(), "marketing"))
+	assert.Equal(t, "new-token", client.AuthToken)
+	assert.Equal(t, "site-2", client.SiteID)
+	assert.Equal(t, "marketing", client.SiteName)
+	assert.True(t, client.IsTokenValid())
+
+	// A rejected switch leaves the current session in place
+	err := client.SwitchSite(context.Background(), "marketing")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "401002")
+	assert.Equal(t, "site-2", client.SiteID)
+}
+
+func TestListSiteContent(t *testing.T) {
+	var projectPages []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		assert.Equal(t, "token", r.Header.Get("X-Tableau-Auth"))
+		assert.Equal(t, "1000", r.URL.Query().Get("pageSize"))
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/3.21/sites/site-1/projects":
+			// The first page is full, so a second is requested
+			page := r.URL.Query().Get("pageNumber")
+			projectPages = append(projectPages, page)
+			var projects []map[string]any
+			if page == "1" {
+				for i := range MaxPageSize {
+					projects = append(projects, map[string]any{"id": fmt.Sprintf("p-%d", i), "name": "Project"})
+				}
+			} else {
+				projects = append(projects, map[string]any{
+					"id":                 "child",
+					"name":               "Finance",
+					"parentProjectId":    "p-0",
+					"contentPermissions": "LockedToProject",
+					"createdAt":          "2024-01-02T03:04:05Z",
+					"owner":              map[string]any{"id": "user-1"},
+				})
+			}
+			json.NewEncoder(w).Encode(map[string]any{
+				"pagination": map[string]any{"pageNumber": page, "pageSize": "1000", "totalAvailable": "1001"},
+				"projects":   map[string]any{"project": projects},
+			})
+		case "/api/3.21/sites/site-1/users":
+			w.Write([]byte(`{"pagination":{"pageNumber":"1","pageSize":"1000","totalAvailable":"2"},"users":{"user":[
+				{"id":"user-1","name":"ana","fullName":"Ana","email":"ana@example.com","siteRole":"Creator","lastLogin":"2024-05-06T07:08:09Z"},
+				{"id":"user-2","name":"bo","siteRole":"Viewer"}]}}`))
+		case "/api/3.21/sites/site-1/groups":
+			w.Write([]byte(`{"pagination":{"pageNumber":"1","pageSize":"1000","totalAvailable":"2"},"groups":{"group":[
+				{"id":"g-1","name":"All Users","domain":{"name":"local"}},
+				{"id":"g-2","name":"Analysts","domain":{"name":"example.com"},"import":{"siteRole":"Explorer"}}]}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":{"code":"404000","summary":"Not Found","detail":"Unknown resource"}}`))
+		}
+	}))
+	defer server.Close()
+
+	client := &TableauClient{
+		HTTPClient:  server.Client(),
+		ServerURL:   server.URL,
+		APIVersion:  "3.21",
+		AuthToken:   "token",
+		SiteID:      "site-1",
+		TokenExpiry: time.Now().Add(time.Hour),
+	}
+
+	projects, err := client.ListProjects(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1", "2"}, projectPages)
+	require.Len(t, projects, MaxPageSize+1)
+	assert.Equal(t, Project{
+		ID:                 "child",
+		Name:               "Finance",
+		ParentProjectID:    "p-0",
+		ContentPermissions: "LockedToProject",
+		OwnerID:            "user-1",
+		CreatedAt:          time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+	}, projects[MaxPageSize])
+
+	users, err := client.ListUsers(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []User{
+		{ID: "user-1", Name: "ana", FullName: "Ana", Email: "ana@example.com", SiteRole: "Creator", LastLogin: time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC)},
+		{ID: "user-2", Name: "bo", SiteRole: "Viewer"},
+	}, users)
+
+	groups, err := client.ListGroups(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []Group{
+		{ID: "g-1", Name: "All Users", Domain: "local"},
+		{ID: "g-2", Name: "Analysts", Domain: "example.com", MinimumSiteRole: "Explorer"},
+	}, groups)
+
+	client.SiteID = "missing"
+	_, err = client.ListGroups(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "404000")
+}
+
+func TestListSiteContentInvalidPagination(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"pagination":{"pageNumber":"1","pageSize":"1000","totalAvailable":"many"},"users":{"user":[
+			{"id":"user-1","name":"ana","siteRole":"Creator"}]}}`))
+	}))
+	defer server.Close()
+
+	client := &TableauClient{
+		HTTPClient:  server.Client(),
+		ServerURL:   server.URL,
+		APIVersion:  "3.21",
+		AuthToken:   "token",
+		SiteID:      "site-1",
+		TokenExpiry: time.Now().Add(time.Hour),
+	}
+
+	// A page count that can't be read is an error, not a truncated list
+	users, err := client.ListUsers(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to parse users pagination")
+	assert.Nil(t, users)
+}
@@ -0,0 +1,184 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package timestream
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/timestreamquery"
+	"github.com/aws/aws-sdk-go-v2/service/timestreamquery/types"
+)
+
+// timestampLayout is the format of Timestream TIMESTAMP values, which are UTC.
+const timestampLayout = "2006-01-02 15:04:05.999999999"
+
+// queryAPI is the subset of the Timestream Query API used by Query.
+type queryAPI interface {
+	Query(ctx context.Context, params *timestreamquery.QueryInput, optFns ...func(*timestreamquery.Options)) (*timestreamquery.QueryOutput, error)
+}
+
+// Column describes a column of a query result.
+type Column struct {
+	Name string
+	Type string // Scalar type such as VARCHAR, BIGINT, or TIMESTAMP; ARRAY, ROW, or TIMESERIES for nested values
+}
+
+// TimeSeriesPoint is one point of a TIMESERIES value.
+type TimeSeriesPoint struct {
+	Time  time.Time
+	Value any
+}
+
+// QueryOutput holds the rows returned by Query, keyed by column name. Values
+// are converted to Go types based on the column type: BIGINT and INTEGER to
+// int64, DOUBLE to float64, BOOLEAN to bool, TIMESTAMP and DATE to time.Time,
+// arrays to []any, rows to map[string]any, time series to []TimeSeriesPoint,
+// and everything else to string. NULLs are nil.
+type QueryOutput struct {
+	QueryID      string
+	Columns      []Column
+	Rows         []map[string]any
+	BytesScanned int64
+}
+
+// Query runs sql and returns every row, following NextToken across pages.
+// Timestream may return empty pages while a query is still running, so paging
+// continues until there is no NextToken.
+func (s *Source) Query(ctx context.Context, sql string) (*QueryOutput, error) {
+	result := &QueryOutput{Rows: []map[string]any{}}
+	input := &timestreamquery.QueryInput{QueryString: aws.String(sql)}
+	var columnInfo []types.ColumnInfo
+	for {
+		out, err := s.queryAPI.Query(ctx, input)
+		if err != nil {
+			return nil, fmt.Errorf("unable to run query: %w", err)
+		}
+		result.QueryID = aws.ToString(out.QueryId)
+		if out.QueryStatus != nil {
+			result.BytesScanned = out.QueryStatus.CumulativeBytesScanned
+		}
+		if columnInfo == nil && len(out.ColumnInfo) > 0 {
+			columnInfo = out.ColumnInfo
+			for _, info := range columnInfo {
+				result.Columns = append(result.Columns, Column{Name: aws.ToString(info.Name), Type: typeName(info.Type)})
+			}
+		}
+
+		for _, row := range out.Rows {
+			values, err := convertRow(row.Data, columnInfo)
+			if err != nil {
+				return nil, err
+			}
+			result.Rows = append(result.Rows, values)
+		}
+
+		if aws.ToString(out.NextToken) == "" {
+			return result, nil
+		}
+		input.NextToken = out.NextToken
+	}
+}
+
+// typeName returns the scalar type, or the kind of nested type.
+func typeName(t *types.Type) string {
+	switch {
+	case t == nil:
+		return ""
+	case t.ArrayColumnInfo != nil:
+		return "ARRAY"
+	case t.RowColumnInfo != nil:
+		return "ROW"
+	case t.TimeSeriesMeasureValueColumnInfo != nil:
+		return "TIMESERIES"
+	default:
+		return string(t.ScalarType)
+	}
+}
+
+func convertRow(data []types.Datum, columns []types.ColumnInfo) (map[string]any, error) {
+	row := make(map[string]any, len(data))
+	for i, datum := range data {
+		if i >= len(columns) {
+			break
+		}
+		value, err := convertDatum(datum, columns[i].Type)
+		if err != nil {
+			return nil, fmt.Errorf("column %s: %w", aws.ToString(columns[i].Name), err)
+		}
+		row[aws.ToString(columns[i].Name)] = value
+	}
+	return row, nil
+}
+
+func convertDatum(datum types.Datum, t *types.Type) (any, error) {
+	if aws.ToBool(datum.NullValue) || t == nil {
+		return nil, nil
+	}
+	switch {
+	case t.ArrayColumnInfo != nil:
+		values := make([]any, len(datum.ArrayValue))
+		for i, elem := range datum.ArrayValue {
+			value, err := convertDatum(elem, t.ArrayColumnInfo.Type)
+			if err != nil {
+				return nil, err
+			}
+			values[i] = value
+		}
+		return values, nil
+	case t.RowColumnInfo != nil:
+		if datum.RowValue == nil {
+			return nil, nil
+		}
+		return convertRow(datum.RowValue.Data, t.RowColumnInfo)
+	case t.TimeSeriesMeasureValueColumnInfo != nil:
+		points := make([]TimeSeriesPoint, len(datum.TimeSeriesValue))
+		for i, point := range datum.TimeSeriesValue {
+			ts, err := time.Parse(timestampLayout, aws.ToString(point.Time))
+			if err != nil {
+				return nil, err
+			}
+			points[i].Time = ts
+			if point.Value != nil {
+				if points[i].Value, err = convertDatum(*point.Value, t.TimeSeriesMeasureValueColumnInfo.Type); err != nil {
+					return nil, err
+				}
+			}
+		}
+		return points, nil
+	}
+
+	if datum.ScalarValue == nil {
+		return nil, nil
+	}
+	v := *datum.ScalarValue
+	switch t.ScalarType {
+	case types.ScalarTypeBigint, types.ScalarTypeInteger:
+		return strconv.ParseInt(v, 10, 64)
+	case types.ScalarTypeDouble:
+		return strconv.ParseFloat(v, 64)
+	case types.ScalarTypeBoolean:
+		return strconv.ParseBool(v)
+	case types.ScalarTypeTimestamp:
+		return time.Parse(timestampLayout, v)
+	case types.ScalarTypeDate:
+		return time.Parse(time.DateOnly, v)
+	default:
+		return v, nil
+	}
+}
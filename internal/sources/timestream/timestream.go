@@ -22,12 +22,12 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/aws/aws-sdk-go-v2/config"
-	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/timestreamquery"
 	"github.com/aws/aws-sdk-go-v2/service/timestreamwrite"
 	"github.com/goccy/go-yaml"
 	"github.com/googleapis/genai-toolbox/internal/sources"
+	sourceutil "github.com/googleapis/genai-toolbox/internal/sources/util"
 	"go.opentelemetry.io/otel/trace"
 )
 
@@ -37,9 +37,7 @@ const SourceKind string = "timestream"
 var _ sources.SourceConfig = Config{}
 
 func init() {
-	if !sources.Register(SourceKind, newConfig) {
-		panic(fmt.Sprintf("source kind %q already registered", SourceKind))
-	}
+	sources.MustRegister(SourceKind, newConfig)
 }
 
 func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources.SourceConfig, error) {
@@ -47,17 +45,49 @@ func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources
 	if err := decoder.DecodeContext(ctx, &actual); err != nil {
 		return nil, err
 	}
+
+	for _, secret := range []struct {
+		field string
+		value *string
+		file  string
+	}{
+		{"secretAccessKey", &actual.SecretAccessKey, actual.SecretAccessKeyFile},
+		{"sessionToken", &actual.SessionToken, actual.SessionTokenFile},
+	} {
+		resolved, err := sourceutil.ResolveSecret(secret.field, *secret.value, secret.file)
+		if err != nil {
+			return nil, fmt.Errorf("source %q (%s): unable to resolve secret: %w", name, SourceKind, err)
+		}
+		*secret.value = resolved
+	}
+
+	if err := sourceutil.ValidateAWSEndpoint(actual.Endpoint, actual.UseFIPS, actual.UseDualStack); err != nil {
+		return nil, fmt.Errorf("source %q (%s): %w", name, SourceKind, err)
+	}
+	if actual.MaxRetries < 0 {
+		return nil, fmt.Errorf("source %q (%s): maxRetries must not be negative", name, SourceKind)
+	}
 	return actual, nil
 }
 
 type Config struct {
-	Name            string `yaml:"name" validate:"required"`
-	Kind            string `yaml:"kind" validate:"required"`
-	Region          string `yaml:"region" validate:"required"`
-	Database        string `yaml:"database"`        // Optional: default database name
-	AccessKeyID     string `yaml:"accessKeyId"`     // Optional: explicit credentials
-	SecretAccessKey string `yaml:"secretAccessKey"` // Optional: explicit credentials
-	SessionToken    string `yaml:"sessionToken"`    // Optional: session token
+	Name                     string `yaml:"name" validate:"required"`
+	Kind                     string `yaml:"kind" validate:"required"`
+	Region                   string `yaml:"region"`                   // Optional: resolved from AWS_REGION, the shared config, or EC2 instance metadata if unset
+	Database                 string `yaml:"database"`                 // Optional: default database name
+	Endpoint                 string `yaml:"endpoint"`                 // Optional: for VPC endpoints or mocks (e.g., LocalStack), used by both clients
+	DisableEndpointDiscovery bool   `yaml:"disableEndpointDiscovery"` // Optional: send requests to Endpoint instead of discovering cell endpoints
+	AccessKeyID              string `yaml:"accessKeyId"`              // Optional: explicit credentials
+	SecretAccessKey          string `yaml:"secretAccessKey"`          // Optional: explicit credentials
+	SecretAccessKeyFile      string `yaml:"secretAccessKeyFile"`      // Optional: file to read secretAccessKey from
+	SessionToken             string `yaml:"sessionToken"`             // Optional: session token
+	SessionTokenFile         string `yaml:"sessionTokenFile"`         // Optional: file to read sessionToken from
+	RoleARN                  string `yaml:"roleArn"`                  // Optional: role to assume with the resolved credentials
+	RoleSessionName          string `yaml:"roleSessionName"`          // Optional: session name for the assumed role
+	ExternalID               string `yaml:"externalId"`               // Optional: external ID required by the role trust policy
+	UseFIPS                  bool   `yaml:"useFIPS"`                  // Optional: use FIPS endpoints
+	UseDualStack             bool   `yaml:"useDualStack"`             // Optional: use dual-stack (IPv4 and IPv6) endpoints
+	MaxRetries               int    `yaml:"maxRetries"`               // Optional: retries after the first attempt, e.g. on throttling (default: 2)
 }
 
 func (r Config) SourceConfigKind() string {
@@ -65,7 +95,7 @@ func (r Config) SourceConfigKind() string {
 }
 
 func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.Source, error) {
-	queryClient, writeClient, err := initTimestreamClients(ctx, tracer, r.Name, r.Region, r.AccessKeyID, r.SecretAccessKey, r.SessionToken)
+	queryClient, writeClient, err := initTimestreamClients(ctx, tracer, r)
 	if err != nil {
 		return nil, fmt.Errorf("source %q (%s): unable to create Timestream clients: %w", r.Name, SourceKind, err)
 	}
@@ -80,6 +110,8 @@ func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.So
 		Config:      r,
 		QueryClient: queryClient,
 		WriteClient: writeClient,
+		queryAPI:    queryClient,
+		writeAPI:    writeClient,
 	}
 	return s, nil
 }
@@ -90,6 +122,8 @@ type Source struct {
 	Config
 	QueryClient *timestreamquery.Client
 	WriteClient *timestreamwrite.Client
+	queryAPI    queryAPI // QueryClient, replaced in tests
+	writeAPI    writeAPI // WriteClient, replaced in tests
 }
 
 func (s *Source) SourceKind() string {
@@ -100,6 +134,14 @@ func (s *Source) ToConfig() sources.SourceConfig {
 	return s.Config
 }
 
+// HealthCheck lists databases, as Initialize does.
+func (s *Source) HealthCheck(ctx context.Context) error {
+	_, err := s.WriteClient.ListDatabases(ctx, &timestreamwrite.ListDatabasesInput{
+		MaxResults: aws.Int32(1),
+	})
+	return err
+}
+
 // TimestreamQueryClient returns the underlying AWS Timestream Query client for direct API access.
 func (s *Source) TimestreamQueryClient() *timestreamquery.Client {
 	return s.QueryClient
@@ -113,31 +155,69 @@ func (s *Source) TimestreamWriteClient() *timestreamwrite.Client {
 // Close is not needed for this source because AWS SDK v2 clients manage
 // their own connection pooling and cleanup automatically.
 
-func initTimestreamClients(ctx context.Context, tracer trace.Tracer, name, region, accessKeyID, secretAccessKey, sessionToken string) (*timestreamquery.Client, *timestreamwrite.Client, error) {
-	ctx, span := sources.InitConnectionSpan(ctx, tracer, SourceKind, name)
+func initTimestreamClients(ctx context.Context, tracer trace.Tracer, r Config) (*timestreamquery.Client, *timestreamwrite.Client, error) {
+	ctx, span := sources.InitConnectionSpan(ctx, tracer, SourceKind, r.Name)
 	defer span.End()
 
-	// Build AWS config load options
-	configOpts := []func(*config.LoadOptions) error{
-		config.WithRegion(region),
-	}
-
-	// Use explicit credentials if provided
-	if accessKeyID != "" && secretAccessKey != "" {
-		configOpts = append(configOpts, config.WithCredentialsProvider(
-			credentials.NewStaticCredentialsProvider(accessKeyID, secretAccessKey, sessionToken),
-		))
-	}
-
-	// Load AWS configuration
-	cfg, err := config.LoadDefaultConfig(ctx, configOpts...)
+	// The endpoint is applied per client, together with endpoint discovery
+	cfg, err := sourceutil.LoadAWSConfig(ctx, sourceutil.AWSOptions{
+		Region:          r.Region,
+		AccessKeyID:     r.AccessKeyID,
+		SecretAccessKey: r.SecretAccessKey,
+		SessionToken:    r.SessionToken,
+		RoleARN:         r.RoleARN,
+		RoleSessionName: r.RoleSessionName,
+		ExternalID:      r.ExternalID,
+		UseFIPS:         r.UseFIPS,
+		UseDualStack:    r.UseDualStack,
+		MaxRetries:      r.MaxRetries,
+	})
 	if err != nil {
-		return nil, nil, fmt.Errorf("unable to load AWS config: %w", err)
+		return nil, nil, err
+	}
+	if cfg.Region == "" {
+		return nil, nil, fmt.Errorf("unable to determine AWS region, set region in the source config or AWS_REGION")
 	}
 
 	// Create Timestream clients
-	queryClient := timestreamquery.NewFromConfig(cfg)
-	writeClient := timestreamwrite.NewFromConfig(cfg)
+	queryClient := timestreamquery.NewFromConfig(cfg, queryClientOptions(r)...)
+	writeClient := timestreamwrite.NewFromConfig(cfg, writeClientOptions(r)...)
 
 	return queryClient, writeClient, nil
 }
+
+// queryClientOptions applies the custom endpoint and endpoint discovery
+// settings to the query client.
+func queryClientOptions(r Config) []func(*timestreamquery.Options) {
+	opts := []func(*timestreamquery.Options){}
+	if r.Endpoint != "" {
+		endpoint := r.Endpoint
+		opts = append(opts, func(o *timestreamquery.Options) {
+			o.BaseEndpoint = &endpoint
+		})
+	}
+	if r.DisableEndpointDiscovery {
+		opts = append(opts, func(o *timestreamquery.Options) {
+			o.EndpointDiscovery.EnableEndpointDiscovery = aws.EndpointDiscoveryDisabled
+		})
+	}
+	return opts
+}
+
+// writeClientOptions applies the custom endpoint and endpoint discovery
+// settings to the write client.
+func writeClientOptions(r Config) []func(*timestreamwrite.Options) {
+	opts := []func(*timestreamwrite.Options){}
+	if r.Endpoint != "" {
+		endpoint := r.Endpoint
+		opts = append(opts, func(o *timestreamwrite.Options) {
+			o.BaseEndpoint = &endpoint
+		})
+	}
+	if r.DisableEndpointDiscovery {
+		opts = append(opts, func(o *timestreamwrite.Options) {
+			o.EndpointDiscovery.EnableEndpointDiscovery = aws.EndpointDiscoveryDisabled
+		})
+	}
+	return opts
+}
@@ -17,10 +17,18 @@ package timestream
 import (
 	"bytes"
 	"context"
+	"errors"
 	"testing"
+	"time"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/timestreamquery"
+	"github.com/aws/aws-sdk-go-v2/service/timestreamquery/types"
+	"github.com/aws/aws-sdk-go-v2/service/timestreamwrite"
+	writetypes "github.com/aws/aws-sdk-go-v2/service/timestreamwrite/types"
 	"github.com/goccy/go-yaml"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestParseFromYamlTimestream(t *testing.T) {
@@ -70,6 +78,68 @@ database: production_metrics`,
 				Database: "production_metrics",
 			},
 		},
+		{
+			name: "valid configuration with custom endpoint",
+			yamlContent: `name: local-timestream
+kind: timestream
+region: us-east-1
+endpoint: http://localhost:4566
+disableEndpointDiscovery: true`,
+			wantErr: false,
+			expected: Config{
+				Name:                     "local-timestream",
+				Kind:                     "timestream",
+				Region:                   "us-east-1",
+				Endpoint:                 "http://localhost:4566",
+				DisableEndpointDiscovery: true,
+			},
+		},
+		{
+			name: "valid configuration with assumed role",
+			yamlContent: `name: test-timestream
+kind: timestream
+region: us-east-1
+roleArn: arn:aws:iam::123456789012:role/toolbox
+roleSessionName: toolbox-session
+externalId: my-external-id`,
+			wantErr: false,
+			expected: Config{
+				Name:            "test-timestream",
+				Kind:            "timestream",
+				Region:          "us-east-1",
+				RoleARN:         "arn:aws:iam::123456789012:role/toolbox",
+				RoleSessionName: "toolbox-session",
+				ExternalID:      "my-external-id",
+			},
+		},
+		{
+			name: "valid configuration with FIPS and dual-stack endpoints",
+			yamlContent: `name: test-timestream
+kind: timestream
+region: us-east-1
+useFIPS: true
+useDualStack: true`,
+			wantErr: false,
+			expected: Config{
+				Name:         "test-timestream",
+				Kind:         "timestream",
+				Region:       "us-east-1",
+				UseFIPS:      true,
+				UseDualStack: true,
+			},
+		},
+		{
+			name: "custom endpoint with FIPS",
+			yamlContent: `name: test-timestream
+kind: timestream
+region: us-east-1
+endpoint: http://localhost:4566
+useFIPS: true`,
+			wantErr: true,
+			expected: Config{
+				Name: "test-timestream",
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -87,6 +157,13 @@ database: production_metrics`,
 				if tt.expected.Database != "" {
 					assert.Equal(t, tt.expected.Database, config.(Config).Database)
 				}
+				assert.Equal(t, tt.expected.Endpoint, config.(Config).Endpoint)
+				assert.Equal(t, tt.expected.DisableEndpointDiscovery, config.(Config).DisableEndpointDiscovery)
+				assert.Equal(t, tt.expected.RoleARN, config.(Config).RoleARN)
+				assert.Equal(t, tt.expected.RoleSessionName, config.(Config).RoleSessionName)
+				assert.Equal(t, tt.expected.ExternalID, config.(Config).ExternalID)
+				assert.Equal(t, tt.expected.UseFIPS, config.(Config).UseFIPS)
+				assert.Equal(t, tt.expected.UseDualStack, config.(Config).UseDualStack)
 			}
 		})
 	}
@@ -131,3 +208,166 @@ func TestSourceKindTimestream(t *testing.T) {
 	source := Source{Config: config}
 	assert.Equal(t, SourceKind, source.SourceKind())
 }
+
+// fakeQueryClient returns canned pages of query results.
+type fakeQueryClient struct {
+	pages []*timestreamquery.QueryOutput
+	reqs  []timestreamquery.QueryInput
+}
+
+func (f *fakeQueryClient) Query(ctx context.Context, params *timestreamquery.QueryInput, optFns ...func(*timestreamquery.Options)) (*timestreamquery.QueryOutput, error) {
+	f.reqs = append(f.reqs, *params)
+	return f.pages[len(f.reqs)-1], nil
+}
+
+func scalar(t types.ScalarType) *types.Type {
+	return &types.Type{ScalarType: t}
+}
+
+func TestQuery(t *testing.T) {
+	columns := []types.ColumnInfo{
+		{Name: aws.String("host"), Type: scalar(types.ScalarTypeVarchar)},
+		{Name: aws.String("time"), Type: scalar(types.ScalarTypeTimestamp)},
+		{Name: aws.String("cpu"), Type: scalar(types.ScalarTypeDouble)},
+		{Name: aws.String("count"), Type: scalar(types.ScalarTypeBigint)},
+		{Name: aws.String("tags"), Type: &types.Type{ArrayColumnInfo: &types.ColumnInfo{Type: scalar(types.ScalarTypeVarchar)}}},
+		{Name: aws.String("series"), Type: &types.Type{TimeSeriesMeasureValueColumnInfo: &types.ColumnInfo{Type: scalar(types.ScalarTypeDouble)}}},
+	}
+	row := types.Row{Data: []types.Datum{
+		{ScalarValue: aws.String("web-1")},
+		{ScalarValue: aws.String("2024-05-01 12:00:00.123000000")},
+		{ScalarValue: aws.String("0.75")},
+		{NullValue: aws.Bool(true)},
+		{ArrayValue: []types.Datum{{ScalarValue: aws.String("prod")}}},
+		{TimeSeriesValue: []types.TimeSeriesDataPoint{{Time: aws.String("2024-05-01 12:00:00.000000000"), Value: &types.Datum{ScalarValue: aws.String("0.5")}}}},
+	}}
+	client := &fakeQueryClient{pages: []*timestreamquery.QueryOutput{
+		// Timestream may return an empty first page while the query runs
+		{QueryId: aws.String("q-1"), ColumnInfo: columns, NextToken: aws.String("page-2")},
+		{QueryId: aws.String("q-1"), ColumnInfo: columns, Rows: []types.Row{row}, QueryStatus: &types.QueryStatus{CumulativeBytesScanned: 1024}},
+	}}
+	source := &Source{Config: Config{Name: "test"}, queryAPI: client}
+
+	out, err := source.Query(context.Background(), "SELECT * FROM metrics.cpu")
+	require.NoError(t, err)
+	assert.Equal(t, "q-1", out.QueryID)
+	assert.Equal(t, int64(1024), out.BytesScanned)
+	assert.Equal(t, []Column{
+		{Name: "host", Type: "VARCHAR"},
+		{Name: "time", Type: "TIMESTAMP"},
+		{Name: "cpu", Type: "DOUBLE"},
+		{Name: "count", Type: "BIGINT"},
+		{Name: "tags", Type: "ARRAY"},
+		{Name: "series", Type: "TIMESERIES"},
+	}, out.Columns)
+	assert.Equal(t, []map[string]any{{
+		"host":   "web-1",
+		"time":   time.Date(2024, 5, 1, 12, 0, 0, 123000000, time.UTC),
+		"cpu":    0.75,
+		"count":  nil,
+		"tags":   []any{"prod"},
+		"series": []TimeSeriesPoint{{Time: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC), Value: 0.5}},
+	}}, out.Rows)
+
+	require.Len(t, client.reqs, 2)
+	assert.Equal(t, "page-2", aws.ToString(client.reqs[1].NextToken))
+	assert.Equal(t, "SELECT * FROM metrics.cpu", aws.ToString(client.reqs[1].QueryString))
+}
+
+// fakeWriteClient records write requests and rejects records whose measure
+// name is "late".
+type fakeWriteClient struct {
+	reqs []*timestreamwrite.WriteRecordsInput
+}
+
+func (f *fakeWriteClient) WriteRecords(ctx context.Context, params *timestreamwrite.WriteRecordsInput, optFns ...func(*timestreamwrite.Options)) (*timestreamwrite.WriteRecordsOutput, error) {
+	f.reqs = append(f.reqs, params)
+	var rejected []writetypes.RejectedRecord
+	for i, r := range params.Records {
+		if aws.ToString(r.MeasureName) == "late" {
+			rejected = append(rejected, writetypes.RejectedRecord{RecordIndex: int32(i), Reason: aws.String("The record timestamp is outside the time range of the data ingestion window.")})
+		}
+	}
+	if rejected != nil {
+		return nil, &writetypes.RejectedRecordsException{RejectedRecords: rejected}
+	}
+	return &timestreamwrite.WriteRecordsOutput{}, nil
+}
+
+func TestWriteRecords(t *testing.T) {
+	client := &fakeWriteClient{}
+	source := &Source{Config: Config{Name: "test", Database: "metrics"}, writeAPI: client}
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	records := []Record{
+		{MeasureName: "cpu", MeasureValue: 0.5, Time: now},
+		{MeasureName: "stats", MeasureValues: map[string]any{"requests": 10, "healthy": true, "region": "us"}},
+	}
+	err := source.WriteRecords(context.Background(), "", "hosts", records, &CommonAttributes{
+		Dimensions: map[string]string{"host": "web-1", "az": "a"},
+		Time:       now,
+	})
+	require.NoError(t, err)
+	require.Len(t, client.reqs, 1)
+	req := client.reqs[0]
+	assert.Equal(t, "metrics", aws.ToString(req.DatabaseName))
+	assert.Equal(t, []writetypes.Dimension{
+		{Name: aws.String("az"), Value: aws.String("a")},
+		{Name: aws.String("host"), Value: aws.String("web-1")},
+	}, req.CommonAttributes.Dimensions)
+	assert.Equal(t, "1714564800000", aws.ToString(req.CommonAttributes.Time))
+
+	assert.Equal(t, writetypes.MeasureValueTypeDouble, req.Records[0].MeasureValueType)
+	assert.Equal(t, "0.5", aws.ToString(req.Records[0].MeasureValue))
+	assert.Equal(t, writetypes.MeasureValueTypeMulti, req.Records[1].MeasureValueType)
+	assert.Equal(t, []writetypes.MeasureValue{
+		{Name: aws.String("healthy"), Value: aws.String("true"), Type: writetypes.MeasureValueTypeBoolean},
+		{Name: aws.String("region"), Value: aws.String("us"), Type: writetypes.MeasureValueTypeVarchar},
+		{Name: aws.String("requests"), Value: aws.String("10"), Type: writetypes.MeasureValueTypeBigint},
+	}, req.Records[1].MeasureValues)
+	assert.Nil(t, req.Records[1].Time)
+}
+
+func TestWriteRecordsRejected(t *testing.T) {
+	client := &fakeWriteClient{}
+	source := &Source{Config: Config{Name: "test"}, writeAPI: client}
+
+	records := make([]Record, MaxWriteRecords+5)
+	for i := range records {
+		records[i] = Record{MeasureName: "cpu", MeasureValue: float64(i)}
+	}
+	records[MaxWriteRecords+2].MeasureName = "late"
+
+	err := source.WriteRecords(context.Background(), "metrics", "hosts", records, nil)
+	var rejected *RejectedRecordsError
+	require.True(t, errors.As(err, &rejected))
+	assert.Equal(t, []RejectedRecord{{Index: MaxWriteRecords + 2, Reason: "The record timestamp is outside the time range of the data ingestion window."}}, rejected.Rejected)
+	require.Len(t, client.reqs, 2)
+	assert.Len(t, client.reqs[0].Records, MaxWriteRecords)
+	assert.Len(t, client.reqs[1].Records, 5)
+
+	err = source.WriteRecords(context.Background(), "metrics", "hosts", []Record{{MeasureName: "cpu"}}, nil)
+	require.Error(t, err)
+	err = source.WriteRecords(context.Background(), "", "hosts", records, nil)
+	require.Error(t, err)
+}
+
+func TestClientOptions(t *testing.T) {
+	assert.Empty(t, queryClientOptions(Config{}))
+	assert.Empty(t, writeClientOptions(Config{}))
+
+	r := Config{Endpoint: "http://localhost:4566", DisableEndpointDiscovery: true}
+	queryOpts := timestreamquery.Options{}
+	for _, fn := range queryClientOptions(r) {
+		fn(&queryOpts)
+	}
+	assert.Equal(t, "http://localhost:4566", aws.ToString(queryOpts.BaseEndpoint))
+	assert.Equal(t, aws.EndpointDiscoveryDisabled, queryOpts.EndpointDiscovery.EnableEndpointDiscovery)
+
+	writeOpts := timestreamwrite.Options{}
+	for _, fn := range writeClientOptions(r) {
+		fn(&writeOpts)
+	}
+	assert.Equal(t, "http://localhost:4566", aws.ToString(writeOpts.BaseEndpoint))
+	assert.Equal(t, aws.EndpointDiscoveryDisabled, writeOpts.EndpointDiscovery.EnableEndpointDiscovery)
+}
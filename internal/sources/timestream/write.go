@@ -0,0 +1,218 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package timestream
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/timestreamwrite"
+	"github.com/aws/aws-sdk-go-v2/service/timestreamwrite/types"
+)
+
+// MaxWriteRecords is the most records WriteRecords accepts in one request.
+const MaxWriteRecords = 100
+
+// writeAPI is the subset of the Timestream Write API used by WriteRecords.
+type writeAPI interface {
+	WriteRecords(ctx context.Context, params *timestreamwrite.WriteRecordsInput, optFns ...func(*timestreamwrite.Options)) (*timestreamwrite.WriteRecordsOutput, error)
+}
+
+// Record is a measurement to write. Set MeasureValue for a single-measure
+// record, or MeasureValues for a multi-measure record. Measure values may be
+// float64, float32, any integer type, bool, string, or time.Time.
+type Record struct {
+	Dimensions    map[string]string
+	MeasureName   string
+	MeasureValue  any
+	MeasureValues map[string]any
+	Time          time.Time // Zero means the CommonAttributes time
+	Version       int64     // Optional: a higher version replaces an existing record with the same dimensions and time
+}
+
+// CommonAttributes are shared by every record of a WriteRecords call. Record
+// fields override them.
+type CommonAttributes struct {
+	Dimensions  map[string]string
+	MeasureName string
+	Time        time.Time
+}
+
+// RejectedRecord is a record Timestream refused to write.
+type RejectedRecord struct {
+	Index  int // Position in the records passed to WriteRecords
+	Reason string
+}
+
+// RejectedRecordsError is returned by WriteRecords when Timestream rejects some
+// records. The other records were written.
+type RejectedRecordsError struct {
+	Rejected []RejectedRecord
+}
+
+func (e *RejectedRecordsError) Error() string {
+	reasons := make([]string, len(e.Rejected))
+	for i, r := range e.Rejected {
+		reasons[i] = fmt.Sprintf("record %d: %s", r.Index, r.Reason)
+	}
+	return fmt.Sprintf("%d records rejected: %s", len(e.Rejected), strings.Join(reasons, "; "))
+}
+
+// WriteRecords writes records to table in batches of MaxWriteRecords. Every
+// batch is attempted; if Timestream rejects records, for example because they
+// fall outside the memory store retention period, the error is a
+// *RejectedRecordsError listing them. An empty database means the configured
+// default database.
+func (s *Source) WriteRecords(ctx context.Context, database, table string, records []Record, common *CommonAttributes) error {
+	if database == "" {
+		database = s.Database
+	}
+	if database == "" || table == "" {
+		return fmt.Errorf("database and table are required")
+	}
+
+	var commonRecord *types.Record
+	if common != nil {
+		commonRecord = &types.Record{Dimensions: buildDimensions(common.Dimensions)}
+		if common.MeasureName != "" {
+			commonRecord.MeasureName = aws.String(common.MeasureName)
+		}
+		if !common.Time.IsZero() {
+			commonRecord.Time = aws.String(strconv.FormatInt(common.Time.UnixMilli(), 10))
+			commonRecord.TimeUnit = types.TimeUnitMilliseconds
+		}
+	}
+
+	var rejected []RejectedRecord
+	for start := 0; start < len(records); start += MaxWriteRecords {
+		batch := records[start:min(start+MaxWriteRecords, len(records))]
+		input := &timestreamwrite.WriteRecordsInput{
+			DatabaseName:     aws.String(database),
+			TableName:        aws.String(table),
+			CommonAttributes: commonRecord,
+		}
+		for i, record := range batch {
+			r, err := buildRecord(record)
+			if err != nil {
+				return fmt.Errorf("record %d: %w", start+i, err)
+			}
+			input.Records = append(input.Records, r)
+		}
+
+		if _, err := s.writeAPI.WriteRecords(ctx, input); err != nil {
+			var rejectedErr *types.RejectedRecordsException
+			if !errors.As(err, &rejectedErr) {
+				return fmt.Errorf("unable to write records to %s.%s: %w", database, table, err)
+			}
+			for _, r := range rejectedErr.RejectedRecords {
+				rejected = append(rejected, RejectedRecord{
+					Index:  start + int(r.RecordIndex),
+					Reason: aws.ToString(r.Reason),
+				})
+			}
+		}
+	}
+	if len(rejected) > 0 {
+		return &RejectedRecordsError{Rejected: rejected}
+	}
+	return nil
+}
+
+func buildRecord(record Record) (types.Record, error) {
+	r := types.Record{Dimensions: buildDimensions(record.Dimensions)}
+	if record.MeasureName != "" {
+		r.MeasureName = aws.String(record.MeasureName)
+	}
+	if !record.Time.IsZero() {
+		r.Time = aws.String(strconv.FormatInt(record.Time.UnixMilli(), 10))
+		r.TimeUnit = types.TimeUnitMilliseconds
+	}
+	if record.Version != 0 {
+		r.Version = aws.Int64(record.Version)
+	}
+
+	switch {
+	case record.MeasureValue != nil && record.MeasureValues != nil:
+		return types.Record{}, errors.New("set only one of MeasureValue and MeasureValues")
+	case record.MeasureValue != nil:
+		value, valueType, err := measureValue(record.MeasureValue)
+		if err != nil {
+			return types.Record{}, err
+		}
+		r.MeasureValue = aws.String(value)
+		r.MeasureValueType = valueType
+	case len(record.MeasureValues) > 0:
+		r.MeasureValueType = types.MeasureValueTypeMulti
+		names := make([]string, 0, len(record.MeasureValues))
+		for name := range record.MeasureValues {
+			names = append(names, name)
+		}
+		slices.Sort(names)
+		for _, name := range names {
+			value, valueType, err := measureValue(record.MeasureValues[name])
+			if err != nil {
+				return types.Record{}, fmt.Errorf("measure %s: %w", name, err)
+			}
+			r.MeasureValues = append(r.MeasureValues, types.MeasureValue{
+				Name:  aws.String(name),
+				Value: aws.String(value),
+				Type:  valueType,
+			})
+		}
+	default:
+		return types.Record{}, errors.New("one of MeasureValue and MeasureValues is required")
+	}
+	return r, nil
+}
+
+// buildDimensions returns dimensions sorted by name.
+func buildDimensions(dimensions map[string]string) []types.Dimension {
+	names := make([]string, 0, len(dimensions))
+	for name := range dimensions {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	result := make([]types.Dimension, len(names))
+	for i, name := range names {
+		result[i] = types.Dimension{Name: aws.String(name), Value: aws.String(dimensions[name])}
+	}
+	return result
+}
+
+// measureValue formats v as a Timestream measure value and returns its type.
+func measureValue(v any) (string, types.MeasureValueType, error) {
+	switch v := v.(type) {
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64), types.MeasureValueTypeDouble, nil
+	case float32:
+		return strconv.FormatFloat(float64(v), 'g', -1, 32), types.MeasureValueTypeDouble, nil
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return fmt.Sprint(v), types.MeasureValueTypeBigint, nil
+	case bool:
+		return strconv.FormatBool(v), types.MeasureValueTypeBoolean, nil
+	case string:
+		return v, types.MeasureValueTypeVarchar, nil
+	case time.Time:
+		return strconv.FormatInt(v.UnixMilli(), 10), types.MeasureValueTypeTimestamp, nil
+	default:
+		return "", "", fmt.Errorf("unsupported measure value type %T", v)
+	}
+}
@@ -19,23 +19,31 @@ import (
 	"database/sql"
 	"fmt"
 	"net/url"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/goccy/go-yaml"
 	"github.com/googleapis/genai-toolbox/internal/sources"
+	sourceutil "github.com/googleapis/genai-toolbox/internal/sources/util"
 	_ "github.com/trinodb/trino-go-client/trino"
 	"go.opentelemetry.io/otel/trace"
 )
 
 const SourceKind string = "trino"
 
+// Default configuration constants
+const (
+	DefaultMaxOpenConns    = 10        // Default maximum open connections
+	DefaultMaxIdleConns    = 5         // Default maximum idle connections
+	DefaultConnMaxLifetime = time.Hour // Default connection maximum lifetime
+)
+
 // validate interface
 var _ sources.SourceConfig = Config{}
 
 func init() {
-	if !sources.Register(SourceKind, newConfig) {
-		panic(fmt.Sprintf("source kind %q already registered", SourceKind))
-	}
+	sources.MustRegister(SourceKind, newConfig)
 }
 
 func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources.SourceConfig, error) {
@@ -43,22 +51,64 @@ func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources
 	if err := decoder.DecodeContext(ctx, &actual); err != nil {
 		return nil, err
 	}
+
+	for _, secret := range []struct {
+		field string
+		value *string
+		file  string
+	}{
+		{"password", &actual.Password, actual.PasswordFile},
+		{"accessToken", &actual.AccessToken, actual.AccessTokenFile},
+	} {
+		resolved, err := sourceutil.ResolveSecret(secret.field, *secret.value, secret.file)
+		if err != nil {
+			return nil, fmt.Errorf("source %q (%s): unable to resolve secret: %w", name, SourceKind, err)
+		}
+		*secret.value = resolved
+	}
+
+	if actual.TLSCAFile != "" && !actual.SSLEnabled {
+		return nil, fmt.Errorf("source %q (%s): tlsCAFile requires sslEnabled", name, SourceKind)
+	}
+	for k, v := range actual.SessionProperties {
+		if k == "" || strings.ContainsAny(k+v, ":;") {
+			return nil, fmt.Errorf("source %q (%s): invalid session property %q: names must be non-empty and names and values must not contain ':' or ';'", name, SourceKind, k)
+		}
+	}
+	if actual.MaxOpenConns < 0 || actual.MaxIdleConns < 0 {
+		return nil, fmt.Errorf("source %q (%s): maxOpenConns and maxIdleConns must not be negative", name, SourceKind)
+	}
+	if _, err := sourceutil.ParseDurationOption("connMaxLifetime", actual.ConnMaxLifetime, DefaultConnMaxLifetime); err != nil {
+		return nil, fmt.Errorf("source %q (%s): %w", name, SourceKind, err)
+	}
+	if _, err := sourceutil.ParseDurationOption("connMaxIdleTime", actual.ConnMaxIdleTime, 0); err != nil {
+		return nil, fmt.Errorf("source %q (%s): %w", name, SourceKind, err)
+	}
 	return actual, nil
 }
 
 type Config struct {
-	Name            string `yaml:"name" validate:"required"`
-	Kind            string `yaml:"kind" validate:"required"`
-	Host            string `yaml:"host" validate:"required"`
-	Port            string `yaml:"port" validate:"required"`
-	User            string `yaml:"user"`
-	Password        string `yaml:"password"`
-	Catalog         string `yaml:"catalog" validate:"required"`
-	Schema          string `yaml:"schema" validate:"required"`
-	QueryTimeout    string `yaml:"queryTimeout"`
-	AccessToken     string `yaml:"accessToken"`
-	KerberosEnabled bool   `yaml:"kerberosEnabled"`
-	SSLEnabled      bool   `yaml:"sslEnabled"`
+	Name              string            `yaml:"name" validate:"required"`
+	Kind              string            `yaml:"kind" validate:"required"`
+	Host              string            `yaml:"host" validate:"required"`
+	Port              string            `yaml:"port" validate:"required"`
+	User              string            `yaml:"user"`
+	Password          string            `yaml:"password"`
+	PasswordFile      string            `yaml:"passwordFile"`
+	Catalog           string            `yaml:"catalog" validate:"required"`
+	Schema            string            `yaml:"schema" validate:"required"`
+	QueryTimeout      string            `yaml:"queryTimeout"`
+	AccessToken       string            `yaml:"accessToken"`
+	AccessTokenFile   string            `yaml:"accessTokenFile"`
+	KerberosEnabled   bool              `yaml:"kerberosEnabled"`
+	SSLEnabled        bool              `yaml:"sslEnabled"`
+	TLSCAFile         string            `yaml:"tlsCAFile"`         // Optional: path to CA certificate used to verify the coordinator, with sslEnabled
+	ClientSource      string            `yaml:"source"`            // Optional: client name shown in the Trino UI and query history
+	SessionProperties map[string]string `yaml:"sessionProperties"` // Optional: session properties set on every query, e.g. query_max_run_time
+	MaxOpenConns      int               `yaml:"maxOpenConns"`      // Optional: max open connections (default 10)
+	MaxIdleConns      int               `yaml:"maxIdleConns"`      // Optional: max idle connections (default 5)
+	ConnMaxLifetime   string            `yaml:"connMaxLifetime"`   // Optional: max time a connection is reused, e.g. "30m" (default 1h)
+	ConnMaxIdleTime   string            `yaml:"connMaxIdleTime"`   // Optional: max time a connection stays idle, e.g. "5m" (default: no limit)
 }
 
 func (r Config) SourceConfigKind() string {
@@ -66,19 +116,18 @@ func (r Config) SourceConfigKind() string {
 }
 
 func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.Source, error) {
-	pool, err := initTrinoConnectionPool(ctx, tracer, r.Name, r.Host, r.Port, r.User, r.Password, r.Catalog, r.Schema, r.QueryTimeout, r.AccessToken, r.KerberosEnabled, r.SSLEnabled)
-	if err != nil {
-		return nil, fmt.Errorf("unable to create pool: %w", err)
-	}
-
-	err = pool.PingContext(ctx)
+	db, err := initTrinoConnectionPool(ctx, tracer, r)
 	if err != nil {
-		return nil, fmt.Errorf("unable to connect successfully: %w", err)
+		return nil, fmt.Errorf("source %q (%s): unable to create pool: %w", r.Name, SourceKind, err)
 	}
 
 	s := &Source{
 		Config: r,
-		Pool:   pool,
+		Pool:   db,
+	}
+	if err := s.HealthCheck(ctx); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("source %q (%s): unable to connect successfully: %w", r.Name, SourceKind, err)
 	}
 	return s, nil
 }
@@ -98,17 +147,33 @@ func (s *Source) ToConfig() sources.SourceConfig {
 	return s.Config
 }
 
+// HealthCheck runs a trivial query. The Trino driver connects over HTTP per
+// request, so a ping alone does not reach the coordinator.
+func (s *Source) HealthCheck(ctx context.Context) error {
+	var one int
+	return s.Pool.QueryRowContext(ctx, "SELECT 1").Scan(&one)
+}
+
+// TrinoDB returns the underlying database connection for direct SQL operations.
 func (s *Source) TrinoDB() *sql.DB {
 	return s.Pool
 }
 
-func initTrinoConnectionPool(ctx context.Context, tracer trace.Tracer, name, host, port, user, password, catalog, schema, queryTimeout, accessToken string, kerberosEnabled, sslEnabled bool) (*sql.DB, error) {
+// Close closes the database connection and releases resources.
+func (s *Source) Close() error {
+	if s == nil || s.Pool == nil {
+		return nil
+	}
+	return s.Pool.Close()
+}
+
+func initTrinoConnectionPool(ctx context.Context, tracer trace.Tracer, r Config) (*sql.DB, error) {
 	//nolint:all // Reassigned ctx
-	ctx, span := sources.InitConnectionSpan(ctx, tracer, SourceKind, name)
+	ctx, span := sources.InitConnectionSpan(ctx, tracer, SourceKind, r.Name)
 	defer span.End()
 
 	// Build Trino DSN
-	dsn, err := buildTrinoDSN(host, port, user, password, catalog, schema, queryTimeout, accessToken, kerberosEnabled, sslEnabled)
+	dsn, err := buildTrinoDSN(r)
 	if err != nil {
 		return nil, fmt.Errorf("failed to build DSN: %w", err)
 	}
@@ -118,46 +183,72 @@ func initTrinoConnectionPool(ctx context.Context, tracer trace.Tracer, name, hos
 		return nil, fmt.Errorf("failed to open connection: %w", err)
 	}
 
-	// Configure connection pool
-	db.SetMaxOpenConns(10)
-	db.SetMaxIdleConns(5)
-	db.SetConnMaxLifetime(time.Hour)
+	// Configure connection pool with defaults
+	maxOpenConns, maxIdleConns := r.MaxOpenConns, r.MaxIdleConns
+	if maxOpenConns == 0 {
+		maxOpenConns = DefaultMaxOpenConns
+	}
+	if maxIdleConns == 0 {
+		maxIdleConns = DefaultMaxIdleConns
+	}
+	db.SetMaxOpenConns(maxOpenConns)
+	db.SetMaxIdleConns(maxIdleConns)
+
+	connMaxLifetime, _ := sourceutil.ParseDurationOption("connMaxLifetime", r.ConnMaxLifetime, DefaultConnMaxLifetime)
+	connMaxIdleTime, _ := sourceutil.ParseDurationOption("connMaxIdleTime", r.ConnMaxIdleTime, 0)
+	db.SetConnMaxLifetime(connMaxLifetime)
+	db.SetConnMaxIdleTime(connMaxIdleTime)
 
 	return db, nil
 }
 
-func buildTrinoDSN(host, port, user, password, catalog, schema, queryTimeout, accessToken string, kerberosEnabled, sslEnabled bool) (string, error) {
+func buildTrinoDSN(r Config) (string, error) {
 	// Build query parameters
 	query := url.Values{}
-	query.Set("catalog", catalog)
-	query.Set("schema", schema)
-	if queryTimeout != "" {
-		query.Set("queryTimeout", queryTimeout)
+	query.Set("catalog", r.Catalog)
+	query.Set("schema", r.Schema)
+	if r.ClientSource != "" {
+		query.Set("source", r.ClientSource)
 	}
-	if accessToken != "" {
-		query.Set("accessToken", accessToken)
+	if r.QueryTimeout != "" {
+		query.Set("queryTimeout", r.QueryTimeout)
 	}
-	if kerberosEnabled {
+	if r.AccessToken != "" {
+		query.Set("accessToken", r.AccessToken)
+	}
+	if r.KerberosEnabled {
 		query.Set("KerberosEnabled", "true")
 	}
+	if r.TLSCAFile != "" {
+		query.Set("SSLCertPath", r.TLSCAFile)
+	}
+	if len(r.SessionProperties) > 0 {
+		// The driver expects name:value pairs separated by semicolons
+		props := make([]string, 0, len(r.SessionProperties))
+		for k, v := range r.SessionProperties {
+			props = append(props, k+":"+v)
+		}
+		sort.Strings(props)
+		query.Set("session_properties", strings.Join(props, ";"))
+	}
 
 	// Build URL
 	scheme := "http"
-	if sslEnabled {
+	if r.SSLEnabled {
 		scheme = "https"
 	}
 
 	u := &url.URL{
 		Scheme:   scheme,
-		Host:     fmt.Sprintf("%s:%s", host, port),
+		Host:     fmt.Sprintf("%s:%s", r.Host, r.Port),
 		RawQuery: query.Encode(),
 	}
 
 	// Only set user and password if not empty
-	if user != "" && password != "" {
-		u.User = url.UserPassword(user, password)
-	} else if user != "" {
-		u.User = url.User(user)
+	if r.User != "" && r.Password != "" {
+		u.User = url.UserPassword(r.User, r.Password)
+	} else if r.User != "" {
+		u.User = url.User(r.User)
 	}
 
 	return u.String(), nil
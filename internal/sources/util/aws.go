@@ -0,0 +1,151 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+)
+
+const (
+	DefaultRoleSessionName = "genai-toolbox" // Session name used when assuming a role without RoleSessionName
+	CredentialExpiryWindow = 5 * time.Minute // Refresh cached credentials this long before they expire
+)
+
+// AWSOptions configures LoadAWSConfig. All fields are optional; unset fields
+// fall back to the default credential chain and shared config.
+type AWSOptions struct {
+	Region          string // AWS region; resolved from AWS_REGION, the shared config, or EC2 instance metadata if unset
+	AccessKeyID     string // Static credentials, used only with SecretAccessKey
+	SecretAccessKey string // Static credentials, used only with AccessKeyID
+	SessionToken    string // Session token for temporary static credentials
+	Endpoint        string // Custom endpoint for service clients, e.g. LocalStack
+	RoleARN         string // Role to assume using the credentials above
+	RoleSessionName string // Session name for the assumed role, defaults to DefaultRoleSessionName
+	ExternalID      string // External ID required by the role's trust policy
+	UseFIPS         bool   // Use FIPS endpoints, including for AssumeRole
+	UseDualStack    bool   // Use dual-stack (IPv4 and IPv6) endpoints, including for AssumeRole
+	MaxRetries      int    // Retries after the first attempt; zero keeps the SDK default of two
+}
+
+// ValidateAWSEndpoint returns an error if a custom endpoint is combined with
+// FIPS or dual-stack endpoints, which the SDK rejects on every request.
+func ValidateAWSEndpoint(endpoint string, useFIPS, useDualStack bool) error {
+	if endpoint == "" {
+		return nil
+	}
+	if useFIPS {
+		return fmt.Errorf("endpoint and useFIPS cannot both be set")
+	}
+	if useDualStack {
+		return fmt.Errorf("endpoint and useDualStack cannot both be set")
+	}
+	return nil
+}
+
+// LoadAWSConfig loads the AWS configuration shared by the AWS sources. Static
+// credentials replace the default credential chain when both keys are set. If
+// RoleARN is set, those credentials are used to assume the role, and clients
+// built from the config use the role's credentials. Endpoint applies to
+// clients built from the config, but not to the STS client used for AssumeRole.
+// UseFIPS and UseDualStack apply to all clients, including STS.
+// MaxRetries sets the attempt count of the SDK's standard retryer, which
+// backs off on throttling errors such as ThrottlingException.
+// Errors from clients built from the config are tagged with sources error
+// kinds by ClassifyAWSError.
+//
+// Credentials are cached and refreshed CredentialExpiryWindow before they
+// expire, so clients created once at Initialize keep working when session or
+// assumed-role credentials roll over. Static credentials are never refreshed.
+//
+// The returned config's Region is empty if no region was set or resolved;
+// callers that need one must check it.
+func LoadAWSConfig(ctx context.Context, opts AWSOptions) (aws.Config, error) {
+	configOpts := []func(*config.LoadOptions) error{
+		config.WithCredentialsCacheOptions(setCredentialExpiryWindow),
+	}
+	if opts.Region != "" {
+		configOpts = append(configOpts, config.WithRegion(opts.Region))
+	} else {
+		// Fall back to the instance metadata service on EC2 when neither the
+		// environment nor the shared config sets a region
+		configOpts = append(configOpts, config.WithEC2IMDSRegion())
+	}
+
+	if opts.UseFIPS {
+		configOpts = append(configOpts, config.WithUseFIPSEndpoint(aws.FIPSEndpointStateEnabled))
+	}
+	if opts.UseDualStack {
+		configOpts = append(configOpts, config.WithUseDualStackEndpoint(aws.DualStackEndpointStateEnabled))
+	}
+
+	if opts.MaxRetries > 0 {
+		configOpts = append(configOpts, config.WithRetryer(func() aws.Retryer {
+			return retry.NewStandard(func(o *retry.StandardOptions) {
+				o.MaxAttempts = opts.MaxRetries + 1
+			})
+		}))
+	}
+
+	// Use explicit credentials if provided
+	if opts.AccessKeyID != "" && opts.SecretAccessKey != "" {
+		configOpts = append(configOpts, config.WithCredentialsProvider(
+			credentials.NewStaticCredentialsProvider(opts.AccessKeyID, opts.SecretAccessKey, opts.SessionToken),
+		))
+	}
+
+	cfg, err := config.LoadDefaultConfig(ctx, configOpts...)
+	if err != nil {
+		return aws.Config{}, fmt.Errorf("unable to load AWS config: %w", err)
+	}
+
+	if opts.RoleARN != "" {
+		sessionName := opts.RoleSessionName
+		if sessionName == "" {
+			sessionName = DefaultRoleSessionName
+		}
+		provider := stscreds.NewAssumeRoleProvider(sts.NewFromConfig(cfg), opts.RoleARN, func(o *stscreds.AssumeRoleOptions) {
+			o.RoleSessionName = sessionName
+			if opts.ExternalID != "" {
+				o.ExternalID = aws.String(opts.ExternalID)
+			}
+		})
+		cfg.Credentials = aws.NewCredentialsCache(provider, setCredentialExpiryWindow)
+	}
+
+	if err := ValidateAWSEndpoint(opts.Endpoint, opts.UseFIPS, opts.UseDualStack); err != nil {
+		return aws.Config{}, err
+	}
+	if opts.Endpoint != "" {
+		cfg.BaseEndpoint = aws.String(opts.Endpoint)
+	}
+
+	// Tag operation errors with sources error kinds, e.g. sources.ErrThrottled
+	cfg.APIOptions = append(cfg.APIOptions, addClassifyAWSErrors)
+
+	return cfg, nil
+}
+
+func setCredentialExpiryWindow(o *aws.CredentialsCacheOptions) {
+	o.ExpiryWindow = CredentialExpiryWindow
+}
@@ -0,0 +1,148 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+)
+
+func TestLoadAWSConfig(t *testing.T) {
+	// Keep the default credential chain from reading the environment
+	t.Setenv("AWS_CONFIG_FILE", "/dev/null")
+	t.Setenv("AWS_SHARED_CREDENTIALS_FILE", "/dev/null")
+
+	t.Run("static credentials and endpoint", func(t *testing.T) {
+		cfg, err := LoadAWSConfig(context.Background(), AWSOptions{
+			Region:          "us-west-2",
+			AccessKeyID:     "AKID",
+			SecretAccessKey: "SECRET",
+			SessionToken:    "TOKEN",
+			Endpoint:        "http://localhost:4566",
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if cfg.Region != "us-west-2" {
+			t.Errorf("got region %q, want us-west-2", cfg.Region)
+		}
+		if aws.ToString(cfg.BaseEndpoint) != "http://localhost:4566" {
+			t.Errorf("got endpoint %q, want http://localhost:4566", aws.ToString(cfg.BaseEndpoint))
+		}
+		creds, err := cfg.Credentials.Retrieve(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if creds.AccessKeyID != "AKID" || creds.SecretAccessKey != "SECRET" || creds.SessionToken != "TOKEN" {
+			t.Errorf("unexpected credentials: %+v", creds)
+		}
+	})
+
+	t.Run("region from environment", func(t *testing.T) {
+		t.Setenv("AWS_REGION", "eu-west-1")
+		t.Setenv("AWS_EC2_METADATA_DISABLED", "true")
+		cfg, err := LoadAWSConfig(context.Background(), AWSOptions{})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if cfg.Region != "eu-west-1" {
+			t.Errorf("got region %q, want eu-west-1", cfg.Region)
+		}
+	})
+
+	t.Run("max retries", func(t *testing.T) {
+		cfg, err := LoadAWSConfig(context.Background(), AWSOptions{Region: "us-west-2", MaxRetries: 5})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if got := cfg.Retryer().MaxAttempts(); got != 6 {
+			t.Errorf("got %d max attempts, want 6", got)
+		}
+	})
+
+	t.Run("default credential chain is cached", func(t *testing.T) {
+		t.Setenv("AWS_ACCESS_KEY_ID", "ENVAKID")
+		t.Setenv("AWS_SECRET_ACCESS_KEY", "ENVSECRET")
+		cfg, err := LoadAWSConfig(context.Background(), AWSOptions{Region: "us-west-2"})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if _, ok := cfg.Credentials.(*aws.CredentialsCache); !ok {
+			t.Fatalf("got credentials %T, want *aws.CredentialsCache", cfg.Credentials)
+		}
+		creds, err := cfg.Credentials.Retrieve(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if creds.AccessKeyID != "ENVAKID" {
+			t.Errorf("got access key %q, want ENVAKID", creds.AccessKeyID)
+		}
+	})
+
+	t.Run("assume role", func(t *testing.T) {
+		cfg, err := LoadAWSConfig(context.Background(), AWSOptions{
+			Region:          "us-west-2",
+			AccessKeyID:     "AKID",
+			SecretAccessKey: "SECRET",
+			RoleARN:         "arn:aws:iam::123456789012:role/reader",
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		cache, ok := cfg.Credentials.(*aws.CredentialsCache)
+		if !ok {
+			t.Fatalf("got credentials %T, want *aws.CredentialsCache", cfg.Credentials)
+		}
+		if !cache.IsCredentialsProvider(&stscreds.AssumeRoleProvider{}) {
+			t.Errorf("credentials don't assume the role")
+		}
+		if cfg.BaseEndpoint != nil {
+			t.Errorf("got endpoint %q, want none", aws.ToString(cfg.BaseEndpoint))
+		}
+	})
+
+	t.Run("FIPS and dual-stack endpoints", func(t *testing.T) {
+		cfg, err := LoadAWSConfig(context.Background(), AWSOptions{
+			Region:       "us-gov-west-1",
+			UseFIPS:      true,
+			UseDualStack: true,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		opts := sts.NewFromConfig(cfg).Options()
+		if opts.EndpointOptions.UseFIPSEndpoint != aws.FIPSEndpointStateEnabled {
+			t.Errorf("got FIPS endpoint state %v, want enabled", opts.EndpointOptions.UseFIPSEndpoint)
+		}
+		if opts.EndpointOptions.UseDualStackEndpoint != aws.DualStackEndpointStateEnabled {
+			t.Errorf("got dual-stack endpoint state %v, want enabled", opts.EndpointOptions.UseDualStackEndpoint)
+		}
+	})
+
+	t.Run("FIPS with custom endpoint", func(t *testing.T) {
+		_, err := LoadAWSConfig(context.Background(), AWSOptions{
+			Region:   "us-gov-west-1",
+			Endpoint: "http://localhost:4566",
+			UseFIPS:  true,
+		})
+		if err == nil {
+			t.Fatalf("expected error")
+		}
+	})
+}
@@ -0,0 +1,86 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"errors"
+
+	"github.com/aws/smithy-go"
+	"github.com/aws/smithy-go/middleware"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+)
+
+// awsErrorKinds maps AWS API error codes to sources error kinds. Codes not
+// listed fall back to the HTTP status of the response.
+var awsErrorKinds = map[string]error{
+	"AccessDenied":                           sources.ErrAuth,
+	"AccessDeniedException":                  sources.ErrAuth,
+	"ExpiredToken":                           sources.ErrAuth,
+	"ExpiredTokenException":                  sources.ErrAuth,
+	"InvalidAccessKeyId":                     sources.ErrAuth,
+	"InvalidClientTokenId":                   sources.ErrAuth,
+	"InvalidSignatureException":              sources.ErrAuth,
+	"SignatureDoesNotMatch":                  sources.ErrAuth,
+	"UnrecognizedClientException":            sources.ErrAuth,
+	"NoSuchBucket":                           sources.ErrNotFound,
+	"NoSuchKey":                              sources.ErrNotFound,
+	"NotFound":                               sources.ErrNotFound,
+	"ResourceNotFoundException":              sources.ErrNotFound,
+	"ProvisionedThroughputExceededException": sources.ErrThrottled,
+	"RequestLimitExceeded":                   sources.ErrThrottled,
+	"SlowDown":                               sources.ErrThrottled,
+	"Throttling":                             sources.ErrThrottled,
+	"ThrottlingException":                    sources.ErrThrottled,
+	"TooManyRequestsException":               sources.ErrThrottled,
+	"RequestTimeout":                         sources.ErrTimeout,
+	"RequestTimeoutException":                sources.ErrTimeout,
+}
+
+// ClassifyAWSError tags an error from an AWS SDK operation with the matching
+// sources error kind, from its API error code, HTTP status, or a timeout.
+// Errors that match no kind are returned unchanged.
+func ClassifyAWSError(err error) error {
+	if err == nil {
+		return nil
+	}
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) {
+		if kind, ok := awsErrorKinds[apiErr.ErrorCode()]; ok {
+			return sources.WithKind(err, kind)
+		}
+	}
+	var respErr *smithyhttp.ResponseError
+	if errors.As(err, &respErr) {
+		if kind := sources.KindForStatus(respErr.HTTPStatusCode()); kind != nil {
+			return sources.WithKind(err, kind)
+		}
+	}
+	return sources.ClassifyError(err)
+}
+
+// classifyAWSErrors runs first in the Initialize step, so it sees the final
+// error of an operation after the SDK's retries.
+var classifyAWSErrors = middleware.InitializeMiddlewareFunc("ClassifyAWSErrors",
+	func(ctx context.Context, in middleware.InitializeInput, next middleware.InitializeHandler) (middleware.InitializeOutput, middleware.Metadata, error) {
+		out, metadata, err := next.HandleInitialize(ctx, in)
+		return out, metadata, ClassifyAWSError(err)
+	})
+
+// addClassifyAWSErrors adds classifyAWSErrors to an operation's stack.
+func addClassifyAWSErrors(stack *middleware.Stack) error {
+	return stack.Initialize.Add(classifyAWSErrors, middleware.Before)
+}
@@ -0,0 +1,100 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/aws/smithy-go"
+	"github.com/aws/smithy-go/middleware"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+)
+
+func TestClassifyAWSError(t *testing.T) {
+	responseError := func(status int) error {
+		return &smithyhttp.ResponseError{
+			Response: &smithyhttp.Response{Response: &http.Response{StatusCode: status}},
+			Err:      errors.New("response error"),
+		}
+	}
+
+	tcs := []struct {
+		desc string
+		err  error
+		want error
+	}{
+		{desc: "nil", err: nil, want: nil},
+		{desc: "throttling code", err: &smithy.GenericAPIError{Code: "ThrottlingException"}, want: sources.ErrThrottled},
+		{desc: "access denied code", err: &smithy.GenericAPIError{Code: "AccessDeniedException"}, want: sources.ErrAuth},
+		{desc: "not found code", err: &smithy.GenericAPIError{Code: "ResourceNotFoundException"}, want: sources.ErrNotFound},
+		{desc: "forbidden status", err: responseError(http.StatusForbidden), want: sources.ErrAuth},
+		{desc: "unmapped status", err: responseError(http.StatusBadRequest), want: nil},
+		{desc: "context deadline", err: context.DeadlineExceeded, want: sources.ErrTimeout},
+		{
+			desc: "wrapped in an operation error",
+			err: &smithy.OperationError{
+				ServiceID:     "DynamoDB",
+				OperationName: "Query",
+				Err:           &smithy.GenericAPIError{Code: "ProvisionedThroughputExceededException"},
+			},
+			want: sources.ErrThrottled,
+		},
+	}
+	kinds := []error{sources.ErrAuth, sources.ErrNotFound, sources.ErrThrottled, sources.ErrTimeout}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := ClassifyAWSError(tc.err)
+			if tc.err == nil {
+				if got != nil {
+					t.Fatalf("expected nil, got %v", got)
+				}
+				return
+			}
+			if !errors.Is(got, tc.err) || got.Error() != tc.err.Error() {
+				t.Errorf("expected %v to wrap %v with the same message", got, tc.err)
+			}
+			for _, kind := range kinds {
+				if is := errors.Is(got, kind); is != (kind == tc.want) {
+					t.Errorf("errors.Is(err, %q) = %t", kind, is)
+				}
+			}
+		})
+	}
+
+	var apiErr smithy.APIError
+	if !errors.As(ClassifyAWSError(&smithy.GenericAPIError{Code: "ThrottlingException"}), &apiErr) {
+		t.Error("expected the API error to stay reachable with errors.As")
+	}
+}
+
+func TestClassifyAWSErrorsMiddleware(t *testing.T) {
+	stack := middleware.NewStack("test", smithyhttp.NewStackRequest)
+	if err := addClassifyAWSErrors(stack); err != nil {
+		t.Fatalf("unable to add middleware: %s", err)
+	}
+	handler := middleware.DecorateHandler(middleware.HandlerFunc(func(ctx context.Context, in interface{}) (interface{}, middleware.Metadata, error) {
+		return nil, middleware.Metadata{}, fmt.Errorf("operation failed: %w", &smithy.GenericAPIError{Code: "ThrottlingException"})
+	}), stack)
+
+	_, _, err := handler.Handle(context.Background(), nil)
+	if !errors.Is(err, sources.ErrThrottled) {
+		t.Errorf("expected throttled error kind, got %v", err)
+	}
+}
@@ -0,0 +1,46 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseDurationOption(t *testing.T) {
+	d, err := ParseDurationOption("connMaxLifetime", "", time.Hour)
+	if err != nil {
+		t.Fatalf("unexpected error for unset value: %s", err)
+	}
+	if d != time.Hour {
+		t.Errorf("got %s, want default %s", d, time.Hour)
+	}
+
+	d, err = ParseDurationOption("connMaxIdleTime", "90s", 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if d != 90*time.Second {
+		t.Errorf("got %s, want %s", d, 90*time.Second)
+	}
+
+	if _, err := ParseDurationOption("connMaxIdleTime", "soon", 0); err == nil || !strings.Contains(err.Error(), `invalid connMaxIdleTime "soon"`) {
+		t.Errorf("expected invalid duration error, got %v", err)
+	}
+	if _, err := ParseDurationOption("connMaxIdleTime", "-1m", 0); err == nil || !strings.Contains(err.Error(), "must not be negative") {
+		t.Errorf("expected negative duration error, got %v", err)
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"fmt"
+	"net/http"
+
+	"golang.org/x/time/rate"
+)
+
+// ValidateRateLimit checks requestsPerSecond and burst config values for
+// NewRateLimitedTransport.
+func ValidateRateLimit(requestsPerSecond float64, burst int) error {
+	if requestsPerSecond < 0 {
+		return fmt.Errorf("requestsPerSecond must not be negative, got %g", requestsPerSecond)
+	}
+	if burst < 0 {
+		return fmt.Errorf("burst must not be negative, got %d", burst)
+	}
+	return nil
+}
+
+// NewRateLimitedTransport wraps base so that requests wait for the rate
+// limiter, or for the request's context to be done, instead of failing when
+// over the limit. A requestsPerSecond of zero disables limiting and returns
+// base unchanged; a burst of zero allows one request at a time. A nil base
+// uses http.DefaultTransport.
+func NewRateLimitedTransport(base http.RoundTripper, requestsPerSecond float64, burst int) http.RoundTripper {
+	if requestsPerSecond <= 0 {
+		return base
+	}
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &rateLimitedTransport{
+		base:    base,
+		limiter: rate.NewLimiter(rate.Limit(requestsPerSecond), max(burst, 1)),
+	}
+}
+
+type rateLimitedTransport struct {
+	base    http.RoundTripper
+	limiter *rate.Limiter
+}
+
+func (t *rateLimitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.limiter.Wait(req.Context()); err != nil {
+		return nil, err
+	}
+	return t.base.RoundTrip(req)
+}
+
+// CloseIdleConnections closes idle connections of the underlying transport,
+// so http.Client.CloseIdleConnections keeps working through the wrapper.
+func (t *rateLimitedTransport) CloseIdleConnections() {
+	if c, ok := t.base.(interface{ CloseIdleConnections() }); ok {
+		c.CloseIdleConnections()
+	}
+}
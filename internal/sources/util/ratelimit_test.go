@@ -0,0 +1,93 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestValidateRateLimit(t *testing.T) {
+	if err := ValidateRateLimit(0, 0); err != nil {
+		t.Errorf("unexpected error for unset rate limit: %s", err)
+	}
+	if err := ValidateRateLimit(2.5, 5); err != nil {
+		t.Errorf("unexpected error for valid rate limit: %s", err)
+	}
+	if err := ValidateRateLimit(-1, 0); err == nil {
+		t.Error("expected error for negative requestsPerSecond")
+	}
+	if err := ValidateRateLimit(1, -1); err == nil {
+		t.Error("expected error for negative burst")
+	}
+}
+
+func TestNewRateLimitedTransport(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+	}))
+	defer server.Close()
+
+	t.Run("disabled", func(t *testing.T) {
+		base := &http.Transport{}
+		if got := NewRateLimitedTransport(base, 0, 0); got != base {
+			t.Fatalf("expected base transport, got %T", got)
+		}
+	})
+
+	t.Run("waits for the limiter", func(t *testing.T) {
+		client := &http.Client{Transport: NewRateLimitedTransport(nil, 20, 1)}
+		start := time.Now()
+		for range 3 {
+			resp, err := client.Get(server.URL)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			resp.Body.Close()
+		}
+		// The first request uses the burst; the next two wait 50ms each
+		if elapsed := time.Since(start); elapsed < 90*time.Millisecond {
+			t.Errorf("3 requests at 20/s took %s, want at least 100ms", elapsed)
+		}
+	})
+
+	t.Run("respects the request context", func(t *testing.T) {
+		requests.Store(0)
+		client := &http.Client{Transport: NewRateLimitedTransport(nil, 0.001, 1)}
+		resp, err := client.Get(server.URL)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		resp.Body.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+		if err != nil {
+			t.Fatalf("unable to create request: %s", err)
+		}
+		if _, err := client.Do(req); err == nil {
+			t.Fatal("expected error once the context is done")
+		}
+		if got := requests.Load(); got != 1 {
+			t.Errorf("server received %d requests, want 1", got)
+		}
+	})
+}
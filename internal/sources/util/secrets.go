@@ -0,0 +1,45 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// ResolveSecret returns the secret for a config field that can be set either
+// inline or as a path to a file holding it, such as password and
+// passwordFile. field names the inline field and is used in errors. An empty
+// file returns value unchanged, so inline secrets, including ones expanded
+// from ${ENV_VAR} when the tools file is loaded, are never reinterpreted. The
+// file's contents are returned without trailing newlines.
+func ResolveSecret(field, value, file string) (string, error) {
+	if file == "" {
+		return value, nil
+	}
+	if value != "" {
+		return "", fmt.Errorf("%s and %sFile cannot both be set", field, field)
+	}
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return "", fmt.Errorf("unable to read %sFile: %w", field, err)
+	}
+	secret := strings.TrimRight(string(b), "\r\n")
+	if secret == "" {
+		return "", fmt.Errorf("%sFile %q is empty", field, file)
+	}
+	return secret, nil
+}
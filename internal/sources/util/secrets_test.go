@@ -0,0 +1,69 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestResolveSecret(t *testing.T) {
+	dir := t.TempDir()
+	secretFile := filepath.Join(dir, "token")
+	if err := os.WriteFile(secretFile, []byte("from-file\n"), 0o600); err != nil {
+		t.Fatalf("unable to write secret file: %s", err)
+	}
+	emptyFile := filepath.Join(dir, "empty")
+	if err := os.WriteFile(emptyFile, []byte("\n"), 0o600); err != nil {
+		t.Fatalf("unable to write secret file: %s", err)
+	}
+	t.Setenv("TOOLBOX_TEST_SECRET", "from-env")
+
+	tcs := []struct {
+		desc    string
+		value   string
+		file    string
+		want    string
+		wantErr string
+	}{
+		{desc: "plaintext", value: "plain-secret", want: "plain-secret"},
+		{desc: "empty", value: "", want: ""},
+		{desc: "literal file prefix is passed through", value: "file:" + secretFile, want: "file:" + secretFile},
+		{desc: "literal env reference is passed through", value: "${TOOLBOX_TEST_SECRET}", want: "${TOOLBOX_TEST_SECRET}"},
+		{desc: "file", file: secretFile, want: "from-file"},
+		{desc: "value and file", value: "plain-secret", file: secretFile, wantErr: "password and passwordFile cannot both be set"},
+		{desc: "missing file", file: filepath.Join(dir, "missing"), wantErr: "unable to read passwordFile"},
+		{desc: "empty file", file: emptyFile, wantErr: "is empty"},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := ResolveSecret("password", tc.value, tc.file)
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got != tc.want {
+				t.Fatalf("got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
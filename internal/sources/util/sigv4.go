@@ -0,0 +1,34 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+)
+
+// SignAWSRequest adds AWS SigV4 authentication headers to req for service in
+// region, e.g. "neptune-db" or "es". payload must be the request body, or nil
+// if there is none, since its hash is part of the signature.
+func SignAWSRequest(ctx context.Context, creds aws.Credentials, req *http.Request, payload []byte, service, region string) error {
+	payloadHash := sha256.Sum256(payload)
+	return v4.NewSigner().SignHTTP(ctx, creds, req, hex.EncodeToString(payloadHash[:]), service, region, time.Now())
+}
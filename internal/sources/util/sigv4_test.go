@@ -0,0 +1,57 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+)
+
+func TestSignAWSRequest(t *testing.T) {
+	payload := []byte(`{"query":{"match_all":{}}}`)
+	req, err := http.NewRequest(http.MethodPost, "https://search-logs.us-east-1.es.amazonaws.com/logs/_search", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	creds := aws.Credentials{AccessKeyID: "AKID", SecretAccessKey: "SECRET", SessionToken: "TOKEN"}
+
+	if err := SignAWSRequest(context.Background(), creds, req, payload, "es", "us-east-1"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	auth := req.Header.Get("Authorization")
+	if !strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential=AKID/") || !strings.Contains(auth, "/us-east-1/es/aws4_request") {
+		t.Errorf("unexpected Authorization header %q", auth)
+	}
+	if got := req.Header.Get("X-Amz-Security-Token"); got != "TOKEN" {
+		t.Errorf("got security token %q, want TOKEN", got)
+	}
+	if req.Header.Get("X-Amz-Date") == "" {
+		t.Errorf("missing X-Amz-Date header")
+	}
+
+	// A different payload produces a different signature
+	other := req.Clone(context.Background())
+	if err := SignAWSRequest(context.Background(), creds, other, []byte(`{}`), "es", "us-east-1"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if other.Header.Get("Authorization") == auth && other.Header.Get("X-Amz-Date") == req.Header.Get("X-Amz-Date") {
+		t.Errorf("signature doesn't depend on the payload")
+	}
+}
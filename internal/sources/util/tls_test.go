@@ -0,0 +1,75 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadTLSConfig(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	caFile := filepath.Join(dir, "ca.pem")
+	pemData := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := os.WriteFile(caFile, pemData, 0o600); err != nil {
+		t.Fatalf("unable to write CA file: %s", err)
+	}
+	invalidFile := filepath.Join(dir, "invalid.pem")
+	if err := os.WriteFile(invalidFile, []byte("not a certificate"), 0o600); err != nil {
+		t.Fatalf("unable to write CA file: %s", err)
+	}
+
+	t.Run("no CA file", func(t *testing.T) {
+		tlsConfig, err := LoadTLSConfig("")
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if tlsConfig != nil {
+			t.Fatalf("expected nil TLS config, got %+v", tlsConfig)
+		}
+	})
+
+	t.Run("trusts CA file", func(t *testing.T) {
+		tlsConfig, err := LoadTLSConfig(caFile)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		client := &http.Client{Transport: &http.Transport{TLSClientConfig: tlsConfig}}
+		resp, err := client.Get(server.URL)
+		if err != nil {
+			t.Fatalf("request with CA file failed: %s", err)
+		}
+		resp.Body.Close()
+	})
+
+	t.Run("missing CA file", func(t *testing.T) {
+		if _, err := LoadTLSConfig(filepath.Join(dir, "missing.pem")); err == nil {
+			t.Fatal("expected error for missing CA file")
+		}
+	})
+
+	t.Run("CA file without certificates", func(t *testing.T) {
+		if _, err := LoadTLSConfig(invalidFile); err == nil {
+			t.Fatal("expected error for CA file without certificates")
+		}
+	})
+}
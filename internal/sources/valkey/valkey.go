@@ -30,9 +30,7 @@ const SourceKind string = "valkey"
 var _ sources.SourceConfig = Config{}
 
 func init() {
-	if !sources.Register(SourceKind, newConfig) {
-		panic(fmt.Sprintf("source kind %q already registered", SourceKind))
-	}
+	sources.MustRegister(SourceKind, newConfig)
 }
 
 func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources.SourceConfig, error) {
@@ -122,6 +120,11 @@ func (s *Source) ToConfig() sources.SourceConfig {
 	return s.Config
 }
 
+// HealthCheck pings the Valkey server.
+func (s *Source) HealthCheck(ctx context.Context) error {
+	return s.Client.Do(ctx, s.Client.B().Ping().Build()).Error()
+}
+
 func (s *Source) ValkeyClient() valkey.Client {
 	return s.Client
 }